@@ -0,0 +1,101 @@
+// Command exportOrders exports lines of orders for accounting to the CSV or XLSX file.
+// The database and the pricing are configured by the same environment variables as the service:
+//
+//	exportOrders -from 2023-01-01 -to 2023-02-01 -format xlsx -out orders.xlsx
+package main
+
+import (
+	"OnlineShopBackend/config"
+	"OnlineShopBackend/internal/app/logger"
+	"OnlineShopBackend/internal/export"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository"
+	"OnlineShopBackend/internal/usecase"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+)
+
+func main() {
+	from := flag.String("from", "", "start of period of creation of orders, 2006-01-02 or RFC3339")
+	to := flag.String("to", "", "end of period of creation of orders, not included, 2006-01-02 or RFC3339")
+	format := flag.String("format", export.CSV, "format of file: csv or xlsx")
+	status := flag.String("status", "", "status of orders")
+	out := flag.String("out", "", "path of file, orders_from_<from>_to_<to>.<format> by default")
+	flag.Parse()
+
+	filter := models.OrderFilter{Status: models.Status(*status)}
+	var err error
+	if filter.From, err = parseTime(*from); err != nil {
+		log.Fatalf("invalid from: %v", err)
+	}
+	if filter.To, err = parseTime(*to); err != nil {
+		log.Fatalf("invalid to: %v", err)
+	}
+	if !export.Valid(*format) {
+		log.Fatalf("invalid format: %s", *format)
+	}
+	if *out == "" {
+		*out = export.FileName("orders", filter.From, filter.To, *format)
+	}
+
+	cfg, err := config.NewConfig()
+	if err != nil {
+		log.Fatal("can't initialize configuration")
+	}
+	l := logger.NewLogger(cfg.LogLevel).Logger
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	// The command is run by the operator with the access to the database, who is an admin of the shop
+	ctx = models.ContextWithActor(ctx, models.Actor{Email: "exportOrders", Role: models.Admin})
+
+	pgstore, err := repository.NewPgxStorage(ctx, l.Sugar(), cfg.DNS)
+	if err != nil {
+		log.Fatalf("can't initalize storage: %v", err)
+	}
+	pricing := models.CartPricing{
+		DiscountThreshold:     cfg.CartDiscountThreshold,
+		DiscountPercent:       cfg.CartDiscountPercent,
+		ShippingCost:          cfg.ShippingCost,
+		FreeShippingThreshold: cfg.FreeShippingThreshold,
+	}
+	orderListUsecase := usecase.NewOrderListUsecase(repository.NewOrderRepo(pgstore, l.Sugar()), pricing, l)
+
+	if err := exportOrders(ctx, orderListUsecase, filter, *format, *out); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("orders are exported to %s", *out)
+}
+
+// exportOrders writes the export to the file, the incomplete file is removed on error
+func exportOrders(ctx context.Context, orderListUsecase usecase.IOrderListUsecase, filter models.OrderFilter, format string, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("can't create file: %w", err)
+	}
+	err = orderListUsecase.ExportOrders(ctx, filter, format, file)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("can't close file: %w", closeErr)
+	}
+	if err != nil {
+		os.Remove(path)
+		return err
+	}
+	return nil
+}
+
+// parseTime parses the date or the time in RFC3339 format, the empty value is the zero time
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -5,12 +5,18 @@ import (
 	"OnlineShopBackend/internal/app/logger"
 	"OnlineShopBackend/internal/app/router"
 	"OnlineShopBackend/internal/app/server"
+	"OnlineShopBackend/internal/carrier"
 	"OnlineShopBackend/internal/delivery"
 	"OnlineShopBackend/internal/delivery/user/password"
+	"OnlineShopBackend/internal/feed"
 	"OnlineShopBackend/internal/filestorage"
+	"OnlineShopBackend/internal/invoice"
+	"OnlineShopBackend/internal/mail"
 	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/payment"
 	"OnlineShopBackend/internal/repository"
 	"OnlineShopBackend/internal/repository/cash"
+	"OnlineShopBackend/internal/shipping"
 	"OnlineShopBackend/internal/usecase"
 	"context"
 	"fmt"
@@ -31,6 +37,14 @@ func main() {
 	if err != nil {
 		log.Fatal("can't initialize configuration")
 	}
+	// Webhooks signed with a known default secret would let anyone mark orders paid,
+	// so production doesn't start without the secret, development only warns about it
+	if cfg.PaymentWebhookSecret == "" {
+		if cfg.IsProd {
+			log.Fatal("can't start without the secret of payment webhooks, set PAYMENT_WEBHOOK_SECRET")
+		}
+		log.Println("WARNING: payment webhooks are checked with the empty secret, set PAYMENT_WEBHOOK_SECRET")
+	}
 	logger := logger.NewLogger(cfg.LogLevel)
 	lsug := logger.Logger.Sugar()
 	l := logger.Logger
@@ -52,6 +66,17 @@ func main() {
 
 	cartStore := repository.NewCartStore(pgstore, lsug)
 	orderStore := repository.NewOrderRepo(pgstore, lsug)
+	questionStore := repository.NewQuestionRepo(pgstore, lsug)
+	catalogStore := repository.NewCatalogRepo(pgstore, lsug)
+	auditStore := repository.NewAuditRepo(pgstore, lsug)
+	reminderStore := repository.NewReminderRepo(pgstore, lsug)
+	paymentStore := repository.NewPaymentRepo(pgstore, lsug)
+	returnStore := repository.NewReturnRepo(pgstore, lsug)
+	invoiceStore := repository.NewInvoiceRepo(pgstore, lsug)
+	addressStore := repository.NewAddressRepo(pgstore, lsug)
+	notificationStore := repository.NewNotificationRepo(pgstore, lsug)
+	shipmentStore := repository.NewShipmentRepo(pgstore, lsug)
+	unitOfWork := repository.NewUnitOfWork(pgstore, lsug)
 
 	redis, err := cash.NewRedisCash(cfg.CashHost, cfg.CashPort, time.Duration(cfg.CashTTL), l)
 	if err != nil {
@@ -59,16 +84,82 @@ func main() {
 	}
 	itemsCash := cash.NewItemsCash(redis, l)
 	categoriesCash := cash.NewCategoriesCash(redis, l)
+	statsCash := cash.NewStatsCash(redis, l)
+	idempotencyCash := cash.NewIdempotencyCash(redis, l)
 
+	filestorage := filestorage.NewOnDiskLocalStorage(cfg.ServerURL, cfg.FsPath, cfg.InvoicesPath, l)
 	itemUsecase := usecase.NewItemUsecase(itemStore, itemsCash, l)
-	categoryUsecase := usecase.NewCategoryUsecase(categoryStore, categoriesCash, l)
+	categoryUsecase := usecase.NewCategoryUsecase(categoryStore, categoriesCash, itemUsecase, filestorage, l)
 	userUsecase := usecase.NewUserUsecase(userStore, l)
 
-	cartUsecase := usecase.NewCartUseCase(cartStore, l)
+	pricing := models.CartPricing{
+		DiscountThreshold:     cfg.CartDiscountThreshold,
+		DiscountPercent:       cfg.CartDiscountPercent,
+		ShippingCost:          cfg.ShippingCost,
+		FreeShippingThreshold: cfg.FreeShippingThreshold,
+	}
+	cartUsecase := usecase.NewCartUseCase(cartStore, cfg.CartMaxQuantity, time.Duration(cfg.CartTTL)*time.Hour, pricing, l)
+	cartCleanupUsecase := usecase.NewCartCleanupUsecase(cartStore, time.Duration(cfg.CartCleanupPeriod)*time.Second, cfg.CartCleanupBatch, l)
 	orderUsecase := usecase.NewOrderUsecase(orderStore, lsug)
+	checkoutUsecase := usecase.NewCheckoutUsecase(unitOfWork, orderStore, cartStore, addressStore, time.Duration(cfg.CartTTL)*time.Hour,
+		newShippingRules(cfg, l), pricing, l)
+	paymentUsecase := usecase.NewPaymentUsecase(unitOfWork, orderStore, paymentStore, newPaymentProvider(cfg, l), pricing, cfg.Currency,
+		time.Duration(cfg.PaymentTimeout)*time.Minute, time.Duration(cfg.PaymentCancelPeriod)*time.Second, cfg.PaymentCancelBatch, l)
+	returnUsecase := usecase.NewReturnUsecase(unitOfWork, orderStore, returnStore, paymentUsecase, l)
+	orderCancelUsecase := usecase.NewOrderCancelUsecase(unitOfWork, orderStore, paymentStore, paymentUsecase, l)
+	idempotencyUsecase := usecase.NewIdempotencyUsecase(idempotencyCash, time.Duration(cfg.IdempotencyKeyTTL)*time.Hour, time.Duration(cfg.WriteTimeout)*time.Second, l)
+	questionUsecase := usecase.NewQuestionUsecase(questionStore, l)
+	auditUsecase := usecase.NewAuditUsecase(auditStore, l)
+	statsUsecase := usecase.NewStatsUsecase(itemStore, itemsCash, statsCash, time.Duration(cfg.StatsFlushPeriod)*time.Second, l)
 
-	filestorage := filestorage.NewOnDiskLocalStorage(cfg.ServerURL, cfg.FsPath, l)
-	delivery := delivery.NewDelivery(itemUsecase, userUsecase, categoryUsecase, cartUsecase, l, filestorage, orderUsecase)
+	shop := feed.Shop{
+		Name:     cfg.ShopName,
+		Company:  cfg.ShopCompany,
+		SiteURL:  cfg.SiteURL,
+		Currency: cfg.Currency,
+	}
+	feedUsecase := usecase.NewFeedUsecase(itemStore, categoryStore, catalogStore, filestorage, shop, l)
+	mailSender := newMailSender(cfg, l)
+	cartReminderUsecase := usecase.NewCartReminderUsecase(reminderStore, mailSender, shop,
+		time.Duration(cfg.CartReminderIdle)*time.Hour, time.Duration(cfg.CartReminderPeriod)*time.Second, cfg.CartReminderBatch,
+		cfg.CartReminderAttempts, time.Duration(cfg.CartReminderRetry)*time.Second, l)
+	notificationUsecase := usecase.NewNotificationUsecase(notificationStore, orderStore, mailSender, shop, pricing,
+		time.Duration(cfg.NotificationPeriod)*time.Second, cfg.NotificationBatch, cfg.NotificationAttempts, time.Duration(cfg.NotificationRetry)*time.Second, l)
+	seller := invoice.Shop{
+		Name:    cfg.ShopName,
+		Company: cfg.ShopCompany,
+		Address: cfg.ShopAddress,
+		TaxId:   cfg.ShopTaxId,
+		Email:   cfg.ShopEmail,
+		SiteURL: cfg.SiteURL,
+	}
+	invoiceUsecase := usecase.NewInvoiceUsecase(orderStore, invoiceStore, filestorage, seller, pricing, cfg.Currency, cfg.InvoicePrefix, cfg.InvoiceTaxRate, l)
+	orderListUsecase := usecase.NewOrderListUsecase(orderStore, pricing, l)
+	addressUsecase := usecase.NewAddressUsecase(addressStore, l)
+	shipmentUsecase := usecase.NewShipmentUsecase(shipmentStore, orderStore, newCarrier(cfg, l),
+		time.Duration(cfg.CarrierPollPeriod)*time.Second, cfg.CarrierPollBatch, l)
+	delivery := delivery.NewDelivery(delivery.Dependencies{
+		Logger:              l,
+		FileStorage:         filestorage,
+		ItemUsecase:         itemUsecase,
+		UserUsecase:         userUsecase,
+		CategoryUsecase:     categoryUsecase,
+		CartUsecase:         cartUsecase,
+		OrderUsecase:        orderUsecase,
+		QuestionUsecase:     questionUsecase,
+		StatsUsecase:        statsUsecase,
+		AuditUsecase:        auditUsecase,
+		CheckoutUsecase:     checkoutUsecase,
+		IdempotencyUsecase:  idempotencyUsecase,
+		PaymentUsecase:      paymentUsecase,
+		ReturnUsecase:       returnUsecase,
+		OrderCancelUsecase:  orderCancelUsecase,
+		InvoiceUsecase:      invoiceUsecase,
+		OrderListUsecase:    orderListUsecase,
+		AddressUsecase:      addressUsecase,
+		NotificationUsecase: notificationUsecase,
+		ShipmentUsecase:     shipmentUsecase,
+	})
 
 	router := router.NewRouter(delivery, l)
 	serverOptions := map[string]int{
@@ -86,6 +177,14 @@ func main() {
 	server.Start()
 	l.Info(fmt.Sprintf("Server start successful on port: %v", cfg.Port))
 
+	go feedUsecase.Run(ctx)
+	go statsUsecase.Run(ctx)
+	go cartCleanupUsecase.Run(ctx)
+	go cartReminderUsecase.Run(ctx)
+	go notificationUsecase.Run(ctx)
+	go shipmentUsecase.Run(ctx)
+	go paymentUsecase.Run(ctx)
+
 	go func() {
 		http.Handle("/metrics", promhttp.Handler())
 		err := http.ListenAndServe(":2112", nil)
@@ -119,6 +218,52 @@ func main() {
 	cancel()
 }
 
+// newMailSender returns the sender of emails chosen in the configuration
+func newMailSender(cfg *config.Config, l *zap.Logger) mail.Sender {
+	switch cfg.MailSender {
+	case "smtp":
+		return mail.NewSMTPSender(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUser, cfg.SMTPPassword, cfg.MailFrom, l)
+	case "file":
+		return mail.NewFileSender(cfg.MailDir, cfg.MailFrom, l)
+	case "memory":
+		return mail.NewMemorySender(l)
+	default:
+		return mail.NewLogSender(l)
+	}
+}
+
+// newPaymentProvider returns the payment provider chosen in the configuration,
+// only the mock provider is built in for now
+func newPaymentProvider(cfg *config.Config, l *zap.Logger) payment.Provider {
+	if cfg.PaymentProvider != "mock" {
+		l.Sugar().Warnf("Unknown payment provider %s, the mock provider is used", cfg.PaymentProvider)
+	}
+	return payment.NewMockProvider(cfg.PaymentWebhookSecret, l)
+}
+
+// newCarrier returns the carrier chosen in the configuration,
+// only the fake carrier is built in for now
+func newCarrier(cfg *config.Config, l *zap.Logger) carrier.Carrier {
+	if cfg.Carrier != "fake" {
+		l.Sugar().Warnf("Unknown carrier %s, the fake carrier is used", cfg.Carrier)
+	}
+	return carrier.NewFakeCarrier(time.Duration(cfg.CarrierFakeStep)*time.Second, l)
+}
+
+// newShippingRules returns the shipping rules from the file of the configuration,
+// without the file the default methods use the shipping cost of the cart
+func newShippingRules(cfg *config.Config, l *zap.Logger) shipping.Rules {
+	if cfg.ShippingRules == "" {
+		return shipping.Default(cfg.ShippingCost, cfg.FreeShippingThreshold)
+	}
+	rules, err := shipping.Load(cfg.ShippingRules)
+	if err != nil {
+		log.Fatalf("can't load shipping rules: %v", err)
+	}
+	l.Sugar().Infof("Shipping rules with %d methods are loaded from %s", len(rules.Methods), cfg.ShippingRules)
+	return rules
+}
+
 func createCashOnStartService(ctx context.Context, categoryUsecase usecase.ICategoryUsecase, itemUsecase usecase.IItemUsecase, l *zap.Logger) error {
 	l.Debug("Enter in main createCashOnStartService")
 	l.Debug("Start create cash...")
@@ -226,7 +371,7 @@ func setCustomerRights(userStore repository.UserStore, logger *zap.Logger) {
 		return
 	}
 	customerRights := models.Rights{
-		Name: "Customer",
+		Name:  "Customer",
 		Rules: []string{"Customer"},
 	}
 	rightsId, err := userStore.CreateRights(ctx, &customerRights)
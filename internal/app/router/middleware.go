@@ -2,6 +2,7 @@ package router
 
 import (
 	"OnlineShopBackend/internal/delivery/user/jwtauth"
+	"OnlineShopBackend/internal/models"
 	"net/http"
 	"strings"
 
@@ -20,7 +21,7 @@ func CORSMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-Auth-Token, Set-Cookie")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-Auth-Token, X-Cart-Token, Idempotency-Key, Set-Cookie")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -29,29 +30,31 @@ func CORSMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// JWTMiddleware checks the session token and sets its claims and the actor of the request,
+// the request without the valid token is aborted
 func JWTMiddleware(c *gin.Context) {
 
 	tokenString := c.GetHeader(authorizationHeader)
 
 	if tokenString == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"message": "Authorization header is empty"})
-		c.Abort()
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Authorization header is empty"})
 		return
 	}
 
 	headerSplit := strings.Split(tokenString, " ")
 	if len(headerSplit) != 2 || headerSplit[0] != "Bearer" {
-		c.JSON(http.StatusUnauthorized, gin.H{"message": "header issue"})
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "header issue"})
 		return
 	}
 	if len(headerSplit[1]) == 0 {
-		c.JSON(http.StatusUnauthorized, gin.H{"message": "empty token"})
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "empty token"})
 		return
 	}
 
 	jwtKey, err := jwtauth.NewJWTKeyConfig()
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"message": "empty config"})
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "empty config"})
 		return
 	}
 
@@ -62,32 +65,53 @@ func JWTMiddleware(c *gin.Context) {
 
 	if err != nil {
 		if err == jwt.ErrSignatureInvalid {
-			c.JSON(http.StatusUnauthorized, gin.H{"message": "Invalid signature"})
-			c.Abort()
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Invalid signature"})
 			return
 		}
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "invalid token"})
+		return
 	}
 
 	if !token.Valid {
-		c.JSON(http.StatusUnauthorized, gin.H{"message": tokenString})
-		c.Abort()
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "invalid token"})
 		return
 	}
 
 	c.Set("claims", claims)
+	// The actor goes down to the repositories with the context of request
+	// and is recorded in the audit log
+	c.Request = c.Request.WithContext(models.ContextWithActor(c.Request.Context(), models.Actor{
+		UserId: claims.UserId,
+		Email:  claims.Email,
+		Role:   claims.Role,
+	}))
+}
+
+// authClaims runs JWTMiddleware and returns the claims of the request,
+// the request is aborted if they are not set
+func authClaims(c *gin.Context) (*jwtauth.Payload, bool) {
+	JWTMiddleware(c)
+	if c.IsAborted() {
+		return nil, false
+	}
+	value, _ := c.Get("claims")
+	userCr, ok := value.(*jwtauth.Payload)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "incorrect claims"})
+		return nil, false
+	}
+	return userCr, true
 }
 
 // AdminAuth method grants permission only to users with the role 'Admin'
 func AdminAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		JWTMiddleware(c)
-		userCr, ok := c.MustGet("claims").(*jwtauth.Payload)
+		userCr, ok := authClaims(c)
 		if !ok {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "incorrect claims"})
 			return
 		}
 		if userCr.Role != admin {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "not permitted"})
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "not permitted"})
 			return
 		}
 		c.Next()
@@ -97,14 +121,12 @@ func AdminAuth() gin.HandlerFunc {
 // CustomerAuth method grants permission only to users with the role 'Customer'
 func CustomerAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		JWTMiddleware(c)
-		userCr, ok := c.MustGet("claims").(*jwtauth.Payload)
+		userCr, ok := authClaims(c)
 		if !ok {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "incorrect claims"})
 			return
 		}
 		if userCr.Role != customer {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "not permitted"})
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "not permitted"})
 			return
 		}
 		c.Next()
@@ -114,14 +136,12 @@ func CustomerAuth() gin.HandlerFunc {
 // UserAuth method confirms that user is authorized
 func UserAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		JWTMiddleware(c)
-		userCr, ok := c.MustGet("claims").(*jwtauth.Payload)
+		userCr, ok := authClaims(c)
 		if !ok {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "incorrect claims"})
 			return
 		}
 		if userCr.UserId == uuid.Nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "user unauthorized 333"})
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "user unauthorized"})
 			return
 		}
 		c.Next()
@@ -0,0 +1,59 @@
+package router
+
+import (
+	"OnlineShopBackend/internal/delivery/user/jwtauth"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthMiddlewares(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	handler := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": 1})
+	}
+	engine.GET("/admin", AdminAuth(), handler)
+	engine.GET("/user", UserAuth(), handler)
+	token := func(role string, userId uuid.UUID) string {
+		token, err := jwtauth.NewJWT(jwtauth.Payload{Email: "user@mail.ru", Role: role, UserId: userId})
+		require.NoError(t, err)
+		return "Bearer " + token
+	}
+	customerToken := token(customer, uuid.New())
+	request := func(path string, authorization string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if authorization != "" {
+			req.Header.Set(authorizationHeader, authorization)
+		}
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	for _, test := range []struct {
+		path          string
+		authorization string
+		code          int
+	}{
+		{"/admin", "", http.StatusUnauthorized},
+		{"/admin", "Bearer", http.StatusUnauthorized},
+		{"/admin", "Bearer broken", http.StatusUnauthorized},
+		{"/admin", customerToken, http.StatusUnauthorized},
+		{"/admin", token(admin, uuid.New()), http.StatusOK},
+		{"/user", "", http.StatusUnauthorized},
+		{"/user", token(customer, uuid.Nil), http.StatusUnauthorized},
+		{"/user", customerToken, http.StatusOK},
+	} {
+		w := request(test.path, test.authorization)
+		require.Equal(t, test.code, w.Code, "%s with %q", test.path, test.authorization)
+		// The handler doesn't run after the failed check
+		if test.code != http.StatusOK {
+			require.NotContains(t, w.Body.String(), `"ok"`)
+		}
+	}
+}
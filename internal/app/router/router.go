@@ -73,6 +73,28 @@ func NewRouter(delivery *delivery.Delivery, logger *zap.Logger) *Router {
 			AdminAuth(),
 			delivery.GetFileList,
 		},
+		// -------------------------FEEDS-------------------------------------------------------------------------------
+		{
+			"Sitemap",
+			http.MethodGet,
+			"/sitemap.xml",
+			noOpMiddleware,
+			delivery.Sitemap,
+		},
+		{
+			"GoogleMerchantFeed",
+			http.MethodGet,
+			"/feeds/google_merchant.xml",
+			noOpMiddleware,
+			delivery.GoogleMerchantFeed,
+		},
+		{
+			"YandexMarketFeed",
+			http.MethodGet,
+			"/feeds/yandex_market.yml",
+			noOpMiddleware,
+			delivery.YandexMarketFeed,
+		},
 		// -------------------------CATEGORY----------------------------------------------------------------------------
 		{
 			"CreateCategory",
@@ -123,6 +145,13 @@ func NewRouter(delivery *delivery.Delivery, logger *zap.Logger) *Router {
 			AdminAuth(),
 			delivery.DeleteCategory,
 		},
+		{
+			"MergeCategories",
+			http.MethodPost,
+			"/categories/merge",
+			AdminAuth(),
+			delivery.MergeCategories,
+		},
 		// -------------------------ITEM--------------------------------------------------------------------------------
 		{
 			"CreateItem",
@@ -236,6 +265,50 @@ func NewRouter(delivery *delivery.Delivery, logger *zap.Logger) *Router {
 			UserAuth(),
 			delivery.GetFavouriteItems,
 		},
+		// -------------------------QUESTIONS---------------------------------------------------------------------------
+		{
+			"AskQuestion",
+			http.MethodPost,
+			"/items/:itemID/questions",
+			UserAuth(),
+			delivery.AskQuestion,
+		},
+		{
+			"GetItemQuestions",
+			http.MethodGet,
+			"/items/:itemID/questions",
+			noOpMiddleware,
+			delivery.GetItemQuestions,
+		},
+		{
+			"AnswerQuestion",
+			http.MethodPost,
+			"/questions/:questionID/answers",
+			UserAuth(),
+			delivery.AnswerQuestion,
+		},
+		{
+			"UpvoteAnswer",
+			http.MethodPost,
+			"/answers/:answerID/upvote",
+			UserAuth(),
+			delivery.UpvoteAnswer,
+		},
+		{
+			"GetUnansweredQuestions",
+			http.MethodGet,
+			"/questions/unanswered",
+			AdminAuth(),
+			delivery.GetUnansweredQuestions,
+		},
+		// -------------------------AUDIT-------------------------------------------------------------------------------
+		{
+			"GetAuditLog",
+			http.MethodGet,
+			"/audit",
+			AdminAuth(),
+			delivery.GetAuditLog,
+		},
 		// -------------------------CART--------------------------------------------------------------------------------
 		{
 			"GetCart",
@@ -244,6 +317,13 @@ func NewRouter(delivery *delivery.Delivery, logger *zap.Logger) *Router {
 			UserAuth(),
 			delivery.GetCart,
 		},
+		{
+			"GetShippingQuotes",
+			http.MethodGet,
+			"/cart/:cartID/shipping",
+			UserAuth(),
+			delivery.GetShippingQuotes,
+		},
 		{
 			"GetCartByUserId",
 			http.MethodGet,
@@ -265,6 +345,27 @@ func NewRouter(delivery *delivery.Delivery, logger *zap.Logger) *Router {
 			UserAuth(),
 			delivery.AddItemToCart,
 		},
+		{
+			"SetItemQuantity",
+			http.MethodPut,
+			"/cart/:cartID/items/:itemID",
+			UserAuth(),
+			delivery.SetItemQuantity,
+		},
+		{
+			"SaveItemForLater",
+			http.MethodPost,
+			"/cart/:cartID/items/:itemID/saveForLater",
+			UserAuth(),
+			delivery.SaveItemForLater,
+		},
+		{
+			"MoveSavedItemToCart",
+			http.MethodPost,
+			"/cart/:cartID/saved/:itemID/moveToCart",
+			UserAuth(),
+			delivery.MoveSavedItemToCart,
+		},
 		{
 			"DeleteItemFromCart",
 			http.MethodDelete,
@@ -279,6 +380,27 @@ func NewRouter(delivery *delivery.Delivery, logger *zap.Logger) *Router {
 			UserAuth(),
 			delivery.DeleteCart,
 		},
+		{
+			"CreateGuestCart",
+			http.MethodPost,
+			"/guest/cart",
+			noOpMiddleware,
+			delivery.CreateGuestCart,
+		},
+		{
+			"GetGuestCart",
+			http.MethodGet,
+			"/guest/cart",
+			noOpMiddleware,
+			delivery.GetGuestCart,
+		},
+		{
+			"SetGuestItemQuantity",
+			http.MethodPut,
+			"/guest/cart/items/:itemID",
+			noOpMiddleware,
+			delivery.SetGuestItemQuantity,
+		},
 		// -------------------------USER--------------------------------------------------------------------------------
 		{
 			"CreateUser",
@@ -358,13 +480,62 @@ func NewRouter(delivery *delivery.Delivery, logger *zap.Logger) *Router {
 			AdminAuth(),
 			delivery.CreateRights,
 		},
+		{
+			"GetAddresses",
+			http.MethodGet,
+			"/user/addresses",
+			UserAuth(),
+			delivery.GetAddresses,
+		},
+		{
+			"CreateAddress",
+			http.MethodPost,
+			"/user/addresses",
+			UserAuth(),
+			delivery.CreateAddress,
+		},
+		{
+			"GetAddress",
+			http.MethodGet,
+			"/user/addresses/:addressID",
+			UserAuth(),
+			delivery.GetAddress,
+		},
+		{
+			"UpdateAddress",
+			http.MethodPut,
+			"/user/addresses/:addressID",
+			UserAuth(),
+			delivery.UpdateAddress,
+		},
+		{
+			"DeleteAddress",
+			http.MethodDelete,
+			"/user/addresses/:addressID",
+			UserAuth(),
+			delivery.DeleteAddress,
+		},
+		{
+			"GetNotificationPreferences",
+			http.MethodGet,
+			"/user/notifications",
+			UserAuth(),
+			delivery.GetNotificationPreferences,
+		},
+		{
+			"SetNotificationPreferences",
+			http.MethodPut,
+			"/user/notifications",
+			UserAuth(),
+			delivery.SetNotificationPreferences,
+		},
 		// -------------------------ORDER--------------------------------------------------------------------------------
 		{
 			"CreateOrder",
 			http.MethodPost,
 			"/order/create",
 			UserAuth(),
-			delivery.CreateOrder,
+			delivery.Idempotent(delivery.CreateOrder),
 		},
 		{
 			"GetOrder",
@@ -373,6 +544,20 @@ func NewRouter(delivery *delivery.Delivery, logger *zap.Logger) *Router {
 			UserAuth(),
 			delivery.GetOrder,
 		},
+		{
+			"GetOrders",
+			http.MethodGet,
+			"/order/list",
+			AdminAuth(),
+			delivery.GetOrders,
+		},
+		{
+			"ExportOrders",
+			http.MethodGet,
+			"/order/export",
+			AdminAuth(),
+			delivery.ExportOrders,
+		},
 		{
 			"GetOrdersForUsers",
 			http.MethodGet,
@@ -380,6 +565,20 @@ func NewRouter(delivery *delivery.Delivery, logger *zap.Logger) *Router {
 			UserAuth(),
 			delivery.GetOrdersForUser,
 		},
+		{
+			"CancelOrder",
+			http.MethodPost,
+			"/order/:orderID/cancel",
+			UserAuth(),
+			delivery.Idempotent(delivery.CancelOrder),
+		},
+		{
+			"GetInvoice",
+			http.MethodGet,
+			"/order/:orderID/invoice",
+			UserAuth(),
+			delivery.GetInvoice,
+		},
 		{
 			"DeleteOrder",
 			http.MethodDelete,
@@ -401,6 +600,85 @@ func NewRouter(delivery *delivery.Delivery, logger *zap.Logger) *Router {
 			AdminAuth(),
 			delivery.ChangeStatus,
 		},
+		{
+			"ShipOrder",
+			http.MethodPost,
+			"/order/:orderID/ship",
+			AdminAuth(),
+			delivery.Idempotent(delivery.ShipOrder),
+		},
+		// -------------------------PAYMENT------------------------------------------------------------------------------
+		{
+			"PayOrder",
+			http.MethodPost,
+			"/order/:orderID/pay",
+			UserAuth(),
+			delivery.Idempotent(delivery.PayOrder),
+		},
+		{
+			"GetOrderPayment",
+			http.MethodGet,
+			"/order/:orderID/payment",
+			UserAuth(),
+			delivery.GetOrderPayment,
+		},
+		{
+			"RefundOrder",
+			http.MethodPost,
+			"/order/:orderID/refund",
+			AdminAuth(),
+			delivery.Idempotent(delivery.RefundOrder),
+		},
+		{
+			"PaymentWebhook",
+			http.MethodPost,
+			"/payments/webhook",
+			noOpMiddleware,
+			delivery.PaymentWebhook,
+		},
+		// -------------------------RETURNS------------------------------------------------------------------------------
+		{
+			"CreateReturn",
+			http.MethodPost,
+			"/order/:orderID/returns",
+			UserAuth(),
+			delivery.Idempotent(delivery.CreateReturn),
+		},
+		{
+			"GetReturns",
+			http.MethodGet,
+			"/returns",
+			AdminAuth(),
+			delivery.GetReturns,
+		},
+		{
+			"GetReturn",
+			http.MethodGet,
+			"/returns/:returnID",
+			UserAuth(),
+			delivery.GetReturn,
+		},
+		{
+			"ChangeReturnStatus",
+			http.MethodPost,
+			"/returns/:returnID/status",
+			AdminAuth(),
+			delivery.ChangeReturnStatus,
+		},
+		{
+			"SetReturnTracking",
+			http.MethodPut,
+			"/returns/:returnID/tracking",
+			UserAuth(),
+			delivery.SetReturnTracking,
+		},
+		{
+			"RefundReturn",
+			http.MethodPost,
+			"/returns/:returnID/refund",
+			AdminAuth(),
+			delivery.Idempotent(delivery.RefundReturn),
+		},
 	}
 
 	for _, route := range routes {
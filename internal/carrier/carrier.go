@@ -0,0 +1,71 @@
+// Package carrier creates shipments of orders in the delivery service and tracks them.
+// For local development and tests the fake carrier is used, which delivers nothing
+package carrier
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Statuses of tracking events
+const (
+	EventAccepted       EventStatus = "accepted"
+	EventInTransit      EventStatus = "in_transit"
+	EventOutForDelivery EventStatus = "out_for_delivery"
+	EventDelivered      EventStatus = "delivered"
+	// EventException means that the delivery is delayed or failed, the carrier reports the reason in the description
+	EventException EventStatus = "exception"
+)
+
+// ErrUnknownTrackingNumber is returned if the carrier has no shipment with the tracking number
+var ErrUnknownTrackingNumber = errors.New("unknown tracking number")
+
+type EventStatus string
+
+// Parcel is the order handed over to the carrier
+type Parcel struct {
+	OrderId   string
+	Zipcode   string
+	Country   string
+	City      string
+	Street    string
+	Apartment string
+	Recipient string
+	Phone     string
+	// Weight is the weight of the parcel in grams, zero if weights of items are unknown
+	Weight int64
+}
+
+// Shipment is the shipment created by the carrier
+type Shipment struct {
+	TrackingNumber string
+}
+
+// Event is the step of the delivery of the shipment reported by the carrier
+type Event struct {
+	Status      EventStatus
+	Description string
+	Location    string
+	Time        time.Time
+}
+
+// Carrier is the delivery service which delivers orders to customers
+type Carrier interface {
+	// Name returns the name of the carrier saved with shipments
+	Name() string
+	// CreateShipment registers the parcel in the carrier and returns its tracking number
+	CreateShipment(ctx context.Context, parcel Parcel) (*Shipment, error)
+	// Track returns all events of the shipment with the tracking number, the oldest events go first
+	Track(ctx context.Context, trackingNumber string) ([]Event, error)
+}
+
+// Delivered reports whether the events report the delivery of the shipment
+func Delivered(events []Event) bool {
+	for _, event := range events {
+		if event.Status == EventDelivered {
+			return true
+		}
+	}
+	return false
+}
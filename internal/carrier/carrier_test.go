@@ -0,0 +1,53 @@
+package carrier
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestFakeCarrier(t *testing.T) {
+	carrier := NewFakeCarrier(time.Hour, zap.L())
+	created := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	carrier.now = func() time.Time { return created }
+	ctx := context.Background()
+
+	_, err := carrier.CreateShipment(ctx, Parcel{OrderId: "1", City: "Moscow"})
+	require.Error(t, err)
+
+	shipment, err := carrier.CreateShipment(ctx, Parcel{OrderId: "1", Zipcode: "123456", City: "Moscow", Street: "Lenina, 1"})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(shipment.TrackingNumber, "FAKE-1672531200-"))
+
+	events, err := carrier.Track(ctx, shipment.TrackingNumber)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, EventAccepted, events[0].Status)
+	require.Equal(t, created, events[0].Time)
+	require.False(t, Delivered(events))
+
+	carrier.now = func() time.Time { return created.Add(2*time.Hour + time.Minute) }
+	events, err = carrier.Track(ctx, shipment.TrackingNumber)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	require.Equal(t, EventOutForDelivery, events[2].Status)
+	require.Equal(t, created.Add(2*time.Hour), events[2].Time)
+	require.False(t, Delivered(events))
+
+	// The shipment is tracked by the new carrier, for example after the restart
+	carrier = NewFakeCarrier(time.Hour, zap.L())
+	carrier.now = func() time.Time { return created.Add(3 * time.Hour) }
+	events, err = carrier.Track(ctx, shipment.TrackingNumber)
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+	require.True(t, Delivered(events))
+
+	for _, number := range []string{"", "1Z999AA10123456784", "FAKE-abc-1", "FAKE-1"} {
+		_, err = carrier.Track(ctx, number)
+		require.ErrorIs(t, err, ErrUnknownTrackingNumber, number)
+	}
+}
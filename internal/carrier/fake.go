@@ -0,0 +1,81 @@
+package carrier
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// fakePrefix is the prefix of tracking numbers of the fake carrier
+const fakePrefix = "FAKE-"
+
+var _ Carrier = (*FakeCarrier)(nil)
+
+// FakeCarrier delivers every shipment in three steps: the accepted shipment is in transit after the step,
+// out for delivery after two steps and delivered after three. The time of creation is kept in the tracking
+// number, so shipments are tracked after the restart of the service
+type FakeCarrier struct {
+	step   time.Duration
+	now    func() time.Time
+	logger *zap.Logger
+}
+
+func NewFakeCarrier(step time.Duration, logger *zap.Logger) *FakeCarrier {
+	logger.Sugar().Debugf("Enter in NewFakeCarrier() with args: step: %v", step)
+	return &FakeCarrier{step: step, now: time.Now, logger: logger}
+}
+
+func (carrier *FakeCarrier) Name() string {
+	return "fake"
+}
+
+// CreateShipment returns the tracking number like FAKE-1672531200-1a2b3c4d
+func (carrier *FakeCarrier) CreateShipment(ctx context.Context, parcel Parcel) (*Shipment, error) {
+	carrier.logger.Sugar().Debugf("Enter in carrier FakeCarrier CreateShipment() with args: ctx, parcel: %v", parcel)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if parcel.Zipcode == "" || parcel.City == "" || parcel.Street == "" {
+		return nil, fmt.Errorf("address of parcel of order %s is incomplete", parcel.OrderId)
+	}
+	number := fakePrefix + strconv.FormatInt(carrier.now().Unix(), 10) + "-" + strings.ToUpper(uuid.NewString()[:8])
+	return &Shipment{TrackingNumber: number}, nil
+}
+
+// Track returns events of the shipment which happened by now
+func (carrier *FakeCarrier) Track(ctx context.Context, trackingNumber string) ([]Event, error) {
+	carrier.logger.Sugar().Debugf("Enter in carrier FakeCarrier Track() with args: ctx, trackingNumber: %s", trackingNumber)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	parts := strings.Split(strings.TrimPrefix(trackingNumber, fakePrefix), "-")
+	if !strings.HasPrefix(trackingNumber, fakePrefix) || len(parts) != 2 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTrackingNumber, trackingNumber)
+	}
+	seconds, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTrackingNumber, trackingNumber)
+	}
+	created := time.Unix(seconds, 0).UTC()
+	steps := []Event{
+		{Status: EventAccepted, Description: "Shipment is accepted by the carrier", Location: "Sorting center"},
+		{Status: EventInTransit, Description: "Shipment is in transit", Location: "Sorting center"},
+		{Status: EventOutForDelivery, Description: "Shipment is out for delivery"},
+		{Status: EventDelivered, Description: "Shipment is delivered to the recipient"},
+	}
+	now := carrier.now()
+	events := make([]Event, 0, len(steps))
+	for i, event := range steps {
+		event.Time = created.Add(time.Duration(i) * carrier.step)
+		if event.Time.After(now) {
+			break
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
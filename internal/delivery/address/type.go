@@ -0,0 +1,29 @@
+package address
+
+import (
+	"OnlineShopBackend/internal/delivery/order"
+	"time"
+)
+
+// Address is a structure for output the saved address of the address book of the user
+type Address struct {
+	Id              string             `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Name            string             `json:"name" example:"home"`
+	Address         order.OrderAddress `json:"address"`
+	DefaultShipping bool               `json:"default_shipping"`
+	CreatedAt       time.Time          `json:"created_at"`
+}
+
+// NewAddress is a structure for the request to save or change the address,
+// the first saved address becomes the default for shipping
+type NewAddress struct {
+	Name            string             `json:"name" binding:"required,max=64" example:"home"`
+	Address         order.OrderAddress `json:"address" binding:"required"`
+	DefaultShipping bool               `json:"default_shipping"`
+}
+
+// AddressesList is a structure for output the address book of the user
+type AddressesList struct {
+	List     []Address `json:"list"`
+	Quantity int       `json:"quantity" example:"1"`
+}
@@ -0,0 +1,208 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/address"
+	"OnlineShopBackend/internal/delivery/order"
+	"OnlineShopBackend/internal/models"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// addressResponse converts the saved address to the structure for output
+func addressResponse(saved *models.SavedAddress) address.Address {
+	return address.Address{
+		Id:              saved.Id.String(),
+		Name:            saved.Name,
+		Address:         order.OrderAddress(saved.Address),
+		DefaultShipping: saved.DefaultShipping,
+		CreatedAt:       saved.CreatedAt,
+	}
+}
+
+// addressErrorStatus returns the status code of the response to the error of the address book
+func addressErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, models.ErrorNotFound{}):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrorAlreadyExists{}):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// GetAddresses - get the address book of the user
+//
+//	@Summary		Get saved addresses
+//	@Description	The method returns addresses saved by the authorized user, the default addresses go first.
+//	@Tags			addresses
+//	@Produce		json
+//	@Success		200	{object}	address.AddressesList	"Saved addresses"
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/user/addresses [get]
+func (delivery *Delivery) GetAddresses(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery GetAddresses()")
+	claims, ok := delivery.getClaims(c)
+	if !ok {
+		delivery.SetError(c, http.StatusUnauthorized, fmt.Errorf("user unauthorized"))
+		return
+	}
+	addresses, err := delivery.addressUsecase.GetAddresses(c.Request.Context(), claims.UserId)
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't get addresses: %s", err)
+		delivery.SetError(c, addressErrorStatus(err), err)
+		return
+	}
+	list := address.AddressesList{List: make([]address.Address, 0, len(addresses)), Quantity: len(addresses)}
+	for i := range addresses {
+		list.List = append(list.List, addressResponse(&addresses[i]))
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// CreateAddress - save the address to the address book of the user
+//
+//	@Summary		Save address
+//	@Description	The method saves the named address of the authorized user. The first saved address becomes the default
+//	@Description	for shipping, marking the address as the default unmarks other addresses of the user.
+//	@Tags			addresses
+//	@Accept			json
+//	@Produce		json
+//	@Param			address	body		address.NewAddress	true	"Name and address"
+//	@Success		201		{object}	address.Address		"Saved address"
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		401		{object}	ErrorResponse
+//	@Failure		409		{object}	ErrorResponse	"Address with the name is already saved"
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/user/addresses [post]
+func (delivery *Delivery) CreateAddress(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery CreateAddress()")
+	claims, ok := delivery.getClaims(c)
+	if !ok {
+		delivery.SetError(c, http.StatusUnauthorized, fmt.Errorf("user unauthorized"))
+		return
+	}
+	var newAddress address.NewAddress
+	if err := c.ShouldBindJSON(&newAddress); err != nil {
+		delivery.logger.Sugar().Errorf("can't bind json from request: %s", err)
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	saved, err := delivery.addressUsecase.CreateAddress(c.Request.Context(), &models.SavedAddress{
+		UserId:          claims.UserId,
+		Name:            newAddress.Name,
+		Address:         models.UserAddress(newAddress.Address),
+		DefaultShipping: newAddress.DefaultShipping,
+	})
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't create address: %s", err)
+		delivery.SetError(c, addressErrorStatus(err), err)
+		return
+	}
+	c.JSON(http.StatusCreated, addressResponse(saved))
+}
+
+// GetAddress - get the saved address by id
+//
+//	@Summary		Get saved address
+//	@Description	The method returns the saved address of the authorized user.
+//	@Tags			addresses
+//	@Produce		json
+//	@Param			addressID	path		string			true	"Id of the address"
+//	@Success		200			{object}	address.Address	"Saved address"
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		404			{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/user/addresses/{addressID} [get]
+func (delivery *Delivery) GetAddress(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery GetAddress()")
+	addressId, err := uuid.Parse(c.Param("addressID"))
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't parse address id: %s", err)
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	saved, err := delivery.addressUsecase.GetAddress(c.Request.Context(), addressId)
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't get address: %s", err)
+		delivery.SetError(c, addressErrorStatus(err), err)
+		return
+	}
+	c.JSON(http.StatusOK, addressResponse(saved))
+}
+
+// UpdateAddress - change the saved address
+//
+//	@Summary		Change saved address
+//	@Description	The method changes the name, the address and the defaults of the saved address.
+//	@Tags			addresses
+//	@Accept			json
+//	@Produce		json
+//	@Param			addressID	path		string				true	"Id of the address"
+//	@Param			address		body		address.NewAddress	true	"Name and address"
+//	@Success		200			{object}	address.Address		"Changed address"
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		404			{object}	ErrorResponse	"404 Not Found"
+//	@Failure		409			{object}	ErrorResponse	"Address with the name is already saved"
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/user/addresses/{addressID} [put]
+func (delivery *Delivery) UpdateAddress(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery UpdateAddress()")
+	addressId, err := uuid.Parse(c.Param("addressID"))
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't parse address id: %s", err)
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var newAddress address.NewAddress
+	if err := c.ShouldBindJSON(&newAddress); err != nil {
+		delivery.logger.Sugar().Errorf("can't bind json from request: %s", err)
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	saved, err := delivery.addressUsecase.UpdateAddress(c.Request.Context(), &models.SavedAddress{
+		Id:              addressId,
+		Name:            newAddress.Name,
+		Address:         models.UserAddress(newAddress.Address),
+		DefaultShipping: newAddress.DefaultShipping,
+	})
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't update address: %s", err)
+		delivery.SetError(c, addressErrorStatus(err), err)
+		return
+	}
+	c.JSON(http.StatusOK, addressResponse(saved))
+}
+
+// DeleteAddress - delete the saved address
+//
+//	@Summary		Delete saved address
+//	@Description	The method removes the address from the address book, the user has no default address
+//	@Description	for shipping if the removed address was the default.
+//	@Tags			addresses
+//	@Param			addressID	path	string	true	"Id of the address"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/user/addresses/{addressID} [delete]
+func (delivery *Delivery) DeleteAddress(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery DeleteAddress()")
+	addressId, err := uuid.Parse(c.Param("addressID"))
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't parse address id: %s", err)
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	if err := delivery.addressUsecase.DeleteAddress(c.Request.Context(), addressId); err != nil {
+		delivery.logger.Sugar().Errorf("can't delete address: %s", err)
+		delivery.SetError(c, addressErrorStatus(err), err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
@@ -0,0 +1,310 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/usecase/mocks"
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var testSavedAddress = models.SavedAddress{
+	Id:     testId,
+	UserId: testUserId,
+	Name:   "home",
+	Address: models.UserAddress{
+		Zipcode: "101000",
+		Country: "Russia",
+		City:    "Moscow",
+		Street:  "Tverskaya, 1",
+	},
+	DefaultShipping: true,
+}
+
+func TestGetAddresses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	addressUsecase := mocks.NewMockIAddressUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: zap.L(), AddressUsecase: addressUsecase})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/user/addresses", nil)
+	delivery.GetAddresses(c)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/user/addresses", nil)
+	c.Set("claims", testClaims)
+	addressUsecase.EXPECT().GetAddresses(gomock.Any(), testUserId).Return(nil, fmt.Errorf("error"))
+	delivery.GetAddresses(c)
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/user/addresses", nil)
+	c.Set("claims", testClaims)
+	addressUsecase.EXPECT().GetAddresses(gomock.Any(), testUserId).Return([]models.SavedAddress{testSavedAddress}, nil)
+	delivery.GetAddresses(c)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"name":"home"`)
+	require.Contains(t, w.Body.String(), `"city":"Moscow"`)
+	require.Contains(t, w.Body.String(), `"default_shipping":true`)
+	require.Contains(t, w.Body.String(), `"quantity":1`)
+}
+
+func TestCreateAddress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	addressUsecase := mocks.NewMockIAddressUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: zap.L(), AddressUsecase: addressUsecase})
+	body := `{"name":"home","address":{"zipcode":"101000","country":"Russia","city":"Moscow","street":"Tverskaya, 1"},
+	"default_shipping":true}`
+	request := func(body string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/user/addresses", bytes.NewBufferString(body))
+		c.Set("claims", testClaims)
+		return w, c
+	}
+
+	for _, body := range []string{`{"address":{"zipcode":"101000","city":"Moscow","street":"Tverskaya, 1"}}`, `{"name":"home"}`, "{"} {
+		w, c := request(body)
+		delivery.CreateAddress(c)
+		require.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+
+	expected := testSavedAddress
+	expected.Id = uuid.Nil
+	for err, code := range map[error]int{
+		models.ErrorAlreadyExists{}: http.StatusConflict,
+		fmt.Errorf("error"):         http.StatusInternalServerError,
+	} {
+		addressUsecase.EXPECT().CreateAddress(gomock.Any(), &expected).Return(nil, err)
+		w, c := request(body)
+		delivery.CreateAddress(c)
+		require.Equal(t, code, w.Code)
+	}
+
+	addressUsecase.EXPECT().CreateAddress(gomock.Any(), &expected).Return(&testSavedAddress, nil)
+	w, c := request(body)
+	delivery.CreateAddress(c)
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Contains(t, w.Body.String(), testId.String())
+}
+
+func TestGetAddress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	addressUsecase := mocks.NewMockIAddressUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: zap.L(), AddressUsecase: addressUsecase})
+	request := func(id string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/user/addresses/"+id, nil)
+		c.Params = gin.Params{{Key: "addressID", Value: id}}
+		return w, c
+	}
+
+	w, c := request("1")
+	delivery.GetAddress(c)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	addressUsecase.EXPECT().GetAddress(gomock.Any(), testId).Return(nil, models.ErrorNotFound{})
+	w, c = request(testId.String())
+	delivery.GetAddress(c)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	addressUsecase.EXPECT().GetAddress(gomock.Any(), testId).Return(&testSavedAddress, nil)
+	w, c = request(testId.String())
+	delivery.GetAddress(c)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"street":"Tverskaya, 1"`)
+}
+
+func TestUpdateAddress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	addressUsecase := mocks.NewMockIAddressUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: zap.L(), AddressUsecase: addressUsecase})
+	body := `{"name":"work","address":{"zipcode":"101000","city":"Moscow","street":"Arbat, 2"},"default_shipping":true}`
+	request := func(id string, body string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPut, "/user/addresses/"+id, bytes.NewBufferString(body))
+		c.Params = gin.Params{{Key: "addressID", Value: id}}
+		return w, c
+	}
+
+	w, c := request("1", body)
+	delivery.UpdateAddress(c)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w, c = request(testId.String(), `{"name":"work"}`)
+	delivery.UpdateAddress(c)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	changed := models.SavedAddress{
+		Id:              testId,
+		Name:            "work",
+		Address:         models.UserAddress{Zipcode: "101000", City: "Moscow", Street: "Arbat, 2"},
+		DefaultShipping: true,
+	}
+	for err, code := range map[error]int{
+		models.ErrorNotFound{}:      http.StatusNotFound,
+		models.ErrorAlreadyExists{}: http.StatusConflict,
+		fmt.Errorf("error"):         http.StatusInternalServerError,
+	} {
+		addressUsecase.EXPECT().UpdateAddress(gomock.Any(), &changed).Return(nil, err)
+		w, c = request(testId.String(), body)
+		delivery.UpdateAddress(c)
+		require.Equal(t, code, w.Code)
+	}
+
+	addressUsecase.EXPECT().UpdateAddress(gomock.Any(), &changed).DoAndReturn(
+		func(_ interface{}, address *models.SavedAddress) (*models.SavedAddress, error) {
+			address.UserId = testUserId
+			return address, nil
+		})
+	w, c = request(testId.String(), body)
+	delivery.UpdateAddress(c)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"name":"work"`)
+	require.Contains(t, w.Body.String(), `"default_shipping":true`)
+}
+
+func TestDeleteAddress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	addressUsecase := mocks.NewMockIAddressUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: zap.L(), AddressUsecase: addressUsecase})
+	request := func(id string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodDelete, "/user/addresses/"+id, nil)
+		c.Params = gin.Params{{Key: "addressID", Value: id}}
+		return w, c
+	}
+
+	w, c := request("1")
+	delivery.DeleteAddress(c)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	addressUsecase.EXPECT().DeleteAddress(gomock.Any(), testId).Return(models.ErrorNotFound{})
+	w, c = request(testId.String())
+	delivery.DeleteAddress(c)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	addressUsecase.EXPECT().DeleteAddress(gomock.Any(), testId).Return(nil)
+	w, c = request(testId.String())
+	delivery.DeleteAddress(c)
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestCreateOrderWithSavedAddress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	checkoutUsecase := mocks.NewMockICheckoutUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: zap.L(), CheckoutUsecase: checkoutUsecase})
+	cartId := uuid.New()
+	request := func(address string) (*httptest.ResponseRecorder, *gin.Context) {
+		body := fmt.Sprintf(`{"cart":{"id":"%s","items":[]},"user":{"id":"%s","email":"test@test.ru"}%s}`, cartId, testUserId, address)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/order/create", bytes.NewBufferString(body))
+		c.Set("claims", testClaims)
+		return w, c
+	}
+	user := models.User{ID: testUserId, Email: "test@test.ru"}
+	itemIds := []uuid.UUID{}
+
+	w, c := request(`,"address_id":"1"`)
+	delivery.CreateOrder(c)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// The saved address of another user is not found
+	checkoutUsecase.EXPECT().OrderAddress(gomock.Any(), testUserId, nil, testId).Return(models.UserAddress{}, models.ErrorNotFound{})
+	w, c = request(fmt.Sprintf(`,"address_id":"%s"`, testId))
+	delivery.CreateOrder(c)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	checkoutUsecase.EXPECT().OrderAddress(gomock.Any(), testUserId, nil, testId).Return(testSavedAddress.Address, nil)
+	checkoutUsecase.EXPECT().Checkout(gomock.Any(), cartId, itemIds, user, testSavedAddress.Address, "").
+		Return(&models.Order{ID: testId}, cartId, nil)
+	w, c = request(fmt.Sprintf(`,"address_id":"%s"`, testId))
+	delivery.CreateOrder(c)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	// The default shipping address is used if the address is not set
+	checkoutUsecase.EXPECT().OrderAddress(gomock.Any(), testUserId, nil, uuid.Nil).Return(models.UserAddress{}, models.ErrorAddressNotSet{})
+	w, c = request("")
+	delivery.CreateOrder(c)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	checkoutUsecase.EXPECT().OrderAddress(gomock.Any(), testUserId, nil, uuid.Nil).Return(testSavedAddress.Address, nil)
+	checkoutUsecase.EXPECT().Checkout(gomock.Any(), cartId, itemIds, user, testSavedAddress.Address, "").
+		Return(&models.Order{ID: testId}, cartId, nil)
+	w, c = request("")
+	delivery.CreateOrder(c)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	// The address of the request is used as is
+	address := models.UserAddress{Zipcode: "190000", City: "Saint Petersburg", Street: "Nevsky, 3"}
+	checkoutUsecase.EXPECT().OrderAddress(gomock.Any(), testUserId, &address, uuid.Nil).Return(address, nil)
+	checkoutUsecase.EXPECT().Checkout(gomock.Any(), cartId, itemIds, user, address, "").
+		Return(&models.Order{ID: testId}, cartId, nil)
+	w, c = request(`,"address":{"zipcode":"190000","city":"Saint Petersburg","street":"Nevsky, 3"}`)
+	delivery.CreateOrder(c)
+	require.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestCreateOrderItemsOfStoredCart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	checkoutUsecase := mocks.NewMockICheckoutUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: zap.L(), CheckoutUsecase: checkoutUsecase})
+	cartId := uuid.New()
+	request := func(items string) (*httptest.ResponseRecorder, *gin.Context) {
+		body := fmt.Sprintf(`{"cart":{"id":"%s","items":[%s]},"user":{"id":"%s","email":"test@test.ru"},
+	"address":{"zipcode":"190000","city":"Saint Petersburg","street":"Nevsky, 3"}}`, cartId, items, testUserId)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/order/create", bytes.NewBufferString(body))
+		c.Set("claims", testClaims)
+		return w, c
+	}
+	line := fmt.Sprintf(`{"item":{"id":"%s","price":100000},"quantity":-5}`, testId)
+	checkoutUsecase.EXPECT().OrderAddress(gomock.Any(), testUserId, gomock.Any(), uuid.Nil).DoAndReturn(
+		func(ctx context.Context, userId uuid.UUID, address *models.UserAddress, addressId uuid.UUID) (models.UserAddress, error) {
+			return *address, nil
+		}).AnyTimes()
+
+	// Items sent more than once are rejected before the checkout
+	w, c := request(line + "," + line)
+	delivery.CreateOrder(c)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Only ids of items are passed, the quantity and the price of the request are replaced by the stored ones
+	checkoutUsecase.EXPECT().Checkout(gomock.Any(), cartId, []uuid.UUID{testId}, gomock.Any(), gomock.Any(), "").
+		Return(&models.Order{ID: testId}, cartId, nil)
+	w, c = request(line)
+	delivery.CreateOrder(c)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	// The item with the inflated price is not in the stored cart
+	checkoutUsecase.EXPECT().Checkout(gomock.Any(), cartId, []uuid.UUID{testId}, gomock.Any(), gomock.Any(), "").
+		Return(nil, uuid.Nil, fmt.Errorf("error on checkout: %w", models.ErrorNotInCart{}))
+	w, c = request(line)
+	delivery.CreateOrder(c)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,30 @@
+package audit
+
+import (
+	"time"
+)
+
+// Actor is a structure for output the user who made the change
+type Actor struct {
+	UserId string `json:"userId,omitempty" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Email  string `json:"email,omitempty" example:"admin@mail.ru"`
+	Role   string `json:"role,omitempty" example:"Admin"`
+}
+
+// Entry is a structure for output entries of the audit log
+type Entry struct {
+	Id        string                 `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Actor     Actor                  `json:"actor"`
+	Action    string                 `json:"action" example:"item.update"`
+	Entity    string                 `json:"entity" example:"item"`
+	EntityId  string                 `json:"entityId" example:"00000000-0000-0000-0000-000000000000"`
+	Before    map[string]interface{} `json:"before"`
+	After     map[string]interface{} `json:"after"`
+	CreatedAt time.Time              `json:"createdAt"`
+}
+
+// EntriesList is a structure for audit log query results
+type EntriesList struct {
+	List     []Entry `json:"entries" binding:"min=0" minimum:"0"`
+	Quantity int     `json:"quantity" example:"10" default:"0" binding:"min=0" minimum:"0"`
+}
@@ -0,0 +1,107 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/audit"
+	"OnlineShopBackend/internal/models"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetAuditLog - get entries of the audit log
+//
+//	@Summary		Get audit log
+//	@Description	Method provides to get changes made by administrators, the newest changes go first.
+//	@Tags			audit
+//	@Accept			json
+//	@Produce		json
+//	@Param			actorID		query		string				false	"Id of user who made the change"
+//	@Param			action		query		string				false	"Action (item.update, user.role_update, order.status_change, category.delete)"
+//	@Param			entity		query		string				false	"Entity (item, user, order, category)"
+//	@Param			entityID	query		string				false	"Id of changed entity"
+//	@Param			from		query		string				false	"Start of period in RFC3339 format"
+//	@Param			to			query		string				false	"End of period in RFC3339 format"
+//	@Param			offset		query		int					false	"Offset"
+//	@Param			limit		query		int					false	"Limit"
+//	@Success		200			{object}	audit.EntriesList	"List of audit log entries"
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		401			"Unauthorized"
+//	@Failure		403			"Forbidden"
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/audit [get]
+func (delivery *Delivery) GetAuditLog(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery GetAuditLog()")
+	filter, err := auditFilter(c)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	entries, err := delivery.auditUsecase.GetAuditLog(c.Request.Context(), filter)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	list := audit.EntriesList{List: make([]audit.Entry, 0, len(entries)), Quantity: len(entries)}
+	for _, entry := range entries {
+		actor := audit.Actor{Email: entry.Actor.Email, Role: entry.Actor.Role}
+		if entry.Actor.UserId != uuid.Nil {
+			actor.UserId = entry.Actor.UserId.String()
+		}
+		list.List = append(list.List, audit.Entry{
+			Id:        entry.Id.String(),
+			Actor:     actor,
+			Action:    entry.Action,
+			Entity:    entry.Entity,
+			EntityId:  entry.EntityId,
+			Before:    entry.Before,
+			After:     entry.After,
+			CreatedAt: entry.CreatedAt,
+		})
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// auditFilter parses query parameters of the audit log request
+func auditFilter(c *gin.Context) (models.AuditFilter, error) {
+	filter := models.AuditFilter{
+		Action:   c.Query("action"),
+		Entity:   c.Query("entity"),
+		EntityId: c.Query("entityID"),
+	}
+	var err error
+	if actorId := c.Query("actorID"); actorId != "" {
+		if filter.ActorId, err = uuid.Parse(actorId); err != nil {
+			return filter, fmt.Errorf("invalid actorID: %w", err)
+		}
+	}
+	if from := c.Query("from"); from != "" {
+		if filter.From, err = time.Parse(time.RFC3339, from); err != nil {
+			return filter, fmt.Errorf("invalid from: %w", err)
+		}
+	}
+	if to := c.Query("to"); to != "" {
+		if filter.To, err = time.Parse(time.RFC3339, to); err != nil {
+			return filter, fmt.Errorf("invalid to: %w", err)
+		}
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.From.After(filter.To) {
+		return filter, fmt.Errorf("from is after to")
+	}
+	if offset := c.Query("offset"); offset != "" {
+		if filter.Offset, err = strconv.Atoi(offset); err != nil || filter.Offset < 0 {
+			return filter, fmt.Errorf("invalid offset: %s", offset)
+		}
+	}
+	if limit := c.Query("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil || filter.Limit < 0 {
+			return filter, fmt.Errorf("invalid limit: %s", limit)
+		}
+	}
+	return filter, nil
+}
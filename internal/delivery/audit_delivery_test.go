@@ -0,0 +1,69 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/usecase/mocks"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestGetAuditLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	auditUsecase := mocks.NewMockIAuditUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, AuditUsecase: auditUsecase})
+
+	for _, query := range []string{"actorID=1", "from=yesterday", "to=1", "limit=-1", "offset=a",
+		"from=2023-01-02T00:00:00Z&to=2023-01-01T00:00:00Z"} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/audit?"+query, nil)
+		delivery.GetAuditLog(c)
+		require.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	filter := models.AuditFilter{
+		ActorId:  testUserId,
+		Action:   models.AuditItemUpdate,
+		Entity:   models.AuditEntityItem,
+		EntityId: testId.String(),
+		From:     from,
+		Limit:    10,
+	}
+	url := fmt.Sprintf("/audit?actorID=%s&action=%s&entity=%s&entityID=%s&from=%s&limit=10",
+		testUserId, models.AuditItemUpdate, models.AuditEntityItem, testId, from.Format(time.RFC3339))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, url, nil)
+	auditUsecase.EXPECT().GetAuditLog(gomock.Any(), filter).Return(nil, fmt.Errorf("error"))
+	delivery.GetAuditLog(c)
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, url, nil)
+	auditUsecase.EXPECT().GetAuditLog(gomock.Any(), filter).Return([]models.AuditEntry{{
+		Id:       testId,
+		Actor:    models.Actor{UserId: testUserId, Email: "admin@mail.ru", Role: models.Admin},
+		Action:   models.AuditItemUpdate,
+		Entity:   models.AuditEntityItem,
+		EntityId: testId.String(),
+		Before:   map[string]interface{}{"price": 10},
+		After:    map[string]interface{}{"price": 20},
+	}}, nil)
+	delivery.GetAuditLog(c)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"before":{"price":10}`)
+	require.Contains(t, w.Body.String(), `"quantity":1`)
+}
@@ -3,16 +3,40 @@ package cart
 import (
 	"OnlineShopBackend/internal/delivery/item"
 	"sort"
+	"time"
 )
 
 type Cart struct {
 	Id     string     `json:"id" binding:"required,uuid" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
 	UserId string     `json:"userId,omitempty" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
 	Items  []CartItem `json:"items" binding:"min=0" minimum:"0"`
+	// SavedItems is the items saved for later, they are not included in the totals and the order
+	SavedItems []CartItem `json:"savedItems,omitempty"`
+	Totals     Totals     `json:"totals"`
+	Notices    []Notice   `json:"notices"`
+}
+
+// Totals is the sums of the cart, deleted and unavailable items are not included
+type Totals struct {
+	Subtotal int64 `json:"subtotal" example:"1500"`
+	Discount int64 `json:"discount" example:"0"`
+	Shipping int64 `json:"shipping" example:"300"`
+	Total    int64 `json:"total" example:"1800"`
+}
+
+// Notice tells about the change of the item since it was added to the cart
+type Notice struct {
+	ItemId    string `json:"itemId" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Title     string `json:"title" example:"Item"`
+	Type      string `json:"type" example:"price_changed" enums:"price_changed,deleted,unavailable"`
+	OldPrice  int32  `json:"oldPrice,omitempty" example:"400"`
+	NewPrice  int32  `json:"newPrice,omitempty" example:"500"`
+	Available *int   `json:"available,omitempty" example:"0"`
 }
 
 func (cart *Cart) SortCartItems() {
 	sort.Slice(cart.Items, func(i, j int) bool { return cart.Items[i].Item.Title < cart.Items[j].Item.Title })
+	sort.Slice(cart.SavedItems, func(i, j int) bool { return cart.SavedItems[i].Item.Title < cart.SavedItems[j].Item.Title })
 }
 
 type ShortCart struct {
@@ -27,8 +51,37 @@ type CartId struct {
 type CartItem struct {
 	Item item.OutItem `json:"item"`
 	Quantity
+	// LineTotal is zero for deleted and unavailable items
+	LineTotal int64 `json:"lineTotal" example:"1000"`
 }
 
 type Quantity struct {
 	Quantity int `json:"quantity" example:"3" default:"1" binding:"required" minimum:"1"`
 }
+
+// SetQuantity is a structure for setting the quantity of the item in the cart
+type SetQuantity struct {
+	Quantity *int `json:"quantity" example:"3" binding:"required,min=0" minimum:"0"`
+}
+
+// GuestCart is a structure of the created guest cart with the token which gives access to it
+type GuestCart struct {
+	CartId string `json:"cartId" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Token  string `json:"token"`
+}
+
+// ShippingAddress is a structure for the address of delivery of the cart in the query
+type ShippingAddress struct {
+	Zipcode string `form:"zipcode" binding:"required" example:"123456"`
+	Country string `form:"country" example:"Russia"`
+	City    string `form:"city" binding:"required" example:"Moscow"`
+}
+
+// ShippingQuote is the cost and the period of delivery of the cart by the shipping method
+type ShippingQuote struct {
+	Method       string    `json:"method" example:"courier"`
+	Name         string    `json:"name" example:"Courier"`
+	Cost         int64     `json:"cost" example:"300"`
+	Days         int       `json:"days" example:"3"`
+	ShipmentTime time.Time `json:"shipment_time"`
+}
@@ -11,7 +11,9 @@ package delivery
 
 import (
 	"OnlineShopBackend/internal/delivery/cart"
+	"OnlineShopBackend/internal/delivery/user/jwtauth"
 	"OnlineShopBackend/internal/models"
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -36,7 +38,7 @@ import (
 //	@Router			/cart/{cartID} [get]
 func (delivery *Delivery) GetCart(c *gin.Context) {
 	delivery.logger.Debug("Enter in delivery GetCart()")
-	ctx := c.Request.Context()
+	ctx := delivery.cartContext(c)
 
 	cartId, err := uuid.Parse(c.Param("cartID"))
 	if err != nil {
@@ -58,29 +60,7 @@ func (delivery *Delivery) GetCart(c *gin.Context) {
 		return
 	}
 
-	cartItems := make([]cart.CartItem, len(modelCart.Items))
-	for idx, item := range modelCart.Items {
-		cartItems[idx].Item.Id = item.Id.String()
-		cartItems[idx].Item.Title = item.Title
-		cartItems[idx].Item.Description = item.Description
-		cartItems[idx].Item.Category.Id = item.Category.Id.String()
-		cartItems[idx].Item.Category.Name = item.Category.Name
-		cartItems[idx].Item.Category.Description = item.Category.Description
-		cartItems[idx].Item.Category.Image = item.Category.Image
-		cartItems[idx].Item.Price = item.Price
-		cartItems[idx].Item.Vendor = item.Vendor
-		cartItems[idx].Item.Images = item.Images
-		cartItems[idx].Quantity.Quantity = item.Quantity
-	}
-
-	cart := cart.Cart{
-		Id:     modelCart.Id.String(),
-		UserId: modelCart.UserId.String(),
-		Items:  cartItems,
-	}
-	cart.SortCartItems()
-
-	c.JSON(http.StatusOK, cart)
+	c.JSON(http.StatusOK, newCartResponse(modelCart))
 }
 
 // GetCartByUserId - get a specific cart by user id
@@ -121,29 +101,70 @@ func (delivery *Delivery) GetCartByUserId(c *gin.Context) {
 		return
 	}
 
+	c.JSON(http.StatusOK, newCartResponse(modelCart))
+}
+
+// newCartResponse converts the cart model to the cart structure of the response
+func newCartResponse(modelCart *models.Cart) cart.Cart {
 	cartItems := make([]cart.CartItem, len(modelCart.Items))
 	for idx, item := range modelCart.Items {
-		cartItems[idx].Item.Id = item.Id.String()
-		cartItems[idx].Item.Title = item.Title
-		cartItems[idx].Item.Description = item.Description
-		cartItems[idx].Item.Category.Id = item.Category.Id.String()
-		cartItems[idx].Item.Category.Name = item.Category.Name
-		cartItems[idx].Item.Category.Description = item.Category.Description
-		cartItems[idx].Item.Category.Image = item.Category.Image
-		cartItems[idx].Item.Price = item.Price
-		cartItems[idx].Item.Vendor = item.Vendor
-		cartItems[idx].Item.Images = item.Images
-		cartItems[idx].Quantity.Quantity = item.Quantity
-	}
-
-	cart := cart.Cart{
-		Id:     modelCart.Id.String(),
-		UserId: modelCart.UserId.String(),
-		Items:  cartItems,
-	}
-	cart.SortCartItems()
-
-	c.JSON(http.StatusOK, cart)
+		cartItems[idx] = newCartItem(item)
+		cartItems[idx].LineTotal = modelCart.Totals.LineTotals[item.Id]
+	}
+	savedItems := make([]cart.CartItem, len(modelCart.SavedItems))
+	for idx, item := range modelCart.SavedItems {
+		savedItems[idx] = newCartItem(item)
+	}
+
+	notices := make([]cart.Notice, len(modelCart.Totals.Notices))
+	for idx, notice := range modelCart.Totals.Notices {
+		notices[idx] = cart.Notice{
+			ItemId:   notice.ItemId.String(),
+			Title:    notice.Title,
+			Type:     string(notice.Type),
+			OldPrice: notice.OldPrice,
+			NewPrice: notice.NewPrice,
+		}
+		if notice.Type == models.CartNoticeUnavailable {
+			available := notice.Available
+			notices[idx].Available = &available
+		}
+	}
+
+	response := cart.Cart{
+		Id:         modelCart.Id.String(),
+		Items:      cartItems,
+		SavedItems: savedItems,
+		Totals: cart.Totals{
+			Subtotal: modelCart.Totals.Subtotal,
+			Discount: modelCart.Totals.Discount,
+			Shipping: modelCart.Totals.Shipping,
+			Total:    modelCart.Totals.Total,
+		},
+		Notices: notices,
+	}
+	if modelCart.UserId != uuid.Nil {
+		response.UserId = modelCart.UserId.String()
+	}
+	response.SortCartItems()
+	return response
+}
+
+// newCartItem converts the line of the cart model to the line of the cart structure of the response
+func newCartItem(item models.ItemWithQuantity) cart.CartItem {
+	var cartItem cart.CartItem
+	cartItem.Item.Id = item.Id.String()
+	cartItem.Item.Title = item.Title
+	cartItem.Item.Description = item.Description
+	cartItem.Item.Category.Id = item.Category.Id.String()
+	cartItem.Item.Category.Name = item.Category.Name
+	cartItem.Item.Category.Description = item.Category.Description
+	cartItem.Item.Category.Image = item.Category.Image
+	cartItem.Item.Price = item.Price
+	cartItem.Item.Vendor = item.Vendor
+	cartItem.Item.Images = item.Images
+	cartItem.Quantity.Quantity = item.Quantity
+	return cartItem
 }
 
 // CreateCart - create a new cart
@@ -172,6 +193,11 @@ func (delivery *Delivery) CreateCart(c *gin.Context) {
 		return
 	}
 	cartId, err := delivery.cartUsecase.Create(ctx, userUid)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	}
 	if err != nil {
 		delivery.logger.Error(err.Error())
 		delivery.SetError(c, http.StatusInternalServerError, err)
@@ -185,6 +211,7 @@ func (delivery *Delivery) CreateCart(c *gin.Context) {
 //
 //	@Summary		Method provides to add item to cart
 //	@Description	Method provides to add item to cart.
+//	@Description	Quantity can't be greater than maximum quantity of one item in cart and quantity of item in stock.
 //	@Tags			carts
 //	@Accept			json
 //	@Produce		json
@@ -197,7 +224,7 @@ func (delivery *Delivery) CreateCart(c *gin.Context) {
 //	@Router			/cart/addItem [put]
 func (delivery *Delivery) AddItemToCart(c *gin.Context) {
 	delivery.logger.Debug("Enter in delivery AddItemToCart()")
-	ctx := c.Request.Context()
+	ctx := delivery.cartContext(c)
 
 	var deliveryCart cart.ShortCart
 	if err := c.ShouldBindJSON(&deliveryCart); err != nil {
@@ -224,6 +251,154 @@ func (delivery *Delivery) AddItemToCart(c *gin.Context) {
 		return
 	}
 	err = delivery.cartUsecase.AddItemToCart(ctx, cartId, itemId)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		err = fmt.Errorf("cart with id: %v or item with id: %v not found", cartId, itemId)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil && errors.Is(err, models.ErrorQuantityExceeded{}) {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if err := delivery.statsUsecase.RecordItemCartAdd(ctx, itemId); err != nil {
+		delivery.logger.Warn(err.Error())
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// SetItemQuantity - set quantity of item in cart
+//
+//	@Summary		Method provides to set quantity of item in cart
+//	@Description	Method provides to set quantity of item in cart. Zero quantity removes item from cart.
+//	@Description	Quantity can't be greater than maximum quantity of one item in cart and quantity of item in stock.
+//	@Tags			carts
+//	@Accept			json
+//	@Produce		json
+//	@Param			cartID		path	string				true	"id of cart"
+//	@Param			itemID		path	string				true	"id of item"
+//	@Param			quantity	body	cart.SetQuantity	true	"Quantity of item"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/cart/{cartID}/items/{itemID} [put]
+func (delivery *Delivery) SetItemQuantity(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery SetItemQuantity()")
+	cartId, err := uuid.Parse(c.Param("cartID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	itemId, err := uuid.Parse(c.Param("itemID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	delivery.setItemQuantity(c, cartId, itemId)
+}
+
+// setItemQuantity sets quantity of item from the request body in the cart
+func (delivery *Delivery) setItemQuantity(c *gin.Context, cartId uuid.UUID, itemId uuid.UUID) {
+	var quantity cart.SetQuantity
+	if err := c.ShouldBindJSON(&quantity); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	ctx := delivery.cartContext(c)
+	err := delivery.cartUsecase.SetItemQuantity(ctx, cartId, itemId, *quantity.Quantity)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		err = fmt.Errorf("cart with id: %v or item with id: %v not found", cartId, itemId)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil && errors.Is(err, models.ErrorQuantityExceeded{}) {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// SaveItemForLater - move item from cart to the list saved for later
+//
+//	@Summary		Method provides to save item of cart for later
+//	@Description	Method provides to move item from cart to the list saved for later.
+//	@Description	Saved items are not included in the totals of the cart and in the order and stay in the cart after the order is placed.
+//	@Tags			carts
+//	@Accept			json
+//	@Produce		json
+//	@Param			cartID	path	string	true	"id of cart"
+//	@Param			itemID	path	string	true	"id of item"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/cart/{cartID}/items/{itemID}/saveForLater [post]
+func (delivery *Delivery) SaveItemForLater(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery SaveItemForLater()")
+	delivery.setItemSaved(c, true)
+}
+
+// MoveSavedItemToCart - move item from the list saved for later to cart
+//
+//	@Summary		Method provides to move saved item to cart
+//	@Description	Method provides to move item from the list saved for later back to cart.
+//	@Tags			carts
+//	@Accept			json
+//	@Produce		json
+//	@Param			cartID	path	string	true	"id of cart"
+//	@Param			itemID	path	string	true	"id of item"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/cart/{cartID}/saved/{itemID}/moveToCart [post]
+func (delivery *Delivery) MoveSavedItemToCart(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery MoveSavedItemToCart()")
+	delivery.setItemSaved(c, false)
+}
+
+// setItemSaved moves item from path parameters between cart and the list saved for later
+func (delivery *Delivery) setItemSaved(c *gin.Context, saved bool) {
+	cartId, err := uuid.Parse(c.Param("cartID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	itemId, err := uuid.Parse(c.Param("itemID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	ctx := delivery.cartContext(c)
+	err = delivery.cartUsecase.SetItemSaved(ctx, cartId, itemId, saved)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		err = fmt.Errorf("item with id: %v not found in cart with id: %v", itemId, cartId)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	}
 	if err != nil {
 		delivery.logger.Error(err.Error())
 		delivery.SetError(c, http.StatusInternalServerError, err)
@@ -249,7 +424,7 @@ func (delivery *Delivery) AddItemToCart(c *gin.Context) {
 func (delivery *Delivery) DeleteCart(c *gin.Context) {
 	delivery.logger.Debug("Enter in delivery DeleteCart()")
 
-	ctx := c.Request.Context()
+	ctx := delivery.cartContext(c)
 
 	cartId, err := uuid.Parse(c.Param("cartID"))
 	if err != nil {
@@ -259,6 +434,11 @@ func (delivery *Delivery) DeleteCart(c *gin.Context) {
 	}
 
 	err = delivery.cartUsecase.DeleteCart(ctx, cartId)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	}
 	if err != nil {
 		delivery.logger.Error(err.Error())
 		delivery.SetError(c, http.StatusInternalServerError, err)
@@ -285,7 +465,7 @@ func (delivery *Delivery) DeleteCart(c *gin.Context) {
 func (delivery *Delivery) DeleteItemFromCart(c *gin.Context) {
 	delivery.logger.Debug("Enter in delivery DeleteItemFromCart()")
 
-	ctx := c.Request.Context()
+	ctx := delivery.cartContext(c)
 
 	cartId, err := uuid.Parse(c.Param("cartID"))
 	if err != nil {
@@ -303,6 +483,11 @@ func (delivery *Delivery) DeleteItemFromCart(c *gin.Context) {
 	delivery.logger.Sugar().Debugf("itemId: %v", itemId)
 
 	err = delivery.cartUsecase.DeleteItemFromCart(ctx, cartId, itemId)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	}
 	if err != nil {
 		delivery.logger.Error(err.Error())
 		delivery.SetError(c, http.StatusInternalServerError, err)
@@ -311,3 +496,147 @@ func (delivery *Delivery) DeleteItemFromCart(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{})
 }
+
+const (
+	// cartTokenHeader is a header with the token of the guest cart
+	cartTokenHeader = "X-Cart-Token"
+	// cartTokenCookie is a cookie with the token of the guest cart, used when the header is not set
+	cartTokenCookie = "cart_token"
+)
+
+// guestCartId returns id of the guest cart from the signed cart token of the request
+func (delivery *Delivery) guestCartId(c *gin.Context) (uuid.UUID, bool) {
+	token := c.GetHeader(cartTokenHeader)
+	if token == "" {
+		cookie, err := c.Cookie(cartTokenCookie)
+		if err != nil {
+			return uuid.Nil, false
+		}
+		token = cookie
+	}
+	cartId, err := jwtauth.ParseCartToken(token)
+	if err != nil {
+		delivery.logger.Warn(err.Error())
+		return uuid.Nil, false
+	}
+	return cartId, true
+}
+
+// cartContext returns the context of the request which carries id of the guest cart from the signed cart token
+// of the request, so the guest cart is accessed only with its token
+func (delivery *Delivery) cartContext(c *gin.Context) context.Context {
+	ctx := c.Request.Context()
+	if cartId, ok := delivery.guestCartId(c); ok {
+		return models.ContextWithGuestCart(ctx, cartId)
+	}
+	return ctx
+}
+
+// CreateGuestCart - create a new cart for not authorized user
+//
+//	@Summary		Method provides to create guest cart
+//	@Description	Method provides to create cart for not authorized user.
+//	@Description	The returned token must be sent in the X-Cart-Token header to use the cart and on login to merge the cart into the cart of the user.
+//	@Tags			carts
+//	@Accept			json
+//	@Produce		json
+//	@Success		201	{object}	cart.GuestCart
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/guest/cart [post]
+func (delivery *Delivery) CreateGuestCart(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery CreateGuestCart()")
+	ctx := c.Request.Context()
+
+	cartId, err := delivery.cartUsecase.Create(ctx, uuid.Nil)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	token, err := jwtauth.CreateCartToken(cartId)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusCreated, cart.GuestCart{CartId: cartId.String(), Token: token})
+}
+
+// GetGuestCart - get the guest cart by cart token
+//
+//	@Summary		Get guest cart
+//	@Description	The method allows you to get the guest cart by the token from the X-Cart-Token header.
+//	@Tags			carts
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Cart-Token	header		string		true	"Token of guest cart"
+//	@Success		200				{object}	cart.Cart	"Cart structure"
+//	@Failure		401				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/guest/cart [get]
+func (delivery *Delivery) GetGuestCart(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery GetGuestCart()")
+	ctx := delivery.cartContext(c)
+
+	cartId, ok := delivery.guestCartId(c)
+	if !ok {
+		err := fmt.Errorf("invalid or empty cart token")
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusUnauthorized, err)
+		return
+	}
+	modelCart, err := delivery.cartUsecase.GetCart(ctx, cartId)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		err := fmt.Errorf("cart with id: %v not found", cartId)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if modelCart.UserId != uuid.Nil {
+		err := fmt.Errorf("cart with id: %v not found", cartId)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	c.JSON(http.StatusOK, newCartResponse(modelCart))
+}
+
+// SetGuestItemQuantity - set quantity of item in guest cart
+//
+//	@Summary		Method provides to set quantity of item in guest cart
+//	@Description	Method provides to set quantity of item in the guest cart from the X-Cart-Token header. Zero quantity removes item from cart.
+//	@Tags			carts
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Cart-Token	header	string				true	"Token of guest cart"
+//	@Param			itemID			path	string				true	"id of item"
+//	@Param			quantity		body	cart.SetQuantity	true	"Quantity of item"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/guest/cart/items/{itemID} [put]
+func (delivery *Delivery) SetGuestItemQuantity(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery SetGuestItemQuantity()")
+	cartId, ok := delivery.guestCartId(c)
+	if !ok {
+		err := fmt.Errorf("invalid or empty cart token")
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusUnauthorized, err)
+		return
+	}
+	itemId, err := uuid.Parse(c.Param("itemID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	delivery.setItemQuantity(c, cartId, itemId)
+}
@@ -4,6 +4,7 @@ import (
 	"OnlineShopBackend/internal/delivery/cart"
 	"OnlineShopBackend/internal/delivery/category"
 	"OnlineShopBackend/internal/delivery/item"
+	"OnlineShopBackend/internal/delivery/user/jwtauth"
 	fs "OnlineShopBackend/internal/filestorage/mocks"
 	"OnlineShopBackend/internal/models"
 	"OnlineShopBackend/internal/usecase/mocks"
@@ -124,7 +125,7 @@ func TestGetCart(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -200,7 +201,7 @@ func TestGetCartByUserId(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -275,7 +276,7 @@ func TestCreateCart(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -338,7 +339,8 @@ func TestAddItemToCart(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	statsUsecase := mocks.NewMockIStatsUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase, StatsUsecase: statsUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -364,11 +366,34 @@ func TestAddItemToCart(t *testing.T) {
 	w = httptest.NewRecorder()
 	c, _ = gin.CreateTestContext(w)
 
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	MockCartJson(c, testShortCart, "PUT")
+	cartUsecase.EXPECT().AddItemToCart(ctx, testCartId, testId).Return(models.ErrorNotFound{})
+	delivery.AddItemToCart(c)
+	require.Equal(t, 404, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	MockCartJson(c, testShortCart, "PUT")
+	cartUsecase.EXPECT().AddItemToCart(ctx, testCartId, testId).Return(models.ErrorQuantityExceeded{})
+	delivery.AddItemToCart(c)
+	require.Equal(t, 400, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+
 	c.Request = &http.Request{
 		Header: make(http.Header),
 	}
 	MockCartJson(c, testShortCart, "PUT")
 	cartUsecase.EXPECT().AddItemToCart(ctx, testCartId, testId).Return(nil)
+	statsUsecase.EXPECT().RecordItemCartAdd(ctx, testId).Return(err)
 	delivery.AddItemToCart(c)
 	require.Equal(t, 200, w.Code)
 }
@@ -383,7 +408,7 @@ func TestDeleteCart(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -431,6 +456,24 @@ func TestDeleteCart(t *testing.T) {
 		},
 	}
 
+	// Carts of other users are not found
+	cartUsecase.EXPECT().DeleteCart(ctx, testCartId).Return(models.ErrorNotFound{})
+	delivery.DeleteCart(c)
+	require.Equal(t, 404, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Params = []gin.Param{
+		{
+			Key:   "cartID",
+			Value: testCartId.String(),
+		},
+	}
+
 	cartUsecase.EXPECT().DeleteCart(ctx, testCartId).Return(nil)
 	delivery.DeleteCart(c)
 	require.Equal(t, 200, w.Code)
@@ -446,7 +489,7 @@ func TestDeleteItemFromCart(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -517,3 +560,416 @@ func TestDeleteItemFromCart(t *testing.T) {
 	delivery.DeleteItemFromCart(c)
 	require.Equal(t, 200, w.Code)
 }
+
+func TestSetItemQuantity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	cartUsecase := mocks.NewMockICartUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, CartUsecase: cartUsecase})
+	params := []gin.Param{
+		{
+			Key:   "cartID",
+			Value: testUserId.String(),
+		},
+		{
+			Key:   "itemID",
+			Value: testId.String(),
+		},
+	}
+	zero, three := 0, 3
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Params = []gin.Param{params[0], {Key: "itemID", Value: testId.String() + "l"}}
+	delivery.SetItemQuantity(c)
+	require.Equal(t, 400, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Params = params
+	MockJson(c, cart.SetQuantity{}, "PUT")
+	delivery.SetItemQuantity(c)
+	require.Equal(t, 400, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Params = params
+	MockJson(c, cart.SetQuantity{Quantity: &three}, "PUT")
+	cartUsecase.EXPECT().SetItemQuantity(ctx, testUserId, testId, 3).Return(models.ErrorNotFound{})
+	delivery.SetItemQuantity(c)
+	require.Equal(t, 404, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Params = params
+	MockJson(c, cart.SetQuantity{Quantity: &three}, "PUT")
+	cartUsecase.EXPECT().SetItemQuantity(ctx, testUserId, testId, 3).Return(fmt.Errorf("only 2 items in stock: %w", models.ErrorQuantityExceeded{}))
+	delivery.SetItemQuantity(c)
+	require.Equal(t, 400, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Params = params
+	MockJson(c, cart.SetQuantity{Quantity: &three}, "PUT")
+	cartUsecase.EXPECT().SetItemQuantity(ctx, testUserId, testId, 3).Return(fmt.Errorf("error"))
+	delivery.SetItemQuantity(c)
+	require.Equal(t, 500, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Params = params
+	MockJson(c, cart.SetQuantity{Quantity: &zero}, "PUT")
+	cartUsecase.EXPECT().SetItemQuantity(ctx, testUserId, testId, 0).Return(nil)
+	delivery.SetItemQuantity(c)
+	require.Equal(t, 200, w.Code)
+}
+
+func TestCreateGuestCart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	cartUsecase := mocks.NewMockICartUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, CartUsecase: cartUsecase})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	cartUsecase.EXPECT().Create(ctx, uuid.Nil).Return(uuid.Nil, err)
+	delivery.CreateGuestCart(c)
+	require.Equal(t, 500, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	cartUsecase.EXPECT().Create(ctx, uuid.Nil).Return(testCartId, nil)
+	delivery.CreateGuestCart(c)
+	require.Equal(t, 201, w.Code)
+	var guestCart cart.GuestCart
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&guestCart))
+	require.Equal(t, testCartId.String(), guestCart.CartId)
+	cartId, err := jwtauth.ParseCartToken(guestCart.Token)
+	require.NoError(t, err)
+	require.Equal(t, testCartId, cartId)
+}
+
+func TestGetGuestCart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	// The usecase gets id of the guest cart from the token of the request
+	ctx := models.ContextWithGuestCart(context.Background(), testCartId)
+	logger := zap.L()
+	cartUsecase := mocks.NewMockICartUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, CartUsecase: cartUsecase})
+	token, err := jwtauth.CreateCartToken(testCartId)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Request.Header.Set(cartTokenHeader, "wrong token")
+	delivery.GetGuestCart(c)
+	require.Equal(t, 401, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Request.Header.Set(cartTokenHeader, token)
+	cartUsecase.EXPECT().GetCart(ctx, testCartId).Return(nil, models.ErrorNotFound{})
+	delivery.GetGuestCart(c)
+	require.Equal(t, 404, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Request.Header.Set(cartTokenHeader, token)
+	cartUsecase.EXPECT().GetCart(ctx, testCartId).Return(&testModelCart, nil)
+	delivery.GetGuestCart(c)
+	require.Equal(t, 404, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Request.AddCookie(&http.Cookie{Name: cartTokenCookie, Value: token})
+	cartUsecase.EXPECT().GetCart(ctx, testCartId).Return(&models.Cart{Id: testCartId}, nil)
+	delivery.GetGuestCart(c)
+	require.Equal(t, 200, w.Code)
+	var guestCart cart.Cart
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&guestCart))
+	require.Equal(t, testCartId.String(), guestCart.Id)
+	require.Empty(t, guestCart.UserId)
+}
+
+func TestSetGuestItemQuantity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	// The usecase gets id of the guest cart from the token of the request
+	ctx := models.ContextWithGuestCart(context.Background(), testCartId)
+	logger := zap.L()
+	cartUsecase := mocks.NewMockICartUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, CartUsecase: cartUsecase})
+	token, err := jwtauth.CreateCartToken(testCartId)
+	require.NoError(t, err)
+	three := 3
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Params = []gin.Param{{Key: "itemID", Value: testId.String()}}
+	delivery.SetGuestItemQuantity(c)
+	require.Equal(t, 401, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Request.Header.Set(cartTokenHeader, token)
+	c.Params = []gin.Param{{Key: "itemID", Value: testId.String() + "l"}}
+	delivery.SetGuestItemQuantity(c)
+	require.Equal(t, 400, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Params = []gin.Param{{Key: "itemID", Value: testId.String()}}
+	MockJson(c, cart.SetQuantity{Quantity: &three}, "PUT")
+	c.Request.Header.Set(cartTokenHeader, token)
+	cartUsecase.EXPECT().SetItemQuantity(ctx, testCartId, testId, 3).Return(nil)
+	delivery.SetGuestItemQuantity(c)
+	require.Equal(t, 200, w.Code)
+}
+
+func TestMergeGuestCartOnLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	// The usecase gets id of the guest cart from the token of the request
+	ctx := models.ContextWithGuestCart(context.Background(), testCartId)
+	logger := zap.L()
+	cartUsecase := mocks.NewMockICartUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, CartUsecase: cartUsecase})
+	token, err := jwtauth.CreateCartToken(testCartId)
+	require.NoError(t, err)
+	userCartId := uuid.New()
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	cartId, merged := delivery.mergeGuestCart(c, testUserId)
+	require.False(t, merged)
+	require.Equal(t, uuid.Nil, cartId)
+
+	c.Request.Header.Set(cartTokenHeader, token)
+	cartUsecase.EXPECT().MergeGuestCart(ctx, testCartId, testUserId).Return(uuid.Nil, models.ErrorForbidden{})
+	cartId, merged = delivery.mergeGuestCart(c, testUserId)
+	require.False(t, merged)
+	require.Equal(t, uuid.Nil, cartId)
+
+	cartUsecase.EXPECT().MergeGuestCart(ctx, testCartId, testUserId).Return(userCartId, nil)
+	cartId, merged = delivery.mergeGuestCart(c, testUserId)
+	require.True(t, merged)
+	require.Equal(t, userCartId, cartId)
+}
+
+func TestGetCartTotals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	cartUsecase := mocks.NewMockICartUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, CartUsecase: cartUsecase})
+	deletedId := uuid.New()
+	modelCart := models.Cart{
+		Id: testCartId,
+		Items: []models.ItemWithQuantity{
+			{Item: models.Item{Id: testId, Title: "test", Price: 150}, Quantity: 2, AddedPrice: 100},
+			{Item: models.Item{Id: deletedId, Title: "deleted", Price: 100}, Quantity: 1, AddedPrice: 100, Deleted: true},
+		},
+		SavedItems: []models.ItemWithQuantity{
+			{Item: models.Item{Id: uuid.New(), Title: "saved", Price: 200}, Quantity: 1, AddedPrice: 200},
+		},
+		Totals: models.CartTotals{
+			LineTotals: map[uuid.UUID]int64{testId: 300},
+			Subtotal:   300,
+			Shipping:   300,
+			Total:      600,
+			Notices: []models.CartNotice{
+				{ItemId: testId, Title: "test", Type: models.CartNoticePriceChanged, OldPrice: 100, NewPrice: 150},
+				{ItemId: deletedId, Title: "deleted", Type: models.CartNoticeDeleted, OldPrice: 100},
+			},
+		},
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Params = []gin.Param{
+		{
+			Key:   "cartID",
+			Value: testCartId.String(),
+		},
+	}
+	cartUsecase.EXPECT().GetCart(ctx, testCartId).Return(&modelCart, nil)
+	delivery.GetCart(c)
+	require.Equal(t, 200, w.Code)
+
+	var res cart.Cart
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+	require.Equal(t, cart.Totals{Subtotal: 300, Shipping: 300, Total: 600}, res.Totals)
+	require.Len(t, res.Items, 2)
+	require.Equal(t, "deleted", res.Items[0].Item.Title)
+	require.Equal(t, int64(0), res.Items[0].LineTotal)
+	require.Equal(t, int64(300), res.Items[1].LineTotal)
+	require.Len(t, res.SavedItems, 1)
+	require.Equal(t, "saved", res.SavedItems[0].Item.Title)
+	require.Equal(t, int64(0), res.SavedItems[0].LineTotal)
+	require.Equal(t, []cart.Notice{
+		{ItemId: testId.String(), Title: "test", Type: "price_changed", OldPrice: 100, NewPrice: 150},
+		{ItemId: deletedId.String(), Title: "deleted", Type: "deleted", OldPrice: 100},
+	}, res.Notices)
+}
+
+func TestSaveItemForLater(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	cartUsecase := mocks.NewMockICartUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, CartUsecase: cartUsecase})
+	params := []gin.Param{
+		{
+			Key:   "cartID",
+			Value: testCartId.String(),
+		},
+		{
+			Key:   "itemID",
+			Value: testId.String(),
+		},
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Params = []gin.Param{params[0], {Key: "itemID", Value: testId.String() + "l"}}
+	delivery.SaveItemForLater(c)
+	require.Equal(t, 400, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Params = params
+	cartUsecase.EXPECT().SetItemSaved(ctx, testCartId, testId, true).Return(models.ErrorNotFound{})
+	delivery.SaveItemForLater(c)
+	require.Equal(t, 404, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Params = params
+	cartUsecase.EXPECT().SetItemSaved(ctx, testCartId, testId, true).Return(err)
+	delivery.SaveItemForLater(c)
+	require.Equal(t, 500, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Params = params
+	cartUsecase.EXPECT().SetItemSaved(ctx, testCartId, testId, true).Return(nil)
+	delivery.SaveItemForLater(c)
+	require.Equal(t, 200, w.Code)
+}
+
+func TestMoveSavedItemToCart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	cartUsecase := mocks.NewMockICartUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, CartUsecase: cartUsecase})
+	params := []gin.Param{
+		{
+			Key:   "cartID",
+			Value: testCartId.String(),
+		},
+		{
+			Key:   "itemID",
+			Value: testId.String(),
+		},
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Params = []gin.Param{{Key: "cartID", Value: testCartId.String() + "l"}, params[1]}
+	delivery.MoveSavedItemToCart(c)
+	require.Equal(t, 400, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Params = params
+	cartUsecase.EXPECT().SetItemSaved(ctx, testCartId, testId, false).Return(models.ErrorNotFound{})
+	delivery.MoveSavedItemToCart(c)
+	require.Equal(t, 404, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Params = params
+	cartUsecase.EXPECT().SetItemSaved(ctx, testCartId, testId, false).Return(nil)
+	delivery.MoveSavedItemToCart(c)
+	require.Equal(t, 200, w.Code)
+}
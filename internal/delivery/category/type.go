@@ -19,3 +19,9 @@ type Category struct {
 	Description string `json:"description" binding:"required" example:"Электротехнические товары для дома"`
 	Image       string `json:"image,omitempty"`
 }
+
+// MergeCategories is a structure for merging the source category into the target category
+type MergeCategories struct {
+	SourceId string `json:"sourceId" binding:"required,uuid" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	TargetId string `json:"targetId" binding:"required,uuid" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+}
@@ -397,15 +397,20 @@ func (delivery *Delivery) GetCategoryList(c *gin.Context) {
 // DeleteCategory deleted category by id
 //
 //	@Summary		Method provides to delete category
-//	@Description	Method provides to delete category.
+//	@Description	Method provides to delete category. Items of the deleted category are moved to the category
+//	@Description	with id targetID or to the system category NoCategory if uncategorized is true.
+//	@Description	Category with items can't be deleted without choosing where to move them.
 //	@Tags			categories
 //	@Accept			json
 //	@Produce		json
-//	@Param			categoryID	path	string	true	"id of category"
+//	@Param			categoryID		path	string	true	"id of category"
+//	@Param			targetID		query	string	false	"id of category for items of deleted category"
+//	@Param			uncategorized	query	bool	false	"move items of deleted category to NoCategory"
 //	@Success		200
 //	@Failure		400	{object}	ErrorResponse
 //	@Failure		403	"Forbidden"
 //	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		409	{object}	ErrorResponse	"Category has items"
 //	@Failure		500	{object}	ErrorResponse
 //	@Router			/categories/delete/{categoryID} [delete]
 func (delivery *Delivery) DeleteCategory(c *gin.Context) {
@@ -423,131 +428,91 @@ func (delivery *Delivery) DeleteCategory(c *gin.Context) {
 		delivery.SetError(c, http.StatusBadRequest, err)
 		return
 	}
-	ctx := c.Request.Context()
-	// We get the deleted category, at the same time we check its existence
-	deletedCategory, err := delivery.categoryUsecase.GetCategory(ctx, uid)
-	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
-		err = fmt.Errorf("category with id: %s not found", uid)
-		delivery.logger.Error(err.Error())
-		delivery.SetError(c, http.StatusNotFound, err)
-		return
-	}
-	if err != nil {
-		delivery.logger.Error(err.Error())
-		delivery.SetError(c, http.StatusInternalServerError, err)
-		return
-	}
-	delivery.logger.Debug(fmt.Sprintf("deletedCategory: %v", deletedCategory))
-
-	// NoCategory is a special category for items without a category that cannot be deleted
-	if deletedCategory.Name == "NoCategory" {
-		err = fmt.Errorf("category NoCategory is a system category and it protected by deleting")
+	targetID := c.Query("targetID")
+	uncategorized := c.Query("uncategorized") == "true"
+	if targetID != "" && uncategorized {
+		err = fmt.Errorf("targetID and uncategorized can't be used together")
 		delivery.logger.Error(err.Error())
 		delivery.SetError(c, http.StatusBadRequest, err)
 		return
 	}
-
-	// Requesting the number of items in the category to be deleted
-	quantity, err := delivery.itemUsecase.ItemsQuantityInCategory(ctx, deletedCategory.Name)
-	if err != nil {
-		delivery.logger.Error(err.Error())
-		delivery.SetError(c, http.StatusInternalServerError, err)
-		return
-	}
-	var items []models.Item
-	// If the quantity is greater than zero, we request a list of products from this category
-	if quantity > 0 {
-		limitOptions := map[string]int{"offset": 0, "limit": quantity}
-		sortOptions := map[string]string{"sortType": "name", "sortOrder": "asc"}
-		items, err = delivery.itemUsecase.GetItemsByCategory(ctx, deletedCategory.Name, limitOptions, sortOptions)
+	var targetId uuid.UUID
+	if targetID != "" {
+		targetId, err = uuid.Parse(targetID)
 		if err != nil {
 			delivery.logger.Error(err.Error())
-			delivery.SetError(c, http.StatusInternalServerError, err)
+			delivery.SetError(c, http.StatusBadRequest, err)
 			return
 		}
 	}
-
-	// Deleting a category
-	err = delivery.categoryUsecase.DeleteCategory(ctx, uid)
+	// The items of the deleted category are moved and the caches are brought in line with the database by the usecase
+	err = delivery.categoryUsecase.DeleteCategory(c.Request.Context(), uid, targetId, uncategorized)
 	if err != nil {
 		delivery.logger.Error(err.Error())
-		delivery.SetError(c, http.StatusInternalServerError, err)
+		delivery.SetError(c, removeCategoryErrorStatus(err), err)
 		return
 	}
+	c.JSON(http.StatusOK, gin.H{})
+}
 
-	// Deleting the cache of the list of products from this category
-	err = delivery.categoryUsecase.DeleteCategoryCash(ctx, deletedCategory.Name)
-	if err != nil {
-		delivery.logger.Error(fmt.Sprintf("error on delete category cash: %v", err))
-	}
-
-	// If the category has a picture, delete this picture
-	if deletedCategory.Image != "" {
-		err = delivery.filestorage.DeleteCategoryImageById(id)
-		if err != nil {
-			delivery.logger.Error(err.Error())
-		}
+// MergeCategories moves items of one category to another and deletes the first one
+//
+//	@Summary		Method provides to merge categories
+//	@Description	Method provides to move all items of the source category to the target category
+//	@Description	and delete the source category.
+//	@Tags			categories
+//	@Accept			json
+//	@Produce		json
+//	@Param			categories	body	category.MergeCategories	true	"Source and target categories"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/categories/merge [post]
+func (delivery *Delivery) MergeCategories(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery MergeCategories()")
+	var merge category.MergeCategories
+	if err := c.ShouldBindJSON(&merge); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
 	}
-
-	// If there were no items in the category, we terminate the function
-	if quantity == 0 {
-		delivery.logger.Sugar().Infof("Category with id: %s deleted success", id)
-		c.JSON(http.StatusOK, gin.H{})
+	if merge.SourceId == merge.TargetId {
+		err := fmt.Errorf("source and target categories are the same")
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
 		return
 	}
-
-	// We are clarifying whether the NoCategory category exists in the database
-	noCategory, err := delivery.categoryUsecase.GetCategoryByName(ctx, "NoCategory")
-	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
-		delivery.logger.Error("NoCategory is not exists")
-		// If such a category does not exist, create it
-		noCategory := models.Category{
-			Name:        "NoCategory",
-			Description: "Category for items from deleting categories",
-		}
-		noCategoryId, err := delivery.categoryUsecase.CreateCategory(ctx, &noCategory)
+	ids := make([]uuid.UUID, 0, 2)
+	for _, id := range []string{merge.SourceId, merge.TargetId} {
+		uid, err := uuid.Parse(id)
 		if err != nil {
 			delivery.logger.Error(err.Error())
-			delivery.SetError(c, http.StatusInternalServerError, err)
+			delivery.SetError(c, http.StatusBadRequest, err)
 			return
 		}
-		noCategory.Id = noCategoryId
-		// We iterate through the items from the category being deleted in the cycle
-		for _, item := range items {
-			// In each item, we change the deleted category to NoCategory
-			item.Category = noCategory
-			// Updating the item in the database
-			err := delivery.itemUsecase.UpdateItem(ctx, &item)
-			if err != nil {
-				delivery.logger.Error(fmt.Sprintf("error on update item: %v", err))
-			}
-			// Updating the cache of the list of items in the category
-			err = delivery.itemUsecase.UpdateItemsInCategoryCash(ctx, &item, "create")
-			if err != nil {
-				delivery.logger.Error(fmt.Sprintf("error on update cash of no category: %v", err))
-			}
-		}
-		delivery.logger.Sugar().Infof("Category with id: %s deleted success", id)
-		c.JSON(http.StatusOK, gin.H{})
-		return
+		ids = append(ids, uid)
 	}
+	err := delivery.categoryUsecase.MergeCategories(c.Request.Context(), ids[0], ids[1])
 	if err != nil {
-		delivery.logger.Error(fmt.Sprintf("error on get category by name: %v", err))
-		delivery.SetError(c, http.StatusInternalServerError, err)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, removeCategoryErrorStatus(err), err)
 		return
 	}
-	// We perform the same operations with items if the NoCategory already exists in the database
-	for _, item := range items {
-		item.Category = *noCategory
-		err := delivery.itemUsecase.UpdateItem(ctx, &item)
-		if err != nil {
-			delivery.logger.Error(fmt.Sprintf("error on update item: %v", err))
-		}
-		err = delivery.itemUsecase.UpdateItemsInCategoryCash(ctx, &item, "create")
-		if err != nil {
-			delivery.logger.Error(fmt.Sprintf("error on update cash of no category: %v", err))
-		}
-	}
-	delivery.logger.Sugar().Infof("Category with id: %s deleted success", id)
 	c.JSON(http.StatusOK, gin.H{})
 }
+
+// removeCategoryErrorStatus returns the status code of the response to the error of deleting the category
+func removeCategoryErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, models.ErrorNotFound{}):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrorNotEmpty{}):
+		return http.StatusConflict
+	case errors.Is(err, models.ErrorSystemCategory{}):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -92,24 +92,10 @@ var (
 		Name:        "NoCategory",
 		Description: "Category for items from deleting categories",
 	}
-	testNoCategoryWithId = models.Category{
-		Id:          testId,
-		Name:        "NoCategory",
-		Description: "Category for items from deleting categories",
-	}
-	testCategoryWithImage2 = &models.Category{
-		Id:          testId,
-		Name:        "testName",
+	testTargetCategory = &models.Category{
+		Id:          uuid.New(),
+		Name:        "targetName",
 		Description: "testDescription",
-		Image:       "testImagePath",
-	}
-	testModelsItemNoCat = models.Item{
-		Id:          testId,
-		Title:       "testTitle",
-		Description: "testDescription",
-		Category:    testNoCategoryWithId,
-		Price:       10,
-		Vendor:      "testVendor",
 	}
 )
 
@@ -151,7 +137,7 @@ func TestCreateCategory(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -226,7 +212,7 @@ func TestUpdateCategory(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -348,7 +334,7 @@ func TestGetCategoryList(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -385,7 +371,7 @@ func TestGetCategoryList2(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -421,7 +407,7 @@ func TestGetCategory(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -509,7 +495,7 @@ func TestUploadCategoryImage(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -655,7 +641,7 @@ func TestDeleteCategoryImage(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -765,223 +751,96 @@ func TestDeleteCategory(t *testing.T) {
 	defer ctrl.Finish()
 	ctx := context.Background()
 	logger := zap.L()
-	itemUsecase := mocks.NewMockIItemUsecase(ctrl)
 	categoryUsecase := mocks.NewMockICategoryUsecase(ctrl)
-
-	cartUsecase := mocks.NewMockICartUsecase(ctrl)
-	filestorage := fs.NewMockFileStorager(ctrl)
-	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
-
-	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-
-	c.Request = &http.Request{
-		Header: make(http.Header),
-	}
+	delivery := NewDelivery(Dependencies{Logger: logger, CategoryUsecase: categoryUsecase})
+	request := func(id string, query string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = &http.Request{
+			Header: make(http.Header),
+			URL:    &url.URL{},
+		}
+		c.Request.URL, _ = url.Parse(query)
+		if id != "" {
+			c.Params = []gin.Param{{Key: "categoryID", Value: id}}
+		}
+		return w, c
+	}
+
+	w, c := request("", "")
 	delivery.DeleteCategory(c)
 	require.Equal(t, 400, w.Code)
 
-	w = httptest.NewRecorder()
-	c, _ = gin.CreateTestContext(w)
-
-	c.Request = &http.Request{
-		Header: make(http.Header),
-	}
-	c.Params = []gin.Param{
-		{
-			Key: "categoryID",
-
-			Value: testId.String() + "l",
-		},
-	}
+	w, c = request(testId.String()+"l", "")
 	delivery.DeleteCategory(c)
 	require.Equal(t, 400, w.Code)
 
-	w = httptest.NewRecorder()
-	c, _ = gin.CreateTestContext(w)
-
-	c.Request = &http.Request{
-		Header: make(http.Header),
-	}
-	c.Params = []gin.Param{
-		{
-			Key:   "categoryID",
-			Value: testId.String(),
-		},
-	}
-	categoryUsecase.EXPECT().GetCategory(ctx, testId).Return(&testEmptyModelsCategory, fmt.Errorf("error"))
+	w, c = request(testId.String(), "?targetID="+testId.String()+"&uncategorized=true")
 	delivery.DeleteCategory(c)
-	require.Equal(t, 500, w.Code)
-
-	w = httptest.NewRecorder()
-	c, _ = gin.CreateTestContext(w)
-
-	c.Request = &http.Request{
-		Header: make(http.Header),
-	}
-	c.Params = []gin.Param{
-		{
-			Key:   "categoryID",
-			Value: testId.String(),
-		},
-	}
-	categoryUsecase.EXPECT().GetCategory(ctx, testId).Return(&testEmptyModelsCategory, models.ErrorNotFound{})
-	delivery.DeleteCategory(c)
-	require.Equal(t, 404, w.Code)
-
-	w = httptest.NewRecorder()
-	c, _ = gin.CreateTestContext(w)
+	require.Equal(t, 400, w.Code)
 
-	c.Request = &http.Request{
-		Header: make(http.Header),
-	}
-	c.Params = []gin.Param{
-		{
-			Key:   "categoryID",
-			Value: testId.String(),
-		},
-	}
-	categoryUsecase.EXPECT().GetCategory(ctx, testId).Return(testModelsCategoryWithId, nil)
-	itemUsecase.EXPECT().ItemsQuantityInCategory(ctx, testModelsCategoryWithId.Name).Return(0, nil)
-	categoryUsecase.EXPECT().DeleteCategory(ctx, testId).Return(fmt.Errorf("error"))
+	w, c = request(testId.String(), "?targetID="+testId.String()+"l")
 	delivery.DeleteCategory(c)
-	require.Equal(t, 500, w.Code)
-
-	w = httptest.NewRecorder()
-	c, _ = gin.CreateTestContext(w)
-
-	c.Request = &http.Request{
-		Header: make(http.Header),
-	}
-	c.Params = []gin.Param{
-		{
-			Key:   "categoryID",
-			Value: testId.String(),
-		},
-	}
-	categoryUsecase.EXPECT().GetCategory(ctx, testId).Return(testModelsCategoryWithId, nil)
-	itemUsecase.EXPECT().ItemsQuantityInCategory(ctx, testModelsCategoryWithId.Name).Return(-1, fmt.Errorf("error"))
-	delivery.DeleteCategory(c)
-	require.Equal(t, 500, w.Code)
-
-	w = httptest.NewRecorder()
-	c, _ = gin.CreateTestContext(w)
+	require.Equal(t, 400, w.Code)
 
-	c.Request = &http.Request{
-		Header: make(http.Header),
-	}
-	c.Params = []gin.Param{
-		{
-			Key:   "categoryID",
-			Value: testId.String(),
-		},
+	for err, code := range map[error]int{
+		models.ErrorNotFound{}:       404,
+		models.ErrorNotEmpty{}:       409,
+		models.ErrorSystemCategory{}: 400,
+		fmt.Errorf("error"):          500,
+	} {
+		categoryUsecase.EXPECT().DeleteCategory(ctx, testId, uuid.Nil, false).Return(err)
+		w, c = request(testId.String(), "")
+		delivery.DeleteCategory(c)
+		require.Equal(t, code, w.Code)
 	}
-	categoryUsecase.EXPECT().GetCategory(ctx, testId).Return(testCategoryWithImage2, nil)
-	itemUsecase.EXPECT().ItemsQuantityInCategory(ctx, testCategoryWithImage2.Name).Return(0, nil)
-	categoryUsecase.EXPECT().DeleteCategory(ctx, testId).Return(nil)
-	categoryUsecase.EXPECT().DeleteCategoryCash(ctx, testCategoryWithImage2.Name).Return(fmt.Errorf("error"))
-	filestorage.EXPECT().DeleteCategoryImageById(testId.String()).Return(nil)
-	delivery.DeleteCategory(c)
-	require.Equal(t, 200, w.Code)
-
-	w = httptest.NewRecorder()
-	c, _ = gin.CreateTestContext(w)
 
-	c.Request = &http.Request{
-		Header: make(http.Header),
-	}
-	c.Params = []gin.Param{
-		{
-			Key:   "categoryID",
-			Value: testId.String(),
-		},
-	}
-	limitOptions := map[string]int{"offset": 0, "limit": 1}
-	sortOptions := map[string]string{"sortType": "name", "sortOrder": "asc"}
-
-	categoryUsecase.EXPECT().GetCategory(ctx, testId).Return(testCategoryWithImage2, nil)
-	itemUsecase.EXPECT().ItemsQuantityInCategory(ctx, testCategoryWithImage2.Name).Return(1, nil)
-	itemUsecase.EXPECT().GetItemsByCategory(ctx, testCategoryWithImage2.Name, limitOptions, sortOptions).Return([]models.Item{*testModelsItemWithId}, nil)
-	categoryUsecase.EXPECT().DeleteCategory(ctx, testId).Return(nil)
-	categoryUsecase.EXPECT().DeleteCategoryCash(ctx, testCategoryWithImage2.Name).Return(nil)
-	filestorage.EXPECT().DeleteCategoryImageById(testId.String()).Return(nil)
-	categoryUsecase.EXPECT().GetCategoryByName(ctx, "NoCategory").Return(&testNoCategoryWithId, nil)
-	itemUsecase.EXPECT().UpdateItem(ctx, &testModelsItemNoCat).Return(fmt.Errorf("error"))
-	itemUsecase.EXPECT().UpdateItemsInCategoryCash(ctx, &testModelsItemNoCat, "create").Return(fmt.Errorf("error"))
+	categoryUsecase.EXPECT().DeleteCategory(ctx, testId, uuid.Nil, true).Return(nil)
+	w, c = request(testId.String(), "?uncategorized=true")
 	delivery.DeleteCategory(c)
 	require.Equal(t, 200, w.Code)
 
-	w = httptest.NewRecorder()
-	c, _ = gin.CreateTestContext(w)
-
-	c.Request = &http.Request{
-		Header: make(http.Header),
-	}
-	c.Params = []gin.Param{
-		{
-			Key:   "categoryID",
-			Value: testId.String(),
-		},
-	}
-	categoryUsecase.EXPECT().GetCategory(ctx, testId).Return(testCategoryWithImage2, nil)
-	itemUsecase.EXPECT().ItemsQuantityInCategory(ctx, testCategoryWithImage2.Name).Return(1, nil)
-	itemUsecase.EXPECT().GetItemsByCategory(ctx, testCategoryWithImage2.Name, limitOptions, sortOptions).Return(nil, fmt.Errorf("error"))
-	delivery.DeleteCategory(c)
-	require.Equal(t, 500, w.Code)
-
-	w = httptest.NewRecorder()
-	c, _ = gin.CreateTestContext(w)
-
-	c.Request = &http.Request{
-		Header: make(http.Header),
-	}
-	c.Params = []gin.Param{
-		{
-			Key:   "categoryID",
-			Value: testId.String(),
-		},
-	}
-	categoryUsecase.EXPECT().GetCategory(ctx, testId).Return(testCategoryWithImage2, nil)
-	itemUsecase.EXPECT().ItemsQuantityInCategory(ctx, testCategoryWithImage2.Name).Return(1, nil)
-	itemUsecase.EXPECT().GetItemsByCategory(ctx, testCategoryWithImage2.Name, limitOptions, sortOptions).Return([]models.Item{*testModelsItemWithId}, nil)
-	categoryUsecase.EXPECT().DeleteCategory(ctx, testId).Return(nil)
-	categoryUsecase.EXPECT().DeleteCategoryCash(ctx, testCategoryWithImage2.Name).Return(fmt.Errorf("error"))
-	filestorage.EXPECT().DeleteCategoryImageById(testId.String()).Return(nil)
-	categoryUsecase.EXPECT().GetCategoryByName(ctx, "NoCategory").Return(&models.Category{}, models.ErrorNotFound{})
-	categoryUsecase.EXPECT().CreateCategory(ctx, &testNoCategory).Return(testId, nil)
-	itemUsecase.EXPECT().UpdateItem(ctx, &testModelsItemNoCat).Return(fmt.Errorf("error"))
-	itemUsecase.EXPECT().UpdateItemsInCategoryCash(ctx, &testModelsItemNoCat, "create").Return(fmt.Errorf("error"))
+	categoryUsecase.EXPECT().DeleteCategory(ctx, testId, testTargetCategory.Id, false).Return(nil)
+	w, c = request(testId.String(), "?targetID="+testTargetCategory.Id.String())
 	delivery.DeleteCategory(c)
 	require.Equal(t, 200, w.Code)
 }
 
-func TestDeleteCategory2(t *testing.T) {
+func TestMergeCategories(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	ctx := context.Background()
 	logger := zap.L()
-	itemUsecase := mocks.NewMockIItemUsecase(ctrl)
 	categoryUsecase := mocks.NewMockICategoryUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, CategoryUsecase: categoryUsecase})
+	merge := category.MergeCategories{SourceId: testId.String(), TargetId: testTargetCategory.Id.String()}
+	request := func(merge category.MergeCategories) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = &http.Request{
+			Header: make(http.Header),
+		}
+		MockCatJson(c, merge, post)
+		return w, c
+	}
+
+	w, c := request(category.MergeCategories{SourceId: testId.String(), TargetId: testId.String()})
+	delivery.MergeCategories(c)
+	require.Equal(t, 400, w.Code)
 
-	cartUsecase := mocks.NewMockICartUsecase(ctrl)
-	filestorage := fs.NewMockFileStorager(ctrl)
-	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
-
-	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-
-	c.Request = &http.Request{
-		Header: make(http.Header),
+	for err, code := range map[error]int{
+		models.ErrorNotFound{}:       404,
+		models.ErrorSystemCategory{}: 400,
+		fmt.Errorf("error"):          500,
+	} {
+		categoryUsecase.EXPECT().MergeCategories(ctx, testId, testTargetCategory.Id).Return(err)
+		w, c = request(merge)
+		delivery.MergeCategories(c)
+		require.Equal(t, code, w.Code)
 	}
-	c.Params = []gin.Param{
-		{
-			Key:   "categoryID",
-			Value: testId.String(),
-		},
-	}
-	categoryUsecase.EXPECT().GetCategory(ctx, testId).Return(&testNoCategoryWithId, nil)
-	delivery.DeleteCategory(c)
-	require.Equal(t, 400, w.Code)
+
+	categoryUsecase.EXPECT().MergeCategories(ctx, testId, testTargetCategory.Id).Return(nil)
+	w, c = request(merge)
+	delivery.MergeCategories(c)
+	require.Equal(t, 200, w.Code)
 }
@@ -2,12 +2,14 @@ package delivery
 
 import (
 	"OnlineShopBackend/internal/delivery/file"
+	"OnlineShopBackend/internal/delivery/user/jwtauth"
 	"OnlineShopBackend/internal/filestorage"
 	"OnlineShopBackend/internal/metrics"
 	"OnlineShopBackend/internal/usecase"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -21,35 +23,95 @@ import (
 //	@BasePath	/
 
 type Delivery struct {
-	itemUsecase     usecase.IItemUsecase
-	categoryUsecase usecase.ICategoryUsecase
-	userUsecase usecase.IUserUsecase
-	cartUsecase     usecase.ICartUsecase
-	logger          *zap.Logger
-	filestorage     filestorage.FileStorager
-	orderUsecase    usecase.IOrderUsecase
+	itemUsecase         usecase.IItemUsecase
+	categoryUsecase     usecase.ICategoryUsecase
+	userUsecase         usecase.IUserUsecase
+	cartUsecase         usecase.ICartUsecase
+	logger              *zap.Logger
+	filestorage         filestorage.FileStorager
+	orderUsecase        usecase.IOrderUsecase
+	questionUsecase     usecase.IQuestionUsecase
+	statsUsecase        usecase.IStatsUsecase
+	auditUsecase        usecase.IAuditUsecase
+	checkoutUsecase     usecase.ICheckoutUsecase
+	idempotencyUsecase  usecase.IIdempotencyUsecase
+	paymentUsecase      usecase.IPaymentUsecase
+	returnUsecase       usecase.IReturnUsecase
+	orderCancelUsecase  usecase.IOrderCancelUsecase
+	invoiceUsecase      usecase.IInvoiceUsecase
+	orderListUsecase    usecase.IOrderListUsecase
+	addressUsecase      usecase.IAddressUsecase
+	notificationUsecase usecase.INotificationUsecase
+	shipmentUsecase     usecase.IShipmentUsecase
+}
+
+// Dependencies are the logger, the file storage and the usecases of the delivery layer,
+// handlers of not set usecases are not used
+type Dependencies struct {
+	Logger              *zap.Logger
+	FileStorage         filestorage.FileStorager
+	ItemUsecase         usecase.IItemUsecase
+	UserUsecase         usecase.IUserUsecase
+	CategoryUsecase     usecase.ICategoryUsecase
+	CartUsecase         usecase.ICartUsecase
+	OrderUsecase        usecase.IOrderUsecase
+	QuestionUsecase     usecase.IQuestionUsecase
+	StatsUsecase        usecase.IStatsUsecase
+	AuditUsecase        usecase.IAuditUsecase
+	CheckoutUsecase     usecase.ICheckoutUsecase
+	IdempotencyUsecase  usecase.IIdempotencyUsecase
+	PaymentUsecase      usecase.IPaymentUsecase
+	ReturnUsecase       usecase.IReturnUsecase
+	OrderCancelUsecase  usecase.IOrderCancelUsecase
+	InvoiceUsecase      usecase.IInvoiceUsecase
+	OrderListUsecase    usecase.IOrderListUsecase
+	AddressUsecase      usecase.IAddressUsecase
+	NotificationUsecase usecase.INotificationUsecase
+	ShipmentUsecase     usecase.IShipmentUsecase
 }
 
 // NewDelivery initialize delivery layer
-func NewDelivery(
-	itemUsecase usecase.IItemUsecase,
-	userUsecase usecase.IUserUsecase,
-	categoryUsecase usecase.ICategoryUsecase,
-	cartUsecase usecase.ICartUsecase,
-	logger *zap.Logger, fs filestorage.FileStorager,
-	orderUsecase usecase.IOrderUsecase,
-) *Delivery {
-	logger.Debug("Enter in NewDelivery()")
+func NewDelivery(deps Dependencies) *Delivery {
+	deps.Logger.Debug("Enter in NewDelivery()")
 	metrics.DeliveryMetrics.NewDeliveryTotal.Inc()
 
 	return &Delivery{
-		itemUsecase:     itemUsecase,
-		categoryUsecase: categoryUsecase,
-		cartUsecase:     cartUsecase,
-		userUsecase: userUsecase,
-		logger:          logger, filestorage: fs,
-		orderUsecase: orderUsecase,
+		itemUsecase:         deps.ItemUsecase,
+		categoryUsecase:     deps.CategoryUsecase,
+		cartUsecase:         deps.CartUsecase,
+		userUsecase:         deps.UserUsecase,
+		logger:              deps.Logger,
+		filestorage:         deps.FileStorage,
+		orderUsecase:        deps.OrderUsecase,
+		questionUsecase:     deps.QuestionUsecase,
+		statsUsecase:        deps.StatsUsecase,
+		auditUsecase:        deps.AuditUsecase,
+		checkoutUsecase:     deps.CheckoutUsecase,
+		idempotencyUsecase:  deps.IdempotencyUsecase,
+		paymentUsecase:      deps.PaymentUsecase,
+		returnUsecase:       deps.ReturnUsecase,
+		orderCancelUsecase:  deps.OrderCancelUsecase,
+		invoiceUsecase:      deps.InvoiceUsecase,
+		orderListUsecase:    deps.OrderListUsecase,
+		addressUsecase:      deps.AddressUsecase,
+		notificationUsecase: deps.NotificationUsecase,
+		shipmentUsecase:     deps.ShipmentUsecase,
+	}
+}
+
+// getClaims returns claims of authorized user set by JWT middleware
+func (delivery *Delivery) getClaims(c *gin.Context) (*jwtauth.Payload, bool) {
+	value, ok := c.Get("claims")
+	if !ok {
+		delivery.logger.Error("claims not found in context")
+		return nil, false
+	}
+	claims, ok := value.(*jwtauth.Payload)
+	if !ok || claims.UserId == uuid.Nil {
+		delivery.logger.Error("incorrect claims in context")
+		return nil, false
 	}
+	return claims, true
 }
 
 // Index is the index handler.
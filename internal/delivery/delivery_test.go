@@ -25,7 +25,7 @@ func TestIndex(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -47,7 +47,7 @@ func TestGetFileList(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
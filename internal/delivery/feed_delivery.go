@@ -0,0 +1,67 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/feed"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const xmlContentType = "application/xml; charset=utf-8"
+
+// Sitemap returns sitemap of the site
+//
+//	@Summary		Get sitemap.xml
+//	@Description	Method provides to get sitemap with pages of categories and items. The sitemap is regenerated in the background after changes of catalog.
+//	@Tags			feeds
+//	@Produce		xml
+//	@Success		200
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Router			/sitemap.xml [get]
+func (delivery *Delivery) Sitemap(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery Sitemap()")
+	delivery.serveFeed(c, feed.SitemapFile, xmlContentType)
+}
+
+// GoogleMerchantFeed returns product feed for Google Merchant Center
+//
+//	@Summary		Get Google Merchant product feed
+//	@Description	Method provides to get product feed in Google Merchant RSS 2.0 format.
+//	@Tags			feeds
+//	@Produce		xml
+//	@Success		200
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Router			/feeds/google_merchant.xml [get]
+func (delivery *Delivery) GoogleMerchantFeed(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery GoogleMerchantFeed()")
+	delivery.serveFeed(c, feed.GoogleMerchantFile, xmlContentType)
+}
+
+// YandexMarketFeed returns product feed for Yandex Market
+//
+//	@Summary		Get Yandex Market product feed
+//	@Description	Method provides to get product feed in Yandex Market YML format.
+//	@Tags			feeds
+//	@Produce		xml
+//	@Success		200
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Router			/feeds/yandex_market.yml [get]
+func (delivery *Delivery) YandexMarketFeed(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery YandexMarketFeed()")
+	delivery.serveFeed(c, feed.YandexMarketFile, xmlContentType)
+}
+
+// serveFeed writes the generated feed file to the response
+func (delivery *Delivery) serveFeed(c *gin.Context, filename string, contentType string) {
+	path, err := delivery.filestorage.GetFeedPath(filename)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, fmt.Errorf("feed %s is not generated yet", filename))
+		return
+	}
+	// http.ServeFile detects content type by extension only if it is not set,
+	// and .yml files would be served as text/plain
+	c.Header("Content-Type", contentType)
+	c.File(path)
+}
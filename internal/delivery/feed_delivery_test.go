@@ -0,0 +1,42 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/feed"
+	fs "OnlineShopBackend/internal/filestorage/mocks"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestYandexMarketFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	filestorage := fs.NewMockFileStorager(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/feeds/yandex_market.yml", nil)
+	filestorage.EXPECT().GetFeedPath(feed.YandexMarketFile).Return("", err)
+	delivery.YandexMarketFeed(c)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	path := filepath.Join(t.TempDir(), feed.YandexMarketFile)
+	require.NoError(t, os.WriteFile(path, []byte("<yml_catalog></yml_catalog>"), 0600))
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/feeds/yandex_market.yml", nil)
+	filestorage.EXPECT().GetFeedPath(feed.YandexMarketFile).Return(path, nil)
+	delivery.YandexMarketFeed(c)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, xmlContentType, w.Header().Get("Content-Type"))
+	require.Equal(t, "<yml_catalog></yml_catalog>", w.Body.String())
+}
@@ -0,0 +1,123 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/models"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
+// responseRecorder writes the response to the client and keeps the body to save it
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotent wraps the handler of the unsafe endpoint, so the request with the header
+// Idempotency-Key is handled once and its response is returned again on retries with the same key.
+// The key reused with another request is answered with 409 Conflict, as well as the retry
+// made while the first request is in progress. Responses with server errors are not saved,
+// so the request can be retried with the same key
+func (delivery *Delivery) Idempotent(handler gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(idempotencyKeyHeader)
+		if key == "" {
+			handler(c)
+			return
+		}
+		delivery.logger.Sugar().Debugf("Enter in delivery Idempotent() with key: %s", key)
+		if len(key) > maxIdempotencyKeyLength {
+			delivery.SetError(c, http.StatusBadRequest, fmt.Errorf("idempotency key is longer than %d characters", maxIdempotencyKeyLength))
+			return
+		}
+		claims, ok := delivery.getClaims(c)
+		if !ok {
+			delivery.SetError(c, http.StatusUnauthorized, fmt.Errorf("user unauthorized"))
+			return
+		}
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			delivery.logger.Sugar().Errorf("can't read request body: %s", err)
+			delivery.SetError(c, http.StatusBadRequest, err)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		hash := sha256.New()
+		hash.Write([]byte(c.Request.Method + " " + c.Request.URL.RequestURI() + "\n"))
+		hash.Write(body)
+		requestHash := hex.EncodeToString(hash.Sum(nil))
+
+		ctx := c.Request.Context()
+		saved, err := delivery.idempotencyUsecase.Begin(ctx, claims.UserId, key, requestHash)
+		if err != nil && (errors.Is(err, models.ErrorIdempotencyKeyReused{}) || errors.Is(err, models.ErrorRequestInProgress{})) {
+			delivery.logger.Sugar().Errorf("can't begin idempotent request: %s", err)
+			delivery.SetError(c, http.StatusConflict, err)
+			return
+		}
+		if err != nil {
+			delivery.logger.Sugar().Errorf("can't begin idempotent request: %s", err)
+			delivery.SetError(c, http.StatusInternalServerError, err)
+			return
+		}
+		if saved != nil {
+			c.Header(idempotentReplayedHeader, "true")
+			c.Data(saved.StatusCode, saved.ContentType, saved.Body)
+			return
+		}
+
+		handled := false
+		// The key is released if the handler failed or panicked
+		defer func() {
+			if handled {
+				return
+			}
+			if err := delivery.idempotencyUsecase.Release(ctx, claims.UserId, key); err != nil {
+				delivery.logger.Sugar().Errorf("can't release idempotent request: %s", err)
+			}
+		}()
+		writer := c.Writer
+		recorder := &responseRecorder{ResponseWriter: writer}
+		c.Writer = recorder
+		handler(c)
+		c.Writer = writer
+		if recorder.Status() >= http.StatusInternalServerError {
+			return
+		}
+		// If the response is not saved, the key stays locked until the lock expires
+		// instead of letting the retry handle the same request again
+		handled = true
+		err = delivery.idempotencyUsecase.Complete(ctx, &models.IdempotentRequest{
+			UserId:      claims.UserId,
+			Key:         key,
+			RequestHash: requestHash,
+			StatusCode:  recorder.Status(),
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		})
+		if err != nil {
+			delivery.logger.Sugar().Errorf("can't complete idempotent request: %s", err)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/usecase/mocks"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestIdempotent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	idempotencyUsecase := mocks.NewMockIIdempotencyUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, IdempotencyUsecase: idempotencyUsecase})
+
+	calls := 0
+	status := http.StatusCreated
+	handler := delivery.Idempotent(func(c *gin.Context) {
+		calls++
+		body, err := io.ReadAll(c.Request.Body)
+		require.NoError(t, err)
+		c.JSON(status, gin.H{"body": string(body)})
+	})
+	request := func(key string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/order/create", bytes.NewBufferString("data"))
+		if key != "" {
+			c.Request.Header.Set(idempotencyKeyHeader, key)
+		}
+		c.Set("claims", testClaims)
+		return w, c
+	}
+
+	// The request without the key is handled as usual
+	w, c := request("")
+	handler(c)
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Equal(t, 1, calls)
+
+	w, c = request(string(make([]byte, maxIdempotencyKeyLength+1)))
+	handler(c)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w, c = request("key")
+	c.Set("claims", nil)
+	handler(c)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	for _, err := range []error{models.ErrorIdempotencyKeyReused{}, models.ErrorRequestInProgress{}} {
+		idempotencyUsecase.EXPECT().Begin(gomock.Any(), testUserId, "key", gomock.Any()).Return(nil, err)
+		w, c = request("key")
+		handler(c)
+		require.Equal(t, http.StatusConflict, w.Code)
+	}
+
+	idempotencyUsecase.EXPECT().Begin(gomock.Any(), testUserId, "key", gomock.Any()).Return(nil, fmt.Errorf("error"))
+	w, c = request("key")
+	handler(c)
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.Equal(t, 1, calls)
+
+	// The first request is handled and its response is saved
+	var saved *models.IdempotentRequest
+	idempotencyUsecase.EXPECT().Begin(gomock.Any(), testUserId, "key", gomock.Any()).Return(nil, nil)
+	idempotencyUsecase.EXPECT().Complete(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ interface{}, request *models.IdempotentRequest) error {
+			saved = request
+			return nil
+		})
+	w, c = request("key")
+	handler(c)
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Equal(t, 2, calls)
+	require.Equal(t, testUserId, saved.UserId)
+	require.Equal(t, "key", saved.Key)
+	require.Equal(t, http.StatusCreated, saved.StatusCode)
+	require.Equal(t, w.Body.Bytes(), saved.Body)
+	require.Contains(t, saved.ContentType, "application/json")
+
+	// The retry gets the saved response without handling
+	saved.Done = true
+	idempotencyUsecase.EXPECT().Begin(gomock.Any(), testUserId, "key", saved.RequestHash).Return(saved, nil)
+	w, c = request("key")
+	handler(c)
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Equal(t, saved.Body, w.Body.Bytes())
+	require.Equal(t, "true", w.Header().Get(idempotentReplayedHeader))
+	require.Equal(t, 2, calls)
+
+	// The key is released after the server error
+	status = http.StatusInternalServerError
+	idempotencyUsecase.EXPECT().Begin(gomock.Any(), testUserId, "key", gomock.Any()).Return(nil, nil)
+	idempotencyUsecase.EXPECT().Release(gomock.Any(), testUserId, "key").Return(nil)
+	w, c = request("key")
+	handler(c)
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.Equal(t, 3, calls)
+}
@@ -0,0 +1,47 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/models"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetInvoice - get the invoice of a specific order by id
+//
+//	@Summary		Get the invoice of an order in PDF
+//	@Description	The method allows the customer to download the invoice of own order and the admin to download the invoice of any order.
+//	@Description	The invoice gets the sequential number when it is requested first time, prices of items are taken at the time of the order.
+//	@Tags			order
+//	@Produce		application/pdf
+//	@Param			orderID	path	string	true	"Id of the order"
+//	@Success		200		{file}	file	"Invoice in PDF"
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		"Forbidden"
+//	@Failure		404		{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/order/{orderID}/invoice [get]
+func (d *Delivery) GetInvoice(c *gin.Context) {
+	d.logger.Debug("Enter in delivery GetInvoice()")
+	orderId, err := uuid.Parse(c.Param("orderID"))
+	if err != nil {
+		d.logger.Sugar().Errorf("can't parse order id: %s", err)
+		d.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	number, path, err := d.invoiceUsecase.GetInvoice(c.Request.Context(), orderId)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		d.logger.Sugar().Errorf("can't get invoice: %s", err)
+		d.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		d.logger.Sugar().Errorf("can't get invoice: %s", err)
+		d.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.Header("Content-Type", "application/pdf")
+	c.FileAttachment(path, number+".pdf")
+}
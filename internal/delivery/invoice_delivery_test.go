@@ -0,0 +1,56 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/usecase/mocks"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestGetInvoice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	invoiceUsecase := mocks.NewMockIInvoiceUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, InvoiceUsecase: invoiceUsecase})
+	request := func(orderId string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/order/"+orderId+"/invoice", nil)
+		c.Params = gin.Params{{Key: "orderID", Value: orderId}}
+		return w, c
+	}
+
+	w, c := request("1")
+	delivery.GetInvoice(c)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	for err, code := range map[error]int{
+		models.ErrorNotFound{}: http.StatusNotFound,
+		fmt.Errorf("error"):    http.StatusInternalServerError,
+	} {
+		invoiceUsecase.EXPECT().GetInvoice(gomock.Any(), testId).Return("", "", fmt.Errorf("can't get invoice: %w", err))
+		w, c = request(testId.String())
+		delivery.GetInvoice(c)
+		require.Equal(t, code, w.Code)
+	}
+
+	path := filepath.Join(t.TempDir(), "invoice.pdf")
+	require.NoError(t, os.WriteFile(path, []byte("%PDF-1.4"), 0600))
+	invoiceUsecase.EXPECT().GetInvoice(gomock.Any(), testId).Return("INV-000001", path, nil)
+	w, c = request(testId.String())
+	delivery.GetInvoice(c)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	require.Contains(t, w.Header().Get("Content-Disposition"), `filename="INV-000001.pdf"`)
+	require.Equal(t, "%PDF-1.4", w.Body.String())
+}
@@ -12,6 +12,12 @@ type ShortItem struct {
 	Price       int32    `json:"price" example:"1990" default:"10" binding:"required" minimum:"0"`
 	Vendor      string   `json:"vendor" example:"Витязь"`
 	Images      []string `json:"image,omitempty"`
+	// Stock is not tracked if it is empty
+	Stock *int `json:"stock,omitempty" example:"10" binding:"omitempty,min=0" minimum:"0"`
+	// Weight is the weight of the item in grams
+	Weight int32 `json:"weight,omitempty" example:"2500" binding:"omitempty,min=0" minimum:"0"`
+	// SellerId is the user who answers questions about the item, the item is sold by the shop if it is empty
+	SellerId string `json:"sellerId,omitempty" binding:"omitempty,uuid" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
 }
 
 // AddFavItem is a structure for add item in favourites
@@ -35,6 +41,14 @@ type OutItem struct {
 	Vendor      string            `json:"vendor" binding:"required" example:"Витязь"`
 	Images      []string          `json:"image,omitempty"`
 	IsFavourite bool              `json:"isFavourite" example:"false"`
+	// AnsweredQuestions is filled only in the response of GetItem, it is zero if no question is answered
+	AnsweredQuestions *int `json:"answeredQuestions,omitempty" example:"3"`
+	// Stock is filled only in the response of GetItem if it is tracked
+	Stock *int `json:"stock,omitempty" example:"10"`
+	// Weight is filled only in the response of GetItem, it is in grams
+	Weight int32 `json:"weight,omitempty" example:"2500"`
+	// SellerId is filled only in the response of GetItem if the item has the seller
+	SellerId string `json:"sellerId,omitempty" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
 }
 
 // InItem is a structure for update item
@@ -46,6 +60,12 @@ type InItem struct {
 	Price       int32    `json:"price" example:"1990" default:"10" binding:"required" minimum:"0"`
 	Vendor      string   `json:"vendor" binding:"required" example:"Витязь"`
 	Images      []string `json:"image,omitempty"`
+	// Stock is not tracked if it is empty
+	Stock *int `json:"stock,omitempty" example:"10" binding:"omitempty,min=0" minimum:"0"`
+	// Weight is the weight of the item in grams
+	Weight int32 `json:"weight,omitempty" example:"2500" binding:"omitempty,min=0" minimum:"0"`
+	// SellerId is the user who answers questions about the item, the item is sold by the shop if it is empty
+	SellerId string `json:"sellerId,omitempty" binding:"omitempty,uuid" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
 }
 
 // ItemsQuantity is a structure for result of the request for the quantity of items
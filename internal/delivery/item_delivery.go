@@ -41,6 +41,14 @@ type ImageOptions struct {
 	Name string `form:"name"`
 }
 
+// parseSellerId returns the seller of the item from the request, uuid.Nil if the seller is not set
+func parseSellerId(sellerId string) (uuid.UUID, error) {
+	if sellerId == "" {
+		return uuid.Nil, nil
+	}
+	return uuid.Parse(sellerId)
+}
+
 // CreateItem
 //
 //	@Summary		Method provides to create store item
@@ -106,6 +114,12 @@ func (delivery *Delivery) CreateItem(c *gin.Context) {
 		delivery.SetError(c, http.StatusBadRequest, err)
 		return
 	}
+	sellerId, err := parseSellerId(deliveryItem.SellerId)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
 	modelsItem := models.Item{
 		Title:       deliveryItem.Title,
 		Description: deliveryItem.Description,
@@ -115,9 +129,17 @@ func (delivery *Delivery) CreateItem(c *gin.Context) {
 		},
 		Vendor: deliveryItem.Vendor,
 		Images: deliveryItem.Images,
+		Stock:    deliveryItem.Stock,
+		Weight:   deliveryItem.Weight,
+		SellerId: sellerId,
 	}
 
 	id, err := delivery.itemUsecase.CreateItem(ctx, &modelsItem)
+	if err != nil && errors.Is(err, models.ErrorUnknownSeller{}) {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
 	if err != nil {
 		delivery.SetError(c, http.StatusInternalServerError, err)
 		return
@@ -186,7 +208,16 @@ func (delivery *Delivery) GetItem(c *gin.Context) {
 		Vendor: modelsItem.Vendor,
 		Images: modelsItem.Images,
 		// If the item in the favourites, put true, if not, put false
-		IsFavourite: delivery.IsFavourite(c, modelsItem.Id),
+		IsFavourite:       delivery.IsFavourite(c, modelsItem.Id),
+		AnsweredQuestions: &modelsItem.AnsweredQuestions,
+		Stock:             modelsItem.Stock,
+		Weight:            modelsItem.Weight,
+	}
+	if modelsItem.SellerId != uuid.Nil {
+		result.SellerId = modelsItem.SellerId.String()
+	}
+	if err := delivery.statsUsecase.RecordItemView(ctx, modelsItem.Id); err != nil {
+		delivery.logger.Warn(err.Error())
 	}
 	c.JSON(http.StatusOK, result)
 }
@@ -226,6 +257,12 @@ func (delivery *Delivery) UpdateItem(c *gin.Context) {
 		delivery.SetError(c, http.StatusBadRequest, err)
 		return
 	}
+	sellerId, err := parseSellerId(deliveryItem.SellerId)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
 	// If the item list is empty, add an empty line to it so as not to cause a mistake on the frontend
 	if len(deliveryItem.Images) == 0 {
 		deliveryItem.Images = append(deliveryItem.Images, "")
@@ -262,6 +299,9 @@ func (delivery *Delivery) UpdateItem(c *gin.Context) {
 		Price:  deliveryItem.Price,
 		Vendor: deliveryItem.Vendor,
 		Images: deliveryItem.Images,
+		Stock:    deliveryItem.Stock,
+		Weight:   deliveryItem.Weight,
+		SellerId: sellerId,
 	}
 
 	if itemBeforUpdate.Category.Id != categoryUid {
@@ -282,6 +322,11 @@ func (delivery *Delivery) UpdateItem(c *gin.Context) {
 	}
 
 	err = delivery.itemUsecase.UpdateItem(ctx, updatingItem)
+	if err != nil && errors.Is(err, models.ErrorUnknownSeller{}) {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
 	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
 		delivery.logger.Sugar().Errorf("item with id: %v not found", updatingItem.Id)
 		err = fmt.Errorf("item with id: %v not found", updatingItem.Id)
@@ -320,7 +365,7 @@ func (delivery *Delivery) UpdateItem(c *gin.Context) {
 //	@Produce		json
 //	@Param			offset		query		int				false	"Offset when receiving records"	default(0)	mininum(0)
 //	@Param			limit		query		int				false	"Quantity of recordings"		default(10)	minimum(0)
-//	@Param			sortType	query		string			false	"Sort type (name or price)"		default("name")
+//	@Param			sortType	query		string			false	"Sort type (name, price, popular or newest)"		default("name")
 //	@Param			sortOrder	query		string			false	"Sort order (asc or desc)"		default("asc")
 //	@Success		200			{object}	item.ItemsList	"List of items"
 //	@Failure		400			{object}	ErrorResponse
@@ -541,7 +586,7 @@ func (delivery *Delivery) ItemsQuantityInSearch(c *gin.Context) {
 //	@Param			param		query		string			false	"Search param"
 //	@Param			offset		query		int				false	"Offset when receiving records"	default(0)	mininum(0)
 //	@Param			limit		query		int				false	"Quantity of recordings"		default(10)	minimum(0)
-//	@Param			sortType	query		string			false	"Sort type (name or price)"		default("name")
+//	@Param			sortType	query		string			false	"Sort type (name, price, popular or newest)"		default("name")
 //	@Param			sortOrder	query		string			false	"Sort order (asc or desc)"		default("asc")
 //	@Success		200			{object}	item.ItemsList	"List of items"
 //	@Failure		400			{object}	ErrorResponse
@@ -631,7 +676,7 @@ func (delivery *Delivery) SearchLine(c *gin.Context) {
 //	@Param			param		query		string			false	"Category name"
 //	@Param			offset		query		int				false	"Offset when receiving records"	default(0)	mininum(0)
 //	@Param			limit		query		int				false	"Quantity of recordings"		default(10)	minimum(0)
-//	@Param			sortType	query		string			false	"Sort type (name or price)"		default("name")
+//	@Param			sortType	query		string			false	"Sort type (name, price, popular or newest)"		default("name")
 //	@Param			sortOrder	query		string			false	"Sort order (asc or desc)"		default("asc")
 //	@Success		200			{object}	item.ItemsList	"List of items"
 //	@Failure		400			{object}	ErrorResponse
@@ -1075,7 +1120,7 @@ func (delivery *Delivery) DeleteFavouriteItem(c *gin.Context) {
 //	@Param			param		query		string			false	"ID of user"
 //	@Param			limit		query		int				false	"Quantity of recordings"		default(10)	minimum(0)
 //	@Param			offset		query		int				false	"Offset when receiving records"	default(0)	mininum(0)
-//	@Param			sortType	query		string			false	"Sort type (name or price)"
+//	@Param			sortType	query		string			false	"Sort type (name, price, popular or newest)"
 //	@Param			sortOrder	query		string			false	"Sort order (asc or desc)"
 //	@Success		200			{object}	item.ItemsList	"List of items"
 //	@Failure		400			{object}	ErrorResponse
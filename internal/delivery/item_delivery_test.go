@@ -271,7 +271,7 @@ func TestCreateItem(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -363,7 +363,8 @@ func TestGetItem(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	statsUsecase := mocks.NewMockIStatsUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase, StatsUsecase: statsUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -377,8 +378,12 @@ func TestGetItem(t *testing.T) {
 			Value: testId.String(),
 		},
 	}
-	bytesRes, _ := json.Marshal(&testOutItem)
+	// The item without answered questions has zero answered questions in GetItem
+	outItem, answered := testOutItem, 0
+	outItem.AnsweredQuestions = &answered
+	bytesRes, _ := json.Marshal(&outItem)
 	itemUsecase.EXPECT().GetItem(ctx, testId).Return(testModelsItemWithId, nil)
+	statsUsecase.EXPECT().RecordItemView(ctx, testId).Return(nil)
 	delivery.GetItem(c)
 	require.Equal(t, 200, w.Code)
 	require.Equal(t, bytesRes, w.Body.Bytes())
@@ -409,6 +414,62 @@ func TestGetItem(t *testing.T) {
 	require.Equal(t, 500, w.Code)
 }
 
+func TestItemSeller(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	itemUsecase := mocks.NewMockIItemUsecase(ctrl)
+	statsUsecase := mocks.NewMockIStatsUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, ItemUsecase: itemUsecase, StatsUsecase: statsUsecase})
+	sellerId := uuid.New()
+	shortItem := testShortItem
+	shortItem.SellerId = sellerId.String()
+	modelsItem := *testModelsItemWithoutId
+	modelsItem.SellerId = sellerId
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	MockJson(c, shortItem, post)
+	itemUsecase.EXPECT().CreateItem(ctx, &modelsItem).Return(testId, nil)
+	delivery.CreateItem(c)
+	require.Equal(t, 201, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	MockJson(c, shortItem, post)
+	itemUsecase.EXPECT().CreateItem(ctx, &modelsItem).Return(uuid.Nil, fmt.Errorf("error on create item: %w", models.ErrorUnknownSeller{}))
+	delivery.CreateItem(c)
+	require.Equal(t, 400, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Params = []gin.Param{
+		{
+			Key:   "itemID",
+			Value: testId.String(),
+		},
+	}
+	storedItem := *testModelsItemWithId
+	storedItem.SellerId = sellerId
+	itemUsecase.EXPECT().GetItem(ctx, testId).Return(&storedItem, nil)
+	statsUsecase.EXPECT().RecordItemView(ctx, testId).Return(nil)
+	delivery.GetItem(c)
+	require.Equal(t, 200, w.Code)
+	var outItem item.OutItem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &outItem))
+	require.Equal(t, sellerId.String(), outItem.SellerId)
+}
+
 func TestUpdateItem(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -420,7 +481,7 @@ func TestUpdateItem(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -547,7 +608,7 @@ func TestItemsList(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -665,7 +726,7 @@ func TestItemsQuantity(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -705,7 +766,7 @@ func TestSearchLine(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -798,7 +859,7 @@ func TestGetItemsByCategory(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -891,7 +952,7 @@ func TestUploadItemImage(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -1037,7 +1098,7 @@ func TestDeleteItemImage(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -1122,7 +1183,7 @@ func TestDeleteItem(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -1212,7 +1273,7 @@ func TestItemsQuantityInCategory(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -1275,7 +1336,7 @@ func TestItemsQuantityInFavourite(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -1338,7 +1399,7 @@ func TestAddFavouriteItem(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -1395,7 +1456,7 @@ func TestDeleteFavouriteItem(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -1503,7 +1564,7 @@ func TestGetFavouriteItems(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -1579,7 +1640,7 @@ func TestItemsQuantityInSearch(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
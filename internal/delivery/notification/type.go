@@ -0,0 +1,11 @@
+package notification
+
+// Preferences is a structure for the choice of the user of emails about orders and of their language,
+// emails about the account are always sent
+type Preferences struct {
+	// Language is the language of emails, emails are in English if it is empty
+	Language     string `json:"language" binding:"omitempty,oneof=en ru" enums:"en,ru" example:"ru"`
+	OrderPlaced  bool   `json:"order_placed" example:"true"`
+	OrderStatus  bool   `json:"order_status" example:"true"`
+	OrderShipped bool   `json:"order_shipped" example:"true"`
+}
@@ -0,0 +1,92 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/notification"
+	"OnlineShopBackend/internal/models"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetNotificationPreferences - get the notification preferences of the user
+//
+//	@Summary		Get notification preferences
+//	@Description	The method returns the choice of the authorized user of emails about orders and of their language.
+//	@Description	The user who has not changed the preferences gets all emails in English.
+//	@Tags			user
+//	@Produce		json
+//	@Success		200	{object}	notification.Preferences	"Notification preferences"
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/user/notifications [get]
+func (delivery *Delivery) GetNotificationPreferences(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery GetNotificationPreferences()")
+	claims, ok := delivery.getClaims(c)
+	if !ok {
+		delivery.SetError(c, http.StatusUnauthorized, fmt.Errorf("user unauthorized"))
+		return
+	}
+	preferences, err := delivery.notificationUsecase.GetPreferences(c.Request.Context(), claims.UserId)
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't get notification preferences: %s", err)
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, notification.Preferences{
+		Language:     preferences.Language,
+		OrderPlaced:  preferences.OrderPlaced,
+		OrderStatus:  preferences.OrderStatus,
+		OrderShipped: preferences.OrderShipped,
+	})
+}
+
+// SetNotificationPreferences - change the notification preferences of the user
+//
+//	@Summary		Change notification preferences
+//	@Description	The method saves the choice of the authorized user of emails about the placed order, about changes
+//	@Description	of the status of the order and about the shipment of the order and the language of emails.
+//	@Description	Emails about the account are always sent.
+//	@Tags			user
+//	@Accept			json
+//	@Produce		json
+//	@Param			preferences	body		notification.Preferences	true	"Notification preferences"
+//	@Success		200			{object}	notification.Preferences	"Saved notification preferences"
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		401			{object}	ErrorResponse
+//	@Failure		404			{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/user/notifications [put]
+func (delivery *Delivery) SetNotificationPreferences(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery SetNotificationPreferences()")
+	claims, ok := delivery.getClaims(c)
+	if !ok {
+		delivery.SetError(c, http.StatusUnauthorized, fmt.Errorf("user unauthorized"))
+		return
+	}
+	var preferences notification.Preferences
+	if err := c.ShouldBindJSON(&preferences); err != nil {
+		delivery.logger.Sugar().Errorf("can't bind json from request: %s", err)
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	err := delivery.notificationUsecase.SetPreferences(c.Request.Context(), &models.NotificationPreferences{
+		UserId:       claims.UserId,
+		Language:     preferences.Language,
+		OrderPlaced:  preferences.OrderPlaced,
+		OrderStatus:  preferences.OrderStatus,
+		OrderShipped: preferences.OrderShipped,
+	})
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		delivery.logger.Sugar().Errorf("can't set notification preferences: %s", err)
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't set notification preferences: %s", err)
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, preferences)
+}
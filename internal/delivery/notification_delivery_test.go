@@ -0,0 +1,81 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/usecase/mocks"
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestGetNotificationPreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	notificationUsecase := mocks.NewMockINotificationUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: zap.L(), NotificationUsecase: notificationUsecase})
+	request := func() (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/user/notifications", nil)
+		c.Set("claims", testClaims)
+		return w, c
+	}
+
+	w, c := request()
+	c.Set("claims", nil)
+	delivery.GetNotificationPreferences(c)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	notificationUsecase.EXPECT().GetPreferences(gomock.Any(), testUserId).Return(nil, fmt.Errorf("error"))
+	w, c = request()
+	delivery.GetNotificationPreferences(c)
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+
+	preferences := models.DefaultNotificationPreferences(testUserId)
+	preferences.OrderStatus = false
+	notificationUsecase.EXPECT().GetPreferences(gomock.Any(), testUserId).Return(&preferences, nil)
+	w, c = request()
+	delivery.GetNotificationPreferences(c)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"language":"","order_placed":true,"order_status":false,"order_shipped":true}`, w.Body.String())
+}
+
+func TestSetNotificationPreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	notificationUsecase := mocks.NewMockINotificationUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: zap.L(), NotificationUsecase: notificationUsecase})
+	body := `{"language":"ru","order_placed":true,"order_status":false,"order_shipped":true}`
+	request := func(body string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPut, "/user/notifications", bytes.NewBufferString(body))
+		c.Set("claims", testClaims)
+		return w, c
+	}
+
+	for _, body := range []string{`{"language":"de"}`, "{"} {
+		w, c := request(body)
+		delivery.SetNotificationPreferences(c)
+		require.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+
+	preferences := models.NotificationPreferences{UserId: testUserId, Language: "ru", OrderPlaced: true, OrderShipped: true}
+	notificationUsecase.EXPECT().SetPreferences(gomock.Any(), &preferences).Return(fmt.Errorf("error"))
+	w, c := request(body)
+	delivery.SetNotificationPreferences(c)
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+
+	notificationUsecase.EXPECT().SetPreferences(gomock.Any(), &preferences).Return(nil)
+	w, c = request(body)
+	delivery.SetNotificationPreferences(c)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, body, w.Body.String())
+}
@@ -2,6 +2,7 @@ package order
 
 import (
 	"OnlineShopBackend/internal/delivery/cart"
+	"OnlineShopBackend/internal/delivery/returns"
 	"sort"
 	"time"
 )
@@ -14,6 +15,13 @@ type Order struct {
 	ShipmentTime time.Time       `json:"shipment_time" binding:"required" time_format:"2006-01-02"`
 	Address      OrderAddress    `json:"address" binding:"required"`
 	Status       string          `json:"status,omitempty"`
+	CancelReason string          `json:"cancel_reason,omitempty"`
+	// ShippingMethod is empty for orders placed before shipping methods
+	ShippingMethod string           `json:"shipping_method,omitempty" example:"courier"`
+	ShippingCost   int64            `json:"shipping_cost" example:"300"`
+	Returns        []returns.Return `json:"returns,omitempty"`
+	// Shipment is set when the order is handed over to the carrier
+	Shipment *Shipment `json:"shipment,omitempty"`
 }
 
 func (order *Order) SortOrderItems() {
@@ -21,10 +29,13 @@ func (order *Order) SortOrderItems() {
 }
 
 type OrderAddress struct {
-	Zipcode string `json:"zipcode" binding:"required"`
-	Country string `json:"country,omitempty"`
-	City    string `json:"city" binding:"required"`
-	Street  string `json:"street"  binding:"required"`
+	Zipcode   string `json:"zipcode" binding:"required,max=16"`
+	Country   string `json:"country,omitempty" binding:"max=256"`
+	City      string `json:"city" binding:"required,max=256"`
+	Street    string `json:"street"  binding:"required"`
+	Apartment string `json:"apartment,omitempty" binding:"max=64" example:"12"`
+	Recipient string `json:"recipient,omitempty" binding:"max=256" example:"Ivan Ivanov"`
+	Phone     string `json:"phone,omitempty" binding:"max=32" example:"+79001234567"`
 }
 
 type UserForCart struct {
@@ -34,9 +45,16 @@ type UserForCart struct {
 }
 
 type CartAdressUser struct {
-	Cart    cart.Cart    `json:"cart"`
-	User    UserForCart  `json:"user"`
-	Address OrderAddress `json:"address"`
+	Cart cart.Cart   `json:"cart"`
+	User UserForCart `json:"user"`
+	// Address is the address of the order, the saved address is used if it is not set
+	Address *OrderAddress `json:"address,omitempty"`
+	// AddressId is the id of the saved address of the user,
+	// the default shipping address is used if both the address and its id are not set
+	AddressId string `json:"address_id,omitempty" binding:"omitempty,uuid" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	// ShippingMethod is the code of the chosen shipping method,
+	// the first method delivering the order to the address is used if it is empty
+	ShippingMethod string `json:"shipping_method,omitempty" example:"courier"`
 }
 
 type OrderId struct {
@@ -55,3 +73,46 @@ type StatusWithUserAndId struct {
 	Status  string      `json:"status"`
 	OrderId string      `json:"order_id" binding:"required,uuid" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
 }
+
+// Cancel is a structure for the reason of the cancel of the order
+type Cancel struct {
+	Reason string `json:"reason" binding:"required,max=1024" example:"Changed my mind"`
+}
+
+// OrderSummary is the order in the list of orders of the admin
+type OrderSummary struct {
+	Id             string      `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	User           UserForCart `json:"user"`
+	CreatedAt      time.Time   `json:"created_at"`
+	ShipmentTime   time.Time   `json:"shipment_time"`
+	Status         string      `json:"status" example:"paid"`
+	ShippingMethod string      `json:"shipping_method,omitempty" example:"courier"`
+	ItemsQuantity  int         `json:"items_quantity" example:"3"`
+	Total          int64       `json:"total" example:"1300"`
+}
+
+// OrdersList is the page of orders with the quantity of orders satisfying the filter
+// and the quantities of orders by status, regardless of the status filter
+type OrdersList struct {
+	List         []OrderSummary `json:"list"`
+	Quantity     int            `json:"quantity" example:"1"`
+	StatusCounts map[string]int `json:"status_counts"`
+}
+
+// Shipment is the order handed over to the carrier with its tracking events
+type Shipment struct {
+	Carrier        string    `json:"carrier" example:"fake"`
+	TrackingNumber string    `json:"tracking_number" example:"FAKE-1672531200-1A2B3C4D"`
+	CreatedAt      time.Time `json:"created_at"`
+	// DeliveredAt is not set until the carrier reports the delivery
+	DeliveredAt *time.Time      `json:"delivered_at,omitempty"`
+	Events      []TrackingEvent `json:"events"`
+}
+
+// TrackingEvent is the step of the delivery reported by the carrier
+type TrackingEvent struct {
+	Status      string    `json:"status" example:"in_transit"`
+	Description string    `json:"description,omitempty" example:"Parcel is in transit"`
+	Location    string    `json:"location,omitempty" example:"Moscow"`
+	Time        time.Time `json:"time"`
+}
@@ -0,0 +1,62 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/order"
+	"OnlineShopBackend/internal/models"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CancelOrder - cancel a specific order by id
+//
+//	@Summary		Cancel an order by id
+//	@Description	The method allows the customer to cancel the order until it is processed and the admin to cancel any order before shipping.
+//	@Description	The order is kept with the canceled status and the reason, its stock is released and its payment is refunded.
+//	@Tags			order
+//	@Accept			json
+//	@Produce		json
+//	@Param			orderID			path	string			true	"Id of the order to cancel"
+//	@Param			cancel			body	order.Cancel	true	"Reason of the cancel"
+//	@Param			Idempotency-Key	header	string			false	"Unique key of the request to retry it safely"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		409	{object}	ErrorResponse	"Order can't be canceled in its status"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/order/{orderID}/cancel [post]
+func (d *Delivery) CancelOrder(c *gin.Context) {
+	d.logger.Debug("Enter in delivery CancelOrder()")
+	orderId, err := uuid.Parse(c.Param("orderID"))
+	if err != nil {
+		d.logger.Sugar().Errorf("can't parse order id: %s", err)
+		d.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var cancel order.Cancel
+	if err := c.ShouldBindJSON(&cancel); err != nil {
+		d.logger.Sugar().Errorf("can't bind json from request: %s", err)
+		d.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	err = d.orderCancelUsecase.CancelOrder(c.Request.Context(), orderId, cancel.Reason)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		d.logger.Sugar().Errorf("can't cancel order: %s", err)
+		d.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil && errors.Is(err, models.ErrorWrongStatus{}) {
+		d.logger.Sugar().Errorf("can't cancel order: %s", err)
+		d.SetError(c, http.StatusConflict, err)
+		return
+	}
+	if err != nil {
+		d.logger.Sugar().Errorf("can't cancel order: %s", err)
+		d.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
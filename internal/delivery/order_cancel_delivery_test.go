@@ -0,0 +1,124 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/usecase/mocks"
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCancelOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	orderCancelUsecase := mocks.NewMockIOrderCancelUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, OrderCancelUsecase: orderCancelUsecase})
+	request := func(orderId string, body string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/order/"+orderId+"/cancel", bytes.NewBufferString(body))
+		c.Params = gin.Params{{Key: "orderID", Value: orderId}}
+		return w, c
+	}
+
+	w, c := request("1", `{"reason": "Changed my mind"}`)
+	delivery.CancelOrder(c)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	w, c = request(testId.String(), `{}`)
+	delivery.CancelOrder(c)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	for err, code := range map[error]int{
+		models.ErrorNotFound{}:    http.StatusNotFound,
+		models.ErrorWrongStatus{}: http.StatusConflict,
+		fmt.Errorf("error"):       http.StatusInternalServerError,
+	} {
+		orderCancelUsecase.EXPECT().CancelOrder(gomock.Any(), testId, "Changed my mind").Return(fmt.Errorf("can't cancel: %w", err))
+		w, c = request(testId.String(), `{"reason": "Changed my mind"}`)
+		delivery.CancelOrder(c)
+		require.Equal(t, code, w.Code)
+	}
+
+	orderCancelUsecase.EXPECT().CancelOrder(gomock.Any(), testId, "Changed my mind").Return(nil)
+	w, c = request(testId.String(), `{"reason": "Changed my mind"}`)
+	delivery.CancelOrder(c)
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestChangeStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
+	orderCancelUsecase := mocks.NewMockIOrderCancelUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, OrderUsecase: orderUsecase, OrderCancelUsecase: orderCancelUsecase})
+	request := func(status models.Status) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		body := fmt.Sprintf(`{"user": {"id": "%s", "email": "admin@mail.ru", "role": "admin"}, "status": "%s", "order_id": "%s"}`, testId, status, testId)
+		c.Request = httptest.NewRequest(http.MethodPatch, "/order/changestatus", bytes.NewBufferString(body))
+		return w, c
+	}
+
+	for err, code := range map[error]int{
+		models.ErrorForbidden{}:   http.StatusForbidden,
+		models.ErrorNotFound{}:    http.StatusNotFound,
+		models.ErrorWrongStatus{}: http.StatusConflict,
+		fmt.Errorf("error"):       http.StatusInternalServerError,
+	} {
+		orderUsecase.EXPECT().ChangeStatus(gomock.Any(), gomock.Any(), models.StatusProcessed).Return(fmt.Errorf("can't change: %w", err))
+		w, c := request(models.StatusProcessed)
+		delivery.ChangeStatus(c)
+		require.Equal(t, code, w.Code)
+	}
+
+	orderUsecase.EXPECT().ChangeStatus(gomock.Any(), gomock.Any(), models.StatusProcessed).Return(nil)
+	w, c := request(models.StatusProcessed)
+	delivery.ChangeStatus(c)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// The canceled status goes through the cancel of the order
+	orderCancelUsecase.EXPECT().CancelOrder(gomock.Any(), testId, "canceled by admin").Return(nil)
+	w, c = request(models.StatusCanceled)
+	delivery.ChangeStatus(c)
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestChangeAddress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, OrderUsecase: orderUsecase})
+	request := func() (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		body := fmt.Sprintf(`{"user": {"id": "%s", "email": "admin@mail.ru", "role": "admin"}, "address": {"zipcode": "101000", "city": "Moscow", "street": "Tverskaya 1"}, "order_id": "%s"}`, testId, testId)
+		c.Request = httptest.NewRequest(http.MethodPatch, "/order/changeaddress", bytes.NewBufferString(body))
+		return w, c
+	}
+
+	for err, code := range map[error]int{
+		models.ErrorNotFound{}:    http.StatusNotFound,
+		models.ErrorWrongStatus{}: http.StatusConflict,
+		fmt.Errorf("error"):       http.StatusInternalServerError,
+	} {
+		orderUsecase.EXPECT().ChangeAddress(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("can't change: %w", err))
+		w, c := request()
+		delivery.ChangeAddress(c)
+		require.Equal(t, code, w.Code)
+	}
+
+	orderUsecase.EXPECT().ChangeAddress(gomock.Any(), gomock.Any(), models.UserAddress{Zipcode: "101000", City: "Moscow", Street: "Tverskaya 1"}).Return(nil)
+	w, c := request()
+	delivery.ChangeAddress(c)
+	require.Equal(t, http.StatusOK, w.Code)
+}
@@ -6,6 +6,8 @@ import (
 	"OnlineShopBackend/internal/delivery/item"
 	"OnlineShopBackend/internal/delivery/order"
 	"OnlineShopBackend/internal/models"
+	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 
@@ -17,14 +19,23 @@ import (
 //
 //	@Summary		Create order
 //	@Description	The method allows you to create an order out of cart and user info
+//	@Description	Items saved for later are not ordered and stay in the cart, which is returned as the new cart.
+//	@Description	The stock of ordered items is reserved until the order is shipped or canceled.
+//	@Description	The shipping cost of the chosen method is saved with the order and its period sets the shipment time.
+//	@Description	The request with the header Idempotency-Key is handled once, its retries with the same key get the same response.
+//	@Description	Items which are not in the stored cart or are sent more than once are rejected, quantities and prices are taken from the stored cart.
+//	@Description	The address is taken from the request, from the saved address with address_id of the user
+//	@Description	or from the default shipping address of the user if both are not set.
 //	@Tags			order
 //	@Accept			json
 //	@Produce		json
 //	@Param			cartAddressUser	body		order.CartAdressUser	true	"Data for creating order"
+//	@Param			Idempotency-Key	header		string					false	"Unique key of the request to retry it safely"
 //	@Success		201				{object}	order.OrderId			"Order id and new cart id"
 //	@Failure		400				{object}	ErrorResponse
 //	@Failure		403				"Forbidden"
 //	@Failure		404				{object}	ErrorResponse	"404 Not Found"
+//	@Failure		409				{object}	ErrorResponse	"Idempotency key is used with another request or the request is in progress"
 //	@Failure		500				{object}	ErrorResponse
 //	@Router			/order/create/ [post]
 func (d *Delivery) CreateOrder(c *gin.Context) {
@@ -46,17 +57,15 @@ func (d *Delivery) CreateOrder(c *gin.Context) {
 		ID:    id,
 		Email: cart.User.Email,
 	}
-	id, err = uuid.Parse(cart.Cart.Id)
+	cartId, err := uuid.Parse(cart.Cart.Id)
 	if err != nil {
 		d.logger.Sugar().Errorf("can't parse cart id: %s", err)
 		d.SetError(c, http.StatusInternalServerError, err)
 		return
 	}
-	cartModel := models.Cart{
-		Id:     id,
-		UserId: user.ID,
-		Items:  make([]models.ItemWithQuantity, 0, len(cart.Cart.Items)),
-	}
+	// Quantities, prices and weights are taken from the stored cart in the checkout, so only ids of items are passed
+	itemIds := make([]uuid.UUID, 0, len(cart.Cart.Items))
+	ordered := make(map[uuid.UUID]struct{}, len(cart.Cart.Items))
 	for _, oitem := range cart.Cart.Items {
 		id, err = uuid.Parse(oitem.Item.Id)
 		if err != nil {
@@ -64,41 +73,64 @@ func (d *Delivery) CreateOrder(c *gin.Context) {
 			d.SetError(c, http.StatusInternalServerError, err)
 			return
 		}
-		itemM := models.ItemWithQuantity{
-			Item: models.Item{
-				Id:    id,
-				Title: oitem.Item.Title,
-				Price: oitem.Item.Price,
-			},
-			Quantity: oitem.Quantity.Quantity,
+		if _, ok := ordered[id]; ok {
+			err = fmt.Errorf("item with id: %v is ordered more than once", id)
+			d.logger.Sugar().Errorf("can't create order: %s", err)
+			d.SetError(c, http.StatusBadRequest, err)
+			return
 		}
-		cartModel.Items = append(cartModel.Items, itemM)
+		ordered[id] = struct{}{}
+		itemIds = append(itemIds, id)
 	}
 
-	addressMdl := models.UserAddress{
-		Country: cart.Address.Country,
-		City:    cart.Address.City,
-		Zipcode: cart.Address.Zipcode,
-		Street:  cart.Address.Street,
+	var address *models.UserAddress
+	if cart.Address != nil {
+		requested := models.UserAddress(*cart.Address)
+		address = &requested
+	}
+	addressId := uuid.Nil
+	if cart.AddressId != "" {
+		addressId, err = uuid.Parse(cart.AddressId)
+		if err != nil {
+			d.logger.Sugar().Errorf("can't parse address id: %s", err)
+			d.SetError(c, http.StatusBadRequest, err)
+			return
+		}
+	}
+	addressMdl, err := d.checkoutUsecase.OrderAddress(ctx, user.ID, address, addressId)
+	if err != nil && errors.Is(err, models.ErrorAddressNotSet{}) {
+		d.logger.Sugar().Errorf("can't get address of order: %s", err)
+		d.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		d.logger.Sugar().Errorf("can't get address of order: %s", err)
+		d.SetError(c, http.StatusNotFound, fmt.Errorf("address with id: %v not found", addressId))
+		return
 	}
-
-	ordr, err := d.orderUsecase.PlaceOrder(ctx, &cartModel, user, addressMdl)
 	if err != nil {
-		d.logger.Sugar().Errorf("can't create order: %s", err)
+		d.logger.Sugar().Errorf("can't get address of order: %s", err)
 		d.SetError(c, http.StatusInternalServerError, err)
 		return
 	}
-	err = d.cartUsecase.DeleteCart(ctx, cartModel.Id)
-	if err != nil {
-		d.logger.Sugar().Warnf("error when deleting cart with id: %v, err: %v", id, err)
-	} else {
-		d.logger.Sugar().Infof("Cart with id: %v delete success", cartModel.Id)
+
+	// The order is placed, the cart is cleared and kept as the new cart of the user in one transaction
+	ordr, newCartId, err := d.checkoutUsecase.Checkout(ctx, cartId, itemIds, user, addressMdl, cart.ShippingMethod)
+	if err != nil && (errors.Is(err, models.ErrorEmptyCart{}) || errors.Is(err, models.ErrorQuantityExceeded{}) ||
+		errors.Is(err, models.ErrorShippingUnavailable{}) || errors.Is(err, models.ErrorNotInCart{})) {
+		d.logger.Sugar().Errorf("can't create order: %s", err)
+		d.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		d.logger.Sugar().Errorf("can't create order: %s", err)
+		d.SetError(c, http.StatusNotFound, err)
+		return
 	}
-	newCartId, err := d.cartUsecase.Create(ctx, user.ID)
 	if err != nil {
-		d.logger.Sugar().Warnf("error when creating new cart for user with id: %v, err: %v", user.ID, err)
-	} else {
-		d.logger.Sugar().Infof("New cart with id: %v for user with id: %v create success", newCartId, user.ID)
+		d.logger.Sugar().Errorf("can't create order: %s", err)
+		d.SetError(c, http.StatusInternalServerError, err)
+		return
 	}
 
 	c.JSON(http.StatusCreated, order.OrderId{
@@ -110,7 +142,7 @@ func (d *Delivery) CreateOrder(c *gin.Context) {
 // GetOrder - get a specific order by id
 //
 //	@Summary		Get order by id
-//	@Description	The method allows you to get the order by id.
+//	@Description	The method allows you to get the order by id with its returns and its shipment with tracking events.
 //	@Tags			order
 //	@Accept			json
 //	@Produce		json
@@ -131,19 +163,27 @@ func (d *Delivery) GetOrder(c *gin.Context) {
 		return
 	}
 	modelOrder, err := d.orderUsecase.GetOrder(ctx, orderId)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		d.logger.Sugar().Errorf("can't get order: %s", err)
+		d.SetError(c, http.StatusNotFound, err)
+		return
+	}
 	if err != nil {
 		d.logger.Sugar().Errorf("can't get order: %s", err)
 		d.SetError(c, http.StatusInternalServerError, err)
 		return
 	}
 	order := order.Order{
-		Id:           modelOrder.ID.String(),
-		UserId:       modelOrder.User.ID.String(),
-		CreatedAt:    modelOrder.CreatedAt,
-		ShipmentTime: modelOrder.ShipmentTime,
-		Address:      order.OrderAddress(modelOrder.Address),
-		Status:       string(modelOrder.Status),
-		Items:        make([]cart.CartItem, 0, len(modelOrder.Items)),
+		Id:             modelOrder.ID.String(),
+		UserId:         modelOrder.User.ID.String(),
+		CreatedAt:      modelOrder.CreatedAt,
+		ShipmentTime:   modelOrder.ShipmentTime,
+		Address:        order.OrderAddress(modelOrder.Address),
+		Status:         string(modelOrder.Status),
+		CancelReason:   modelOrder.CancelReason,
+		Items:          make([]cart.CartItem, 0, len(modelOrder.Items)),
+		ShippingMethod: modelOrder.ShippingMethod,
+		ShippingCost:   modelOrder.ShippingCost,
 	}
 	for _, oitem := range modelOrder.Items {
 		cartItem := cart.CartItem{
@@ -166,6 +206,24 @@ func (d *Delivery) GetOrder(c *gin.Context) {
 		order.Items = append(order.Items, cartItem)
 	}
 	order.SortOrderItems()
+	modelReturns, err := d.returnUsecase.GetOrderReturns(ctx, orderId)
+	if err != nil {
+		d.logger.Sugar().Errorf("can't get returns of order: %s", err)
+		d.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	for i := range modelReturns {
+		order.Returns = append(order.Returns, returnResponse(&modelReturns[i]))
+	}
+	modelShipment, err := d.shipmentUsecase.GetShipment(ctx, orderId)
+	if err != nil && !errors.Is(err, models.ErrorNotFound{}) {
+		d.logger.Sugar().Errorf("can't get shipment of order: %s", err)
+		d.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if err == nil {
+		order.Shipment = shipmentResponse(modelShipment)
+	}
 	c.JSON(http.StatusOK, order)
 }
 
@@ -193,6 +251,11 @@ func (d *Delivery) GetOrdersForUser(c *gin.Context) {
 		return
 	}
 	modelOrders, err := d.orderUsecase.GetOrdersForUser(ctx, &models.User{ID: userId})
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		d.logger.Sugar().Errorf("can't get orders: %s", err)
+		d.SetError(c, http.StatusNotFound, err)
+		return
+	}
 	if err != nil {
 		d.logger.Sugar().Errorf("can't get order: %s", err)
 		d.SetError(c, http.StatusInternalServerError, err)
@@ -201,13 +264,15 @@ func (d *Delivery) GetOrdersForUser(c *gin.Context) {
 	orders := make([]order.Order, 0, len(modelOrders))
 	for _, modelOrder := range modelOrders {
 		order := order.Order{
-			Id:           modelOrder.ID.String(),
-			UserId:       modelOrder.User.ID.String(),
-			CreatedAt:    modelOrder.CreatedAt,
-			ShipmentTime: modelOrder.ShipmentTime,
-			Address:      order.OrderAddress(modelOrder.Address),
-			Status:       string(modelOrder.Status),
-			Items:        make([]cart.CartItem, 0, len(modelOrder.Items)),
+			Id:             modelOrder.ID.String(),
+			UserId:         modelOrder.User.ID.String(),
+			CreatedAt:      modelOrder.CreatedAt,
+			ShipmentTime:   modelOrder.ShipmentTime,
+			Address:        order.OrderAddress(modelOrder.Address),
+			Status:         string(modelOrder.Status),
+			Items:          make([]cart.CartItem, 0, len(modelOrder.Items)),
+			ShippingMethod: modelOrder.ShippingMethod,
+			ShippingCost:   modelOrder.ShippingCost,
 		}
 		for _, oitem := range modelOrder.Items {
 			cartItem := cart.CartItem{
@@ -238,7 +303,7 @@ func (d *Delivery) GetOrdersForUser(c *gin.Context) {
 // DeleteOrder - delete a specific order by id
 //
 //	@Summary		Delete an order by id
-//	@Description	The method allows you to delete an order by id.
+//	@Description	The method allows the admin to delete an order by id. Customers cancel their orders instead.
 //	@Tags			order
 //	@Accept			json
 //	@Produce		json
@@ -262,6 +327,16 @@ func (d *Delivery) DeleteOrder(c *gin.Context) {
 	}
 
 	err = d.orderUsecase.DeleteOrder(ctx, &models.Order{ID: orderId})
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		d.logger.Sugar().Errorf("can't delete order: %s", err)
+		d.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil && errors.Is(err, models.ErrorForbidden{}) {
+		d.logger.Sugar().Errorf("can't delete order: %s", err)
+		d.SetError(c, http.StatusForbidden, err)
+		return
+	}
 	if err != nil {
 		d.logger.Sugar().Errorf("Can't delete order with orderID %s", err)
 		d.SetError(c, http.StatusInternalServerError, err)
@@ -273,7 +348,7 @@ func (d *Delivery) DeleteOrder(c *gin.Context) {
 // ChangeAddress - change address of a specific order by Id
 //
 //	@Summary		Change address of a  specific order by Id
-//	@Description	The method allows you to change address of an order by Id.
+//	@Description	The method allows you to change address of an order by Id before the order is shipped.
 //	@Tags			order
 //	@Accept			json
 //	@Produce		json
@@ -282,6 +357,7 @@ func (d *Delivery) DeleteOrder(c *gin.Context) {
 //	@Failure		400	{object}	ErrorResponse
 //	@Failure		403	"Forbidden"
 //	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		409	{object}	ErrorResponse	"Order is already shipped"
 //	@Failure		500	{object}	ErrorResponse
 //	@Router			/order/changeaddress/ [patch]
 func (d *Delivery) ChangeAddress(c *gin.Context) {
@@ -315,6 +391,16 @@ func (d *Delivery) ChangeAddress(c *gin.Context) {
 			ID:   orderID,
 			User: models.User{ID: userID},
 		}, models.UserAddress(address.Address))
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		d.logger.Sugar().Errorf("can't change address: %s", err)
+		d.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil && errors.Is(err, models.ErrorWrongStatus{}) {
+		d.logger.Sugar().Errorf("can't change address: %s", err)
+		d.SetError(c, http.StatusConflict, err)
+		return
+	}
 	if err != nil {
 		d.logger.Sugar().Errorf("can't change address for order with id: %s %s", orderID, err)
 		d.SetError(c, http.StatusInternalServerError, err)
@@ -325,7 +411,9 @@ func (d *Delivery) ChangeAddress(c *gin.Context) {
 // ChangeStatus - change status of a specific order by Id
 //
 //	@Summary		Change status of a specific order by Id
-//	@Description	The method allows you to change status of an order by Id.
+//	@Description	The method allows the admin to move an order to the next status by Id: from paid to processing,
+//	@Description	from processing to processed, from processed to ready for shipment and from picked by courier to delivered.
+//	@Description	The canceled status cancels the order with its stock and refund, other statuses are set by the payment and the shipment.
 //	@Tags			order
 //	@Accept			json
 //	@Produce		json
@@ -334,6 +422,7 @@ func (d *Delivery) ChangeAddress(c *gin.Context) {
 //	@Failure		400	{object}	ErrorResponse
 //	@Failure		403	"Forbidden"
 //	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		409	{object}	ErrorResponse	"Order can't move to the status"
 //	@Failure		500	{object}	ErrorResponse
 //	@Router			/order/changestatus/ [patch]
 func (d *Delivery) ChangeStatus(c *gin.Context) {
@@ -362,14 +451,33 @@ func (d *Delivery) ChangeStatus(c *gin.Context) {
 		d.SetError(c, http.StatusBadRequest, err)
 		return
 	}
-	err = d.orderUsecase.ChangeStatus(ctx, &models.Order{
-		ID: orderID,
-		User: models.User{
-			ID: userID,
-		},
-	}, models.Status(status.Status))
+	if models.Status(status.Status) == models.StatusCanceled {
+		err = d.orderCancelUsecase.CancelOrder(ctx, orderID, "canceled by admin")
+	} else {
+		err = d.orderUsecase.ChangeStatus(ctx, &models.Order{
+			ID: orderID,
+			User: models.User{
+				ID: userID,
+			},
+		}, models.Status(status.Status))
+	}
+	if err != nil && errors.Is(err, models.ErrorForbidden{}) {
+		d.logger.Sugar().Errorf("can't change status: %s", err)
+		d.SetError(c, http.StatusForbidden, err)
+		return
+	}
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		d.logger.Sugar().Errorf("can't change status: %s", err)
+		d.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil && errors.Is(err, models.ErrorWrongStatus{}) {
+		d.logger.Sugar().Errorf("can't change status: %s", err)
+		d.SetError(c, http.StatusConflict, err)
+		return
+	}
 	if err != nil {
-		d.logger.Sugar().Errorf("can't change address for order with id: %s %s", orderID, err)
+		d.logger.Sugar().Errorf("can't change status for order with id: %s %s", orderID, err)
 		d.SetError(c, http.StatusInternalServerError, err)
 		return
 	}
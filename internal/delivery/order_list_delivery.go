@@ -0,0 +1,210 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/order"
+	"OnlineShopBackend/internal/export"
+	"OnlineShopBackend/internal/models"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetOrders - get orders of all users
+//
+//	@Summary		Get list of orders
+//	@Description	Method provides to get orders of all users by filter with quantities of orders by status. The newest orders go first by default.
+//	@Tags			orders
+//	@Accept			json
+//	@Produce		json
+//	@Param			status		query		string				false	"Status of orders"
+//	@Param			from		query		string				false	"Start of period of creation in RFC3339 format"
+//	@Param			to			query		string				false	"End of period of creation in RFC3339 format, not included"
+//	@Param			email		query		string				false	"Part of email of the customer"
+//	@Param			city		query		string				false	"City of the address of orders"
+//	@Param			minTotal	query		int					false	"Minimal total of orders"
+//	@Param			maxTotal	query		int					false	"Maximal total of orders"
+//	@Param			itemID		query		string				false	"Id of item contained in orders"
+//	@Param			sortType	query		string				false	"Sort type (created_at, total, status, email)"
+//	@Param			sortOrder	query		string				false	"Sort order (asc, desc), desc by default"
+//	@Param			offset		query		int					false	"Offset"
+//	@Param			limit		query		int					false	"Limit"
+//	@Success		200			{object}	order.OrdersList	"List of orders"
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		401			"Unauthorized"
+//	@Failure		403			"Forbidden"
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/order/list [get]
+func (delivery *Delivery) GetOrders(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery GetOrders()")
+	filter, err := orderFilter(c)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	list, err := delivery.orderListUsecase.GetOrders(c.Request.Context(), filter)
+	if err != nil && errors.Is(err, models.ErrorForbidden{}) {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusForbidden, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	response := order.OrdersList{
+		List:         make([]order.OrderSummary, 0, len(list.Orders)),
+		Quantity:     list.Quantity,
+		StatusCounts: make(map[string]int),
+	}
+	for _, status := range models.OrderStatuses() {
+		response.StatusCounts[string(status)] = list.StatusCounts[status]
+	}
+	for _, summary := range list.Orders {
+		response.List = append(response.List, order.OrderSummary{
+			Id:             summary.ID.String(),
+			User:           order.UserForCart{Id: summary.User.ID.String(), Email: summary.User.Email},
+			CreatedAt:      summary.CreatedAt,
+			ShipmentTime:   summary.ShipmentTime,
+			Status:         string(summary.Status),
+			ShippingMethod: summary.ShippingMethod,
+			ItemsQuantity:  summary.ItemsQuantity,
+			Total:          summary.Total,
+		})
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// orderFilter parses query parameters of the request of the list of orders
+func orderFilter(c *gin.Context) (models.OrderFilter, error) {
+	filter := models.OrderFilter{
+		Status:   models.Status(c.Query("status")),
+		Email:    c.Query("email"),
+		City:     c.Query("city"),
+		SortType: c.Query("sortType"),
+	}
+	var err error
+	if filter.Status != "" && !filter.Status.Valid() {
+		return filter, fmt.Errorf("invalid status: %s", filter.Status)
+	}
+	if from := c.Query("from"); from != "" {
+		if filter.From, err = time.Parse(time.RFC3339, from); err != nil {
+			return filter, fmt.Errorf("invalid from: %w", err)
+		}
+	}
+	if to := c.Query("to"); to != "" {
+		if filter.To, err = time.Parse(time.RFC3339, to); err != nil {
+			return filter, fmt.Errorf("invalid to: %w", err)
+		}
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.From.After(filter.To) {
+		return filter, fmt.Errorf("from is after to")
+	}
+	if minTotal := c.Query("minTotal"); minTotal != "" {
+		total, err := strconv.ParseInt(minTotal, 10, 64)
+		if err != nil {
+			return filter, fmt.Errorf("invalid minTotal: %s", minTotal)
+		}
+		filter.MinTotal = &total
+	}
+	if maxTotal := c.Query("maxTotal"); maxTotal != "" {
+		total, err := strconv.ParseInt(maxTotal, 10, 64)
+		if err != nil {
+			return filter, fmt.Errorf("invalid maxTotal: %s", maxTotal)
+		}
+		filter.MaxTotal = &total
+	}
+	if filter.MinTotal != nil && filter.MaxTotal != nil && *filter.MinTotal > *filter.MaxTotal {
+		return filter, fmt.Errorf("minTotal is greater than maxTotal")
+	}
+	if itemId := c.Query("itemID"); itemId != "" {
+		if filter.ItemId, err = uuid.Parse(itemId); err != nil {
+			return filter, fmt.Errorf("invalid itemID: %w", err)
+		}
+	}
+	switch filter.SortType {
+	case "", models.OrderSortCreatedAt, models.OrderSortTotal, models.OrderSortStatus, models.OrderSortEmail:
+	default:
+		return filter, fmt.Errorf("invalid sortType: %s", filter.SortType)
+	}
+	switch sortOrder := c.Query("sortOrder"); sortOrder {
+	case "asc":
+	case "", "desc":
+		filter.SortDesc = true
+	default:
+		return filter, fmt.Errorf("invalid sortOrder: %s", sortOrder)
+	}
+	if offset := c.Query("offset"); offset != "" {
+		if filter.Offset, err = strconv.Atoi(offset); err != nil || filter.Offset < 0 {
+			return filter, fmt.Errorf("invalid offset: %s", offset)
+		}
+	}
+	if limit := c.Query("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil || filter.Limit < 0 {
+			return filter, fmt.Errorf("invalid limit: %s", limit)
+		}
+	}
+	return filter, nil
+}
+
+// ExportOrders - export orders for accounting
+//
+//	@Summary		Export orders
+//	@Description	Method provides to download lines of orders with customers, addresses, prices and totals of orders in CSV or XLSX format. Orders are filtered as in the list of orders, the oldest orders go first.
+//	@Tags			orders
+//	@Produce		text/csv
+//	@Produce		application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Param			format		query		string				false	"Format of file (csv, xlsx), csv by default"
+//	@Param			from		query		string				false	"Start of period of creation in RFC3339 format"
+//	@Param			to			query		string				false	"End of period of creation in RFC3339 format, not included"
+//	@Param			status		query		string				false	"Status of orders"
+//	@Param			email		query		string				false	"Part of email of the customer"
+//	@Param			city		query		string				false	"City of the address of orders"
+//	@Param			minTotal	query		int					false	"Minimal total of orders"
+//	@Param			maxTotal	query		int					false	"Maximal total of orders"
+//	@Param			itemID		query		string				false	"Id of item contained in orders"
+//	@Success		200			{file}		file				"File with lines of orders"
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		401			"Unauthorized"
+//	@Failure		403			"Forbidden"
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/order/export [get]
+func (delivery *Delivery) ExportOrders(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery ExportOrders()")
+	filter, err := orderFilter(c)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	format := c.DefaultQuery("format", export.CSV)
+	if !export.Valid(format) {
+		err = fmt.Errorf("invalid format: %s", format)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	c.Header("Content-Type", export.ContentType(format))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, export.FileName("orders", filter.From, filter.To, format)))
+	err = delivery.orderListUsecase.ExportOrders(c.Request.Context(), filter, format, c.Writer)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		if c.Writer.Written() {
+			// the file is partly sent, so the status can't be changed
+			return
+		}
+		c.Header("Content-Type", "")
+		c.Header("Content-Disposition", "")
+		if errors.Is(err, models.ErrorForbidden{}) {
+			delivery.SetError(c, http.StatusForbidden, err)
+			return
+		}
+		delivery.SetError(c, http.StatusInternalServerError, err)
+	}
+}
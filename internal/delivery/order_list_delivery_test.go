@@ -0,0 +1,155 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/export"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/usecase/mocks"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestGetOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	orderListUsecase := mocks.NewMockIOrderListUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, OrderListUsecase: orderListUsecase})
+
+	for _, query := range []string{"status=lost", "from=yesterday", "to=1", "minTotal=a", "maxTotal=1.5",
+		"minTotal=10&maxTotal=5", "itemID=1", "sortType=title", "sortOrder=up", "limit=-1", "offset=a",
+		"from=2023-01-02T00:00:00Z&to=2023-01-01T00:00:00Z"} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/order/list?"+query, nil)
+		delivery.GetOrders(c)
+		require.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	minTotal := int64(1000)
+	filter := models.OrderFilter{
+		Status:   models.StatusPaid,
+		From:     from,
+		Email:    "mail.ru",
+		City:     "Moscow",
+		MinTotal: &minTotal,
+		ItemId:   testId,
+		SortType: models.OrderSortTotal,
+		Limit:    10,
+	}
+	target := fmt.Sprintf("/order/list?status=%s&from=%s&email=mail.ru&city=Moscow&minTotal=1000&itemID=%s&sortType=total&sortOrder=asc&limit=10",
+		url.QueryEscape(string(models.StatusPaid)), from.Format(time.RFC3339), testId)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	orderListUsecase.EXPECT().GetOrders(gomock.Any(), filter).Return(nil, fmt.Errorf("error"))
+	delivery.GetOrders(c)
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	orderListUsecase.EXPECT().GetOrders(gomock.Any(), filter).Return(nil, fmt.Errorf("can't list: %w", models.ErrorForbidden{}))
+	delivery.GetOrders(c)
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	orderListUsecase.EXPECT().GetOrders(gomock.Any(), filter).Return(&models.OrderList{
+		Orders: []models.OrderSummary{{
+			ID:            testId,
+			User:          models.User{ID: testUserId, Email: "user@mail.ru"},
+			Status:        models.StatusPaid,
+			ItemsQuantity: 2,
+			Total:         1300,
+		}},
+		Quantity:     1,
+		StatusCounts: map[models.Status]int{models.StatusPaid: 1, models.StatusCreated: 4},
+	}, nil)
+	delivery.GetOrders(c)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"total":1300`)
+	require.Contains(t, w.Body.String(), `"email":"user@mail.ru"`)
+	require.Contains(t, w.Body.String(), `"quantity":1`)
+	require.Contains(t, w.Body.String(), `"order created":4`)
+	require.Contains(t, w.Body.String(), `"delivered":0`)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/order/list", nil)
+	orderListUsecase.EXPECT().GetOrders(gomock.Any(), models.OrderFilter{SortDesc: true}).Return(&models.OrderList{}, nil)
+	delivery.GetOrders(c)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"list":[]`)
+}
+
+func TestExportOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	orderListUsecase := mocks.NewMockIOrderListUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, OrderListUsecase: orderListUsecase})
+
+	for _, query := range []string{"format=pdf", "from=yesterday", "itemID=1"} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/order/export?"+query, nil)
+		delivery.ExportOrders(c)
+		require.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+	filter := models.OrderFilter{From: from, To: to, SortDesc: true}
+	target := fmt.Sprintf("/order/export?format=xlsx&from=%s&to=%s", from.Format(time.RFC3339), to.Format(time.RFC3339))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	orderListUsecase.EXPECT().ExportOrders(gomock.Any(), filter, "xlsx", gomock.Any()).Return(fmt.Errorf("error"))
+	delivery.ExportOrders(c)
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	orderListUsecase.EXPECT().ExportOrders(gomock.Any(), filter, "xlsx", gomock.Any()).Return(fmt.Errorf("can't export: %w", models.ErrorForbidden{}))
+	delivery.ExportOrders(c)
+	require.Equal(t, http.StatusForbidden, w.Code)
+	require.Empty(t, w.Header().Get("Content-Disposition"))
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	orderListUsecase.EXPECT().ExportOrders(gomock.Any(), filter, "xlsx", gomock.Any()).DoAndReturn(
+		func(ctx context.Context, filter models.OrderFilter, format string, w io.Writer) error {
+			_, err := w.Write([]byte("file"))
+			return err
+		})
+	delivery.ExportOrders(c)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "file", w.Body.String())
+	require.Equal(t, export.ContentType(export.XLSX), w.Header().Get("Content-Type"))
+	require.Equal(t, `attachment; filename="orders_from_2023-01-01_to_2023-02-01.xlsx"`, w.Header().Get("Content-Disposition"))
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/order/export", nil)
+	orderListUsecase.EXPECT().ExportOrders(gomock.Any(), models.OrderFilter{SortDesc: true}, "csv", gomock.Any()).Return(nil)
+	delivery.ExportOrders(c)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `attachment; filename="orders.csv"`, w.Header().Get("Content-Disposition"))
+}
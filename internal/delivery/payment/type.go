@@ -0,0 +1,24 @@
+package payment
+
+import (
+	"time"
+)
+
+// Payment is a structure for output the payment of the order
+type Payment struct {
+	Id             string    `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	OrderId        string    `json:"orderId" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Provider       string    `json:"provider" example:"mock"`
+	ClientSecret   string    `json:"clientSecret,omitempty" example:"mock_00000000-0000-0000-0000-000000000000_secret"`
+	Amount         int64     `json:"amount" example:"10300"`
+	RefundedAmount int64     `json:"refundedAmount" example:"0"`
+	Currency       string    `json:"currency" example:"RUB"`
+	Status         string    `json:"status" example:"pending"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// Refund is a structure for the amount to refund, zero amount refunds the rest of the payment
+type Refund struct {
+	Amount int64 `json:"amount" example:"0" default:"0" binding:"min=0" minimum:"0"`
+}
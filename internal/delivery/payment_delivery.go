@@ -0,0 +1,195 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/payment"
+	"OnlineShopBackend/internal/models"
+	shoppayment "OnlineShopBackend/internal/payment"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// paymentResponse converts the payment to the structure for output
+func paymentResponse(modelPayment *models.Payment) payment.Payment {
+	return payment.Payment{
+		Id:             modelPayment.Id.String(),
+		OrderId:        modelPayment.OrderId.String(),
+		Provider:       modelPayment.Provider,
+		ClientSecret:   modelPayment.ClientSecret,
+		Amount:         modelPayment.Amount,
+		RefundedAmount: modelPayment.RefundedAmount,
+		Currency:       modelPayment.Currency,
+		Status:         string(modelPayment.Status),
+		CreatedAt:      modelPayment.CreatedAt,
+		UpdatedAt:      modelPayment.UpdatedAt,
+	}
+}
+
+// paymentErrorStatus returns the status code of the response to the error of the payment
+func paymentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, models.ErrorNotFound{}):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrorForbidden{}):
+		return http.StatusForbidden
+	case errors.Is(err, models.ErrorWrongStatus{}):
+		return http.StatusConflict
+	case errors.Is(err, models.ErrorEmptyCart{}), errors.Is(err, models.ErrorAmountExceeded{}):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// PayOrder - create the payment for the order
+//
+//	@Summary		Pay for the order
+//	@Description	The method creates the payment for the order waiting for the payment and returns the client secret to confirm it in the payment provider.
+//	@Description	If the order already has the pending payment, it is returned. The order becomes paid when the provider notifies the webhook.
+//	@Tags			payment
+//	@Accept			json
+//	@Produce		json
+//	@Param			orderID			path		string			true	"Id of the order"
+//	@Param			Idempotency-Key	header		string			false	"Unique key of the request to retry it safely"
+//	@Success		201				{object}	payment.Payment	"Payment of the order"
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		403				"Forbidden"
+//	@Failure		404				{object}	ErrorResponse	"404 Not Found"
+//	@Failure		409				{object}	ErrorResponse	"Order is not waiting for the payment"
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/order/{orderID}/pay [post]
+func (delivery *Delivery) PayOrder(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery PayOrder()")
+	orderId, err := uuid.Parse(c.Param("orderID"))
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't parse order id: %s", err)
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	modelPayment, err := delivery.paymentUsecase.Pay(c.Request.Context(), orderId)
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't pay order: %s", err)
+		delivery.SetError(c, paymentErrorStatus(err), err)
+		return
+	}
+	c.JSON(http.StatusCreated, paymentResponse(modelPayment))
+}
+
+// GetOrderPayment - get the last payment of the order
+//
+//	@Summary		Get payment of the order
+//	@Description	The method allows you to get the last payment of the order.
+//	@Tags			payment
+//	@Accept			json
+//	@Produce		json
+//	@Param			orderID	path		string			true	"Id of the order"
+//	@Success		200		{object}	payment.Payment	"Payment of the order"
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		"Forbidden"
+//	@Failure		404		{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/order/{orderID}/payment [get]
+func (delivery *Delivery) GetOrderPayment(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery GetOrderPayment()")
+	orderId, err := uuid.Parse(c.Param("orderID"))
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't parse order id: %s", err)
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	modelPayment, err := delivery.paymentUsecase.GetPayment(c.Request.Context(), orderId)
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't get payment: %s", err)
+		delivery.SetError(c, paymentErrorStatus(err), err)
+		return
+	}
+	c.JSON(http.StatusOK, paymentResponse(modelPayment))
+}
+
+// RefundOrder - refund the payment of the order
+//
+//	@Summary		Refund the payment of the order
+//	@Description	The method returns the amount of the succeeded payment of the order to the customer, zero amount refunds the rest of the payment.
+//	@Tags			payment
+//	@Accept			json
+//	@Produce		json
+//	@Param			orderID			path		string			true	"Id of the order"
+//	@Param			refund			body		payment.Refund	true	"Amount to refund"
+//	@Param			Idempotency-Key	header		string			false	"Unique key of the request to retry it safely"
+//	@Success		200				{object}	payment.Payment	"Refunded payment"
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		403				"Forbidden"
+//	@Failure		404				{object}	ErrorResponse	"404 Not Found"
+//	@Failure		409				{object}	ErrorResponse	"Order has no succeeded payment"
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/order/{orderID}/refund [post]
+func (delivery *Delivery) RefundOrder(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery RefundOrder()")
+	orderId, err := uuid.Parse(c.Param("orderID"))
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't parse order id: %s", err)
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var refund payment.Refund
+	if err := c.ShouldBindJSON(&refund); err != nil {
+		delivery.logger.Sugar().Errorf("can't bind json from request: %s", err)
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	modelPayment, err := delivery.paymentUsecase.Refund(c.Request.Context(), orderId, refund.Amount)
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't refund order: %s", err)
+		delivery.SetError(c, paymentErrorStatus(err), err)
+		return
+	}
+	c.JSON(http.StatusOK, paymentResponse(modelPayment))
+}
+
+// PaymentWebhook - receive events of payments from the payment provider
+//
+//	@Summary		Webhook of the payment provider
+//	@Description	The payment provider notifies about changes of payments. The request must be signed by the provider.
+//	@Tags			payment
+//	@Accept			json
+//	@Produce		json
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		401	{object}	ErrorResponse	"Invalid signature"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/payments/webhook [post]
+func (delivery *Delivery) PaymentWebhook(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery PaymentWebhook()")
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't read request body: %s", err)
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	err = delivery.paymentUsecase.HandleWebhook(c.Request.Context(), c.Request.Header, body)
+	if err != nil && errors.Is(err, shoppayment.ErrInvalidSignature) {
+		delivery.logger.Sugar().Errorf("can't handle webhook: %s", err)
+		delivery.SetError(c, http.StatusUnauthorized, err)
+		return
+	}
+	if err != nil && errors.Is(err, shoppayment.ErrInvalidEvent) {
+		delivery.logger.Sugar().Errorf("can't handle webhook: %s", err)
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		delivery.logger.Sugar().Errorf("can't handle webhook: %s", err)
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't handle webhook: %s", err)
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
@@ -0,0 +1,108 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/payment"
+	"OnlineShopBackend/internal/usecase/mocks"
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPayOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	paymentUsecase := mocks.NewMockIPaymentUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, PaymentUsecase: paymentUsecase})
+	request := func(orderId string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/order/"+orderId+"/pay", nil)
+		c.Params = gin.Params{{Key: "orderID", Value: orderId}}
+		return w, c
+	}
+
+	w, c := request("1")
+	delivery.PayOrder(c)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	for err, code := range map[error]int{
+		models.ErrorNotFound{}:    http.StatusNotFound,
+		models.ErrorWrongStatus{}: http.StatusConflict,
+		models.ErrorEmptyCart{}:   http.StatusBadRequest,
+		fmt.Errorf("error"):       http.StatusInternalServerError,
+	} {
+		paymentUsecase.EXPECT().Pay(gomock.Any(), testId).Return(nil, fmt.Errorf("can't pay: %w", err))
+		w, c = request(testId.String())
+		delivery.PayOrder(c)
+		require.Equal(t, code, w.Code)
+	}
+
+	paymentUsecase.EXPECT().Pay(gomock.Any(), testId).Return(&models.Payment{Id: testId, OrderId: testId, Amount: 100, Status: models.PaymentPending}, nil)
+	w, c = request(testId.String())
+	delivery.PayOrder(c)
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Contains(t, w.Body.String(), `"status":"pending"`)
+}
+
+func TestRefundOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	paymentUsecase := mocks.NewMockIPaymentUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, PaymentUsecase: paymentUsecase})
+	request := func(body string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/order/"+testId.String()+"/refund", bytes.NewBufferString(body))
+		c.Params = gin.Params{{Key: "orderID", Value: testId.String()}}
+		return w, c
+	}
+
+	w, c := request(`{"amount": -1}`)
+	delivery.RefundOrder(c)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	paymentUsecase.EXPECT().Refund(gomock.Any(), testId, int64(500)).Return(nil, models.ErrorAmountExceeded{})
+	w, c = request(`{"amount": 500}`)
+	delivery.RefundOrder(c)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	paymentUsecase.EXPECT().Refund(gomock.Any(), testId, int64(0)).Return(&models.Payment{Id: testId, OrderId: testId, Amount: 100, RefundedAmount: 100, Status: models.PaymentRefunded}, nil)
+	w, c = request(`{}`)
+	delivery.RefundOrder(c)
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestPaymentWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	paymentUsecase := mocks.NewMockIPaymentUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, PaymentUsecase: paymentUsecase})
+	body := `{"type": "payment.succeeded", "intentId": "mock_1"}`
+
+	for err, code := range map[error]int{
+		payment.ErrInvalidSignature: http.StatusUnauthorized,
+		payment.ErrInvalidEvent:     http.StatusBadRequest,
+		models.ErrorNotFound{}:      http.StatusNotFound,
+		fmt.Errorf("error"):         http.StatusInternalServerError,
+		nil:                         http.StatusOK,
+	} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/payments/webhook", bytes.NewBufferString(body))
+		c.Request.Header.Set(payment.SignatureHeader, "signature")
+		paymentUsecase.EXPECT().HandleWebhook(gomock.Any(), gomock.Any(), []byte(body)).Return(err)
+		delivery.PaymentWebhook(c)
+		require.Equal(t, code, w.Code)
+	}
+}
@@ -0,0 +1,50 @@
+package question
+
+import (
+	"time"
+)
+
+// ShortQuestion is a structure for ask new question about item
+type ShortQuestion struct {
+	Text string `json:"text" binding:"required" example:"Какая мощность у пылесоса?"`
+}
+
+// ShortAnswer is a structure for answer to the question
+type ShortAnswer struct {
+	Text string `json:"text" binding:"required" example:"Мощность всасывания 1.5 кВт"`
+}
+
+// QuestionId is a structure for result of creating question
+type QuestionId struct {
+	Value string `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+}
+
+// AnswerId is a structure for result of creating answer
+type AnswerId struct {
+	Value string `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+}
+
+// Answer is a structure for output answers to the question
+type Answer struct {
+	Id        string    `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	UserId    string    `json:"userId" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Text      string    `json:"text" example:"Мощность всасывания 1.5 кВт"`
+	Votes     int       `json:"votes" example:"5" minimum:"0"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Question is a structure for output questions with answers
+type Question struct {
+	Id        string    `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	ItemId    string    `json:"itemId" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	UserId    string    `json:"userId" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Text      string    `json:"text" example:"Какая мощность у пылесоса?"`
+	CreatedAt time.Time `json:"createdAt"`
+	Answers   []Answer  `json:"answers"`
+}
+
+// QuestionsList is a structure for questions list query results
+type QuestionsList struct {
+	List     []Question `json:"questions" binding:"min=0" minimum:"0"`
+	Quantity int        `json:"quantity" example:"10" default:"0" binding:"min=0" minimum:"0"`
+}
@@ -0,0 +1,253 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/question"
+	"OnlineShopBackend/internal/models"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AskQuestion - ask new question about item
+//
+//	@Summary		Method provides to ask question about item
+//	@Description	Method provides to ask pre-sale question about item.
+//	@Tags			questions
+//	@Accept			json
+//	@Produce		json
+//	@Param			itemID		path		string					true	"Id of item"
+//	@Param			question	body		question.ShortQuestion	true	"Text of question"
+//	@Success		201			{object}	question.QuestionId
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		401			"Unauthorized"
+//	@Failure		404			{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/items/{itemID}/questions [post]
+func (delivery *Delivery) AskQuestion(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery AskQuestion()")
+	claims, ok := delivery.getClaims(c)
+	if !ok {
+		delivery.SetError(c, http.StatusUnauthorized, fmt.Errorf("user unauthorized"))
+		return
+	}
+	itemId, err := uuid.Parse(c.Param("itemID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var shortQuestion question.ShortQuestion
+	if err := c.ShouldBindJSON(&shortQuestion); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	ctx := c.Request.Context()
+	id, err := delivery.questionUsecase.AskQuestion(ctx, &models.Question{
+		ItemId: itemId,
+		UserId: claims.UserId,
+		Text:   shortQuestion.Text,
+	})
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		err = fmt.Errorf("item with id: %v not found", itemId)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusCreated, question.QuestionId{Value: id.String()})
+}
+
+// GetItemQuestions - get questions about item with answers
+//
+//	@Summary		Get questions about item
+//	@Description	Method provides to get list of questions about item with their answers.
+//	@Tags			questions
+//	@Accept			json
+//	@Produce		json
+//	@Param			itemID	path		string					true	"Id of item"
+//	@Success		200		{object}	question.QuestionsList	"List of questions"
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/items/{itemID}/questions [get]
+func (delivery *Delivery) GetItemQuestions(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery GetItemQuestions()")
+	itemId, err := uuid.Parse(c.Param("itemID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	questions, err := delivery.questionUsecase.GetItemQuestions(c.Request.Context(), itemId)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, questionsList(questions))
+}
+
+// AnswerQuestion - answer the question about item
+//
+//	@Summary		Method provides to answer the question
+//	@Description	Method provides to answer the question about item. Only admins and the seller of the item can answer.
+//	@Tags			questions
+//	@Accept			json
+//	@Produce		json
+//	@Param			questionID	path		string					true	"Id of question"
+//	@Param			answer		body		question.ShortAnswer	true	"Text of answer"
+//	@Success		201			{object}	question.AnswerId
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		401			"Unauthorized"
+//	@Failure		403			{object}	ErrorResponse	"Forbidden"
+//	@Failure		404			{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/questions/{questionID}/answers [post]
+func (delivery *Delivery) AnswerQuestion(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery AnswerQuestion()")
+	claims, ok := delivery.getClaims(c)
+	if !ok {
+		delivery.SetError(c, http.StatusUnauthorized, fmt.Errorf("user unauthorized"))
+		return
+	}
+	questionId, err := uuid.Parse(c.Param("questionID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var shortAnswer question.ShortAnswer
+	if err := c.ShouldBindJSON(&shortAnswer); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	ctx := c.Request.Context()
+	id, err := delivery.questionUsecase.AnswerQuestion(ctx, &models.Answer{
+		QuestionId: questionId,
+		UserId:     claims.UserId,
+		Text:       shortAnswer.Text,
+	}, claims.Role)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		err = fmt.Errorf("question with id: %v not found", questionId)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil && errors.Is(err, models.ErrorForbidden{}) {
+		err = fmt.Errorf("only admins and the seller of the item can answer the question")
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusForbidden, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusCreated, question.AnswerId{Value: id.String()})
+}
+
+// UpvoteAnswer - vote for the answer
+//
+//	@Summary		Method provides to upvote the answer
+//	@Description	Method provides to upvote the answer. Every user can vote for the answer only once.
+//	@Tags			questions
+//	@Accept			json
+//	@Produce		json
+//	@Param			answerID	path	string	true	"Id of answer"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		401	"Unauthorized"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		409	{object}	ErrorResponse	"Already voted"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/answers/{answerID}/upvote [post]
+func (delivery *Delivery) UpvoteAnswer(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery UpvoteAnswer()")
+	claims, ok := delivery.getClaims(c)
+	if !ok {
+		delivery.SetError(c, http.StatusUnauthorized, fmt.Errorf("user unauthorized"))
+		return
+	}
+	answerId, err := uuid.Parse(c.Param("answerID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	err = delivery.questionUsecase.UpvoteAnswer(c.Request.Context(), answerId, claims.UserId)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		err = fmt.Errorf("answer with id: %v not found", answerId)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil && errors.Is(err, models.ErrorAlreadyExists{}) {
+		err = fmt.Errorf("user already voted for answer with id: %v", answerId)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusConflict, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// GetUnansweredQuestions - get queue of questions without answers
+//
+//	@Summary		Get unanswered questions
+//	@Description	Method provides to get the queue of questions without answers, the oldest questions go first.
+//	@Tags			questions
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	question.QuestionsList	"List of questions"
+//	@Failure		403	"Forbidden"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/questions/unanswered [get]
+func (delivery *Delivery) GetUnansweredQuestions(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery GetUnansweredQuestions()")
+	questions, err := delivery.questionUsecase.GetUnansweredQuestions(c.Request.Context())
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, questionsList(questions))
+}
+
+// questionsList converts questions to the output structure
+func questionsList(questions []models.Question) question.QuestionsList {
+	list := make([]question.Question, len(questions))
+	for idx, modelsQuestion := range questions {
+		answers := make([]question.Answer, len(modelsQuestion.Answers))
+		for i, answer := range modelsQuestion.Answers {
+			answers[i] = question.Answer{
+				Id:        answer.Id.String(),
+				UserId:    answer.UserId.String(),
+				Text:      answer.Text,
+				Votes:     answer.Votes,
+				CreatedAt: answer.CreatedAt,
+			}
+		}
+		list[idx] = question.Question{
+			Id:        modelsQuestion.Id.String(),
+			ItemId:    modelsQuestion.ItemId.String(),
+			UserId:    modelsQuestion.UserId.String(),
+			Text:      modelsQuestion.Text,
+			CreatedAt: modelsQuestion.CreatedAt,
+			Answers:   answers,
+		}
+	}
+	return question.QuestionsList{List: list, Quantity: len(list)}
+}
@@ -0,0 +1,186 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/question"
+	"OnlineShopBackend/internal/delivery/user/jwtauth"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/usecase/mocks"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var (
+	testClaims = &jwtauth.Payload{
+		Email:  "test@test.ru",
+		Role:   models.Customer,
+		UserId: testUserId,
+	}
+	testShortQuestion = question.ShortQuestion{
+		Text: "test question",
+	}
+	testShortAnswer = question.ShortAnswer{
+		Text: "test answer",
+	}
+	testModelQuestion = models.Question{
+		Id:     testId,
+		ItemId: testId,
+		UserId: testUserId,
+		Text:   "test question",
+	}
+)
+
+func TestAskQuestion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	questionUsecase := mocks.NewMockIQuestionUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, QuestionUsecase: questionUsecase})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	delivery.AskQuestion(c)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Set("claims", testClaims)
+	c.Params = []gin.Param{{Key: "itemID", Value: "test"}}
+	delivery.AskQuestion(c)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Set("claims", testClaims)
+	c.Params = []gin.Param{{Key: "itemID", Value: testId.String()}}
+	MockJson(c, testShortQuestion, "POST")
+	questionUsecase.EXPECT().AskQuestion(ctx, &models.Question{
+		ItemId: testId,
+		UserId: testUserId,
+		Text:   testShortQuestion.Text,
+	}).Return(uuid.Nil, models.ErrorNotFound{})
+	delivery.AskQuestion(c)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Set("claims", testClaims)
+	c.Params = []gin.Param{{Key: "itemID", Value: testId.String()}}
+	MockJson(c, testShortQuestion, "POST")
+	questionUsecase.EXPECT().AskQuestion(ctx, gomock.Any()).Return(testId, nil)
+	delivery.AskQuestion(c)
+	require.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestGetItemQuestions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	questionUsecase := mocks.NewMockIQuestionUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, QuestionUsecase: questionUsecase})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Params = []gin.Param{{Key: "itemID", Value: testId.String()}}
+	questionUsecase.EXPECT().GetItemQuestions(ctx, testId).Return(nil, err)
+	delivery.GetItemQuestions(c)
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Params = []gin.Param{{Key: "itemID", Value: testId.String()}}
+	questionUsecase.EXPECT().GetItemQuestions(ctx, testId).Return([]models.Question{testModelQuestion}, nil)
+	delivery.GetItemQuestions(c)
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAnswerQuestion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	questionUsecase := mocks.NewMockIQuestionUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, QuestionUsecase: questionUsecase})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Set("claims", testClaims)
+	c.Params = []gin.Param{{Key: "questionID", Value: testId.String()}}
+	MockJson(c, testShortAnswer, "POST")
+	questionUsecase.EXPECT().AnswerQuestion(ctx, gomock.Any(), models.Customer).Return(uuid.Nil, models.ErrorForbidden{})
+	delivery.AnswerQuestion(c)
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Set("claims", testClaims)
+	c.Params = []gin.Param{{Key: "questionID", Value: testId.String()}}
+	MockJson(c, testShortAnswer, "POST")
+	questionUsecase.EXPECT().AnswerQuestion(ctx, gomock.Any(), models.Customer).Return(testId, nil)
+	delivery.AnswerQuestion(c)
+	require.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestUpvoteAnswer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	questionUsecase := mocks.NewMockIQuestionUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, QuestionUsecase: questionUsecase})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Set("claims", testClaims)
+	c.Params = []gin.Param{{Key: "answerID", Value: testId.String()}}
+	questionUsecase.EXPECT().UpvoteAnswer(ctx, testId, testUserId).Return(models.ErrorAlreadyExists{})
+	delivery.UpvoteAnswer(c)
+	require.Equal(t, http.StatusConflict, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	c.Set("claims", testClaims)
+	c.Params = []gin.Param{{Key: "answerID", Value: testId.String()}}
+	questionUsecase.EXPECT().UpvoteAnswer(ctx, testId, testUserId).Return(nil)
+	delivery.UpvoteAnswer(c)
+	require.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,66 @@
+package returns
+
+import (
+	"time"
+)
+
+// Return is a structure for output the return of lines of the order
+type Return struct {
+	Id             string         `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	OrderId        string         `json:"orderId" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	UserId         string         `json:"userId" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Reason         string         `json:"reason" example:"Wrong size"`
+	Status         string         `json:"status" example:"requested"`
+	TrackingNumber string         `json:"trackingNumber,omitempty" example:"RA123456789RU"`
+	Total          int64          `json:"total" example:"2000"`
+	RefundAmount   int64          `json:"refundAmount" example:"0"`
+	Items          []ReturnItem   `json:"items"`
+	History        []StatusChange `json:"history"`
+	CreatedAt      time.Time      `json:"createdAt"`
+	UpdatedAt      time.Time      `json:"updatedAt"`
+}
+
+// ReturnItem is a structure for the returned line of the order
+type ReturnItem struct {
+	ItemId   string `json:"itemId" binding:"required,uuid" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Title    string `json:"title,omitempty" example:"Item title"`
+	Price    int32  `json:"price,omitempty" example:"1000"`
+	Quantity int    `json:"quantity" binding:"required,min=1" example:"2" minimum:"1"`
+}
+
+// StatusChange is a structure for output the record of the status history of the return
+type StatusChange struct {
+	Status    string    `json:"status" example:"approved"`
+	Comment   string    `json:"comment,omitempty" example:"Send the goods back"`
+	ActorId   string    `json:"actorId,omitempty" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// NewReturn is a structure for the request to return lines of the order
+type NewReturn struct {
+	Reason string       `json:"reason" binding:"required" example:"Wrong size"`
+	Items  []ReturnItem `json:"items" binding:"required,min=1,dive"`
+}
+
+// Status is a structure for the new status of the return set by the admin
+type Status struct {
+	Status  string `json:"status" binding:"required,oneof=approved rejected received" example:"approved"`
+	Comment string `json:"comment" example:"Send the goods back"`
+}
+
+// Tracking is a structure for the number of the parcel with returned goods
+type Tracking struct {
+	TrackingNumber string `json:"trackingNumber" binding:"required,max=256" example:"RA123456789RU"`
+}
+
+// Refund is a structure for the amount to refund, zero amount refunds the sum of the returned lines
+type Refund struct {
+	Amount int64 `json:"amount" example:"0" default:"0" binding:"min=0" minimum:"0"`
+}
+
+// ReturnsOptions is a structure for the query of the list of returns
+type ReturnsOptions struct {
+	Status string `form:"status" binding:"omitempty,oneof=requested approved rejected received refunded"`
+	Offset int    `form:"offset" binding:"min=0"`
+	Limit  int    `form:"limit" binding:"min=0"`
+}
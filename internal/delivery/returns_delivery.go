@@ -0,0 +1,314 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/returns"
+	"OnlineShopBackend/internal/models"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// defaultReturnsLimit is the size of the page of returns if the limit is not indicated
+const defaultReturnsLimit = 20
+
+// returnResponse converts the return to the structure for output
+func returnResponse(modelReturn *models.Return) returns.Return {
+	ret := returns.Return{
+		Id:             modelReturn.Id.String(),
+		OrderId:        modelReturn.OrderId.String(),
+		UserId:         modelReturn.UserId.String(),
+		Reason:         modelReturn.Reason,
+		Status:         string(modelReturn.Status),
+		TrackingNumber: modelReturn.TrackingNumber,
+		Total:          modelReturn.Total(),
+		RefundAmount:   modelReturn.RefundAmount,
+		Items:          make([]returns.ReturnItem, 0, len(modelReturn.Items)),
+		History:        make([]returns.StatusChange, 0, len(modelReturn.History)),
+		CreatedAt:      modelReturn.CreatedAt,
+		UpdatedAt:      modelReturn.UpdatedAt,
+	}
+	for _, item := range modelReturn.Items {
+		ret.Items = append(ret.Items, returns.ReturnItem{
+			ItemId:   item.ItemId.String(),
+			Title:    item.Title,
+			Price:    item.Price,
+			Quantity: item.Quantity,
+		})
+	}
+	for _, change := range modelReturn.History {
+		statusChange := returns.StatusChange{
+			Status:    string(change.Status),
+			Comment:   change.Comment,
+			CreatedAt: change.CreatedAt,
+		}
+		if change.ActorId != uuid.Nil {
+			statusChange.ActorId = change.ActorId.String()
+		}
+		ret.History = append(ret.History, statusChange)
+	}
+	return ret
+}
+
+// returnErrorStatus returns the status code of the response to the error of the return
+func returnErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, models.ErrorNotFound{}):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrorForbidden{}):
+		return http.StatusForbidden
+	case errors.Is(err, models.ErrorWrongStatus{}):
+		return http.StatusConflict
+	case errors.Is(err, models.ErrorEmptyCart{}), errors.Is(err, models.ErrorQuantityExceeded{}),
+		errors.Is(err, models.ErrorAmountExceeded{}):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// CreateReturn - request the return of lines of the delivered order
+//
+//	@Summary		Request the return
+//	@Description	The method opens the return of lines of the delivered order with the reason. The quantity of every line
+//	@Description	must not exceed the quantity ordered minus the quantity already returned.
+//	@Tags			returns
+//	@Accept			json
+//	@Produce		json
+//	@Param			orderID			path		string				true	"Id of the order"
+//	@Param			return			body		returns.NewReturn	true	"Lines to return and the reason"
+//	@Param			Idempotency-Key	header		string				false	"Unique key of the request to retry it safely"
+//	@Success		201				{object}	returns.Return		"Requested return"
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		403				"Forbidden"
+//	@Failure		404				{object}	ErrorResponse	"404 Not Found"
+//	@Failure		409				{object}	ErrorResponse	"Order is not delivered"
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/order/{orderID}/returns [post]
+func (delivery *Delivery) CreateReturn(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery CreateReturn()")
+	orderId, err := uuid.Parse(c.Param("orderID"))
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't parse order id: %s", err)
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var newReturn returns.NewReturn
+	if err := c.ShouldBindJSON(&newReturn); err != nil {
+		delivery.logger.Sugar().Errorf("can't bind json from request: %s", err)
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	modelReturn := &models.Return{
+		OrderId: orderId,
+		Reason:  newReturn.Reason,
+		Items:   make([]models.ReturnItem, 0, len(newReturn.Items)),
+	}
+	for _, item := range newReturn.Items {
+		itemId, err := uuid.Parse(item.ItemId)
+		if err != nil {
+			delivery.logger.Sugar().Errorf("can't parse item id: %s", err)
+			delivery.SetError(c, http.StatusBadRequest, err)
+			return
+		}
+		modelReturn.Items = append(modelReturn.Items, models.ReturnItem{ItemId: itemId, Quantity: item.Quantity})
+	}
+	created, err := delivery.returnUsecase.CreateReturn(c.Request.Context(), modelReturn)
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't create return: %s", err)
+		delivery.SetError(c, returnErrorStatus(err), err)
+		return
+	}
+	c.JSON(http.StatusCreated, returnResponse(created))
+}
+
+// GetReturn - get the return by id
+//
+//	@Summary		Get return by id
+//	@Description	The method allows you to get the return with its lines and status history.
+//	@Tags			returns
+//	@Accept			json
+//	@Produce		json
+//	@Param			returnID	path		string			true	"Id of the return"
+//	@Success		200			{object}	returns.Return	"Return"
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		403			"Forbidden"
+//	@Failure		404			{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/returns/{returnID} [get]
+func (delivery *Delivery) GetReturn(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery GetReturn()")
+	returnId, err := uuid.Parse(c.Param("returnID"))
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't parse return id: %s", err)
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	modelReturn, err := delivery.returnUsecase.GetReturn(c.Request.Context(), returnId)
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't get return: %s", err)
+		delivery.SetError(c, returnErrorStatus(err), err)
+		return
+	}
+	c.JSON(http.StatusOK, returnResponse(modelReturn))
+}
+
+// GetReturns - get the list of returns
+//
+//	@Summary		Get list of returns
+//	@Description	The method allows the admin to get returns in the status, the oldest returns go first.
+//	@Tags			returns
+//	@Accept			json
+//	@Produce		json
+//	@Param			status	query		string			false	"Status of returns"	Enums(requested, approved, rejected, received, refunded)
+//	@Param			offset	query		int				false	"Offset when receiving records"	default(0)	minimum(0)
+//	@Param			limit	query		int				false	"Quantity of recordings"		default(20)	minimum(0)
+//	@Success		200		{array}		returns.Return	"List of returns"
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		"Forbidden"
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/returns [get]
+func (delivery *Delivery) GetReturns(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery GetReturns()")
+	var options returns.ReturnsOptions
+	if err := c.ShouldBindQuery(&options); err != nil {
+		delivery.logger.Sugar().Errorf("can't bind query: %s", err)
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	if options.Limit == 0 {
+		options.Limit = defaultReturnsLimit
+	}
+	list, err := delivery.returnUsecase.GetReturns(c.Request.Context(), models.ReturnStatus(options.Status), options.Offset, options.Limit)
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't get returns: %s", err)
+		delivery.SetError(c, returnErrorStatus(err), err)
+		return
+	}
+	result := make([]returns.Return, 0, len(list))
+	for i := range list {
+		result = append(result, returnResponse(&list[i]))
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// ChangeReturnStatus - approve, reject or receive the return
+//
+//	@Summary		Change status of the return
+//	@Description	The method allows the admin to approve or reject the requested return and to mark the goods of the approved return as received.
+//	@Description	Received goods are put back to stock.
+//	@Tags			returns
+//	@Accept			json
+//	@Produce		json
+//	@Param			returnID	path		string			true	"Id of the return"
+//	@Param			status		body		returns.Status	true	"New status and comment"
+//	@Success		200			{object}	returns.Return	"Changed return"
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		403			"Forbidden"
+//	@Failure		404			{object}	ErrorResponse	"404 Not Found"
+//	@Failure		409			{object}	ErrorResponse	"Return can't move to the status"
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/returns/{returnID}/status [post]
+func (delivery *Delivery) ChangeReturnStatus(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery ChangeReturnStatus()")
+	returnId, err := uuid.Parse(c.Param("returnID"))
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't parse return id: %s", err)
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var status returns.Status
+	if err := c.ShouldBindJSON(&status); err != nil {
+		delivery.logger.Sugar().Errorf("can't bind json from request: %s", err)
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	modelReturn, err := delivery.returnUsecase.ChangeReturnStatus(c.Request.Context(), returnId, models.ReturnStatus(status.Status), status.Comment)
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't change return status: %s", err)
+		delivery.SetError(c, returnErrorStatus(err), err)
+		return
+	}
+	c.JSON(http.StatusOK, returnResponse(modelReturn))
+}
+
+// SetReturnTracking - set the tracking number of the parcel with returned goods
+//
+//	@Summary		Set tracking number of the return
+//	@Description	The method allows the customer to set the number of the parcel the goods of the approved return are sent with.
+//	@Tags			returns
+//	@Accept			json
+//	@Produce		json
+//	@Param			returnID	path		string				true	"Id of the return"
+//	@Param			tracking	body		returns.Tracking	true	"Tracking number"
+//	@Success		200			{object}	returns.Return		"Changed return"
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		403			"Forbidden"
+//	@Failure		404			{object}	ErrorResponse	"404 Not Found"
+//	@Failure		409			{object}	ErrorResponse	"Return is not approved"
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/returns/{returnID}/tracking [put]
+func (delivery *Delivery) SetReturnTracking(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery SetReturnTracking()")
+	returnId, err := uuid.Parse(c.Param("returnID"))
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't parse return id: %s", err)
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var tracking returns.Tracking
+	if err := c.ShouldBindJSON(&tracking); err != nil {
+		delivery.logger.Sugar().Errorf("can't bind json from request: %s", err)
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	modelReturn, err := delivery.returnUsecase.SetTrackingNumber(c.Request.Context(), returnId, tracking.TrackingNumber)
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't set tracking number: %s", err)
+		delivery.SetError(c, returnErrorStatus(err), err)
+		return
+	}
+	c.JSON(http.StatusOK, returnResponse(modelReturn))
+}
+
+// RefundReturn - refund the received return
+//
+//	@Summary		Refund the return
+//	@Description	The method refunds the received return through the payment of the order, zero amount refunds the sum of the returned lines.
+//	@Description	The amount may be less than the sum for the partial refund.
+//	@Tags			returns
+//	@Accept			json
+//	@Produce		json
+//	@Param			returnID		path		string			true	"Id of the return"
+//	@Param			refund			body		returns.Refund	true	"Amount to refund"
+//	@Param			Idempotency-Key	header		string			false	"Unique key of the request to retry it safely"
+//	@Success		200				{object}	returns.Return	"Refunded return"
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		403				"Forbidden"
+//	@Failure		404				{object}	ErrorResponse	"404 Not Found"
+//	@Failure		409				{object}	ErrorResponse	"Return is not received or the order has no succeeded payment"
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/returns/{returnID}/refund [post]
+func (delivery *Delivery) RefundReturn(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery RefundReturn()")
+	returnId, err := uuid.Parse(c.Param("returnID"))
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't parse return id: %s", err)
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var refund returns.Refund
+	if err := c.ShouldBindJSON(&refund); err != nil {
+		delivery.logger.Sugar().Errorf("can't bind json from request: %s", err)
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	modelReturn, err := delivery.returnUsecase.RefundReturn(c.Request.Context(), returnId, refund.Amount)
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't refund return: %s", err)
+		delivery.SetError(c, returnErrorStatus(err), err)
+		return
+	}
+	c.JSON(http.StatusOK, returnResponse(modelReturn))
+}
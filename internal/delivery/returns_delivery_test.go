@@ -0,0 +1,168 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/usecase/mocks"
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCreateReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	returnUsecase := mocks.NewMockIReturnUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, ReturnUsecase: returnUsecase})
+	request := func(orderId string, body string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/order/"+orderId+"/returns", bytes.NewBufferString(body))
+		c.Params = gin.Params{{Key: "orderID", Value: orderId}}
+		return w, c
+	}
+	body := fmt.Sprintf(`{"reason": "Wrong size", "items": [{"itemId": "%s", "quantity": 1}]}`, testId)
+
+	for _, req := range [][2]string{
+		{"1", body},
+		{testId.String(), `{"items": [{"itemId": "` + testId.String() + `", "quantity": 1}]}`},
+		{testId.String(), `{"reason": "Wrong size", "items": []}`},
+		{testId.String(), `{"reason": "Wrong size", "items": [{"itemId": "1", "quantity": 1}]}`},
+		{testId.String(), `{"reason": "Wrong size", "items": [{"itemId": "` + testId.String() + `", "quantity": 0}]}`},
+	} {
+		w, c := request(req[0], req[1])
+		delivery.CreateReturn(c)
+		require.Equal(t, http.StatusBadRequest, w.Code, req[1])
+	}
+
+	for err, code := range map[error]int{
+		models.ErrorNotFound{}:         http.StatusNotFound,
+		models.ErrorWrongStatus{}:      http.StatusConflict,
+		models.ErrorQuantityExceeded{}: http.StatusBadRequest,
+		fmt.Errorf("error"):            http.StatusInternalServerError,
+	} {
+		returnUsecase.EXPECT().CreateReturn(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("can't create return: %w", err))
+		w, c := request(testId.String(), body)
+		delivery.CreateReturn(c)
+		require.Equal(t, code, w.Code)
+	}
+
+	returnUsecase.EXPECT().CreateReturn(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, ret *models.Return) (*models.Return, error) {
+		require.Equal(t, testId, ret.OrderId)
+		require.Equal(t, "Wrong size", ret.Reason)
+		require.Equal(t, []models.ReturnItem{{ItemId: testId, Quantity: 1}}, ret.Items)
+		ret.Id = uuid.New()
+		ret.Status = models.ReturnRequested
+		ret.Items[0].Price = 500
+		return ret, nil
+	})
+	w, c := request(testId.String(), body)
+	delivery.CreateReturn(c)
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Contains(t, w.Body.String(), `"status":"requested"`)
+	require.Contains(t, w.Body.String(), `"total":500`)
+}
+
+func TestGetReturns(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	returnUsecase := mocks.NewMockIReturnUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, ReturnUsecase: returnUsecase})
+	request := func(query string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/returns?"+query, nil)
+		return w, c
+	}
+
+	for _, query := range []string{"status=unknown", "offset=-1", "limit=a"} {
+		w, c := request(query)
+		delivery.GetReturns(c)
+		require.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+
+	returnUsecase.EXPECT().GetReturns(gomock.Any(), models.ReturnRequested, 0, defaultReturnsLimit).Return(nil, fmt.Errorf("error"))
+	w, c := request("status=requested")
+	delivery.GetReturns(c)
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+
+	returnUsecase.EXPECT().GetReturns(gomock.Any(), models.ReturnStatus(""), 10, 5).Return([]models.Return{{Id: testId, Status: models.ReturnApproved}}, nil)
+	w, c = request("offset=10&limit=5")
+	delivery.GetReturns(c)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"status":"approved"`)
+}
+
+func TestChangeReturnStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	returnUsecase := mocks.NewMockIReturnUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, ReturnUsecase: returnUsecase})
+	request := func(body string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/returns/"+testId.String()+"/status", bytes.NewBufferString(body))
+		c.Params = gin.Params{{Key: "returnID", Value: testId.String()}}
+		return w, c
+	}
+
+	// The return is refunded by the refund only
+	w, c := request(`{"status": "refunded"}`)
+	delivery.ChangeReturnStatus(c)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	returnUsecase.EXPECT().ChangeReturnStatus(gomock.Any(), testId, models.ReturnReceived, "").Return(nil, models.ErrorWrongStatus{})
+	w, c = request(`{"status": "received"}`)
+	delivery.ChangeReturnStatus(c)
+	require.Equal(t, http.StatusConflict, w.Code)
+
+	returnUsecase.EXPECT().ChangeReturnStatus(gomock.Any(), testId, models.ReturnApproved, "Send it back").Return(&models.Return{
+		Id:      testId,
+		Status:  models.ReturnApproved,
+		History: []models.ReturnStatusChange{{Status: models.ReturnApproved, Comment: "Send it back", ActorId: testId}},
+	}, nil)
+	w, c = request(`{"status": "approved", "comment": "Send it back"}`)
+	delivery.ChangeReturnStatus(c)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"comment":"Send it back"`)
+}
+
+func TestRefundReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	returnUsecase := mocks.NewMockIReturnUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, ReturnUsecase: returnUsecase})
+	request := func(body string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/returns/"+testId.String()+"/refund", bytes.NewBufferString(body))
+		c.Params = gin.Params{{Key: "returnID", Value: testId.String()}}
+		return w, c
+	}
+
+	w, c := request(`{"amount": -1}`)
+	delivery.RefundReturn(c)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	returnUsecase.EXPECT().RefundReturn(gomock.Any(), testId, int64(5000)).Return(nil, models.ErrorAmountExceeded{})
+	w, c = request(`{"amount": 5000}`)
+	delivery.RefundReturn(c)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	returnUsecase.EXPECT().RefundReturn(gomock.Any(), testId, int64(0)).Return(&models.Return{Id: testId, Status: models.ReturnRefunded, RefundAmount: 500}, nil)
+	w, c = request(`{}`)
+	delivery.RefundReturn(c)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"refundAmount":500`)
+}
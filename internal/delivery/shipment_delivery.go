@@ -0,0 +1,77 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/order"
+	"OnlineShopBackend/internal/models"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// shipmentResponse converts the shipment to the structure for output
+func shipmentResponse(modelShipment *models.Shipment) *order.Shipment {
+	shipment := &order.Shipment{
+		Carrier:        modelShipment.Carrier,
+		TrackingNumber: modelShipment.TrackingNumber,
+		CreatedAt:      modelShipment.CreatedAt,
+		Events:         make([]order.TrackingEvent, 0, len(modelShipment.Events)),
+	}
+	if !modelShipment.DeliveredAt.IsZero() {
+		deliveredAt := modelShipment.DeliveredAt
+		shipment.DeliveredAt = &deliveredAt
+	}
+	for _, event := range modelShipment.Events {
+		shipment.Events = append(shipment.Events, order.TrackingEvent(event))
+	}
+	return shipment
+}
+
+// shipmentErrorStatus returns the status code of the response to the error of the shipment
+func shipmentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, models.ErrorForbidden{}):
+		return http.StatusForbidden
+	case errors.Is(err, models.ErrorNotFound{}):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrorWrongStatus{}), errors.Is(err, models.ErrorAlreadyExists{}):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ShipOrder - hand the order over to the carrier
+//
+//	@Summary		Ship the order
+//	@Description	The method creates the shipment of the processed or ready order in the carrier and returns its tracking number.
+//	@Description	The order is picked by the courier and becomes delivered when the carrier reports the delivery.
+//	@Tags			order
+//	@Accept			json
+//	@Produce		json
+//	@Param			orderID			path		string			true	"Id of the order"
+//	@Param			Idempotency-Key	header		string			false	"Unique key of the request to retry it safely"
+//	@Success		201				{object}	order.Shipment	"Shipment of the order"
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		403				"Forbidden"
+//	@Failure		404				{object}	ErrorResponse	"404 Not Found"
+//	@Failure		409				{object}	ErrorResponse	"Order can't be shipped or is already shipped"
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/order/{orderID}/ship [post]
+func (delivery *Delivery) ShipOrder(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery ShipOrder()")
+	orderId, err := uuid.Parse(c.Param("orderID"))
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't parse order id: %s", err)
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	modelShipment, err := delivery.shipmentUsecase.Ship(c.Request.Context(), orderId)
+	if err != nil {
+		delivery.logger.Sugar().Errorf("can't ship order: %s", err)
+		delivery.SetError(c, shipmentErrorStatus(err), err)
+		return
+	}
+	c.JSON(http.StatusCreated, shipmentResponse(modelShipment))
+}
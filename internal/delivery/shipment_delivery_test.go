@@ -0,0 +1,115 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/order"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/usecase/mocks"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestShipOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	shipmentUsecase := mocks.NewMockIShipmentUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, ShipmentUsecase: shipmentUsecase})
+	request := func(orderId string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/order/"+orderId+"/ship", nil)
+		c.Params = gin.Params{{Key: "orderID", Value: orderId}}
+		return w, c
+	}
+
+	w, c := request("1")
+	delivery.ShipOrder(c)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	for err, code := range map[error]int{
+		models.ErrorForbidden{}:     http.StatusForbidden,
+		models.ErrorNotFound{}:      http.StatusNotFound,
+		models.ErrorWrongStatus{}:   http.StatusConflict,
+		models.ErrorAlreadyExists{}: http.StatusConflict,
+		fmt.Errorf("error"):         http.StatusInternalServerError,
+	} {
+		shipmentUsecase.EXPECT().Ship(gomock.Any(), testId).Return(nil, fmt.Errorf("can't ship: %w", err))
+		w, c = request(testId.String())
+		delivery.ShipOrder(c)
+		require.Equal(t, code, w.Code)
+	}
+
+	shipmentUsecase.EXPECT().Ship(gomock.Any(), testId).Return(&models.Shipment{OrderId: testId, Carrier: "fake", TrackingNumber: "FAKE-1"}, nil)
+	w, c = request(testId.String())
+	delivery.ShipOrder(c)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var shipment order.Shipment
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &shipment))
+	require.Equal(t, "FAKE-1", shipment.TrackingNumber)
+	require.Nil(t, shipment.DeliveredAt)
+	require.Empty(t, shipment.Events)
+}
+
+func TestGetOrderWithShipment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
+	returnUsecase := mocks.NewMockIReturnUsecase(ctrl)
+	shipmentUsecase := mocks.NewMockIShipmentUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: logger, OrderUsecase: orderUsecase, ReturnUsecase: returnUsecase, ShipmentUsecase: shipmentUsecase})
+	request := func() (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/order/"+testId.String(), nil)
+		c.Params = gin.Params{{Key: "orderID", Value: testId.String()}}
+		return w, c
+	}
+	modelOrder := models.Order{ID: testId, Status: models.StatusShipped}
+	orderUsecase.EXPECT().GetOrder(gomock.Any(), testId).Return(&modelOrder, nil).Times(3)
+	returnUsecase.EXPECT().GetOrderReturns(gomock.Any(), testId).Return(nil, nil).Times(3)
+
+	// The order not handed over to the carrier has no shipment
+	shipmentUsecase.EXPECT().GetShipment(gomock.Any(), testId).Return(nil, fmt.Errorf("can't get: %w", models.ErrorNotFound{}))
+	w, c := request()
+	delivery.GetOrder(c)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotContains(t, w.Body.String(), `"shipment"`)
+
+	shipmentUsecase.EXPECT().GetShipment(gomock.Any(), testId).Return(nil, fmt.Errorf("error"))
+	w, c = request()
+	delivery.GetOrder(c)
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	shipmentUsecase.EXPECT().GetShipment(gomock.Any(), testId).Return(&models.Shipment{
+		OrderId:        testId,
+		Carrier:        "fake",
+		TrackingNumber: "FAKE-1",
+		CreatedAt:      now.Add(-time.Hour),
+		DeliveredAt:    now,
+		Events: []models.TrackingEvent{
+			{Status: "accepted", Time: now.Add(-time.Hour)},
+			{Status: "delivered", Location: "Moscow", Time: now},
+		},
+	}, nil)
+	w, c = request()
+	delivery.GetOrder(c)
+	require.Equal(t, http.StatusOK, w.Code)
+	var res order.Order
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	require.NotNil(t, res.Shipment)
+	require.Equal(t, "FAKE-1", res.Shipment.TrackingNumber)
+	require.True(t, now.Equal(*res.Shipment.DeliveredAt))
+	require.Len(t, res.Shipment.Events, 2)
+	require.Equal(t, "Moscow", res.Shipment.Events[1].Location)
+}
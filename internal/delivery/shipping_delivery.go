@@ -0,0 +1,86 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/cart"
+	"OnlineShopBackend/internal/models"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetShippingQuotes - get shipping methods for the cart
+//
+//	@Summary		Get shipping methods with costs for the cart
+//	@Description	The method returns the shipping methods delivering the items of the cart to the address
+//	@Description	with the cost by the weight and the value of the cart and the expected shipment time.
+//	@Tags			carts
+//	@Produce		json
+//	@Param			cartID	path		string	true	"Id of cart"
+//	@Param			zipcode	query		string	true	"Zipcode of the address"
+//	@Param			country	query		string	false	"Country of the address"
+//	@Param			city	query		string	true	"City of the address"
+//	@Success		200		{array}		cart.ShippingQuote
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		"Forbidden"
+//	@Failure		404		{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/cart/{cartID}/shipping [get]
+func (delivery *Delivery) GetShippingQuotes(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery GetShippingQuotes()")
+	ctx := delivery.cartContext(c)
+	cartId, err := uuid.Parse(c.Param("cartID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var address cart.ShippingAddress
+	if err := c.ShouldBindQuery(&address); err != nil {
+		delivery.logger.Sugar().Errorf("can't bind query: %s", err)
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	modelCart, err := delivery.cartUsecase.GetCart(ctx, cartId)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		err := fmt.Errorf("cart with id: %v not found", cartId)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	quotes, err := delivery.checkoutUsecase.ShippingQuotes(ctx, modelCart, models.UserAddress{
+		Zipcode: address.Zipcode,
+		Country: address.Country,
+		City:    address.City,
+	})
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, fmt.Errorf("cart with id: %v not found", cartId))
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	now := time.Now()
+	result := make([]cart.ShippingQuote, 0, len(quotes))
+	for _, quote := range quotes {
+		result = append(result, cart.ShippingQuote{
+			Method:       quote.Method,
+			Name:         quote.Name,
+			Cost:         quote.Cost,
+			Days:         int(quote.Period / (24 * time.Hour)),
+			ShipmentTime: now.Add(quote.Period),
+		})
+	}
+	c.JSON(http.StatusOK, result)
+}
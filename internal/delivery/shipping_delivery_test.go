@@ -0,0 +1,73 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/shipping"
+	"OnlineShopBackend/internal/usecase/mocks"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestGetShippingQuotes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cartUsecase := mocks.NewMockICartUsecase(ctrl)
+	checkoutUsecase := mocks.NewMockICheckoutUsecase(ctrl)
+	delivery := NewDelivery(Dependencies{Logger: zap.L(), CartUsecase: cartUsecase, CheckoutUsecase: checkoutUsecase})
+	request := func(cartId string, query string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/cart/"+cartId+"/shipping?"+query, nil)
+		c.Params = gin.Params{{Key: "cartID", Value: cartId}}
+		return w, c
+	}
+	query := "zipcode=101000&country=Russia&city=Moscow"
+	address := models.UserAddress{Zipcode: "101000", Country: "Russia", City: "Moscow"}
+	cart := &models.Cart{Id: testId, UserId: testId}
+
+	w, c := request("1", query)
+	delivery.GetShippingQuotes(c)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	w, c = request(testId.String(), "country=Russia")
+	delivery.GetShippingQuotes(c)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	cartUsecase.EXPECT().GetCart(gomock.Any(), testId).Return(nil, models.ErrorNotFound{})
+	w, c = request(testId.String(), query)
+	delivery.GetShippingQuotes(c)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	for err, code := range map[error]int{
+		models.ErrorNotFound{}: http.StatusNotFound,
+		fmt.Errorf("error"):    http.StatusInternalServerError,
+	} {
+		cartUsecase.EXPECT().GetCart(gomock.Any(), testId).Return(cart, nil)
+		checkoutUsecase.EXPECT().ShippingQuotes(gomock.Any(), cart, address).Return(nil, err)
+		w, c = request(testId.String(), query)
+		delivery.GetShippingQuotes(c)
+		require.Equal(t, code, w.Code)
+	}
+
+	cartUsecase.EXPECT().GetCart(gomock.Any(), testId).Return(cart, nil)
+	checkoutUsecase.EXPECT().ShippingQuotes(gomock.Any(), cart, address).Return([]shipping.Quote{
+		{Method: shipping.Courier, Name: "Courier", Cost: 300, Period: 72 * time.Hour},
+	}, nil)
+	w, c = request(testId.String(), query)
+	delivery.GetShippingQuotes(c)
+	require.Equal(t, http.StatusOK, w.Code)
+	var quotes []map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &quotes))
+	require.Len(t, quotes, 1)
+	require.Equal(t, "courier", quotes[0]["method"])
+	require.Equal(t, float64(300), quotes[0]["cost"])
+	require.Equal(t, float64(3), quotes[0]["days"])
+}
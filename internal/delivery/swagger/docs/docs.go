@@ -1,4 +1,5 @@
-// Code generated by swaggo/swag. DO NOT EDIT
+// Package docs GENERATED BY SWAG; DO NOT EDIT
+// This file was generated by swaggo/swag
 package docs
 
 import "github.com/swaggo/swag"
@@ -20,9 +21,156 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/answers/{answerID}/upvote": {
+            "post": {
+                "description": "Method provides to upvote the answer. Every user can vote for the answer only once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Method provides to upvote the answer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of answer",
+                        "name": "answerID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Already voted",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/audit": {
+            "get": {
+                "description": "Method provides to get changes made by administrators, the newest changes go first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "Get audit log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of user who made the change",
+                        "name": "actorID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Action (item.update, user.role_update, order.status_change, category.delete)",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity (item, user, order, category)",
+                        "name": "entity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Id of changed entity",
+                        "name": "entityID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of period in RFC3339 format",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of period in RFC3339 format",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of audit log entries",
+                        "schema": {
+                            "$ref": "#/definitions/audit.EntriesList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/cart/addItem": {
             "put": {
-                "description": "Method provides to add item to cart.",
+                "description": "Method provides to add item to cart.\nQuantity can't be greater than maximum quantity of one item in cart and quantity of item in stock.",
                 "consumes": [
                     "application/json"
                 ],
@@ -338,9 +486,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/categories/create": {
-            "post": {
-                "description": "Method provides to create category of items.",
+        "/cart/{cartID}/items/{itemID}": {
+            "put": {
+                "description": "Method provides to set quantity of item in cart. Zero quantity removes item from cart.\nQuantity can't be greater than maximum quantity of one item in cart and quantity of item in stock.",
                 "consumes": [
                     "application/json"
                 ],
@@ -348,26 +496,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "carts"
                 ],
-                "summary": "Method provides to create category of items",
+                "summary": "Method provides to set quantity of item in cart",
                 "parameters": [
                     {
-                        "description": "Data for creating category",
-                        "name": "category",
+                        "type": "string",
+                        "description": "id of cart",
+                        "name": "cartID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "id of item",
+                        "name": "itemID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Quantity of item",
+                        "name": "quantity",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/category.ShortCategory"
+                            "$ref": "#/definitions/cart.SetQuantity"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/category.CategoryId"
-                        }
+                    "200": {
+                        "description": "OK"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -393,9 +552,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/categories/delete/{categoryID}": {
-            "delete": {
-                "description": "Method provides to delete category.",
+        "/cart/{cartID}/items/{itemID}/saveForLater": {
+            "post": {
+                "description": "Method provides to move item from cart to the list saved for later.\nSaved items are not included in the totals of the cart and in the order and stay in the cart after the order is placed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -403,14 +562,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "carts"
                 ],
-                "summary": "Method provides to delete category",
+                "summary": "Method provides to save item of cart for later",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "id of category",
-                        "name": "categoryID",
+                        "description": "id of cart",
+                        "name": "cartID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "id of item",
+                        "name": "itemID",
                         "in": "path",
                         "required": true
                     }
@@ -443,9 +609,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/categories/image/delete": {
-            "delete": {
-                "description": "The method allows you to delete a category image by category id.",
+        "/cart/{cartID}/saved/{itemID}/moveToCart": {
+            "post": {
+                "description": "Method provides to move item from the list saved for later back to cart.",
                 "consumes": [
                     "application/json"
                 ],
@@ -453,22 +619,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "carts"
                 ],
-                "summary": "Delete a category image by category id",
+                "summary": "Method provides to move saved item to cart",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Category id",
-                        "name": "id",
-                        "in": "query",
+                        "description": "id of cart",
+                        "name": "cartID",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Image name",
-                        "name": "name",
-                        "in": "query",
+                        "description": "id of item",
+                        "name": "itemID",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -500,38 +666,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/categories/image/upload/:categoryID": {
-            "post": {
-                "description": "Method provides to upload an image of category.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/cart/{cartID}/shipping": {
+            "get": {
+                "description": "The method returns the shipping methods delivering the items of the cart to the address\nwith the cost by the weight and the value of the cart and the expected shipment time.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "carts"
                 ],
-                "summary": "Upload an image of category",
+                "summary": "Get shipping methods with costs for the cart",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "id of category",
-                        "name": "id",
+                        "description": "Id of cart",
+                        "name": "cartID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "picture of category",
-                        "name": "image",
-                        "in": "formData",
+                        "type": "string",
+                        "description": "Zipcode of the address",
+                        "name": "zipcode",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Country of the address",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "City of the address",
+                        "name": "city",
+                        "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/cart.ShippingQuote"
+                            }
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -548,30 +730,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/delivery.ErrorResponse"
                         }
                     },
-                    "415": {
-                        "description": "Unsupported Media Type",
-                        "schema": {
-                            "$ref": "#/definitions/delivery.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/delivery.ErrorResponse"
                         }
-                    },
-                    "507": {
-                        "description": "Insufficient Storage",
-                        "schema": {
-                            "$ref": "#/definitions/delivery.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/categories/list": {
-            "get": {
-                "description": "Method provides to get list of categories",
+        "/categories/create": {
+            "post": {
+                "description": "Method provides to create category of items.",
                 "consumes": [
                     "application/json"
                 ],
@@ -581,15 +751,23 @@ const docTemplate = `{
                 "tags": [
                     "categories"
                 ],
-                "summary": "Get list of categories",
-                "responses": {
-                    "200": {
-                        "description": "List of categories",
+                "summary": "Method provides to create category of items",
+                "parameters": [
+                    {
+                        "description": "Data for creating category",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/category.Category"
-                            }
+                            "$ref": "#/definitions/category.ShortCategory"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/category.CategoryId"
                         }
                     },
                     "400": {
@@ -616,9 +794,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/categories/update": {
-            "put": {
-                "description": "Method provides to update category.",
+        "/categories/delete/{categoryID}": {
+            "delete": {
+                "description": "Method provides to delete category. Items of the deleted category are moved to the category\nwith id targetID or to the system category NoCategory if uncategorized is true.\nCategory with items can't be deleted without choosing where to move them.",
                 "consumes": [
                     "application/json"
                 ],
@@ -628,23 +806,26 @@ const docTemplate = `{
                 "tags": [
                     "categories"
                 ],
-                "summary": "Method provides to update category",
+                "summary": "Method provides to delete category",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "id of category",
-                        "name": "id",
+                        "name": "categoryID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Data for updating category",
-                        "name": "category",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/category.ShortCategory"
-                        }
+                        "type": "string",
+                        "description": "id of category for items of deleted category",
+                        "name": "targetID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "move items of deleted category to NoCategory",
+                        "name": "uncategorized",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -666,6 +847,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/delivery.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Category has items",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -675,9 +862,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/categories/{categoryID}": {
-            "get": {
-                "description": "The method allows you to get the category by id.",
+        "/categories/image/delete": {
+            "delete": {
+                "description": "The method allows you to delete a category image by category id.",
                 "consumes": [
                     "application/json"
                 ],
@@ -687,22 +874,26 @@ const docTemplate = `{
                 "tags": [
                     "categories"
                 ],
-                "summary": "Get category by id",
+                "summary": "Delete a category image by category id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Id of category",
-                        "name": "categoryID",
-                        "in": "path",
+                        "description": "Category id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Image name",
+                        "name": "name",
+                        "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Category structure",
-                        "schema": {
-                            "$ref": "#/definitions/category.Category"
-                        }
+                        "description": "OK"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -728,9 +919,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/images/list": {
-            "get": {
-                "description": "Method provides to get list of files.",
+        "/categories/image/upload/:categoryID": {
+            "post": {
+                "description": "Method provides to upload an image of category.",
                 "consumes": [
                     "application/json"
                 ],
@@ -738,15 +929,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "files"
+                    "categories"
+                ],
+                "summary": "Upload an image of category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of category",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "picture of category",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
                 ],
-                "summary": "Get list of files",
                 "responses": {
-                    "200": {
-                        "description": "List of files",
-                        "schema": {
-                            "$ref": "#/definitions/file.FileListResponse"
-                        }
+                    "201": {
+                        "description": "Created"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -763,18 +967,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/delivery.ErrorResponse"
                         }
                     },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/delivery.ErrorResponse"
                         }
+                    },
+                    "507": {
+                        "description": "Insufficient Storage",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/items": {
+        "/categories/list": {
             "get": {
-                "description": "Method provides to get list of items by category name",
+                "description": "Method provides to get list of categories",
                 "consumes": [
                     "application/json"
                 ],
@@ -782,51 +998,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "items"
-                ],
-                "summary": "Get list of items by category name",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Category name",
-                        "name": "param",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Offset when receiving records",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "minimum": 0,
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Quantity of recordings",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "default": "\"name\"",
-                        "description": "Sort type (name or price)",
-                        "name": "sortType",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "default": "\"asc\"",
-                        "description": "Sort order (asc or desc)",
-                        "name": "sortOrder",
-                        "in": "query"
-                    }
+                    "categories"
                 ],
+                "summary": "Get list of categories",
                 "responses": {
                     "200": {
-                        "description": "List of items",
+                        "description": "List of categories",
                         "schema": {
-                            "$ref": "#/definitions/item.ItemsList"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/category.Category"
+                            }
                         }
                     },
                     "400": {
@@ -853,9 +1035,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/items/addFavItem": {
+        "/categories/merge": {
             "post": {
-                "description": "Method provides add item in favourites.",
+                "description": "Method provides to move all items of the source category to the target category\nand delete the source category.",
                 "consumes": [
                     "application/json"
                 ],
@@ -863,17 +1045,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "items"
+                    "categories"
                 ],
-                "summary": "Method provides add item in favourites",
+                "summary": "Method provides to merge categories",
                 "parameters": [
                     {
-                        "description": "Data for add item to favourite",
-                        "name": "item",
+                        "description": "Source and target categories",
+                        "name": "categories",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/item.AddFavItem"
+                            "$ref": "#/definitions/category.MergeCategories"
                         }
                     }
                 ],
@@ -905,9 +1087,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/items/create/": {
-            "post": {
-                "description": "Method provides to create store item",
+        "/categories/update": {
+            "put": {
+                "description": "Method provides to update category.",
                 "consumes": [
                     "application/json"
                 ],
@@ -915,26 +1097,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "items"
+                    "categories"
                 ],
-                "summary": "Method provides to create store item",
+                "summary": "Method provides to update category",
                 "parameters": [
                     {
-                        "description": "Data for creating item",
-                        "name": "item",
+                        "type": "string",
+                        "description": "id of category",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data for updating category",
+                        "name": "category",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/item.ShortItem"
+                            "$ref": "#/definitions/category.ShortCategory"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/item.ItemId"
-                        }
+                    "200": {
+                        "description": "OK"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -960,9 +1146,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/items/delete/{itemID}": {
-            "delete": {
-                "description": "Method provides to delete item.",
+        "/categories/{categoryID}": {
+            "get": {
+                "description": "The method allows you to get the category by id.",
                 "consumes": [
                     "application/json"
                 ],
@@ -970,21 +1156,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "items"
+                    "categories"
                 ],
-                "summary": "Method provides to delete item",
+                "summary": "Get category by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "id of item",
-                        "name": "itemID",
+                        "description": "Id of category",
+                        "name": "categoryID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "Category structure",
+                        "schema": {
+                            "$ref": "#/definitions/category.Category"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1010,127 +1199,87 @@ const docTemplate = `{
                 }
             }
         },
-        "/items/deleteFav/{userID}/{itemID}": {
-            "delete": {
-                "description": "Method provides delete item from favourites.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/feeds/google_merchant.xml": {
+            "get": {
+                "description": "Method provides to get product feed in Google Merchant RSS 2.0 format.",
                 "produces": [
-                    "application/json"
+                    "text/xml"
                 ],
                 "tags": [
-                    "items"
-                ],
-                "summary": "Method provides delete item from favourites",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "id of user",
-                        "name": "userID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "id of item",
-                        "name": "itemID",
-                        "in": "path",
-                        "required": true
-                    }
+                    "feeds"
                 ],
+                "summary": "Get Google Merchant product feed",
                 "responses": {
                     "200": {
                         "description": "OK"
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/delivery.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden"
-                    },
                     "404": {
                         "description": "404 Not Found",
                         "schema": {
                             "$ref": "#/definitions/delivery.ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/delivery.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/items/favList": {
+        "/feeds/yandex_market.yml": {
             "get": {
-                "description": "Method provides to get list of favourite items",
-                "consumes": [
+                "description": "Method provides to get product feed in Yandex Market YML format.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "feeds"
+                ],
+                "summary": "Get Yandex Market product feed",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guest/cart": {
+            "get": {
+                "description": "The method allows you to get the guest cart by the token from the X-Cart-Token header.",
+                "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "items"
+                    "carts"
                 ],
-                "summary": "Get list of favourite items",
+                "summary": "Get guest cart",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID of user",
-                        "name": "param",
-                        "in": "query"
-                    },
-                    {
-                        "minimum": 0,
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Quantity of recordings",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Offset when receiving records",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort type (name or price)",
-                        "name": "sortType",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort order (asc or desc)",
-                        "name": "sortOrder",
-                        "in": "query"
+                        "description": "Token of guest cart",
+                        "name": "X-Cart-Token",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of items",
+                        "description": "Cart structure",
                         "schema": {
-                            "$ref": "#/definitions/item.ItemsList"
+                            "$ref": "#/definitions/cart.Cart"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/delivery.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden"
-                    },
                     "404": {
                         "description": "404 Not Found",
                         "schema": {
@@ -1144,11 +1293,38 @@ const docTemplate = `{
                         }
                     }
                 }
+            },
+            "post": {
+                "description": "Method provides to create cart for not authorized user.\nThe returned token must be sent in the X-Cart-Token header to use the cart and on login to merge the cart into the cart of the user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carts"
+                ],
+                "summary": "Method provides to create guest cart",
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/cart.GuestCart"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
             }
         },
-        "/items/image/delete": {
-            "delete": {
-                "description": "The method allows you to delete an item image by item id.",
+        "/guest/cart/items/{itemID}": {
+            "put": {
+                "description": "Method provides to set quantity of item in the guest cart from the X-Cart-Token header. Zero quantity removes item from cart.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1156,23 +1332,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "items"
+                    "carts"
                 ],
-                "summary": "Delete an item image by item id",
+                "summary": "Method provides to set quantity of item in guest cart",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Item id",
-                        "name": "id",
-                        "in": "query",
+                        "description": "Token of guest cart",
+                        "name": "X-Cart-Token",
+                        "in": "header",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Image name",
-                        "name": "name",
-                        "in": "query",
+                        "description": "id of item",
+                        "name": "itemID",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Quantity of item",
+                        "name": "quantity",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/cart.SetQuantity"
+                        }
                     }
                 ],
                 "responses": {
@@ -1185,8 +1370,11 @@ const docTemplate = `{
                             "$ref": "#/definitions/delivery.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden"
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
                     },
                     "404": {
                         "description": "404 Not Found",
@@ -1203,9 +1391,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/items/image/upload/:itemID": {
-            "post": {
-                "description": "Method provides to upload an image of item",
+        "/images/list": {
+            "get": {
+                "description": "Method provides to get list of files.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1213,28 +1401,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "items"
-                ],
-                "summary": "Upload an image of item",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "id of item",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "picture of item",
-                        "name": "image",
-                        "in": "formData",
-                        "required": true
-                    }
+                    "files"
                 ],
+                "summary": "Get list of files",
                 "responses": {
-                    "201": {
-                        "description": "Created"
+                    "200": {
+                        "description": "List of files",
+                        "schema": {
+                            "$ref": "#/definitions/file.FileListResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1251,30 +1426,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/delivery.ErrorResponse"
                         }
                     },
-                    "415": {
-                        "description": "Unsupported Media Type",
-                        "schema": {
-                            "$ref": "#/definitions/delivery.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/delivery.ErrorResponse"
                         }
-                    },
-                    "507": {
-                        "description": "Insufficient Storage",
-                        "schema": {
-                            "$ref": "#/definitions/delivery.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/items/list": {
+        "/items": {
             "get": {
-                "description": "Method provides to get list of items",
+                "description": "Method provides to get list of items by category name",
                 "consumes": [
                     "application/json"
                 ],
@@ -1284,8 +1447,14 @@ const docTemplate = `{
                 "tags": [
                     "items"
                 ],
-                "summary": "Get list of items",
+                "summary": "Get list of items by category name",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category name",
+                        "name": "param",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "default": 0,
@@ -1304,7 +1473,7 @@ const docTemplate = `{
                     {
                         "type": "string",
                         "default": "\"name\"",
-                        "description": "Sort type (name or price)",
+                        "description": "Sort type (name, price, popular or newest)",
                         "name": "sortType",
                         "in": "query"
                     },
@@ -1347,9 +1516,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/items/quantity": {
-            "get": {
-                "description": "Method provides to get quantity of items",
+        "/items/addFavItem": {
+            "post": {
+                "description": "Method provides add item in favourites.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1359,12 +1528,26 @@ const docTemplate = `{
                 "tags": [
                     "items"
                 ],
-                "summary": "Get quantity of items",
+                "summary": "Method provides add item in favourites",
+                "parameters": [
+                    {
+                        "description": "Data for add item to favourite",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/item.AddFavItem"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "Quantity of items",
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/item.ItemsQuantity"
+                            "$ref": "#/definitions/delivery.ErrorResponse"
                         }
                     },
                     "403": {
@@ -1385,9 +1568,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/items/quantityCat/{categoryName}": {
-            "get": {
-                "description": "Method provides to get quantity of items in category",
+        "/items/create/": {
+            "post": {
+                "description": "Method provides to create store item",
                 "consumes": [
                     "application/json"
                 ],
@@ -1397,21 +1580,29 @@ const docTemplate = `{
                 "tags": [
                     "items"
                 ],
-                "summary": "Get quantity of items in category",
+                "summary": "Method provides to create store item",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Name of category",
-                        "name": "categoryName",
-                        "in": "path",
-                        "required": true
+                        "description": "Data for creating item",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/item.ShortItem"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Quantity of items",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/item.ItemsQuantity"
+                            "$ref": "#/definitions/item.ItemId"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
                         }
                     },
                     "403": {
@@ -1432,9 +1623,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/items/quantityFav/{userID}": {
-            "get": {
-                "description": "Method provides to get quantity favourite items",
+        "/items/delete/{itemID}": {
+            "delete": {
+                "description": "Method provides to delete item.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1444,21 +1635,24 @@ const docTemplate = `{
                 "tags": [
                     "items"
                 ],
-                "summary": "Get quantity of favourite items",
+                "summary": "Method provides to delete item",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "id of user",
-                        "name": "userID",
+                        "description": "id of item",
+                        "name": "itemID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Quantity of items",
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/item.ItemsQuantity"
+                            "$ref": "#/definitions/delivery.ErrorResponse"
                         }
                     },
                     "403": {
@@ -1479,9 +1673,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/items/quantitySearch/{searchRequest}": {
-            "get": {
-                "description": "Method provides to get quantity of items in search request",
+        "/items/deleteFav/{userID}/{itemID}": {
+            "delete": {
+                "description": "Method provides delete item from favourites.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1491,21 +1685,31 @@ const docTemplate = `{
                 "tags": [
                     "items"
                 ],
-                "summary": "Get quantity of items in search request",
+                "summary": "Method provides delete item from favourites",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search request",
-                        "name": "searchRequest",
+                        "description": "id of user",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "id of item",
+                        "name": "itemID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Quantity of items",
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/item.ItemsQuantity"
+                            "$ref": "#/definitions/delivery.ErrorResponse"
                         }
                     },
                     "403": {
@@ -1526,9 +1730,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/items/search": {
+        "/items/favList": {
             "get": {
-                "description": "Method provides to get list of items by search parameters",
+                "description": "Method provides to get list of favourite items",
                 "consumes": [
                     "application/json"
                 ],
@@ -1538,21 +1742,14 @@ const docTemplate = `{
                 "tags": [
                     "items"
                 ],
-                "summary": "Get list of items by search parameters",
+                "summary": "Get list of favourite items",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search param",
+                        "description": "ID of user",
                         "name": "param",
                         "in": "query"
                     },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Offset when receiving records",
-                        "name": "offset",
-                        "in": "query"
-                    },
                     {
                         "minimum": 0,
                         "type": "integer",
@@ -1561,16 +1758,21 @@ const docTemplate = `{
                         "name": "limit",
                         "in": "query"
                     },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset when receiving records",
+                        "name": "offset",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "default": "\"name\"",
-                        "description": "Sort type (name or price)",
+                        "description": "Sort type (name, price, popular or newest)",
                         "name": "sortType",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "default": "\"asc\"",
                         "description": "Sort order (asc or desc)",
                         "name": "sortOrder",
                         "in": "query"
@@ -1607,9 +1809,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/items/update": {
-            "put": {
-                "description": "Method provides to update store item",
+        "/items/image/delete": {
+            "delete": {
+                "description": "The method allows you to delete an item image by item id.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1619,16 +1821,21 @@ const docTemplate = `{
                 "tags": [
                     "items"
                 ],
-                "summary": "Method provides to update store item",
+                "summary": "Delete an item image by item id",
                 "parameters": [
                     {
-                        "description": "Data for updating item",
-                        "name": "item",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/item.InItem"
-                        }
+                        "type": "string",
+                        "description": "Item id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Image name",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1659,9 +1866,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/items/{itemID}": {
-            "get": {
-                "description": "The method allows you to get the product by id.",
+        "/items/image/upload/:itemID": {
+            "post": {
+                "description": "Method provides to upload an image of item",
                 "consumes": [
                     "application/json"
                 ],
@@ -1671,22 +1878,26 @@ const docTemplate = `{
                 "tags": [
                     "items"
                 ],
-                "summary": "Get item by id",
+                "summary": "Upload an image of item",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "id of item",
-                        "name": "itemID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "picture of item",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Item structure",
-                        "schema": {
-                            "$ref": "#/definitions/item.OutItem"
-                        }
+                    "201": {
+                        "description": "Created"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1703,18 +1914,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/delivery.ErrorResponse"
                         }
                     },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/delivery.ErrorResponse"
                         }
+                    },
+                    "507": {
+                        "description": "Insufficient Storage",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/order/changeaddress/": {
-            "patch": {
-                "description": "The method allows you to change address of an order by Id.",
+        "/items/list": {
+            "get": {
+                "description": "Method provides to get list of items",
                 "consumes": [
                     "application/json"
                 ],
@@ -1722,23 +1945,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "order"
+                    "items"
                 ],
-                "summary": "Change address of a  specific order by Id",
+                "summary": "Get list of items",
                 "parameters": [
                     {
-                        "description": "New address with orderID and user structure",
-                        "name": "AddressWithUserAndId",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/order.AddressWithUserAndId"
-                        }
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset when receiving records",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Quantity of recordings",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "\"name\"",
+                        "description": "Sort type (name, price, popular or newest)",
+                        "name": "sortType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "\"asc\"",
+                        "description": "Sort order (asc or desc)",
+                        "name": "sortOrder",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "List of items",
+                        "schema": {
+                            "$ref": "#/definitions/item.ItemsList"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1764,9 +2010,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/order/changestatus/": {
-            "patch": {
-                "description": "The method allows you to change status of an order by Id.",
+        "/items/quantity": {
+            "get": {
+                "description": "Method provides to get quantity of items",
                 "consumes": [
                     "application/json"
                 ],
@@ -1774,28 +2020,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "order"
-                ],
-                "summary": "Change status of a specific order by Id",
-                "parameters": [
-                    {
-                        "description": "New status with orderID and User structure",
-                        "name": "statusWithUserAndId",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/order.StatusWithUserAndId"
-                        }
-                    }
+                    "items"
                 ],
+                "summary": "Get quantity of items",
                 "responses": {
                     "200": {
-                        "description": "OK"
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                        "description": "Quantity of items",
                         "schema": {
-                            "$ref": "#/definitions/delivery.ErrorResponse"
+                            "$ref": "#/definitions/item.ItemsQuantity"
                         }
                     },
                     "403": {
@@ -1816,9 +2048,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/order/create/": {
-            "post": {
-                "description": "The method allows you to create an order out of cart and user info",
+        "/items/quantityCat/{categoryName}": {
+            "get": {
+                "description": "Method provides to get quantity of items in category",
                 "consumes": [
                     "application/json"
                 ],
@@ -1826,31 +2058,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "order"
+                    "items"
                 ],
-                "summary": "Create order",
+                "summary": "Get quantity of items in category",
                 "parameters": [
                     {
-                        "description": "Data for creating order",
-                        "name": "cartAddressUser",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/order.CartAdressUser"
-                        }
+                        "type": "string",
+                        "description": "Name of category",
+                        "name": "categoryName",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Order id and new cart id",
-                        "schema": {
-                            "$ref": "#/definitions/order.OrderId"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "Quantity of items",
                         "schema": {
-                            "$ref": "#/definitions/delivery.ErrorResponse"
+                            "$ref": "#/definitions/item.ItemsQuantity"
                         }
                     },
                     "403": {
@@ -1871,9 +2095,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/order/delete/{orderID}": {
-            "delete": {
-                "description": "The method allows you to delete an order by id.",
+        "/items/quantityFav/{userID}": {
+            "get": {
+                "description": "Method provides to get quantity favourite items",
                 "consumes": [
                     "application/json"
                 ],
@@ -1881,26 +2105,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "order"
+                    "items"
                 ],
-                "summary": "Delete an order by id",
+                "summary": "Get quantity of favourite items",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Id of the order to delete",
-                        "name": "orderID",
+                        "description": "id of user",
+                        "name": "userID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                        "description": "Quantity of items",
                         "schema": {
-                            "$ref": "#/definitions/delivery.ErrorResponse"
+                            "$ref": "#/definitions/item.ItemsQuantity"
                         }
                     },
                     "403": {
@@ -1921,9 +2142,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/order/list/{userID}": {
+        "/items/quantitySearch/{searchRequest}": {
             "get": {
-                "description": "The method allows you to get all orders by UserId.",
+                "description": "Method provides to get quantity of items in search request",
                 "consumes": [
                     "application/json"
                 ],
@@ -1931,32 +2152,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "order"
+                    "items"
                 ],
-                "summary": "Get all orders by UserId",
+                "summary": "Get quantity of items in search request",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Id of the user",
-                        "name": "userID",
+                        "description": "Search request",
+                        "name": "searchRequest",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of orders",
+                        "description": "Quantity of items",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/order.Order"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/delivery.ErrorResponse"
+                            "$ref": "#/definitions/item.ItemsQuantity"
                         }
                     },
                     "403": {
@@ -1977,9 +2189,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/order/{orderID}": {
+        "/items/search": {
             "get": {
-                "description": "The method allows you to get the order by id.",
+                "description": "Method provides to get list of items by search parameters",
                 "consumes": [
                     "application/json"
                 ],
@@ -1987,23 +2199,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "order"
+                    "items"
                 ],
-                "summary": "Get order by id",
+                "summary": "Get list of items by search parameters",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Id of order",
-                        "name": "orderID",
-                        "in": "path",
-                        "required": true
+                        "description": "Search param",
+                        "name": "param",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset when receiving records",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Quantity of recordings",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "\"name\"",
+                        "description": "Sort type (name, price, popular or newest)",
+                        "name": "sortType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "\"asc\"",
+                        "description": "Sort order (asc or desc)",
+                        "name": "sortOrder",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Order structure",
+                        "description": "List of items",
                         "schema": {
-                            "$ref": "#/definitions/order.Order"
+                            "$ref": "#/definitions/item.ItemsList"
                         }
                     },
                     "400": {
@@ -2030,9 +2270,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/callbackGoogle": {
-            "get": {
-                "description": "Method provides to log in with Google",
+        "/items/update": {
+            "put": {
+                "description": "Method provides to update store item",
                 "consumes": [
                     "application/json"
                 ],
@@ -2040,20 +2280,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "items"
+                ],
+                "summary": "Method provides to update store item",
+                "parameters": [
+                    {
+                        "description": "Data for updating item",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/item.InItem"
+                        }
+                    }
                 ],
-                "summary": "Callback Google provides logic for oauth google login",
                 "responses": {
                     "200": {
                         "description": "OK"
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
                     "500": {
-                        "description": "Internal Server Error"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "put": {
-                "description": "Method provides to Change User Role",
+            }
+        },
+        "/items/{itemID}": {
+            "get": {
+                "description": "The method allows you to get the product by id.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2061,22 +2332,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "items"
+                ],
+                "summary": "Get item by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of item",
+                        "name": "itemID",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Change User Role",
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "Item structure",
+                        "schema": {
+                            "$ref": "#/definitions/item.OutItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/user/create": {
-            "post": {
-                "description": "Method provides to create a user",
+        "/items/{itemID}/questions": {
+            "get": {
+                "description": "Method provides to get list of questions about item with their answers.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2084,42 +2385,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "questions"
                 ],
-                "summary": "Create a new user",
+                "summary": "Get questions about item",
                 "parameters": [
                     {
-                        "description": "User data",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/user.CreateUserData"
-                        }
+                        "type": "string",
+                        "description": "Id of item",
+                        "name": "itemID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "100": {
-                        "description": "Continue",
+                    "200": {
+                        "description": "List of questions",
                         "schema": {
-                            "$ref": "#/definitions/delivery.ErrorResponse"
+                            "$ref": "#/definitions/question.QuestionsList"
                         }
                     },
-                    "201": {
-                        "description": "Created"
-                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/delivery.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/user/createRights/": {
+            },
             "post": {
-                "description": "Method provides to create rights.",
+                "description": "Method provides to ask pre-sale question about item.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2127,17 +2427,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "questions"
                 ],
-                "summary": "Method provides to create rights",
+                "summary": "Method provides to ask question about item",
                 "parameters": [
                     {
-                        "description": "Data for creating rights",
-                        "name": "rights",
+                        "type": "string",
+                        "description": "Id of item",
+                        "name": "itemID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Text of question",
+                        "name": "question",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/user.ShortRights"
+                            "$ref": "#/definitions/question.ShortQuestion"
                         }
                     }
                 ],
@@ -2145,7 +2452,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/user.RightsId"
+                            "$ref": "#/definitions/question.QuestionId"
                         }
                     },
                     "400": {
@@ -2154,8 +2461,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/delivery.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden"
+                    "401": {
+                        "description": "Unauthorized"
                     },
                     "404": {
                         "description": "404 Not Found",
@@ -2172,9 +2479,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/login": {
-            "post": {
-                "description": "Method provides to login a user",
+        "/order/changeaddress/": {
+            "patch": {
+                "description": "The method allows you to change address of an order by Id before the order is shipped.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2182,33 +2489,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "order"
                 ],
-                "summary": "Login user",
+                "summary": "Change address of a  specific order by Id",
                 "parameters": [
                     {
-                        "description": "Login",
-                        "name": "user",
+                        "description": "New address with orderID and user structure",
+                        "name": "AddressWithUserAndId",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/password.Credentials"
+                            "$ref": "#/definitions/order.AddressWithUserAndId"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/user.LoginResponseData"
+                            "$ref": "#/definitions/delivery.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden"
+                    },
                     "404": {
                         "description": "404 Not Found",
                         "schema": {
                             "$ref": "#/definitions/delivery.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Order is already shipped",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2218,9 +2537,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/login/google": {
-            "get": {
-                "description": "Method provides to log in with Google",
+        "/order/changestatus/": {
+            "patch": {
+                "description": "The method allows the admin to move an order to the next status by Id: from paid to processing,\nfrom processing to processed, from processed to ready for shipment and from picked by courier to delivered.\nThe canceled status cancels the order with its stock and refund, other statuses are set by the payment and the shipment.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2228,51 +2547,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "order"
                 ],
-                "summary": "Login with Google oauth2",
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    },
-                    "500": {
-                        "description": "Internal Server Error"
+                "summary": "Change status of a specific order by Id",
+                "parameters": [
+                    {
+                        "description": "New status with orderID and User structure",
+                        "name": "statusWithUserAndId",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/order.StatusWithUserAndId"
+                        }
                     }
-                }
-            }
-        },
-        "/user/logout": {
-            "get": {
-                "description": "Method provides to log out",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "user"
                 ],
-                "summary": "Logout",
                 "responses": {
                     "200": {
                         "description": "OK"
                     },
-                    "404": {
-                        "description": "Bad Request"
-                    }
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order can't move to the status",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "/user/profile": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": [],
-                        "firebase": []
-                    }
-                ],
-                "description": "Method provides to get profile info",
+        "/order/create/": {
+            "post": {
+                "description": "The method allows you to create an order out of cart and user info\nItems saved for later are not ordered and stay in the cart, which is returned as the new cart.\nThe stock of ordered items is reserved until the order is shipped or canceled.\nThe shipping cost of the chosen method is saved with the order and its period sets the shipment time.\nThe request with the header Idempotency-Key is handled once, its retries with the same key get the same response.\nItems which are not in the stored cart or are sent more than once are rejected, quantities and prices are taken from the stored cart.\nThe address is taken from the request, from the saved address with address_id of the user\nor from the default shipping address of the user if both are not set.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2280,34 +2605,66 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "order"
+                ],
+                "summary": "Create order",
+                "parameters": [
+                    {
+                        "description": "Data for creating order",
+                        "name": "cartAddressUser",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/order.CartAdressUser"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key of the request to retry it safely",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
                 ],
-                "summary": "User profile",
                 "responses": {
                     "201": {
-                        "description": "Created",
+                        "description": "Order id and new cart id",
                         "schema": {
-                            "$ref": "#/definitions/user.CreateUserData"
+                            "$ref": "#/definitions/order.OrderId"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden"
+                    },
                     "404": {
                         "description": "404 Not Found",
                         "schema": {
                             "$ref": "#/definitions/delivery.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Idempotency key is used with another request or the request is in progress",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/user/profile/edit": {
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": [],
-                        "firebase": []
-                    }
-                ],
-                "description": "Method provides to update profile info",
+        "/order/delete/{orderID}": {
+            "delete": {
+                "description": "The method allows the admin to delete an order by id. Customers cancel their orders instead.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2315,59 +2672,132 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "order"
                 ],
-                "summary": "User profile update",
+                "summary": "Delete an order by id",
                 "parameters": [
                     {
-                        "description": "User data",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/user.CreateUserData"
-                        }
+                        "type": "string",
+                        "description": "Id of the order to delete",
+                        "name": "orderID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/user.CreateUserData"
+                            "$ref": "#/definitions/delivery.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden"
+                    },
                     "404": {
                         "description": "404 Not Found",
                         "schema": {
                             "$ref": "#/definitions/delivery.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/user/rights/list": {
+        "/order/export": {
             "get": {
-                "description": "Method provides to Change User Role",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Method provides to download lines of orders with customers, addresses, prices and totals of orders in CSV or XLSX format. Orders are filtered as in the list of orders, the oldest orders go first.",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "user"
+                    "orders"
+                ],
+                "summary": "Export orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Format of file (csv, xlsx), csv by default",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of period of creation in RFC3339 format",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of period of creation in RFC3339 format, not included",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status of orders",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of email of the customer",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "City of the address of orders",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimal total of orders",
+                        "name": "minTotal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximal total of orders",
+                        "name": "maxTotal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Id of item contained in orders",
+                        "name": "itemID",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Change User Role",
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "File with lines of orders",
+                        "schema": {
+                            "type": "file"
+                        }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/delivery.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2376,447 +2806,3151 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "cart.Cart": {
-            "type": "object",
-            "required": [
-                "id"
-            ],
-            "properties": {
-                "id": {
-                    "type": "string",
-                    "format": "uuid",
-                    "example": "00000000-0000-0000-0000-000000000000"
-                },
-                "items": {
-                    "type": "array",
-                    "minItems": 0,
-                    "items": {
-                        "$ref": "#/definitions/cart.CartItem"
-                    }
-                },
-                "userId": {
-                    "type": "string",
-                    "format": "uuid",
-                    "example": "00000000-0000-0000-0000-000000000000"
-                }
-            }
         },
-        "cart.CartId": {
-            "type": "object",
-            "required": [
-                "id"
-            ],
-            "properties": {
-                "id": {
-                    "type": "string",
-                    "format": "uuid",
-                    "example": "00000000-0000-0000-0000-000000000000"
-                }
-            }
-        },
-        "cart.CartItem": {
-            "type": "object",
-            "required": [
-                "quantity"
-            ],
-            "properties": {
-                "item": {
-                    "$ref": "#/definitions/item.OutItem"
-                },
-                "quantity": {
-                    "type": "integer",
-                    "default": 1,
-                    "minimum": 1,
-                    "example": 3
+        "/order/list": {
+            "get": {
+                "description": "Method provides to get orders of all users by filter with quantities of orders by status. The newest orders go first by default.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Get list of orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Status of orders",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of period of creation in RFC3339 format",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of period of creation in RFC3339 format, not included",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of email of the customer",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "City of the address of orders",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimal total of orders",
+                        "name": "minTotal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximal total of orders",
+                        "name": "maxTotal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Id of item contained in orders",
+                        "name": "itemID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort type (created_at, total, status, email)",
+                        "name": "sortType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order (asc, desc), desc by default",
+                        "name": "sortOrder",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of orders",
+                        "schema": {
+                            "$ref": "#/definitions/order.OrdersList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/list/{userID}": {
+            "get": {
+                "description": "The method allows you to get all orders by UserId.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Get all orders by UserId",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of the user",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of orders",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/order.Order"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{orderID}": {
+            "get": {
+                "description": "The method allows you to get the order by id with its returns and its shipment with tracking events.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Get order by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of order",
+                        "name": "orderID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order structure",
+                        "schema": {
+                            "$ref": "#/definitions/order.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{orderID}/cancel": {
+            "post": {
+                "description": "The method allows the customer to cancel the order until it is processed and the admin to cancel any order before shipping.\nThe order is kept with the canceled status and the reason, its stock is released and its payment is refunded.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Cancel an order by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of the order to cancel",
+                        "name": "orderID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason of the cancel",
+                        "name": "cancel",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/order.Cancel"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key of the request to retry it safely",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order can't be canceled in its status",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{orderID}/invoice": {
+            "get": {
+                "description": "The method allows the customer to download the invoice of own order and the admin to download the invoice of any order.\nThe invoice gets the sequential number when it is requested first time, prices of items are taken at the time of the order.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Get the invoice of an order in PDF",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of the order",
+                        "name": "orderID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invoice in PDF",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{orderID}/pay": {
+            "post": {
+                "description": "The method creates the payment for the order waiting for the payment and returns the client secret to confirm it in the payment provider.\nIf the order already has the pending payment, it is returned. The order becomes paid when the provider notifies the webhook.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment"
+                ],
+                "summary": "Pay for the order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of the order",
+                        "name": "orderID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key of the request to retry it safely",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Payment of the order",
+                        "schema": {
+                            "$ref": "#/definitions/payment.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not waiting for the payment",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{orderID}/payment": {
+            "get": {
+                "description": "The method allows you to get the last payment of the order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment"
+                ],
+                "summary": "Get payment of the order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of the order",
+                        "name": "orderID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Payment of the order",
+                        "schema": {
+                            "$ref": "#/definitions/payment.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{orderID}/refund": {
+            "post": {
+                "description": "The method returns the amount of the succeeded payment of the order to the customer, zero amount refunds the rest of the payment.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment"
+                ],
+                "summary": "Refund the payment of the order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of the order",
+                        "name": "orderID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Amount to refund",
+                        "name": "refund",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/payment.Refund"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key of the request to retry it safely",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Refunded payment",
+                        "schema": {
+                            "$ref": "#/definitions/payment.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order has no succeeded payment",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{orderID}/returns": {
+            "post": {
+                "description": "The method opens the return of lines of the delivered order with the reason. The quantity of every line\nmust not exceed the quantity ordered minus the quantity already returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "returns"
+                ],
+                "summary": "Request the return",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of the order",
+                        "name": "orderID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Lines to return and the reason",
+                        "name": "return",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/returns.NewReturn"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key of the request to retry it safely",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Requested return",
+                        "schema": {
+                            "$ref": "#/definitions/returns.Return"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not delivered",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{orderID}/ship": {
+            "post": {
+                "description": "The method creates the shipment of the processed or ready order in the carrier and returns its tracking number.\nThe order is picked by the courier and becomes delivered when the carrier reports the delivery.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Ship the order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of the order",
+                        "name": "orderID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key of the request to retry it safely",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Shipment of the order",
+                        "schema": {
+                            "$ref": "#/definitions/order.Shipment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order can't be shipped or is already shipped",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/webhook": {
+            "post": {
+                "description": "The payment provider notifies about changes of payments. The request must be signed by the provider.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment"
+                ],
+                "summary": "Webhook of the payment provider",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid signature",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/questions/unanswered": {
+            "get": {
+                "description": "Method provides to get the queue of questions without answers, the oldest questions go first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Get unanswered questions",
+                "responses": {
+                    "200": {
+                        "description": "List of questions",
+                        "schema": {
+                            "$ref": "#/definitions/question.QuestionsList"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/questions/{questionID}/answers": {
+            "post": {
+                "description": "Method provides to answer the question about item. Only admins and the seller of the item can answer.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Method provides to answer the question",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of question",
+                        "name": "questionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Text of answer",
+                        "name": "answer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/question.ShortAnswer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/question.AnswerId"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/returns": {
+            "get": {
+                "description": "The method allows the admin to get returns in the status, the oldest returns go first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "returns"
+                ],
+                "summary": "Get list of returns",
+                "parameters": [
+                    {
+                        "enum": [
+                            "requested",
+                            "approved",
+                            "rejected",
+                            "received",
+                            "refunded"
+                        ],
+                        "type": "string",
+                        "description": "Status of returns",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset when receiving records",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Quantity of recordings",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of returns",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/returns.Return"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/returns/{returnID}": {
+            "get": {
+                "description": "The method allows you to get the return with its lines and status history.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "returns"
+                ],
+                "summary": "Get return by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of the return",
+                        "name": "returnID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Return",
+                        "schema": {
+                            "$ref": "#/definitions/returns.Return"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/returns/{returnID}/refund": {
+            "post": {
+                "description": "The method refunds the received return through the payment of the order, zero amount refunds the sum of the returned lines.\nThe amount may be less than the sum for the partial refund.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "returns"
+                ],
+                "summary": "Refund the return",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of the return",
+                        "name": "returnID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Amount to refund",
+                        "name": "refund",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/returns.Refund"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key of the request to retry it safely",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Refunded return",
+                        "schema": {
+                            "$ref": "#/definitions/returns.Return"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Return is not received or the order has no succeeded payment",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/returns/{returnID}/status": {
+            "post": {
+                "description": "The method allows the admin to approve or reject the requested return and to mark the goods of the approved return as received.\nReceived goods are put back to stock.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "returns"
+                ],
+                "summary": "Change status of the return",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of the return",
+                        "name": "returnID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status and comment",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/returns.Status"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Changed return",
+                        "schema": {
+                            "$ref": "#/definitions/returns.Return"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Return can't move to the status",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/returns/{returnID}/tracking": {
+            "put": {
+                "description": "The method allows the customer to set the number of the parcel the goods of the approved return are sent with.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "returns"
+                ],
+                "summary": "Set tracking number of the return",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of the return",
+                        "name": "returnID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tracking number",
+                        "name": "tracking",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/returns.Tracking"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Changed return",
+                        "schema": {
+                            "$ref": "#/definitions/returns.Return"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Return is not approved",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sitemap.xml": {
+            "get": {
+                "description": "Method provides to get sitemap with pages of categories and items. The sitemap is regenerated in the background after changes of catalog.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "feeds"
+                ],
+                "summary": "Get sitemap.xml",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/addresses": {
+            "get": {
+                "description": "The method returns addresses saved by the authorized user, the default addresses go first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "addresses"
+                ],
+                "summary": "Get saved addresses",
+                "responses": {
+                    "200": {
+                        "description": "Saved addresses",
+                        "schema": {
+                            "$ref": "#/definitions/address.AddressesList"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "The method saves the named address of the authorized user. The first saved address becomes the default\nfor shipping, marking the address as the default unmarks other addresses of the user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "addresses"
+                ],
+                "summary": "Save address",
+                "parameters": [
+                    {
+                        "description": "Name and address",
+                        "name": "address",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/address.NewAddress"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Saved address",
+                        "schema": {
+                            "$ref": "#/definitions/address.Address"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Address with the name is already saved",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/addresses/{addressID}": {
+            "get": {
+                "description": "The method returns the saved address of the authorized user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "addresses"
+                ],
+                "summary": "Get saved address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of the address",
+                        "name": "addressID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Saved address",
+                        "schema": {
+                            "$ref": "#/definitions/address.Address"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "The method changes the name, the address and the defaults of the saved address.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "addresses"
+                ],
+                "summary": "Change saved address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of the address",
+                        "name": "addressID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Name and address",
+                        "name": "address",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/address.NewAddress"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Changed address",
+                        "schema": {
+                            "$ref": "#/definitions/address.Address"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Address with the name is already saved",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "The method removes the address from the address book, the user has no default address\nfor shipping if the removed address was the default.",
+                "tags": [
+                    "addresses"
+                ],
+                "summary": "Delete saved address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of the address",
+                        "name": "addressID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/callbackGoogle": {
+            "put": {
+                "description": "Method provides to Change User Role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Change User Role",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/create": {
+            "post": {
+                "description": "Method provides to create a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Create a new user",
+                "parameters": [
+                    {
+                        "description": "User data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.CreateUserData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "100": {
+                        "description": "Continue",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/createRights/": {
+            "post": {
+                "description": "Method provides to create rights.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Method provides to create rights",
+                "parameters": [
+                    {
+                        "description": "Data for creating rights",
+                        "name": "rights",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.ShortRights"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/user.RightsId"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/login": {
+            "post": {
+                "description": "Method provides to login a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Login user",
+                "parameters": [
+                    {
+                        "description": "Login",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/password.Credentials"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.LoginResponseData"
+                        }
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/login/google": {
+            "get": {
+                "description": "Method provides to log in with Google",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Login with Google oauth2",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/logout": {
+            "get": {
+                "description": "Method provides to log out",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Logout",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "404": {
+                        "description": "Bad Request"
+                    }
+                }
+            }
+        },
+        "/user/notifications": {
+            "get": {
+                "description": "The method returns the choice of the authorized user of emails about orders and of their language.\nThe user who has not changed the preferences gets all emails in English.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get notification preferences",
+                "responses": {
+                    "200": {
+                        "description": "Notification preferences",
+                        "schema": {
+                            "$ref": "#/definitions/notification.Preferences"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "The method saves the choice of the authorized user of emails about the placed order, about changes\nof the status of the order and about the shipment of the order and the language of emails.\nEmails about the account are always sent.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Change notification preferences",
+                "parameters": [
+                    {
+                        "description": "Notification preferences",
+                        "name": "preferences",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notification.Preferences"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Saved notification preferences",
+                        "schema": {
+                            "$ref": "#/definitions/notification.Preferences"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/profile": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [],
+                        "firebase": []
+                    }
+                ],
+                "description": "Method provides to get profile info",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "User profile",
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/user.CreateUserData"
+                        }
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/profile/edit": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [],
+                        "firebase": []
+                    }
+                ],
+                "description": "Method provides to update profile info",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "User profile update",
+                "parameters": [
+                    {
+                        "description": "New user data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.CreateUserData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/user.CreateUserData"
+                        }
+                    },
+                    "404": {
+                        "description": "404 Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/rights/list": {
+            "get": {
+                "description": "Method provides to Change User Role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Change User Role",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/delivery.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "address.Address": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/order.OrderAddress"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "default_shipping": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "home"
+                }
+            }
+        },
+        "address.AddressesList": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/address.Address"
+                    }
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "address.NewAddress": {
+            "type": "object",
+            "required": [
+                "address",
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/order.OrderAddress"
+                },
+                "default_shipping": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "home"
+                }
+            }
+        },
+        "audit.Actor": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "admin@mail.ru"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "Admin"
+                },
+                "userId": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "audit.EntriesList": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "minItems": 0,
+                    "items": {
+                        "$ref": "#/definitions/audit.Entry"
+                    }
+                },
+                "quantity": {
+                    "type": "integer",
+                    "default": 0,
+                    "minimum": 0,
+                    "example": 10
+                }
+            }
+        },
+        "audit.Entry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "item.update"
+                },
+                "actor": {
+                    "$ref": "#/definitions/audit.Actor"
+                },
+                "after": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "before": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "entity": {
+                    "type": "string",
+                    "example": "item"
+                },
+                "entityId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "cart.Cart": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "items": {
+                    "type": "array",
+                    "minItems": 0,
+                    "items": {
+                        "$ref": "#/definitions/cart.CartItem"
+                    }
+                },
+                "notices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/cart.Notice"
+                    }
+                },
+                "savedItems": {
+                    "description": "SavedItems is the items saved for later, they are not included in the totals and the order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/cart.CartItem"
+                    }
+                },
+                "totals": {
+                    "$ref": "#/definitions/cart.Totals"
+                },
+                "userId": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "cart.CartId": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "cart.CartItem": {
+            "type": "object",
+            "required": [
+                "quantity"
+            ],
+            "properties": {
+                "item": {
+                    "$ref": "#/definitions/item.OutItem"
+                },
+                "lineTotal": {
+                    "description": "LineTotal is zero for deleted and unavailable items",
+                    "type": "integer",
+                    "example": 1000
+                },
+                "quantity": {
+                    "type": "integer",
+                    "default": 1,
+                    "minimum": 1,
+                    "example": 3
+                }
+            }
+        },
+        "cart.GuestCart": {
+            "type": "object",
+            "properties": {
+                "cartId": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "cart.Notice": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "itemId": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "newPrice": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "oldPrice": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Item"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "price_changed",
+                        "deleted",
+                        "unavailable"
+                    ],
+                    "example": "price_changed"
+                }
+            }
+        },
+        "cart.SetQuantity": {
+            "type": "object",
+            "required": [
+                "quantity"
+            ],
+            "properties": {
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 3
+                }
+            }
+        },
+        "cart.ShippingQuote": {
+            "type": "object",
+            "properties": {
+                "cost": {
+                    "type": "integer",
+                    "example": 300
+                },
+                "days": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "method": {
+                    "type": "string",
+                    "example": "courier"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Courier"
+                },
+                "shipment_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "cart.ShortCart": {
+            "type": "object",
+            "required": [
+                "cartId",
+                "itemId"
+            ],
+            "properties": {
+                "cartId": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "itemId": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "cart.Totals": {
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "shipping": {
+                    "type": "integer",
+                    "example": 300
+                },
+                "subtotal": {
+                    "type": "integer",
+                    "example": 1500
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1800
+                }
+            }
+        },
+        "category.Category": {
+            "type": "object",
+            "required": [
+                "description",
+                "id",
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Электротехнические товары для дома"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Электротехника"
+                }
+            }
+        },
+        "category.CategoryId": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "category.MergeCategories": {
+            "type": "object",
+            "required": [
+                "sourceId",
+                "targetId"
+            ],
+            "properties": {
+                "sourceId": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "targetId": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "category.ShortCategory": {
+            "type": "object",
+            "required": [
+                "description",
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Электротехнические товары для дома"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Электротехника"
+                }
+            }
+        },
+        "delivery.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "info": {},
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "file.FileListResponse": {
+            "type": "object",
+            "properties": {
+                "files": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/file.FilesInfo"
+                    }
+                }
+            }
+        },
+        "file.FilesInfo": {
+            "type": "object",
+            "properties": {
+                "createdDate": {
+                    "type": "string",
+                    "example": "2022-12-13 12:46:16.0964549 +0300 MSK"
+                },
+                "modifyDate": {
+                    "type": "string",
+                    "example": "2022-12-13 12:46:16.0964549 +0300 MSK"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "20221213125935.jpeg"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "storage\\files\\categories\\d0d3df2d-f6c8-4956-9d76-998ee1ec8a39\\20221213125935.jpeg"
+                }
+            }
+        },
+        "item.AddFavItem": {
+            "type": "object",
+            "required": [
+                "itemId",
+                "userId"
+            ],
+            "properties": {
+                "itemId": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "userId": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "item.InItem": {
+            "type": "object",
+            "required": [
+                "category",
+                "description",
+                "id",
+                "price",
+                "title",
+                "vendor"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Мощность всасывания 1.5 кВт"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "image": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "price": {
+                    "type": "integer",
+                    "default": 10,
+                    "minimum": 0,
+                    "example": 1990
+                },
+                "sellerId": {
+                    "description": "SellerId is the user who answers questions about the item, the item is sold by the shop if it is empty",
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "stock": {
+                    "description": "Stock is not tracked if it is empty",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 10
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Пылесос"
+                },
+                "vendor": {
+                    "type": "string",
+                    "example": "Витязь"
+                },
+                "weight": {
+                    "description": "Weight is the weight of the item in grams",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2500
+                }
+            }
+        },
+        "item.ItemId": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "item.ItemsList": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "minItems": 0,
+                    "items": {
+                        "$ref": "#/definitions/item.OutItem"
+                    }
+                },
+                "quantity": {
+                    "type": "integer",
+                    "default": 0,
+                    "minimum": 0,
+                    "example": 10
+                }
+            }
+        },
+        "item.ItemsQuantity": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "type": "integer",
+                    "default": 0,
+                    "minimum": 0,
+                    "example": 10
+                }
+            }
+        },
+        "item.OutItem": {
+            "type": "object",
+            "required": [
+                "category",
+                "description",
+                "id",
+                "price",
+                "title",
+                "vendor"
+            ],
+            "properties": {
+                "answeredQuestions": {
+                    "description": "AnsweredQuestions is filled only in the response of GetItem, it is zero if no question is answered",
+                    "type": "integer",
+                    "example": 3
+                },
+                "category": {
+                    "$ref": "#/definitions/category.Category"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Мощность всасывания 1.5 кВт"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "image": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "isFavourite": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "price": {
+                    "type": "integer",
+                    "default": 10,
+                    "minimum": 0,
+                    "example": 1990
+                },
+                "sellerId": {
+                    "description": "SellerId is filled only in the response of GetItem if the item has the seller",
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "stock": {
+                    "description": "Stock is filled only in the response of GetItem if it is tracked",
+                    "type": "integer",
+                    "example": 10
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Пылесос"
+                },
+                "vendor": {
+                    "type": "string",
+                    "example": "Витязь"
+                },
+                "weight": {
+                    "description": "Weight is filled only in the response of GetItem, it is in grams",
+                    "type": "integer",
+                    "example": 2500
+                }
+            }
+        },
+        "item.ShortItem": {
+            "type": "object",
+            "required": [
+                "description",
+                "price",
+                "title"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Мощность всасывания 1.5 кВт"
+                },
+                "image": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "price": {
+                    "type": "integer",
+                    "default": 10,
+                    "minimum": 0,
+                    "example": 1990
+                },
+                "sellerId": {
+                    "description": "SellerId is the user who answers questions about the item, the item is sold by the shop if it is empty",
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "stock": {
+                    "description": "Stock is not tracked if it is empty",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 10
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Пылесос"
+                },
+                "vendor": {
+                    "type": "string",
+                    "example": "Витязь"
+                },
+                "weight": {
+                    "description": "Weight is the weight of the item in grams",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2500
+                }
+            }
+        },
+        "jwtauth.Token": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "notification.Preferences": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "description": "Language is the language of emails, emails are in English if it is empty",
+                    "type": "string",
+                    "enum": [
+                        "en",
+                        "ru"
+                    ],
+                    "example": "ru"
+                },
+                "order_placed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "order_shipped": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "order_status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "order.AddressWithUserAndId": {
+            "type": "object",
+            "required": [
+                "order_id"
+            ],
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/order.OrderAddress"
+                },
+                "order_id": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "user": {
+                    "$ref": "#/definitions/order.UserForCart"
+                }
+            }
+        },
+        "order.Cancel": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 1024,
+                    "example": "Changed my mind"
+                }
+            }
+        },
+        "order.CartAdressUser": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "Address is the address of the order, the saved address is used if it is not set",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/order.OrderAddress"
+                        }
+                    ]
+                },
+                "address_id": {
+                    "description": "AddressId is the id of the saved address of the user,\nthe default shipping address is used if both the address and its id are not set",
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "cart": {
+                    "$ref": "#/definitions/cart.Cart"
+                },
+                "shipping_method": {
+                    "description": "ShippingMethod is the code of the chosen shipping method,\nthe first method delivering the order to the address is used if it is empty",
+                    "type": "string",
+                    "example": "courier"
+                },
+                "user": {
+                    "$ref": "#/definitions/order.UserForCart"
                 }
             }
         },
-        "cart.ShortCart": {
+        "order.Order": {
             "type": "object",
             "required": [
-                "cartId",
-                "itemId"
+                "address",
+                "created_at",
+                "id",
+                "shipment_time"
             ],
             "properties": {
-                "cartId": {
+                "address": {
+                    "$ref": "#/definitions/order.OrderAddress"
+                },
+                "cancel_reason": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "string",
                     "format": "uuid",
                     "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "itemId": {
+                "items": {
+                    "type": "array",
+                    "minItems": 0,
+                    "items": {
+                        "$ref": "#/definitions/cart.CartItem"
+                    }
+                },
+                "returns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/returns.Return"
+                    }
+                },
+                "shipment": {
+                    "description": "Shipment is set when the order is handed over to the carrier",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/order.Shipment"
+                        }
+                    ]
+                },
+                "shipment_time": {
+                    "type": "string"
+                },
+                "shipping_cost": {
+                    "type": "integer",
+                    "example": 300
+                },
+                "shipping_method": {
+                    "description": "ShippingMethod is empty for orders placed before shipping methods",
+                    "type": "string",
+                    "example": "courier"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
                     "type": "string",
                     "format": "uuid",
                     "example": "00000000-0000-0000-0000-000000000000"
                 }
             }
         },
-        "category.Category": {
+        "order.OrderAddress": {
             "type": "object",
             "required": [
-                "description",
-                "id",
-                "name"
+                "city",
+                "street",
+                "zipcode"
             ],
             "properties": {
-                "description": {
+                "apartment": {
                     "type": "string",
-                    "example": "Электротехнические товары для дома"
+                    "maxLength": 64,
+                    "example": "12"
                 },
-                "id": {
+                "city": {
                     "type": "string",
-                    "format": "uuid",
-                    "example": "00000000-0000-0000-0000-000000000000"
+                    "maxLength": 256
                 },
-                "image": {
+                "country": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "+79001234567"
+                },
+                "recipient": {
+                    "type": "string",
+                    "maxLength": 256,
+                    "example": "Ivan Ivanov"
+                },
+                "street": {
                     "type": "string"
                 },
-                "name": {
+                "zipcode": {
                     "type": "string",
-                    "example": "Электротехника"
+                    "maxLength": 16
                 }
             }
         },
-        "category.CategoryId": {
+        "order.OrderId": {
             "type": "object",
             "required": [
-                "id"
+                "id",
+                "newCartId"
             ],
             "properties": {
                 "id": {
                     "type": "string",
                     "format": "uuid",
                     "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "newCartId": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
                 }
             }
         },
-        "category.ShortCategory": {
+        "order.OrderSummary": {
             "type": "object",
-            "required": [
-                "description",
-                "name"
-            ],
             "properties": {
-                "description": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "string",
-                    "example": "Электротехнические товары для дома"
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "image": {
+                "items_quantity": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "shipment_time": {
                     "type": "string"
                 },
-                "name": {
+                "shipping_method": {
                     "type": "string",
-                    "example": "Электротехника"
+                    "example": "courier"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "paid"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1300
+                },
+                "user": {
+                    "$ref": "#/definitions/order.UserForCart"
                 }
             }
         },
-        "delivery.ErrorResponse": {
+        "order.OrdersList": {
             "type": "object",
             "properties": {
-                "errors": {
+                "list": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/order.OrderSummary"
                     }
                 },
-                "id": {
-                    "type": "string"
+                "quantity": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "info": {},
-                "message": {
-                    "type": "string"
-                }
-            }
-        },
-        "file.FileListResponse": {
-            "type": "object",
-            "properties": {
-                "files": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/file.FilesInfo"
+                "status_counts": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
                     }
                 }
             }
         },
-        "file.FilesInfo": {
+        "order.Shipment": {
             "type": "object",
             "properties": {
-                "createdDate": {
+                "carrier": {
                     "type": "string",
-                    "example": "2022-12-13 12:46:16.0964549 +0300 MSK"
+                    "example": "fake"
                 },
-                "modifyDate": {
-                    "type": "string",
-                    "example": "2022-12-13 12:46:16.0964549 +0300 MSK"
+                "created_at": {
+                    "type": "string"
                 },
-                "name": {
-                    "type": "string",
-                    "example": "20221213125935.jpeg"
+                "delivered_at": {
+                    "description": "DeliveredAt is not set until the carrier reports the delivery",
+                    "type": "string"
                 },
-                "path": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/order.TrackingEvent"
+                    }
+                },
+                "tracking_number": {
                     "type": "string",
-                    "example": "storage\\files\\categories\\d0d3df2d-f6c8-4956-9d76-998ee1ec8a39\\20221213125935.jpeg"
+                    "example": "FAKE-1672531200-1A2B3C4D"
                 }
             }
         },
-        "item.AddFavItem": {
+        "order.StatusWithUserAndId": {
             "type": "object",
             "required": [
-                "itemId",
-                "userId"
+                "order_id"
             ],
             "properties": {
-                "itemId": {
+                "order_id": {
                     "type": "string",
                     "format": "uuid",
                     "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "userId": {
-                    "type": "string",
-                    "format": "uuid",
-                    "example": "00000000-0000-0000-0000-000000000000"
+                "status": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/order.UserForCart"
                 }
             }
         },
-        "item.InItem": {
+        "order.TrackingEvent": {
             "type": "object",
-            "required": [
-                "category",
-                "description",
-                "id",
-                "price",
-                "title",
-                "vendor"
-            ],
             "properties": {
-                "category": {
-                    "type": "string",
-                    "format": "uuid",
-                    "example": "00000000-0000-0000-0000-000000000000"
-                },
                 "description": {
                     "type": "string",
-                    "example": "Мощность всасывания 1.5 кВт"
+                    "example": "Parcel is in transit"
                 },
-                "id": {
+                "location": {
                     "type": "string",
-                    "format": "uuid",
-                    "example": "00000000-0000-0000-0000-000000000000"
-                },
-                "image": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "price": {
-                    "type": "integer",
-                    "default": 10,
-                    "minimum": 0,
-                    "example": 1990
+                    "example": "Moscow"
                 },
-                "title": {
+                "status": {
                     "type": "string",
-                    "example": "Пылесос"
+                    "example": "in_transit"
                 },
-                "vendor": {
-                    "type": "string",
-                    "example": "Витязь"
+                "time": {
+                    "type": "string"
                 }
             }
         },
-        "item.ItemId": {
+        "order.UserForCart": {
             "type": "object",
             "required": [
+                "email",
                 "id"
             ],
             "properties": {
+                "email": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string",
                     "format": "uuid",
                     "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "role": {
+                    "type": "string"
                 }
             }
         },
-        "item.ItemsList": {
+        "password.Credentials": {
             "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
             "properties": {
-                "items": {
-                    "type": "array",
-                    "minItems": 0,
-                    "items": {
-                        "$ref": "#/definitions/item.OutItem"
-                    }
+                "email": {
+                    "type": "string"
                 },
-                "quantity": {
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "payment.Payment": {
+            "type": "object",
+            "properties": {
+                "amount": {
                     "type": "integer",
-                    "default": 0,
-                    "minimum": 0,
-                    "example": 10
+                    "example": 10300
+                },
+                "clientSecret": {
+                    "type": "string",
+                    "example": "mock_00000000-0000-0000-0000-000000000000_secret"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "orderId": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "provider": {
+                    "type": "string",
+                    "example": "mock"
+                },
+                "refundedAmount": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "updatedAt": {
+                    "type": "string"
                 }
             }
         },
-        "item.ItemsQuantity": {
+        "payment.Refund": {
             "type": "object",
             "properties": {
-                "quantity": {
+                "amount": {
                     "type": "integer",
                     "default": 0,
                     "minimum": 0,
-                    "example": 10
+                    "example": 0
                 }
             }
         },
-        "item.OutItem": {
+        "question.Answer": {
             "type": "object",
-            "required": [
-                "category",
-                "description",
-                "id",
-                "price",
-                "title",
-                "vendor"
-            ],
             "properties": {
-                "category": {
-                    "$ref": "#/definitions/category.Category"
+                "createdAt": {
+                    "type": "string"
                 },
-                "description": {
+                "id": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "text": {
                     "type": "string",
                     "example": "Мощность всасывания 1.5 кВт"
                 },
-                "id": {
+                "userId": {
                     "type": "string",
                     "format": "uuid",
                     "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "image": {
+                "votes": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 5
+                }
+            }
+        },
+        "question.AnswerId": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "question.Question": {
+            "type": "object",
+            "properties": {
+                "answers": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/question.Answer"
                     }
                 },
-                "isFavourite": {
-                    "type": "boolean",
-                    "example": false
+                "createdAt": {
+                    "type": "string"
                 },
-                "price": {
-                    "type": "integer",
-                    "default": 10,
-                    "minimum": 0,
-                    "example": 1990
+                "id": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "title": {
+                "itemId": {
                     "type": "string",
-                    "example": "Пылесос"
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "vendor": {
+                "text": {
                     "type": "string",
-                    "example": "Витязь"
+                    "example": "Какая мощность у пылесоса?"
+                },
+                "userId": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
                 }
             }
         },
-        "item.ShortItem": {
+        "question.QuestionId": {
             "type": "object",
-            "required": [
-                "description",
-                "price",
-                "title"
-            ],
             "properties": {
-                "category": {
+                "id": {
                     "type": "string",
                     "format": "uuid",
                     "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "question.QuestionsList": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "type": "integer",
+                    "default": 0,
+                    "minimum": 0,
+                    "example": 10
                 },
-                "description": {
-                    "type": "string",
-                    "example": "Мощность всасывания 1.5 кВт"
-                },
-                "image": {
+                "questions": {
                     "type": "array",
+                    "minItems": 0,
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/question.Question"
                     }
-                },
-                "price": {
-                    "type": "integer",
-                    "default": 10,
-                    "minimum": 0,
-                    "example": 1990
-                },
-                "title": {
-                    "type": "string",
-                    "example": "Пылесос"
-                },
-                "vendor": {
+                }
+            }
+        },
+        "question.ShortAnswer": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "text": {
                     "type": "string",
-                    "example": "Витязь"
+                    "example": "Мощность всасывания 1.5 кВт"
                 }
             }
         },
-        "jwtauth.Token": {
+        "question.ShortQuestion": {
             "type": "object",
+            "required": [
+                "text"
+            ],
             "properties": {
-                "access_token": {
-                    "type": "string"
-                },
-                "refresh_token": {
-                    "type": "string"
+                "text": {
+                    "type": "string",
+                    "example": "Какая мощность у пылесоса?"
                 }
             }
         },
-        "order.AddressWithUserAndId": {
+        "returns.NewReturn": {
             "type": "object",
             "required": [
-                "order_id"
+                "items",
+                "reason"
             ],
             "properties": {
-                "address": {
-                    "$ref": "#/definitions/order.OrderAddress"
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/returns.ReturnItem"
+                    }
                 },
-                "order_id": {
+                "reason": {
                     "type": "string",
-                    "format": "uuid",
-                    "example": "00000000-0000-0000-0000-000000000000"
-                },
-                "user": {
-                    "$ref": "#/definitions/order.UserForCart"
+                    "example": "Wrong size"
                 }
             }
         },
-        "order.CartAdressUser": {
+        "returns.Refund": {
             "type": "object",
             "properties": {
-                "address": {
-                    "$ref": "#/definitions/order.OrderAddress"
-                },
-                "cart": {
-                    "$ref": "#/definitions/cart.Cart"
-                },
-                "user": {
-                    "$ref": "#/definitions/order.UserForCart"
+                "amount": {
+                    "type": "integer",
+                    "default": 0,
+                    "minimum": 0,
+                    "example": 0
                 }
             }
         },
-        "order.Order": {
+        "returns.Return": {
             "type": "object",
-            "required": [
-                "address",
-                "created_at",
-                "id",
-                "shipment_time"
-            ],
             "properties": {
-                "address": {
-                    "$ref": "#/definitions/order.OrderAddress"
-                },
-                "created_at": {
+                "createdAt": {
                     "type": "string"
                 },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/returns.StatusChange"
+                    }
+                },
                 "id": {
                     "type": "string",
                     "format": "uuid",
@@ -2824,116 +5958,124 @@ const docTemplate = `{
                 },
                 "items": {
                     "type": "array",
-                    "minItems": 0,
                     "items": {
-                        "$ref": "#/definitions/cart.CartItem"
+                        "$ref": "#/definitions/returns.ReturnItem"
                     }
                 },
-                "shipment_time": {
-                    "type": "string"
+                "orderId": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Wrong size"
+                },
+                "refundAmount": {
+                    "type": "integer",
+                    "example": 0
                 },
                 "status": {
+                    "type": "string",
+                    "example": "requested"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 2000
+                },
+                "trackingNumber": {
+                    "type": "string",
+                    "example": "RA123456789RU"
+                },
+                "updatedAt": {
                     "type": "string"
                 },
-                "user_id": {
+                "userId": {
                     "type": "string",
                     "format": "uuid",
                     "example": "00000000-0000-0000-0000-000000000000"
                 }
             }
         },
-        "order.OrderAddress": {
+        "returns.ReturnItem": {
             "type": "object",
             "required": [
-                "city",
-                "street",
-                "zipcode"
+                "itemId",
+                "quantity"
             ],
             "properties": {
-                "city": {
-                    "type": "string"
+                "itemId": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "country": {
-                    "type": "string"
+                "price": {
+                    "type": "integer",
+                    "example": 1000
                 },
-                "street": {
-                    "type": "string"
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
                 },
-                "zipcode": {
-                    "type": "string"
+                "title": {
+                    "type": "string",
+                    "example": "Item title"
                 }
             }
         },
-        "order.OrderId": {
+        "returns.Status": {
             "type": "object",
             "required": [
-                "id",
-                "newCartId"
+                "status"
             ],
             "properties": {
-                "id": {
+                "comment": {
                     "type": "string",
-                    "format": "uuid",
-                    "example": "00000000-0000-0000-0000-000000000000"
+                    "example": "Send the goods back"
                 },
-                "newCartId": {
+                "status": {
                     "type": "string",
-                    "format": "uuid",
-                    "example": "00000000-0000-0000-0000-000000000000"
+                    "enum": [
+                        "approved",
+                        "rejected",
+                        "received"
+                    ],
+                    "example": "approved"
                 }
             }
         },
-        "order.StatusWithUserAndId": {
+        "returns.StatusChange": {
             "type": "object",
-            "required": [
-                "order_id"
-            ],
             "properties": {
-                "order_id": {
+                "actorId": {
                     "type": "string",
                     "format": "uuid",
                     "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "status": {
-                    "type": "string"
+                "comment": {
+                    "type": "string",
+                    "example": "Send the goods back"
                 },
-                "user": {
-                    "$ref": "#/definitions/order.UserForCart"
-                }
-            }
-        },
-        "order.UserForCart": {
-            "type": "object",
-            "required": [
-                "email",
-                "id"
-            ],
-            "properties": {
-                "email": {
+                "createdAt": {
                     "type": "string"
                 },
-                "id": {
+                "status": {
                     "type": "string",
-                    "format": "uuid",
-                    "example": "00000000-0000-0000-0000-000000000000"
-                },
-                "role": {
-                    "type": "string"
+                    "example": "approved"
                 }
             }
         },
-        "password.Credentials": {
+        "returns.Tracking": {
             "type": "object",
             "required": [
-                "email",
-                "password"
+                "trackingNumber"
             ],
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "password": {
-                    "type": "string"
+                "trackingNumber": {
+                    "type": "string",
+                    "maxLength": 256,
+                    "example": "RA123456789RU"
                 }
             }
         },
@@ -0,0 +1,60 @@
+package jwtauth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+)
+
+const (
+	// cartTokenSubject distinguishes tokens of guest carts from session tokens signed with the same key
+	cartTokenSubject = "guest_cart"
+	cartTokenTTL     = 30 * 24 * time.Hour
+)
+
+// CartPayload is the claims of the token of the guest cart
+type CartPayload struct {
+	CartId uuid.UUID `json:"cartId"`
+	jwt.StandardClaims
+}
+
+// CreateCartToken returns the signed token which gives access to the guest cart
+func CreateCartToken(cartId uuid.UUID) (string, error) {
+	key, err := NewJWTKeyConfig()
+	if err != nil {
+		return "", err
+	}
+	payload := CartPayload{
+		CartId: cartId,
+		StandardClaims: jwt.StandardClaims{
+			Subject:   cartTokenSubject,
+			ExpiresAt: time.Now().Add(cartTokenTTL).Unix(),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &payload)
+	return token.SignedString([]byte(key.Key))
+}
+
+// ParseCartToken checks the signature of the token of the guest cart and returns id of the cart
+func ParseCartToken(tokenString string) (uuid.UUID, error) {
+	key, err := NewJWTKeyConfig()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	payload := &CartPayload{}
+	token, err := jwt.ParseWithClaims(tokenString, payload, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(key.Key), nil
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid cart token: %w", err)
+	}
+	if !token.Valid || payload.Subject != cartTokenSubject || payload.CartId == uuid.Nil {
+		return uuid.Nil, fmt.Errorf("invalid cart token")
+	}
+	return payload.CartId, nil
+}
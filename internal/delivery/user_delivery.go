@@ -16,9 +16,11 @@ import (
 	"OnlineShopBackend/internal/delivery/user/password"
 	"OnlineShopBackend/internal/models"
 	"OnlineShopBackend/internal/usecase"
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/dghubble/gologin/v2"
 	gg "github.com/dghubble/gologin/v2/google"
@@ -122,20 +124,24 @@ func (delivery *Delivery) LoginUser(c *gin.Context) {
 		return
 	}
 
-	cartExist, err := delivery.cartUsecase.GetCartByUserId(ctx, userExist.ID)
-	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
-		delivery.logger.Error(err.Error())
-		delivery.SetError(c, http.StatusContinue, err)
-	}
-
-	var cartId uuid.UUID
-	if cartExist != nil {
-		cartId = cartExist.Id
-	} else {
-		cartId, err = delivery.cartUsecase.Create(ctx, userExist.ID)
-		if err != nil {
+	ctx = setActor(c, userExist)
+	// The guest cart is merged into the cart of the user, otherwise the cart of the user is used as before
+	cartId, merged := delivery.mergeGuestCart(c, userExist.ID)
+	if !merged {
+		cartExist, err := delivery.cartUsecase.GetCartByUserId(ctx, userExist.ID)
+		if err != nil && errors.Is(err, models.ErrorNotFound{}) {
 			delivery.logger.Error(err.Error())
-			delivery.SetError(c, http.StatusNotFound, err)
+			delivery.SetError(c, http.StatusContinue, err)
+		}
+
+		if cartExist != nil {
+			cartId = cartExist.Id
+		} else {
+			cartId, err = delivery.cartUsecase.Create(ctx, userExist.ID)
+			if err != nil {
+				delivery.logger.Error(err.Error())
+				delivery.SetError(c, http.StatusNotFound, err)
+			}
 		}
 	}
 
@@ -260,6 +266,11 @@ func (delivery *Delivery) LoginUserGoogle(c *gin.Context) {
 		Scopes:       []string{"profile", "email"},
 	}
 
+	// The token of the guest cart is kept in the cookie until the callback to merge the cart after login
+	if cartToken := c.Query("cartToken"); cartToken != "" {
+		c.SetCookie(cartTokenCookie, cartToken, int(time.Hour.Seconds()), "/", "", false, true)
+	}
+
 	stateConfig := gologin.DefaultCookieConfig
 	gg.StateHandler(stateConfig, gg.LoginHandler(oauth2Config, nil)).ServeHTTP(c.Writer, c.Request)
 
@@ -315,6 +326,10 @@ func (delivery *Delivery) success(c *gin.Context) http.HandlerFunc {
 				return
 			}
 		}
+		setActor(c, u)
+		if _, merged := delivery.mergeGuestCart(c, u.ID); merged {
+			c.SetCookie(cartTokenCookie, "", -1, "/", "", false, true)
+		}
 		token, err := jwtauth.CreateSessionJWT(c.Request.Context(), u)
 		if err != nil {
 			delivery.logger.Error(err.Error())
@@ -328,6 +343,32 @@ func (delivery *Delivery) success(c *gin.Context) http.HandlerFunc {
 	return fn
 }
 
+// setActor sets the logged in user as the caller of usecases in the context of the request,
+// because there are no claims of the user before the login. Returns the new context
+func setActor(c *gin.Context, user *models.User) context.Context {
+	c.Request = c.Request.WithContext(models.ContextWithActor(c.Request.Context(), models.Actor{
+		UserId: user.ID,
+		Email:  user.Email,
+		Role:   user.Rights.Name,
+	}))
+	return c.Request.Context()
+}
+
+// mergeGuestCart merges the guest cart from the cart token of the request into the cart of the user.
+// Returns id of the cart of the user and false if there is no guest cart or merge failed
+func (delivery *Delivery) mergeGuestCart(c *gin.Context, userId uuid.UUID) (uuid.UUID, bool) {
+	guestCartId, ok := delivery.guestCartId(c)
+	if !ok {
+		return uuid.Nil, false
+	}
+	cartId, err := delivery.cartUsecase.MergeGuestCart(models.ContextWithGuestCart(c.Request.Context(), guestCartId), guestCartId, userId)
+	if err != nil {
+		delivery.logger.Warn(fmt.Sprintf("can't merge guest cart %v: %s", guestCartId, err))
+		return uuid.Nil, false
+	}
+	return cartId, true
+}
+
 func (delivery *Delivery) failure(c *gin.Context) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		delivery.logger.Error("google callback failure")
@@ -39,7 +39,7 @@ func TestCreateUser(t *testing.T) {
 	userUsecase := mocks.NewMockIUserUsecase(ctrl)
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage)
+	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -124,7 +124,7 @@ func TestCreateRights(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, userUsecase, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Dependencies{Logger: logger, FileStorage: filestorage, ItemUsecase: itemUsecase, UserUsecase: userUsecase, CategoryUsecase: categoryUsecase, CartUsecase: cartUsecase, OrderUsecase: orderUsecase})
 	ctx := context.Background()
 
 	w := httptest.NewRecorder()
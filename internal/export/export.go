@@ -0,0 +1,227 @@
+// Package export writes tables to CSV and XLSX files row by row,
+// so that large tables are streamed without keeping them in memory
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Formats of exported files
+const (
+	CSV  = "csv"
+	XLSX = "xlsx"
+)
+
+// TimeLayout is the layout of time values in exported files
+const TimeLayout = "2006-01-02 15:04:05"
+
+// Writer writes rows of the table. Values of rows are strings, integers, time.Time
+// or fmt.Stringer, integers are written as numbers. Close must be called to complete the file
+type Writer interface {
+	Write(row []interface{}) error
+	Close() error
+}
+
+// NewWriter returns the writer of the table in the format to w
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case CSV:
+		return newCSVWriter(w)
+	case XLSX:
+		return newXLSXWriter(w)
+	default:
+		return nil, fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+// ContentType returns the MIME type of files in the format
+func ContentType(format string) string {
+	switch format {
+	case CSV:
+		return "text/csv; charset=utf-8"
+	case XLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// Valid reports whether the format is supported
+func Valid(format string) bool {
+	return format == CSV || format == XLSX
+}
+
+// FileName returns the name of the file with the table for the period in the format,
+// for example orders_from_2023-01-01_to_2023-02-01.csv. Zero bounds of the period are omitted
+func FileName(name string, from time.Time, to time.Time, format string) string {
+	if !from.IsZero() {
+		name += "_from_" + from.Format("2006-01-02")
+	}
+	if !to.IsZero() {
+		name += "_to_" + to.Format("2006-01-02")
+	}
+	return name + "." + format
+}
+
+// formatValue returns the text of the value and whether it is a number
+func formatValue(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, false
+	case int:
+		return strconv.Itoa(v), true
+	case int32:
+		return strconv.FormatInt(int64(v), 10), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case time.Time:
+		if v.IsZero() {
+			return "", false
+		}
+		return v.Format(TimeLayout), false
+	case fmt.Stringer:
+		return v.String(), false
+	default:
+		return fmt.Sprint(v), false
+	}
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+	record []string
+}
+
+// newCSVWriter writes the byte order mark first, so that spreadsheets detect the encoding of the file
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return nil, fmt.Errorf("can't write csv: %w", err)
+	}
+	return &csvWriter{writer: csv.NewWriter(w)}, nil
+}
+
+func (writer *csvWriter) Write(row []interface{}) error {
+	writer.record = writer.record[:0]
+	for _, value := range row {
+		text, _ := formatValue(value)
+		writer.record = append(writer.record, text)
+	}
+	if err := writer.writer.Write(writer.record); err != nil {
+		return fmt.Errorf("can't write csv: %w", err)
+	}
+	return nil
+}
+
+func (writer *csvWriter) Close() error {
+	writer.writer.Flush()
+	if err := writer.writer.Error(); err != nil {
+		return fmt.Errorf("can't write csv: %w", err)
+	}
+	return nil
+}
+
+// Parts of the workbook with the single sheet, the sheet is written by rows between its header and footer
+const (
+	xlsxContentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+	xlsxRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbook = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxWorkbookRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+	xlsxSheetHeader = xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetFooter = `</sheetData></worksheet>`
+)
+
+type xlsxWriter struct {
+	archive *zip.Writer
+	sheet   *bufio.Writer
+	rows    int
+}
+
+func newXLSXWriter(w io.Writer) (*xlsxWriter, error) {
+	archive := zip.NewWriter(w)
+	for _, part := range []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	} {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return nil, fmt.Errorf("can't write xlsx: %w", err)
+		}
+		if _, err := io.WriteString(file, part.content); err != nil {
+			return nil, fmt.Errorf("can't write xlsx: %w", err)
+		}
+	}
+	file, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, fmt.Errorf("can't write xlsx: %w", err)
+	}
+	sheet := bufio.NewWriter(file)
+	if _, err := sheet.WriteString(xlsxSheetHeader); err != nil {
+		return nil, fmt.Errorf("can't write xlsx: %w", err)
+	}
+	return &xlsxWriter{archive: archive, sheet: sheet}, nil
+}
+
+func (writer *xlsxWriter) Write(row []interface{}) error {
+	writer.rows++
+	fmt.Fprintf(writer.sheet, `<row r="%d">`, writer.rows)
+	for i, value := range row {
+		text, number := formatValue(value)
+		reference := columnName(i) + strconv.Itoa(writer.rows)
+		if number {
+			fmt.Fprintf(writer.sheet, `<c r="%s"><v>%s</v></c>`, reference, text)
+			continue
+		}
+		fmt.Fprintf(writer.sheet, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, reference)
+		if err := xml.EscapeText(writer.sheet, []byte(text)); err != nil {
+			return fmt.Errorf("can't write xlsx: %w", err)
+		}
+		writer.sheet.WriteString(`</t></is></c>`)
+	}
+	if _, err := writer.sheet.WriteString(`</row>`); err != nil {
+		return fmt.Errorf("can't write xlsx: %w", err)
+	}
+	return nil
+}
+
+func (writer *xlsxWriter) Close() error {
+	if _, err := writer.sheet.WriteString(xlsxSheetFooter); err != nil {
+		return fmt.Errorf("can't write xlsx: %w", err)
+	}
+	if err := writer.sheet.Flush(); err != nil {
+		return fmt.Errorf("can't write xlsx: %w", err)
+	}
+	if err := writer.archive.Close(); err != nil {
+		return fmt.Errorf("can't write xlsx: %w", err)
+	}
+	return nil
+}
+
+// columnName returns the name of the column of the sheet by its index from zero: A, B, ..., Z, AA, AB, ...
+func columnName(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
@@ -0,0 +1,95 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testId   = uuid.MustParse("0b74b0ac-68aa-462b-8609-4bf5eac3f9f7")
+	testTime = time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+)
+
+func writeTable(t *testing.T, format string) []byte {
+	var buf bytes.Buffer
+	writer, err := NewWriter(format, &buf)
+	require.NoError(t, err)
+	require.NoError(t, writer.Write([]interface{}{"Id", "Title", "Price", "Created at"}))
+	require.NoError(t, writer.Write([]interface{}{testId, `smartphone, "samsung" <s10>`, int64(1500), testTime}))
+	require.NoError(t, writer.Close())
+	return buf.Bytes()
+}
+
+func TestCSV(t *testing.T) {
+	res := writeTable(t, CSV)
+	require.Equal(t, "\ufeffId,Title,Price,Created at\n"+
+		`0b74b0ac-68aa-462b-8609-4bf5eac3f9f7,"smartphone, ""samsung"" <s10>",1500,2023-01-02 03:04:05`+"\n", string(res))
+}
+
+func TestXLSX(t *testing.T) {
+	res := writeTable(t, XLSX)
+	archive, err := zip.NewReader(bytes.NewReader(res), int64(len(res)))
+	require.NoError(t, err)
+	names := make([]string, 0, len(archive.File))
+	var sheet []byte
+	for _, file := range archive.File {
+		names = append(names, file.Name)
+		if file.Name == "xl/worksheets/sheet1.xml" {
+			reader, err := file.Open()
+			require.NoError(t, err)
+			sheet, err = io.ReadAll(reader)
+			require.NoError(t, err)
+		}
+	}
+	require.ElementsMatch(t, []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml",
+		"xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"}, names)
+
+	var worksheet struct {
+		Rows []struct {
+			R     int `xml:"r,attr"`
+			Cells []struct {
+				R     string `xml:"r,attr"`
+				T     string `xml:"t,attr"`
+				Value string `xml:"v"`
+				Text  string `xml:"is>t"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	require.NoError(t, xml.Unmarshal(sheet, &worksheet))
+	require.Len(t, worksheet.Rows, 2)
+	row := worksheet.Rows[1]
+	require.Equal(t, 2, row.R)
+	require.Len(t, row.Cells, 4)
+	require.Equal(t, "B2", row.Cells[1].R)
+	require.Equal(t, `smartphone, "samsung" <s10>`, row.Cells[1].Text)
+	require.Equal(t, "", row.Cells[2].T)
+	require.Equal(t, "1500", row.Cells[2].Value)
+	require.Equal(t, "2023-01-02 03:04:05", row.Cells[3].Text)
+}
+
+func TestUnknownFormat(t *testing.T) {
+	_, err := NewWriter("pdf", io.Discard)
+	require.Error(t, err)
+	require.False(t, Valid("pdf"))
+	require.True(t, Valid(XLSX))
+}
+
+func TestColumnName(t *testing.T) {
+	require.Equal(t, "A", columnName(0))
+	require.Equal(t, "Z", columnName(25))
+	require.Equal(t, "AA", columnName(26))
+	require.Equal(t, "AZ", columnName(51))
+	require.Equal(t, "BA", columnName(52))
+}
+
+func TestFileName(t *testing.T) {
+	require.Equal(t, "orders.csv", FileName("orders", time.Time{}, time.Time{}, CSV))
+	require.Equal(t, "orders_from_2023-01-02_to_2023-02-01.xlsx", FileName("orders", testTime, time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC), XLSX))
+}
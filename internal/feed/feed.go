@@ -0,0 +1,237 @@
+// Package feed builds machine-readable catalog feeds: sitemap.xml,
+// Google Merchant product feed and Yandex Market YML
+package feed
+
+import (
+	"OnlineShopBackend/internal/models"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"hash/crc32"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Names of the files with feeds
+const (
+	SitemapFile        = "sitemap.xml"
+	GoogleMerchantFile = "google_merchant.xml"
+	YandexMarketFile   = "yandex_market.yml"
+)
+
+// Shop is the information about the shop placed in the feeds
+type Shop struct {
+	Name     string
+	Company  string
+	SiteURL  string
+	Currency string
+}
+
+// ItemURL returns the address of the item page on the site
+func (shop Shop) ItemURL(item models.Item) string {
+	return strings.TrimSuffix(shop.SiteURL, "/") + "/items/" + item.Id.String()
+}
+
+// CategoryURL returns the address of the category page on the site
+func (shop Shop) CategoryURL(category models.Category) string {
+	return strings.TrimSuffix(shop.SiteURL, "/") + "/categories/" + category.Id.String()
+}
+
+type sitemapURL struct {
+	Loc string `xml:"loc"`
+}
+
+type urlSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+// Sitemap returns sitemap.xml with the main page, pages of categories and pages of items
+func Sitemap(shop Shop, categories []models.Category, items []models.Item) ([]byte, error) {
+	set := urlSet{
+		Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9",
+		URLs:  make([]sitemapURL, 0, len(categories)+len(items)+1),
+	}
+	set.URLs = append(set.URLs, sitemapURL{Loc: strings.TrimSuffix(shop.SiteURL, "/") + "/"})
+	for _, category := range categories {
+		set.URLs = append(set.URLs, sitemapURL{Loc: shop.CategoryURL(category)})
+	}
+	for _, item := range items {
+		set.URLs = append(set.URLs, sitemapURL{Loc: shop.ItemURL(item)})
+	}
+	return marshal(set)
+}
+
+type merchantItem struct {
+	Id                   string   `xml:"g:id"`
+	Title                string   `xml:"g:title"`
+	Description          string   `xml:"g:description"`
+	Link                 string   `xml:"g:link"`
+	ImageLink            string   `xml:"g:image_link,omitempty"`
+	AdditionalImageLinks []string `xml:"g:additional_image_link"`
+	Availability         string   `xml:"g:availability"`
+	Price                string   `xml:"g:price"`
+	Brand                string   `xml:"g:brand,omitempty"`
+	ProductType          string   `xml:"g:product_type,omitempty"`
+	Condition            string   `xml:"g:condition"`
+}
+
+type merchantChannel struct {
+	Title       string         `xml:"title"`
+	Link        string         `xml:"link"`
+	Description string         `xml:"description"`
+	Items       []merchantItem `xml:"item"`
+}
+
+type merchantRSS struct {
+	XMLName xml.Name        `xml:"rss"`
+	Version string          `xml:"version,attr"`
+	XmlnsG  string          `xml:"xmlns:g,attr"`
+	Channel merchantChannel `xml:"channel"`
+}
+
+// GoogleMerchant returns product feed in Google Merchant RSS 2.0 format
+func GoogleMerchant(shop Shop, items []models.Item) ([]byte, error) {
+	rss := merchantRSS{
+		Version: "2.0",
+		XmlnsG:  "http://base.google.com/ns/1.0",
+		Channel: merchantChannel{
+			Title:       shop.Name,
+			Link:        shop.SiteURL,
+			Description: shop.Name,
+			Items:       make([]merchantItem, 0, len(items)),
+		},
+	}
+	for _, item := range items {
+		merchant := merchantItem{
+			Id:           item.Id.String(),
+			Title:        item.Title,
+			Description:  item.Description,
+			Link:         shop.ItemURL(item),
+			Availability: merchantAvailability(item),
+			Price:        fmt.Sprintf("%d.00 %s", item.Price, shop.Currency),
+			Brand:        item.Vendor,
+			ProductType:  item.Category.Name,
+			Condition:    "new",
+		}
+		if len(item.Images) > 0 {
+			merchant.ImageLink = item.Images[0]
+			merchant.AdditionalImageLinks = item.Images[1:]
+		}
+		rss.Channel.Items = append(rss.Channel.Items, merchant)
+	}
+	return marshal(rss)
+}
+
+type ymlCurrency struct {
+	Id   string `xml:"id,attr"`
+	Rate string `xml:"rate,attr"`
+}
+
+type ymlCategory struct {
+	Id   uint32 `xml:"id,attr"`
+	Name string `xml:",chardata"`
+}
+
+type ymlOffer struct {
+	Id          string   `xml:"id,attr"`
+	Available   bool     `xml:"available,attr"`
+	Name        string   `xml:"name"`
+	URL         string   `xml:"url"`
+	Price       int32    `xml:"price"`
+	CurrencyId  string   `xml:"currencyId"`
+	CategoryId  uint32   `xml:"categoryId"`
+	Pictures    []string `xml:"picture"`
+	Vendor      string   `xml:"vendor,omitempty"`
+	Description string   `xml:"description"`
+}
+
+type ymlShop struct {
+	Name       string        `xml:"name"`
+	Company    string        `xml:"company"`
+	URL        string        `xml:"url"`
+	Currencies []ymlCurrency `xml:"currencies>currency"`
+	Categories []ymlCategory `xml:"categories>category"`
+	Offers     []ymlOffer    `xml:"offers>offer"`
+}
+
+type ymlCatalog struct {
+	XMLName xml.Name `xml:"yml_catalog"`
+	Date    string   `xml:"date,attr"`
+	Shop    ymlShop  `xml:"shop"`
+}
+
+// YandexMarket returns product feed in Yandex Market YML format
+func YandexMarket(shop Shop, categories []models.Category, items []models.Item, date time.Time) ([]byte, error) {
+	catalog := ymlCatalog{
+		Date: date.Format(time.RFC3339),
+		Shop: ymlShop{
+			Name:       shop.Name,
+			Company:    shop.Company,
+			URL:        shop.SiteURL,
+			Currencies: []ymlCurrency{{Id: shop.Currency, Rate: "1"}},
+			Categories: make([]ymlCategory, 0, len(categories)),
+			Offers:     make([]ymlOffer, 0, len(items)),
+		},
+	}
+	for _, category := range categories {
+		catalog.Shop.Categories = append(catalog.Shop.Categories, ymlCategory{
+			Id:   ymlCategoryId(category),
+			Name: category.Name,
+		})
+	}
+	for _, item := range items {
+		catalog.Shop.Offers = append(catalog.Shop.Offers, ymlOffer{
+			Id:          ymlOfferId(item),
+			Available:   inStock(item),
+			Name:        item.Title,
+			URL:         shop.ItemURL(item),
+			Price:       item.Price,
+			CurrencyId:  shop.Currency,
+			CategoryId:  ymlCategoryId(item.Category),
+			Pictures:    item.Images,
+			Vendor:      item.Vendor,
+			Description: item.Description,
+		})
+	}
+	return marshal(catalog)
+}
+
+// ymlCategoryId returns numeric id of category required by YML format,
+// the id doesn't change while the category exists
+func ymlCategoryId(category models.Category) uint32 {
+	return crc32.ChecksumIEEE(category.Id[:])
+}
+
+// ymlOfferId returns id of offer which is limited by 20 characters in YML format,
+// the numeric id of the item fits it and is unique
+func ymlOfferId(item models.Item) string {
+	return strconv.FormatInt(item.OfferId, 10)
+}
+
+// inStock reports whether the item can be ordered, the item is always in stock
+// if its stock is not tracked
+func inStock(item models.Item) bool {
+	return item.Stock == nil || *item.Stock > 0
+}
+
+// merchantAvailability returns availability of the item in Google Merchant format
+func merchantAvailability(item models.Item) string {
+	if inStock(item) {
+		return "in stock"
+	}
+	return "out of stock"
+}
+
+func marshal(v interface{}) ([]byte, error) {
+	buf := bytes.NewBufferString(xml.Header)
+	encoder := xml.NewEncoder(buf)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return nil, fmt.Errorf("can't encode feed: %w", err)
+	}
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
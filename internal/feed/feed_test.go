@@ -0,0 +1,93 @@
+package feed
+
+import (
+	"OnlineShopBackend/internal/models"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testShop = Shop{
+		Name:     "Shop",
+		Company:  "Company",
+		SiteURL:  "http://localhost:3000/",
+		Currency: "RUB",
+	}
+	testCategory = models.Category{
+		Id:   uuid.MustParse("d0d3df2d-f6c8-4956-9d76-998ee1ec8a39"),
+		Name: "electronics",
+	}
+	testItem = models.Item{
+		Id:          uuid.MustParse("0b74b0ac-68aa-462b-8609-4bf5eac3f9f7"),
+		Title:       "smartphone <samsung>",
+		Description: "best smartphone",
+		Price:       10000,
+		Category:    testCategory,
+		Vendor:      "samsung",
+		Images: []string{
+			"http://localhost:8000/files/items/0b74b0ac-68aa-462b-8609-4bf5eac3f9f7/1.jpeg",
+			"http://localhost:8000/files/items/0b74b0ac-68aa-462b-8609-4bf5eac3f9f7/2.jpeg",
+		},
+		OfferId: 42,
+	}
+	soldOut = 0
+	// testSoldOutItem has the same first 20 characters of the id as testItem
+	testSoldOutItem = models.Item{
+		Id:       uuid.MustParse("0b74b0ac-68aa-462b-8609-000000000001"),
+		Title:    "smartphone <xiaomi>",
+		Price:    8000,
+		Category: testCategory,
+		Stock:    &soldOut,
+		OfferId:  43,
+	}
+)
+
+func TestSitemap(t *testing.T) {
+	res, err := Sitemap(testShop, []models.Category{testCategory}, []models.Item{testItem})
+	require.NoError(t, err)
+
+	set := urlSet{}
+	require.NoError(t, xml.Unmarshal(res, &set))
+	require.Equal(t, []sitemapURL{
+		{Loc: "http://localhost:3000/"},
+		{Loc: "http://localhost:3000/categories/d0d3df2d-f6c8-4956-9d76-998ee1ec8a39"},
+		{Loc: "http://localhost:3000/items/0b74b0ac-68aa-462b-8609-4bf5eac3f9f7"},
+	}, set.URLs)
+}
+
+func TestGoogleMerchant(t *testing.T) {
+	res, err := GoogleMerchant(testShop, []models.Item{testItem, testSoldOutItem})
+	require.NoError(t, err)
+	require.Contains(t, string(res), `<rss version="2.0" xmlns:g="http://base.google.com/ns/1.0">`)
+	require.Contains(t, string(res), "<g:title>smartphone &lt;samsung&gt;</g:title>")
+	require.Contains(t, string(res), "<g:price>10000.00 RUB</g:price>")
+	require.Contains(t, string(res), "<g:image_link>"+testItem.Images[0]+"</g:image_link>")
+	require.Contains(t, string(res), "<g:additional_image_link>"+testItem.Images[1]+"</g:additional_image_link>")
+	require.Contains(t, string(res), "<g:availability>in stock</g:availability>")
+	require.Contains(t, string(res), "<g:availability>out of stock</g:availability>")
+}
+
+func TestYandexMarket(t *testing.T) {
+	date := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	res, err := YandexMarket(testShop, []models.Category{testCategory}, []models.Item{testItem, testSoldOutItem}, date)
+	require.NoError(t, err)
+
+	catalog := ymlCatalog{}
+	require.NoError(t, xml.Unmarshal(res, &catalog))
+	require.Equal(t, "2023-01-02T03:04:05Z", catalog.Date)
+	require.Len(t, catalog.Shop.Categories, 1)
+	require.Len(t, catalog.Shop.Offers, 2)
+	offer := catalog.Shop.Offers[0]
+	require.Equal(t, "42", offer.Id)
+	require.True(t, offer.Available)
+	require.Equal(t, catalog.Shop.Categories[0].Id, offer.CategoryId)
+	require.Equal(t, testItem.Images, offer.Pictures)
+	require.Equal(t, int32(10000), offer.Price)
+	offer = catalog.Shop.Offers[1]
+	require.Equal(t, "43", offer.Id)
+	require.False(t, offer.Available)
+}
@@ -17,8 +17,16 @@ type FileStorager interface {
 	DeleteCategoryImage(id string, filename string) error
 	DeleteCategoryImageById(id string) error
 	DeleteItemImagesFolderById(id string) error
+	PutFeed(filename string, file []byte) error
+	GetFeedPath(filename string) (string, error)
+	PutInvoice(orderId string, version string, file []byte) error
+	GetInvoicePath(orderId string, version string) (string, error)
 }
 
+// feedsDir is a folder for generated catalog feeds, it is not
+// listed in GetFileList because it doesn't contain images
+const feedsDir = "feeds"
+
 type FileInStorageInfo struct {
 	Name       string `json:"Name"`
 	Path       string `json:"Path"`
@@ -29,12 +37,15 @@ type FileInStorageInfo struct {
 type OnDiskLocalStorage struct {
 	serverURL string
 	path      string
-	logger    *zap.Logger
+	// invoicesPath is a folder for generated invoices of orders, it must be outside
+	// of path because files of path are served without authorization
+	invoicesPath string
+	logger       *zap.Logger
 }
 
-func NewOnDiskLocalStorage(url string, path string, logger *zap.Logger) *OnDiskLocalStorage {
-	logger.Sugar().Debugf("Enter in NewOnDiskLocalStorage() with args: url: %s, path: %s, logger", url, path)
-	d := OnDiskLocalStorage{serverURL: url, path: path, logger: logger}
+func NewOnDiskLocalStorage(url string, path string, invoicesPath string, logger *zap.Logger) *OnDiskLocalStorage {
+	logger.Sugar().Debugf("Enter in NewOnDiskLocalStorage() with args: url: %s, path: %s, invoicesPath: %s, logger", url, path, invoicesPath)
+	d := OnDiskLocalStorage{serverURL: url, path: path, invoicesPath: invoicesPath, logger: logger}
 	return &d
 }
 
@@ -130,6 +141,9 @@ func (imagestorage *OnDiskLocalStorage) GetFileList() ([]FileInStorageInfo, erro
 	imagestorage.logger.Debug("Enter in filestorage GetFileList()")
 	result := make([]FileInStorageInfo, 0)
 	err := filepath.Walk(imagestorage.path, func(path string, info fs.FileInfo, err error) error {
+		if info.IsDir() && path == filepath.Join(imagestorage.path, feedsDir) {
+			return filepath.SkipDir
+		}
 		if !info.IsDir() {
 			result = append(result, FileInStorageInfo{
 				Name:       info.Name(),
@@ -146,3 +160,94 @@ func (imagestorage *OnDiskLocalStorage) GetFileList() ([]FileInStorageInfo, erro
 	}
 	return result, nil
 }
+
+// PutFeed saves the feed file, the previous version of the file is replaced atomically
+// so the feed being served is never read half-written
+func (imagestorage *OnDiskLocalStorage) PutFeed(filename string, file []byte) error {
+	imagestorage.logger.Sugar().Debugf("Enter in filestorage PutFeed() with args: filename: %s, file", filename)
+	dir := filepath.Join(imagestorage.path, feedsDir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		imagestorage.logger.Debug(fmt.Sprintf("error on create dir for save feed %v", err))
+		return fmt.Errorf("error on create dir for save feed: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, filename+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error on create temp file for feed: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(file); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error on write feed: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error on close feed file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, filename)); err != nil {
+		imagestorage.logger.Debug(fmt.Sprintf("error on filestorage put feed: %v", err))
+		return fmt.Errorf("error on filestorage put feed: %w", err)
+	}
+	imagestorage.logger.Sugar().Infof("Put feed %s success", filename)
+	return nil
+}
+
+// GetFeedPath returns path to the feed file on disk or error if the feed is not generated yet
+func (imagestorage *OnDiskLocalStorage) GetFeedPath(filename string) (string, error) {
+	imagestorage.logger.Sugar().Debugf("Enter in filestorage GetFeedPath() with args: filename: %s", filename)
+	path := filepath.Join(imagestorage.path, feedsDir, filepath.Base(filename))
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("error on get feed %s: %w", filename, err)
+	}
+	return path, nil
+}
+
+// PutInvoice saves the invoice of the order as the file of the version and removes
+// the previous versions of the invoice. The file is replaced atomically as the feed is
+func (imagestorage *OnDiskLocalStorage) PutInvoice(orderId string, version string, file []byte) error {
+	imagestorage.logger.Sugar().Debugf("Enter in filestorage PutInvoice() with args: orderId: %s, version: %s, file", orderId, version)
+	dir := filepath.Join(imagestorage.invoicesPath, filepath.Base(orderId))
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		imagestorage.logger.Debug(fmt.Sprintf("error on create dir for save invoice %v", err))
+		return fmt.Errorf("error on create dir for save invoice: %w", err)
+	}
+	filename := filepath.Base(version) + ".pdf"
+	tmp, err := os.CreateTemp(dir, filename+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error on create temp file for invoice: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(file); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error on write invoice: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error on close invoice file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, filename)); err != nil {
+		imagestorage.logger.Debug(fmt.Sprintf("error on filestorage put invoice: %v", err))
+		return fmt.Errorf("error on filestorage put invoice: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("error on read invoices dir: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.Name() != filename && filepath.Ext(entry.Name()) == ".pdf" {
+			if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+				imagestorage.logger.Sugar().Warnf("can't remove old invoice %s: %v", entry.Name(), err)
+			}
+		}
+	}
+	imagestorage.logger.Sugar().Infof("Put invoice %s of order %s success", version, orderId)
+	return nil
+}
+
+// GetInvoicePath returns path to the invoice file of the version on disk
+// or error if the invoice of the version is not generated yet
+func (imagestorage *OnDiskLocalStorage) GetInvoicePath(orderId string, version string) (string, error) {
+	imagestorage.logger.Sugar().Debugf("Enter in filestorage GetInvoicePath() with args: orderId: %s, version: %s", orderId, version)
+	path := filepath.Join(imagestorage.invoicesPath, filepath.Base(orderId), filepath.Base(version)+".pdf")
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("error on get invoice %s of order %s: %w", version, orderId, err)
+	}
+	return path, nil
+}
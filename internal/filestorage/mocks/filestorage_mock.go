@@ -90,6 +90,21 @@ func (mr *MockFileStoragerMockRecorder) DeleteItemImagesFolderById(id interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItemImagesFolderById", reflect.TypeOf((*MockFileStorager)(nil).DeleteItemImagesFolderById), id)
 }
 
+// GetFeedPath mocks base method.
+func (m *MockFileStorager) GetFeedPath(filename string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeedPath", filename)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeedPath indicates an expected call of GetFeedPath.
+func (mr *MockFileStoragerMockRecorder) GetFeedPath(filename interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeedPath", reflect.TypeOf((*MockFileStorager)(nil).GetFeedPath), filename)
+}
+
 // GetFileList mocks base method.
 func (m *MockFileStorager) GetFileList() ([]filestorage.FileInStorageInfo, error) {
 	m.ctrl.T.Helper()
@@ -105,6 +120,21 @@ func (mr *MockFileStoragerMockRecorder) GetFileList() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileList", reflect.TypeOf((*MockFileStorager)(nil).GetFileList))
 }
 
+// GetInvoicePath mocks base method.
+func (m *MockFileStorager) GetInvoicePath(orderId, version string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoicePath", orderId, version)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoicePath indicates an expected call of GetInvoicePath.
+func (mr *MockFileStoragerMockRecorder) GetInvoicePath(orderId, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoicePath", reflect.TypeOf((*MockFileStorager)(nil).GetInvoicePath), orderId, version)
+}
+
 // PutCategoryImage mocks base method.
 func (m *MockFileStorager) PutCategoryImage(id, filename string, file []byte) (string, error) {
 	m.ctrl.T.Helper()
@@ -120,6 +150,34 @@ func (mr *MockFileStoragerMockRecorder) PutCategoryImage(id, filename, file inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutCategoryImage", reflect.TypeOf((*MockFileStorager)(nil).PutCategoryImage), id, filename, file)
 }
 
+// PutFeed mocks base method.
+func (m *MockFileStorager) PutFeed(filename string, file []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutFeed", filename, file)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutFeed indicates an expected call of PutFeed.
+func (mr *MockFileStoragerMockRecorder) PutFeed(filename, file interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutFeed", reflect.TypeOf((*MockFileStorager)(nil).PutFeed), filename, file)
+}
+
+// PutInvoice mocks base method.
+func (m *MockFileStorager) PutInvoice(orderId, version string, file []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutInvoice", orderId, version, file)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutInvoice indicates an expected call of PutInvoice.
+func (mr *MockFileStoragerMockRecorder) PutInvoice(orderId, version, file interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutInvoice", reflect.TypeOf((*MockFileStorager)(nil).PutInvoice), orderId, version, file)
+}
+
 // PutItemImage mocks base method.
 func (m *MockFileStorager) PutItemImage(id, filename string, file []byte) (string, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,198 @@
+// Package invoice renders invoices of orders to PDF without external services
+package invoice
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Shop is the details of the seller printed on invoices
+type Shop struct {
+	Name    string
+	Company string
+	Address string
+	TaxId   string
+	Email   string
+	SiteURL string
+}
+
+// Line is the line of the invoice with the price of the item at the time of the order
+type Line struct {
+	Title    string
+	Quantity int
+	Price    int64
+	Amount   int64
+}
+
+// Invoice is the data printed on the invoice of the order. Amounts are in units
+// of the currency, the tax is in hundredths of the unit
+type Invoice struct {
+	Number   string
+	IssuedAt time.Time
+	OrderId  string
+	Status   string
+	Shop     Shop
+	Customer string
+	Address  string
+	Lines    []Line
+	Subtotal int64
+	Discount int64
+	Shipping int64
+	Total    int64
+	// TaxRate is the percent of VAT included in prices
+	TaxRate  int64
+	Tax      int64
+	Currency string
+}
+
+// IncludedTax returns VAT included in the amount at the rate in percent, in hundredths of the unit
+func IncludedTax(amount int64, rate int64) int64 {
+	if rate <= 0 {
+		return 0
+	}
+	cents := amount * 100 * rate
+	return (cents + (100+rate)/2) / (100 + rate)
+}
+
+// Fingerprint returns the short hash of the data of the invoice, the invoice
+// with the same fingerprint is rendered to the same file
+func (invoice Invoice) Fingerprint() string {
+	data, _ := json.Marshal(invoice)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// Layout of the page
+const (
+	marginLeft   = 40
+	marginRight  = pageWidth - 40
+	marginBottom = 80
+	lineHeight   = 14
+	titleLength  = 60
+)
+
+// Render returns the invoice in PDF. Lines which don't fit on the page go to the next pages
+func Render(invoice Invoice) []byte {
+	doc := &document{}
+	doc.addPage()
+	y := float64(pageHeight - 60)
+
+	doc.text(fontBold, 16, marginLeft, y, invoice.Shop.Name)
+	doc.textRight(fontBold, 16, marginRight, y, "INVOICE")
+	y -= 18
+	details := []string{invoice.Shop.Company, invoice.Shop.Address}
+	if invoice.Shop.TaxId != "" {
+		details = append(details, "Tax ID: "+invoice.Shop.TaxId)
+	}
+	details = append(details, invoice.Shop.Email, invoice.Shop.SiteURL)
+	right := []string{
+		"No. " + invoice.Number,
+		"Date: " + invoice.IssuedAt.Format("2006-01-02"),
+		"Order: " + invoice.OrderId,
+		"Status: " + invoice.Status,
+	}
+	top := y
+	for _, detail := range details {
+		if detail == "" {
+			continue
+		}
+		doc.text(fontRegular, 9, marginLeft, y, detail)
+		y -= 12
+	}
+	for i, detail := range right {
+		doc.textRight(fontRegular, 9, marginRight, top-float64(12*i), detail)
+	}
+	if bottom := top - float64(12*len(right)); bottom < y {
+		y = bottom
+	}
+
+	y -= 16
+	doc.text(fontBold, 10, marginLeft, y, "Bill to")
+	y -= 13
+	doc.text(fontRegular, 9, marginLeft, y, invoice.Customer)
+	y -= 12
+	doc.text(fontRegular, 9, marginLeft, y, invoice.Address)
+
+	y -= 28
+	header := func() {
+		doc.text(fontBold, 9, marginLeft, y, "#")
+		doc.text(fontBold, 9, marginLeft+25, y, "Item")
+		doc.textRight(fontBold, 9, 380, y, "Qty")
+		doc.textRight(fontBold, 9, 465, y, "Price")
+		doc.textRight(fontBold, 9, marginRight, y, "Amount")
+		y -= 5
+		doc.line(marginLeft, y, marginRight, y)
+		y -= lineHeight
+	}
+	header()
+	for i, line := range invoice.Lines {
+		if y < marginBottom {
+			doc.addPage()
+			y = float64(pageHeight - 60)
+			header()
+		}
+		doc.text(fontRegular, 9, marginLeft, y, strconv.Itoa(i+1))
+		doc.text(fontRegular, 9, marginLeft+25, y, truncate(line.Title, titleLength))
+		doc.textRight(fontRegular, 9, 380, y, strconv.Itoa(line.Quantity))
+		doc.textRight(fontRegular, 9, 465, y, formatAmount(line.Price*100))
+		doc.textRight(fontRegular, 9, marginRight, y, formatAmount(line.Amount*100))
+		y -= lineHeight
+	}
+	y += lineHeight - 5
+	doc.line(marginLeft, y, marginRight, y)
+	y -= lineHeight + 4
+
+	if y < marginBottom+5*lineHeight {
+		doc.addPage()
+		y = float64(pageHeight - 60)
+	}
+	totals := [][2]string{
+		{"Subtotal", formatAmount(invoice.Subtotal * 100)},
+		{"Discount", formatAmount(-invoice.Discount * 100)},
+		{"Shipping", formatAmount(invoice.Shipping * 100)},
+	}
+	for _, total := range totals {
+		doc.textRight(fontRegular, 9, 465, y, total[0])
+		doc.textRight(fontRegular, 9, marginRight, y, total[1])
+		y -= lineHeight
+	}
+	doc.textRight(fontBold, 10, 465, y, "Total, "+invoice.Currency)
+	doc.textRight(fontBold, 10, marginRight, y, formatAmount(invoice.Total*100))
+	y -= lineHeight
+	if invoice.TaxRate > 0 {
+		doc.textRight(fontRegular, 9, 465, y, fmt.Sprintf("Including VAT %d%%", invoice.TaxRate))
+		doc.textRight(fontRegular, 9, marginRight, y, formatAmount(invoice.Tax))
+	}
+	return doc.bytes()
+}
+
+// formatAmount formats the amount in hundredths of the unit with spaces between thousands
+func formatAmount(cents int64) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	units := strconv.FormatInt(cents/100, 10)
+	var groups []string
+	for len(units) > 3 {
+		groups = append([]string{units[len(units)-3:]}, groups...)
+		units = units[:len(units)-3]
+	}
+	groups = append([]string{units}, groups...)
+	return fmt.Sprintf("%s%s.%02d", sign, strings.Join(groups, " "), cents%100)
+}
+
+// truncate cuts the text to the length in characters
+func truncate(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+	return string(runes[:length-3]) + "..."
+}
@@ -0,0 +1,78 @@
+package invoice
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testInvoice = Invoice{
+	Number:   "INV-000001",
+	IssuedAt: time.Date(2023, 1, 15, 10, 0, 0, 0, time.UTC),
+	OrderId:  "0b74b0ac-68aa-462b-8609-4bf5eac3f9f7",
+	Status:   "order paid",
+	Shop:     Shop{Name: "Shop", Company: "Company", Address: "Moscow", TaxId: "7700000000", Email: "shop@mail.ru"},
+	Customer: "Иван Петров (ivan@mail.ru)",
+	Address:  "123456, Russia, Moscow, Tverskaya (1)",
+	Lines:    []Line{{Title: "Смартфон", Quantity: 2, Price: 12000, Amount: 24000}},
+	Subtotal: 24000,
+	Discount: 1200,
+	Shipping: 0,
+	Total:    22800,
+	TaxRate:  20,
+	Tax:      IncludedTax(22800, 20),
+	Currency: "RUB",
+}
+
+func TestRender(t *testing.T) {
+	pdf := Render(testInvoice)
+	require.True(t, bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")))
+	require.True(t, bytes.HasSuffix(pdf, []byte("%%EOF\n")))
+	for _, text := range []string{"(No. INV-000001)", "(Ivan Petrov \\(ivan@mail.ru\\))", "(Smartfon)", "(24 000.00)", "(-1 200.00)",
+		"(22 800.00)", "(Including VAT 20%)", "(3 800.00)", "Tverskaya \\(1\\)"} {
+		require.Contains(t, string(pdf), text)
+	}
+
+	// The cross-reference table points to the objects
+	xref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+	require.NotNil(t, xref)
+	offset, err := strconv.Atoi(string(xref[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(pdf[offset:], []byte("xref\n0 7\n")))
+	require.True(t, bytes.HasPrefix(pdf[bytes.Index(pdf, []byte("5 0 obj")):], []byte("5 0 obj\n<< /Type /Page ")))
+}
+
+func TestRenderPages(t *testing.T) {
+	invoice := testInvoice
+	invoice.Lines = nil
+	for i := 0; i < 100; i++ {
+		invoice.Lines = append(invoice.Lines, Line{Title: fmt.Sprintf("Item %d", i), Quantity: 1, Price: 100, Amount: 100})
+	}
+	pdf := Render(invoice)
+	require.Contains(t, string(pdf), "/Count 3")
+	require.Contains(t, string(pdf), "(Item 99)")
+}
+
+func TestFingerprint(t *testing.T) {
+	invoice := testInvoice
+	require.Equal(t, testInvoice.Fingerprint(), invoice.Fingerprint())
+	invoice.Status = "order canceled"
+	require.NotEqual(t, testInvoice.Fingerprint(), invoice.Fingerprint())
+}
+
+func TestHelpers(t *testing.T) {
+	require.Equal(t, "0.00", formatAmount(0))
+	require.Equal(t, "999.99", formatAmount(99999))
+	require.Equal(t, "1 234 567.05", formatAmount(123456705))
+	require.Equal(t, "-1 000.00", formatAmount(-100000))
+	require.Equal(t, int64(16667), IncludedTax(1000, 20))
+	require.Equal(t, int64(0), IncludedTax(1000, 0))
+	require.Equal(t, "Ezh i shchuka No.1", encode("Ёж и щука №1"))
+	require.Equal(t, "caf\xe9 \x96 ?", encode("café – 漢"))
+	require.Equal(t, "abc...", truncate("abcdefgh", 6))
+}
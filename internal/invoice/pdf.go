@@ -0,0 +1,144 @@
+package invoice
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Size of the A4 page in points
+const (
+	pageWidth  = 595
+	pageHeight = 842
+)
+
+// Names of the fonts in the resources of pages
+const (
+	fontRegular = "F1"
+	fontBold    = "F2"
+)
+
+// document is a minimal PDF writer with standard Helvetica fonts. The standard fonts have
+// WinAnsi encoding only, so Cyrillic text is transliterated and other characters are replaced with '?'
+type document struct {
+	pages []*bytes.Buffer
+}
+
+// page is the content stream of the current page
+func (doc *document) page() *bytes.Buffer {
+	return doc.pages[len(doc.pages)-1]
+}
+
+// addPage starts the new page
+func (doc *document) addPage() {
+	doc.pages = append(doc.pages, &bytes.Buffer{})
+}
+
+// text writes the text with the left bottom corner at x, y
+func (doc *document) text(font string, size float64, x, y float64, text string) {
+	fmt.Fprintf(doc.page(), "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escape(encode(text)))
+}
+
+// textRight writes the text with the right bottom corner at x, y
+func (doc *document) textRight(font string, size float64, x, y float64, text string) {
+	doc.text(font, size, x-textWidth(text, size), y, text)
+}
+
+// line draws the line from x1, y1 to x2, y2
+func (doc *document) line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(doc.page(), "0.5 w %.2f %.2f m %.2f %.2f l S\n", x1, y1, x2, y2)
+}
+
+// bytes returns the PDF file with all pages
+func (doc *document) bytes() []byte {
+	var out bytes.Buffer
+	offsets := make([]int, 0)
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+	out.WriteString("%PDF-1.4\n")
+	// The catalog, the page tree and the fonts go first, so the pages follow from object 5
+	kids := make([]string, len(doc.pages))
+	for i := range doc.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(doc.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, content := range doc.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, fontRegular, fontBold, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// escape escapes the special characters of the PDF string
+func escape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`, "\r", " ", "\n", " ").Replace(text)
+}
+
+// cyrillic is the transliteration of Cyrillic letters which are missing in WinAnsi encoding
+var cyrillic = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z", 'и': "i",
+	'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t",
+	'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "",
+	'э': "e", 'ю': "yu", 'я': "ya",
+}
+
+// winAnsi is the characters of WinAnsi encoding which differ from Latin-1
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// encode converts the text to WinAnsi encoding
+func encode(text string) string {
+	var out strings.Builder
+	for _, r := range text {
+		switch {
+		case r < 0x80 || r >= 0xA0 && r <= 0xFF:
+			out.WriteByte(byte(r))
+		case winAnsi[r] != 0:
+			out.WriteByte(winAnsi[r])
+		case r == '№':
+			out.WriteString("No.")
+		default:
+			lower := []rune(strings.ToLower(string(r)))[0]
+			latin, ok := cyrillic[lower]
+			if !ok {
+				out.WriteByte('?')
+				continue
+			}
+			if lower != r && latin != "" {
+				latin = strings.ToUpper(latin[:1]) + latin[1:]
+			}
+			out.WriteString(latin)
+		}
+	}
+	return out.String()
+}
+
+// widths is the widths of characters of Helvetica used in numbers, in thousandths of the font size
+var widths = map[byte]float64{' ': 278, ',': 278, '.': 278, '-': 333, '%': 889}
+
+// textWidth returns the approximate width of the text in Helvetica: digits and characters
+// of numbers are measured exactly, other characters are taken as wide as digits
+func textWidth(text string, size float64) float64 {
+	var width float64
+	for _, c := range []byte(encode(text)) {
+		if w, ok := widths[c]; ok {
+			width += w
+			continue
+		}
+		width += 556
+	}
+	return width * size / 1000
+}
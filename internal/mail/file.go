@@ -0,0 +1,106 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	_ Sender = (*FileSender)(nil)
+	_ Sender = (*LogSender)(nil)
+	_ Sender = (*MemorySender)(nil)
+)
+
+// FileSender writes emails to .eml files in the folder instead of sending them,
+// it is used for local development
+type FileSender struct {
+	dir    string
+	from   string
+	logger *zap.Logger
+}
+
+func NewFileSender(dir string, from string, logger *zap.Logger) *FileSender {
+	logger.Sugar().Debugf("Enter in NewFileSender() with args: dir: %s, from: %s", dir, from)
+	return &FileSender{dir: dir, from: from, logger: logger}
+}
+
+// Send writes the message to the new file named by the time of sending
+func (sender *FileSender) Send(ctx context.Context, message Message) error {
+	sender.logger.Sugar().Debugf("Enter in mail FileSender Send() with args: ctx, message to: %s", message.To)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	now := time.Now()
+	data, err := message.Bytes(sender.from, now)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(sender.dir, 0700); err != nil {
+		return fmt.Errorf("can't create dir for emails: %w", err)
+	}
+	name := now.Format("20060102150405") + "-" + uuid.NewString() + ".eml"
+	if err := os.WriteFile(filepath.Join(sender.dir, name), data, 0600); err != nil {
+		return fmt.Errorf("can't write email to file: %w", err)
+	}
+	sender.logger.Sugar().Infof("Email %q to %s written to %s", message.Subject, message.To, name)
+	return nil
+}
+
+// LogSender writes emails to the log instead of sending them
+type LogSender struct {
+	logger *zap.Logger
+}
+
+func NewLogSender(logger *zap.Logger) *LogSender {
+	logger.Debug("Enter in NewLogSender()")
+	return &LogSender{logger: logger}
+}
+
+// Send writes the subject and the text of the message to the log
+func (sender *LogSender) Send(ctx context.Context, message Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	sender.logger.Sugar().Infof("Email to: %s, subject: %q, text: %s", message.To, message.Subject, strings.ReplaceAll(message.Text, "\n", " "))
+	return nil
+}
+
+// MemorySender keeps emails in memory instead of sending them,
+// it is used for tests and local development
+type MemorySender struct {
+	mu       sync.Mutex
+	messages []Message
+	logger   *zap.Logger
+}
+
+func NewMemorySender(logger *zap.Logger) *MemorySender {
+	logger.Debug("Enter in NewMemorySender()")
+	return &MemorySender{logger: logger}
+}
+
+// Send appends the message to the sent messages
+func (sender *MemorySender) Send(ctx context.Context, message Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	sender.mu.Lock()
+	defer sender.mu.Unlock()
+	sender.messages = append(sender.messages, message)
+	sender.logger.Sugar().Infof("Email %q to %s kept in memory", message.Subject, message.To)
+	return nil
+}
+
+// Messages returns the sent messages in the order of sending
+func (sender *MemorySender) Messages() []Message {
+	sender.mu.Lock()
+	defer sender.mu.Unlock()
+	return append([]Message{}, sender.messages...)
+}
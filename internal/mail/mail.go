@@ -0,0 +1,186 @@
+// Package mail sends emails to users through SMTP or, for local development,
+// writes them to files or to the log
+package mail
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// Names of the templates of emails
+const (
+	CartReminderTemplate = "cart_reminder"
+	RegistrationTemplate = "registration"
+	OrderPlacedTemplate  = "order_placed"
+	OrderStatusTemplate  = "order_status"
+	OrderShippedTemplate = "order_shipped"
+)
+
+// DefaultLanguage is the language of emails to users who have not chosen the language,
+// it is also used for templates which are not translated to the chosen language
+const DefaultLanguage = "en"
+
+// languages is the languages to which templates are translated
+var languages = []string{"en", "ru"}
+
+//go:embed templates
+var templates embed.FS
+
+// Message is an email with the plain text and the HTML versions of the body
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// CartReminderData is the data of the template of the reminder about the abandoned cart
+type CartReminderData struct {
+	Name     string
+	ShopName string
+	Currency string
+	CartURL  string
+	Items    []CartReminderItem
+}
+
+// CartReminderItem is the line of the abandoned cart in the reminder
+type CartReminderItem struct {
+	Title    string
+	Quantity int
+	Price    int32
+}
+
+// AccountData is the data of the template of the email about the account of the user
+type AccountData struct {
+	Name     string
+	ShopName string
+	SiteURL  string
+}
+
+// OrderData is the data of templates of emails about the order
+type OrderData struct {
+	Name           string
+	ShopName       string
+	Currency       string
+	OrderId        string
+	OrderURL       string
+	Status         string
+	Address        string
+	ShippingMethod string
+	Items          []OrderItem
+	Subtotal       int64
+	Discount       int64
+	Shipping       int64
+	Total          int64
+}
+
+// OrderItem is the line of the order in the email
+type OrderItem struct {
+	Title    string
+	Quantity int
+	Price    int32
+}
+
+// Sender sends emails
+type Sender interface {
+	Send(ctx context.Context, message Message) error
+}
+
+// Languages returns the languages to which templates of emails are translated
+func Languages() []string {
+	return append([]string{}, languages...)
+}
+
+// ValidLanguage reports whether templates are translated to the language
+func ValidLanguage(language string) bool {
+	for _, valid := range languages {
+		if language == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// NewMessage builds the message from the template with the name in the default language
+func NewMessage(to string, name string, data interface{}) (Message, error) {
+	return NewLocalizedMessage(to, name, DefaultLanguage, data)
+}
+
+// NewLocalizedMessage builds the message from the template with the name translated to the language,
+// the template in the default language is used if it is not translated. The text template
+// defines blocks "subject" and "body", the HTML template is the body of the email
+func NewLocalizedMessage(to string, name string, language string, data interface{}) (Message, error) {
+	dir := "templates/" + language + "/"
+	if _, err := fs.Stat(templates, dir+name+".txt"); err != nil {
+		dir = "templates/" + DefaultLanguage + "/"
+	}
+	textTemplate, err := texttemplate.ParseFS(templates, dir+name+".txt")
+	if err != nil {
+		return Message{}, fmt.Errorf("can't parse text template %s: %w", name, err)
+	}
+	htmlTemplate, err := htmltemplate.ParseFS(templates, dir+name+".html")
+	if err != nil {
+		return Message{}, fmt.Errorf("can't parse html template %s: %w", name, err)
+	}
+	var subject, text, html bytes.Buffer
+	if err := textTemplate.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Message{}, fmt.Errorf("can't execute subject of template %s: %w", name, err)
+	}
+	if err := textTemplate.ExecuteTemplate(&text, "body", data); err != nil {
+		return Message{}, fmt.Errorf("can't execute text of template %s: %w", name, err)
+	}
+	if err := htmlTemplate.Execute(&html, data); err != nil {
+		return Message{}, fmt.Errorf("can't execute html of template %s: %w", name, err)
+	}
+	return Message{
+		To:      to,
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(text.String()),
+		HTML:    strings.TrimSpace(html.String()),
+	}, nil
+}
+
+// Bytes returns the message in the MIME format with both versions of the body
+func (message Message) Bytes(from string, date time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", message.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", message.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", writer.Boundary())
+	parts := []struct {
+		contentType string
+		body        string
+	}{
+		{contentType: "text/plain; charset=UTF-8", body: message.Text},
+		{contentType: "text/html; charset=UTF-8", body: message.HTML},
+	}
+	for _, part := range parts {
+		if part.body == "" {
+			continue
+		}
+		w, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {part.contentType}})
+		if err != nil {
+			return nil, fmt.Errorf("can't create part of message: %w", err)
+		}
+		if _, err := w.Write([]byte(part.body)); err != nil {
+			return nil, fmt.Errorf("can't write part of message: %w", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("can't close message: %w", err)
+	}
+	return buf.Bytes(), nil
+}
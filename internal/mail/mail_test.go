@@ -0,0 +1,145 @@
+package mail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var testReminderData = CartReminderData{
+	Name:     "Ivan",
+	ShopName: "Shop",
+	Currency: "RUB",
+	CartURL:  "http://localhost:3000/cart",
+	Items: []CartReminderItem{
+		{Title: "smartphone <samsung>", Quantity: 2, Price: 10000},
+	},
+}
+
+func TestNewMessage(t *testing.T) {
+	message, err := NewMessage("user@mail.ru", CartReminderTemplate, testReminderData)
+	require.NoError(t, err)
+	require.Equal(t, "user@mail.ru", message.To)
+	require.Equal(t, "Shop: you left items in your cart", message.Subject)
+	require.Contains(t, message.Text, "Hello, Ivan!")
+	require.Contains(t, message.Text, "smartphone <samsung> x 2: 10000 RUB")
+	require.Contains(t, message.Text, "http://localhost:3000/cart")
+	// The HTML version escapes the data
+	require.Contains(t, message.HTML, "smartphone &lt;samsung&gt;")
+	require.Contains(t, message.HTML, `href="http://localhost:3000/cart"`)
+
+	_, err = NewMessage("user@mail.ru", "unknown", testReminderData)
+	require.Error(t, err)
+}
+
+func TestMessageBytes(t *testing.T) {
+	message := Message{
+		To:      "user@mail.ru",
+		Subject: "Корзина",
+		Text:    "text",
+		HTML:    "<p>html</p>",
+	}
+	data, err := message.Bytes("shop@mail.ru", time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC))
+	require.NoError(t, err)
+	res := string(data)
+	require.Contains(t, res, "From: shop@mail.ru\r\n")
+	require.Contains(t, res, "To: user@mail.ru\r\n")
+	require.Contains(t, res, "Subject: =?UTF-8?b?")
+	require.Contains(t, res, "Date: Mon, 02 Jan 2023 03:04:05 +0000\r\n")
+	require.Contains(t, res, "multipart/alternative")
+	require.Contains(t, res, "text/plain; charset=UTF-8")
+	require.Contains(t, res, "<p>html</p>")
+
+	// The empty version of the body is not added
+	message.HTML = ""
+	data, err = message.Bytes("shop@mail.ru", time.Now())
+	require.NoError(t, err)
+	require.False(t, strings.Contains(string(data), "text/html"))
+}
+
+func TestFileSender(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	sender := NewFileSender(dir, "shop@mail.ru", zap.L())
+	message := Message{To: "user@mail.ru", Subject: "subject", Text: "text"}
+	err := sender.Send(context.Background(), message)
+	require.NoError(t, err)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.True(t, strings.HasSuffix(files[0].Name(), ".eml"))
+	data, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	require.NoError(t, err)
+	require.Contains(t, string(data), "Subject: subject\r\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = sender.Send(ctx, message)
+	require.Error(t, err)
+}
+
+func TestNewLocalizedMessage(t *testing.T) {
+	data := OrderData{
+		Name:     "Ivan",
+		ShopName: "Shop",
+		Currency: "RUB",
+		OrderId:  "1",
+		OrderURL: "http://localhost:3000/orders/1",
+		Status:   "order paid",
+	}
+	message, err := NewLocalizedMessage("user@mail.ru", OrderStatusTemplate, "ru", data)
+	require.NoError(t, err)
+	require.Equal(t, "Shop: заказ 1 оплачен", message.Subject)
+	require.Contains(t, message.Text, "Здравствуйте, Ivan!")
+	require.Contains(t, message.HTML, "изменен: оплачен.")
+	require.True(t, strings.HasPrefix(message.HTML, "<!DOCTYPE html>"))
+
+	message, err = NewLocalizedMessage("user@mail.ru", OrderStatusTemplate, "en", data)
+	require.NoError(t, err)
+	require.Equal(t, "Shop: order 1 is order paid", message.Subject)
+
+	// The template in the default language is used for the unknown language and the template which is not translated
+	for _, language := range []string{"", "de", "../en"} {
+		message, err = NewLocalizedMessage("user@mail.ru", OrderStatusTemplate, language, data)
+		require.NoError(t, err)
+		require.Contains(t, message.Text, "Hello, Ivan!", language)
+	}
+	message, err = NewLocalizedMessage("user@mail.ru", CartReminderTemplate, "ru", testReminderData)
+	require.NoError(t, err)
+	require.Equal(t, "Shop: you left items in your cart", message.Subject)
+
+	// All templates are translated to all languages
+	for _, language := range Languages() {
+		for name, data := range map[string]interface{}{
+			RegistrationTemplate: AccountData{Name: "Ivan", ShopName: "Shop", SiteURL: "http://localhost:3000"},
+			OrderPlacedTemplate:  data,
+			OrderStatusTemplate:  data,
+			OrderShippedTemplate: data,
+		} {
+			message, err := NewLocalizedMessage("user@mail.ru", name, language, data)
+			require.NoError(t, err, name)
+			require.NotEmpty(t, message.Subject, name)
+			require.NotEmpty(t, message.HTML, name)
+		}
+	}
+	require.True(t, ValidLanguage("ru"))
+	require.False(t, ValidLanguage("de"))
+}
+
+func TestMemorySender(t *testing.T) {
+	sender := NewMemorySender(zap.L())
+	message := Message{To: "user@mail.ru", Subject: "subject", Text: "text"}
+	require.NoError(t, sender.Send(context.Background(), message))
+	require.Equal(t, []Message{message}, sender.Messages())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.Error(t, sender.Send(ctx, message))
+	require.Len(t, sender.Messages(), 1)
+}
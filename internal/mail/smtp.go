@@ -0,0 +1,56 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var _ Sender = (*SMTPSender)(nil)
+
+// SMTPSender sends emails through the SMTP server
+type SMTPSender struct {
+	addr   string
+	host   string
+	auth   smtp.Auth
+	from   string
+	logger *zap.Logger
+}
+
+// NewSMTPSender returns the sender through the SMTP server, authentication
+// is not used if the username is empty
+func NewSMTPSender(host string, port string, username string, password string, from string, logger *zap.Logger) *SMTPSender {
+	logger.Sugar().Debugf("Enter in NewSMTPSender() with args: host: %s, port: %s, username: %s, from: %s", host, port, username, from)
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPSender{
+		addr:   net.JoinHostPort(host, port),
+		host:   host,
+		auth:   auth,
+		from:   from,
+		logger: logger,
+	}
+}
+
+// Send sends the message to the SMTP server
+func (sender *SMTPSender) Send(ctx context.Context, message Message) error {
+	sender.logger.Sugar().Debugf("Enter in mail SMTPSender Send() with args: ctx, message to: %s", message.To)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	data, err := message.Bytes(sender.from, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := smtp.SendMail(sender.addr, sender.auth, sender.from, []string{message.To}, data); err != nil {
+		return fmt.Errorf("can't send email to %s: %w", message.To, err)
+	}
+	sender.logger.Sugar().Infof("Email %q sent to %s", message.Subject, message.To)
+	return nil
+}
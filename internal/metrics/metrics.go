@@ -37,6 +37,128 @@ var ItemsMetrics = struct {
 	}),
 }
 
+var CartsMetrics = struct {
+	CartsPurgedTotal   prometheus.Counter
+	CleanupErrorsTotal prometheus.Counter
+	CartsActive        prometheus.Gauge
+	CartsExpired       prometheus.Gauge
+}{
+	CartsPurgedTotal: promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "shop",
+		Name:      "carts_purged_total",
+		Help:      "number of expired carts deleted by the cleanup worker",
+	}),
+	CleanupErrorsTotal: promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "shop",
+		Name:      "carts_cleanup_errors_total",
+		Help:      "number of failed runs of the cart cleanup worker",
+	}),
+	CartsActive: promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "shop",
+		Name:      "carts_active",
+		Help:      "number of not expired carts",
+	}),
+	CartsExpired: promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "shop",
+		Name:      "carts_expired",
+		Help:      "number of expired carts waiting for the cleanup worker",
+	}),
+}
+
+var CartRemindersMetrics = struct {
+	RemindersSentTotal    prometheus.Counter
+	RemindersRetriedTotal prometheus.Counter
+	RemindersFailedTotal  prometheus.Counter
+}{
+	RemindersSentTotal: promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "shop",
+		Name:      "cart_reminders_sent_total",
+		Help:      "number of reminders about abandoned carts sent to users",
+	}),
+	RemindersRetriedTotal: promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "shop",
+		Name:      "cart_reminders_retried_total",
+		Help:      "number of failed attempts to send reminders about abandoned carts which are retried later",
+	}),
+	RemindersFailedTotal: promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "shop",
+		Name:      "cart_reminders_failed_total",
+		Help:      "number of reminders about abandoned carts which failed to be sent after the last attempt",
+	}),
+}
+
+var NotificationsMetrics = struct {
+	NotificationsSentTotal    prometheus.Counter
+	NotificationsRetriedTotal prometheus.Counter
+	NotificationsFailedTotal  prometheus.Counter
+}{
+	NotificationsSentTotal: promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "shop",
+		Name:      "notifications_sent_total",
+		Help:      "number of emails about accounts and orders sent to users",
+	}),
+	NotificationsRetriedTotal: promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "shop",
+		Name:      "notifications_retried_total",
+		Help:      "number of failed attempts to send emails about accounts and orders which are retried later",
+	}),
+	NotificationsFailedTotal: promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "shop",
+		Name:      "notifications_failed_total",
+		Help:      "number of emails about accounts and orders which failed to be sent after the last attempt",
+	}),
+}
+
+var PaymentsMetrics = struct {
+	PaymentsSucceededTotal    prometheus.Counter
+	PaymentsFailedTotal       prometheus.Counter
+	RefundsTotal              prometheus.Counter
+	UnpaidOrdersCanceledTotal prometheus.Counter
+}{
+	PaymentsSucceededTotal: promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "shop",
+		Name:      "payments_succeeded_total",
+		Help:      "number of succeeded payments for orders",
+	}),
+	PaymentsFailedTotal: promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "shop",
+		Name:      "payments_failed_total",
+		Help:      "number of failed payments for orders",
+	}),
+	RefundsTotal: promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "shop",
+		Name:      "payment_refunds_total",
+		Help:      "number of refunds of payments for orders",
+	}),
+	UnpaidOrdersCanceledTotal: promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "shop",
+		Name:      "unpaid_orders_canceled_total",
+		Help:      "number of orders canceled because they were not paid in time",
+	}),
+}
+
+var ShipmentsMetrics = struct {
+	ShipmentsCreatedTotal   prometheus.Counter
+	ShipmentsDeliveredTotal prometheus.Counter
+	TrackingErrorsTotal     prometheus.Counter
+}{
+	ShipmentsCreatedTotal: promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "shop",
+		Name:      "shipments_created_total",
+		Help:      "number of orders handed over to the carrier",
+	}),
+	ShipmentsDeliveredTotal: promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "shop",
+		Name:      "shipments_delivered_total",
+		Help:      "number of shipments delivered by the carrier",
+	}),
+	TrackingErrorsTotal: promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "shop",
+		Name:      "shipment_tracking_errors_total",
+		Help:      "number of failed requests of tracking events from the carrier",
+	}),
+}
+
 func init() { // 2
 	DeliveryMetrics.FinishDeliveryTotal.Inc()
 	DeliveryMetrics.NewDeliveryTotal.Inc()
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SavedAddress is the named address in the address book of the user. The user has at most
+// one default address for shipping, the first saved address is the default
+type SavedAddress struct {
+	Id              uuid.UUID
+	UserId          uuid.UUID
+	Name            string
+	Address         UserAddress
+	DefaultShipping bool
+	CreatedAt       time.Time
+}
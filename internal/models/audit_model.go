@@ -0,0 +1,112 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Actions recorded in the audit log
+const (
+	AuditItemUpdate        = "item.update"
+	AuditUserRoleUpdate    = "user.role_update"
+	AuditOrderStatusChange = "order.status_change"
+	AuditCategoryDelete    = "category.delete"
+	AuditCategoryMerge     = "category.merge"
+)
+
+// Entities recorded in the audit log
+const (
+	AuditEntityItem     = "item"
+	AuditEntityUser     = "user"
+	AuditEntityOrder    = "order"
+	AuditEntityCategory = "category"
+)
+
+// Actor is the user who makes the change
+type Actor struct {
+	UserId uuid.UUID
+	Email  string
+	Role   string
+}
+
+// AuditEntry is a record of the audit log about one change.
+// Before and After contain only the changed fields
+type AuditEntry struct {
+	Id        uuid.UUID
+	Actor     Actor
+	Action    string
+	Entity    string
+	EntityId  string
+	Before    map[string]interface{}
+	After     map[string]interface{}
+	CreatedAt time.Time
+}
+
+// AuditFilter is the parameters of search in the audit log,
+// zero values of fields mean no filter
+type AuditFilter struct {
+	ActorId  uuid.UUID
+	Action   string
+	Entity   string
+	EntityId string
+	From     time.Time
+	To       time.Time
+	Offset   int
+	Limit    int
+}
+
+type actorKey struct{}
+
+// ContextWithActor returns the copy of ctx which carries actor
+func ContextWithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor set by ContextWithActor
+func ActorFromContext(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorKey{}).(Actor)
+	return actor, ok
+}
+
+// CanAccess reports whether the actor may access the resource of the user with ownerId.
+// Admins may access resources of any user
+func (actor Actor) CanAccess(ownerId uuid.UUID) bool {
+	if actor.Role == Admin {
+		return true
+	}
+	return actor.UserId != uuid.Nil && actor.UserId == ownerId
+}
+
+// NewAuditEntry returns the audit entry with the actor from ctx and with
+// the fields of before and after which have different values
+func NewAuditEntry(ctx context.Context, action, entity, entityId string, before, after map[string]interface{}) *AuditEntry {
+	actor, _ := ActorFromContext(ctx)
+	entry := &AuditEntry{
+		Actor:    actor,
+		Action:   action,
+		Entity:   entity,
+		EntityId: entityId,
+		Before:   make(map[string]interface{}),
+		After:    make(map[string]interface{}),
+	}
+	for field, afterValue := range after {
+		beforeValue := before[field]
+		// Values are compared in the form they are stored in the audit log
+		beforeJson, _ := json.Marshal(beforeValue)
+		afterJson, _ := json.Marshal(afterValue)
+		if string(beforeJson) == string(afterJson) {
+			continue
+		}
+		entry.Before[field] = beforeValue
+		entry.After[field] = afterValue
+	}
+	return entry
+}
+
+// Empty reports whether the entry has no changes
+func (entry *AuditEntry) Empty() bool {
+	return len(entry.After) == 0
+}
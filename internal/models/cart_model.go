@@ -10,14 +10,139 @@
 package models
 
 import (
+	"context"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type Cart struct {
-	Id       uuid.UUID
-	UserId   uuid.UUID
-	Items    []ItemWithQuantity
-	ExpireAt time.Time
+	Id     uuid.UUID
+	UserId uuid.UUID
+	Items  []ItemWithQuantity
+	// SavedItems is the lines saved for later, they are not included in the totals and the order
+	SavedItems []ItemWithQuantity
+	ExpireAt   time.Time
+	// Totals is calculated from the current prices of the items
+	Totals CartTotals
+}
+
+type guestCartKey struct{}
+
+// ContextWithGuestCart returns the copy of ctx which carries id of the guest cart from the signed cart token of the caller
+func ContextWithGuestCart(ctx context.Context, cartId uuid.UUID) context.Context {
+	return context.WithValue(ctx, guestCartKey{}, cartId)
+}
+
+// GuestCartFromContext returns id of the guest cart set by ContextWithGuestCart
+func GuestCartFromContext(ctx context.Context) (uuid.UUID, bool) {
+	cartId, ok := ctx.Value(guestCartKey{}).(uuid.UUID)
+	return cartId, ok
+}
+
+// CartLine is a quantity of one item in the cart
+type CartLine struct {
+	ItemId   uuid.UUID
+	Quantity int
+	// Saved is set for the line saved for later
+	Saved bool
+}
+
+type CartNoticeType string
+
+const (
+	CartNoticePriceChanged CartNoticeType = "price_changed"
+	CartNoticeDeleted      CartNoticeType = "deleted"
+	CartNoticeUnavailable  CartNoticeType = "unavailable"
+)
+
+// CartNotice tells the user about the change of the item since it was added to the cart
+type CartNotice struct {
+	ItemId   uuid.UUID
+	Title    string
+	Type     CartNoticeType
+	OldPrice int32
+	NewPrice int32
+	// Available is the quantity of the item in stock for unavailable items
+	Available int
+}
+
+// CartPricing is the rules of the discount and the shipping estimate of the cart
+type CartPricing struct {
+	// DiscountPercent of the subtotal is given if the subtotal is at least DiscountThreshold
+	DiscountThreshold int64
+	DiscountPercent   int64
+	// ShippingCost is free if the subtotal after discount is at least FreeShippingThreshold,
+	// zero threshold means that shipping is never free
+	ShippingCost          int64
+	FreeShippingThreshold int64
+}
+
+// CartTotals is the sums of the cart. Deleted and unavailable items are not included in the sums
+type CartTotals struct {
+	// LineTotals is the sums of lines of the cart by item id
+	LineTotals map[uuid.UUID]int64
+	Subtotal   int64
+	Discount   int64
+	Shipping   int64
+	Total      int64
+	Notices    []CartNotice
+}
+
+// CalculateTotals returns the sums of the cart at the current prices of the items
+// and the notices about items changed since they were added to the cart
+func (cart Cart) CalculateTotals(pricing CartPricing) CartTotals {
+	totals := CartTotals{
+		LineTotals: make(map[uuid.UUID]int64, len(cart.Items)),
+		Notices:    make([]CartNotice, 0),
+	}
+	for _, item := range cart.Items {
+		if item.Deleted {
+			totals.Notices = append(totals.Notices, CartNotice{
+				ItemId:   item.Id,
+				Title:    item.Title,
+				Type:     CartNoticeDeleted,
+				OldPrice: item.AddedPrice,
+			})
+			continue
+		}
+		if item.AddedPrice != item.Price {
+			totals.Notices = append(totals.Notices, CartNotice{
+				ItemId:   item.Id,
+				Title:    item.Title,
+				Type:     CartNoticePriceChanged,
+				OldPrice: item.AddedPrice,
+				NewPrice: item.Price,
+			})
+		}
+		if item.Stock != nil && *item.Stock < item.Quantity {
+			totals.Notices = append(totals.Notices, CartNotice{
+				ItemId:    item.Id,
+				Title:     item.Title,
+				Type:      CartNoticeUnavailable,
+				NewPrice:  item.Price,
+				Available: *item.Stock,
+			})
+			continue
+		}
+		lineTotal := int64(item.Price) * int64(item.Quantity)
+		totals.LineTotals[item.Id] = lineTotal
+		totals.Subtotal += lineTotal
+	}
+	sort.SliceStable(totals.Notices, func(i, j int) bool {
+		return totals.Notices[i].ItemId.String() < totals.Notices[j].ItemId.String()
+	})
+	if totals.Subtotal == 0 {
+		return totals
+	}
+	if pricing.DiscountPercent > 0 && totals.Subtotal >= pricing.DiscountThreshold {
+		totals.Discount = totals.Subtotal * pricing.DiscountPercent / 100
+	}
+	totals.Shipping = pricing.ShippingCost
+	if pricing.FreeShippingThreshold > 0 && totals.Subtotal-totals.Discount >= pricing.FreeShippingThreshold {
+		totals.Shipping = 0
+	}
+	totals.Total = totals.Subtotal - totals.Discount + totals.Shipping
+	return totals
 }
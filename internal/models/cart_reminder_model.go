@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AbandonedCart is the cart of the user with items which was not changed for a long time
+type AbandonedCart struct {
+	CartId uuid.UUID
+	UserId uuid.UUID
+	Email  string
+	Name   string
+	// UpdatedAt is the time of the last change of the cart
+	UpdatedAt time.Time
+	// Attempts is the number of failed attempts to send the reminder since the last change
+	Attempts int
+	Items    []ItemWithQuantity
+}
+
+// CartReminderAttempt is the record about failed attempts to send the reminder about the cart
+// since its last change. The reminder is retried after NextAttemptAt until it is Failed
+type CartReminderAttempt struct {
+	CartId        uuid.UUID
+	CartUpdatedAt time.Time
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	Failed        bool
+}
+
+// CartReminder is the record about the reminder sent to the user about the abandoned cart
+type CartReminder struct {
+	Id     uuid.UUID
+	CartId uuid.UUID
+	UserId uuid.UUID
+	Email  string
+	SentAt time.Time
+}
@@ -8,3 +8,7 @@ type Category struct {
 	Description string
 	Image       string
 }
+
+// UncategorizedName is the name of the system category for items
+// from deleted categories, this category can't be deleted
+const UncategorizedName = "NoCategory"
@@ -7,3 +7,115 @@ type ErrorNotFound struct {
 func (e ErrorNotFound) Error() string {
 	return ""
 }
+
+type ErrorForbidden struct {
+
+}
+
+func (e ErrorForbidden) Error() string {
+	return "forbidden"
+}
+
+type ErrorAlreadyExists struct {
+
+}
+
+func (e ErrorAlreadyExists) Error() string {
+	return "already exists"
+}
+
+type ErrorNotEmpty struct {
+
+}
+
+func (e ErrorNotEmpty) Error() string {
+	return "not empty"
+}
+
+type ErrorQuantityExceeded struct {
+
+}
+
+func (e ErrorQuantityExceeded) Error() string {
+	return "quantity exceeded"
+}
+
+type ErrorEmptyCart struct {
+
+}
+
+func (e ErrorEmptyCart) Error() string {
+	return "empty cart"
+}
+
+type ErrorIdempotencyKeyReused struct {
+
+}
+
+func (e ErrorIdempotencyKeyReused) Error() string {
+	return "idempotency key is already used with another request"
+}
+
+type ErrorRequestInProgress struct {
+
+}
+
+func (e ErrorRequestInProgress) Error() string {
+	return "request with the same idempotency key is in progress"
+}
+
+type ErrorWrongStatus struct {
+
+}
+
+func (e ErrorWrongStatus) Error() string {
+	return "wrong status"
+}
+
+type ErrorAmountExceeded struct {
+
+}
+
+func (e ErrorAmountExceeded) Error() string {
+	return "amount exceeded"
+}
+
+type ErrorShippingUnavailable struct {
+
+}
+
+func (e ErrorShippingUnavailable) Error() string {
+	return "shipping method is unavailable"
+}
+
+type ErrorSystemCategory struct {
+
+}
+
+func (e ErrorSystemCategory) Error() string {
+	return "system category can't be deleted"
+}
+
+type ErrorNotInCart struct {
+
+}
+
+func (e ErrorNotInCart) Error() string {
+	return "item is not in cart"
+}
+
+type ErrorAddressNotSet struct {
+
+}
+
+func (e ErrorAddressNotSet) Error() string {
+	return "address is not set and user has no default shipping address"
+}
+
+type ErrorUnknownSeller struct {
+
+}
+
+func (e ErrorUnknownSeller) Error() string {
+	return "seller of item is not a user"
+}
@@ -0,0 +1,17 @@
+package models
+
+import "github.com/google/uuid"
+
+// IdempotentRequest is the request made by the user with the idempotency key
+// and the response to it, which is returned again on retries of the request
+type IdempotentRequest struct {
+	UserId uuid.UUID `json:"userId"`
+	Key    string    `json:"key"`
+	// RequestHash is the hash of the method, the path and the body of the request
+	RequestHash string `json:"requestHash"`
+	// Done is false while the first request with the key is handled
+	Done        bool   `json:"done"`
+	StatusCode  int    `json:"statusCode,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Invoice is the invoice of the order. The number is given to the order once
+// and invoices of the shop are numbered sequentially without gaps
+type Invoice struct {
+	OrderId  uuid.UUID
+	Number   int64
+	IssuedAt time.Time
+}
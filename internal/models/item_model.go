@@ -9,7 +9,15 @@
 
 package models
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// cartAddWeight is how many views one adding of the item to cart is worth
+// when the popularity of the item is calculated
+const cartAddWeight = 5
 
 type Item struct {
 	Id          uuid.UUID
@@ -19,9 +27,41 @@ type Item struct {
 	Category    Category
 	Vendor      string
 	Images      []string
+	// AnsweredQuestions is a quantity of questions about the item
+	// which have at least one answer
+	AnsweredQuestions int
+	CreatedAt         time.Time
+	Views             int64
+	CartAdds          int64
+	// Stock is a quantity of the item in stock, nil means
+	// that the stock of the item is not tracked
+	Stock *int
+	// Weight is the weight of the item in grams used to calculate the shipping cost
+	Weight int32
+	// SellerId is the user who sells the item and answers questions about it,
+	// uuid.Nil means that the item is sold by the shop
+	SellerId uuid.UUID
+	// OfferId is the numeric id of the item in the feeds which don't take the UUID
+	OfferId int64
+}
+
+// Popularity returns the rating of the item used for sorting by popularity
+func (item Item) Popularity() int64 {
+	return item.Views + cartAddWeight*item.CartAdds
+}
+
+// ItemStats is the increments of the counters of actions with the item
+type ItemStats struct {
+	ItemId   uuid.UUID
+	Views    int64
+	CartAdds int64
 }
 
 type ItemWithQuantity struct {
 	Item
 	Quantity int
+	// AddedPrice is the price of the item at the time it was added to the cart
+	AddedPrice int32
+	// Deleted is true if the item was deleted from the catalog after it was added to the cart
+	Deleted bool
 }
\ No newline at end of file
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationKind is the event the email to the user is sent about
+type NotificationKind string
+
+const (
+	NotificationRegistration NotificationKind = "registration"
+	NotificationOrderPlaced  NotificationKind = "order_placed"
+	NotificationOrderStatus  NotificationKind = "order_status"
+	NotificationOrderShipped NotificationKind = "order_shipped"
+)
+
+// NotificationState is the state of sending of the email
+type NotificationState string
+
+const (
+	NotificationPending NotificationState = "pending"
+	NotificationSent    NotificationState = "sent"
+	// NotificationSkipped is the state of the email turned off by the user
+	// or about the order which no longer exists
+	NotificationSkipped NotificationState = "skipped"
+	// NotificationFailed is the state of the email which was not sent after the last attempt
+	NotificationFailed NotificationState = "failed"
+)
+
+// Notification is the email to the user about the event queued to be sent
+type Notification struct {
+	Id     uuid.UUID
+	Kind   NotificationKind
+	UserId uuid.UUID
+	// OrderId is the order of emails about orders and Status is the status of the order at the event
+	OrderId       uuid.UUID
+	Status        Status
+	State         NotificationState
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	SentAt        time.Time
+	// Email, Name and Preferences of the user are read with the notification
+	Email       string
+	Name        string
+	Preferences NotificationPreferences
+}
+
+// NotificationPreferences is the choice of the user of emails and of their language,
+// the empty language is the default language of emails
+type NotificationPreferences struct {
+	UserId       uuid.UUID
+	Language     string
+	OrderPlaced  bool
+	OrderStatus  bool
+	OrderShipped bool
+}
+
+// DefaultNotificationPreferences returns the preferences of the user who has not changed them,
+// all emails are sent
+func DefaultNotificationPreferences(userId uuid.UUID) NotificationPreferences {
+	return NotificationPreferences{
+		UserId:       userId,
+		OrderPlaced:  true,
+		OrderStatus:  true,
+		OrderShipped: true,
+	}
+}
+
+// Allows reports whether the user gets emails of the kind, emails about the account are always sent
+func (preferences NotificationPreferences) Allows(kind NotificationKind) bool {
+	switch kind {
+	case NotificationOrderPlaced:
+		return preferences.OrderPlaced
+	case NotificationOrderStatus:
+		return preferences.OrderStatus
+	case NotificationOrderShipped:
+		return preferences.OrderShipped
+	default:
+		return true
+	}
+}
@@ -10,6 +10,8 @@ type Status string
 
 const (
 	StatusCreated    Status = "order created"
+	StatusPaid       Status = "order paid"
+	StatusCanceled   Status = "order canceled"
 	StatusProcessing Status = "order processing"
 	StatusProcessed  Status = "order processed"
 	StatusReady      Status = "ready for shipment"
@@ -28,4 +30,172 @@ type Order struct {
 	Address      UserAddress
 	Status       Status
 	Items        []ItemWithQuantity
+	// CancelReason is the reason the order was canceled with
+	CancelReason string
+	// ShippingMethod is the code of the shipping method chosen at checkout, ShippingCost
+	// is the cost of the delivery and Discount is the discount calculated at checkout.
+	// Orders placed before shipping methods have no method and their shipping
+	// and discount are calculated by the pricing of the cart
+	ShippingMethod string
+	ShippingCost   int64
+	Discount       int64
+}
+
+// orderStatuses is all statuses of orders in the order of processing
+var orderStatuses = []Status{StatusCreated, StatusPaid, StatusProcessing, StatusProcessed, StatusReady, StatusCourier, StatusShipped, StatusCanceled}
+
+// OrderStatuses returns all statuses of orders in the order of processing
+func OrderStatuses() []Status {
+	return append([]Status{}, orderStatuses...)
+}
+
+// Valid reports whether the status is one of the statuses of orders
+func (status Status) Valid() bool {
+	for _, valid := range orderStatuses {
+		if valid == status {
+			return true
+		}
+	}
+	return false
+}
+
+// customerCancelable is the statuses in which the customer may cancel the order
+var customerCancelable = []Status{StatusCreated, StatusPaid, StatusProcessing}
+
+// adminCancelable is the statuses in which the admin may cancel the order, that is any status before shipping
+var adminCancelable = []Status{StatusCreated, StatusPaid, StatusProcessing, StatusProcessed, StatusReady}
+
+// CancelableStatuses returns the statuses in which the order may be canceled by the admin or by the customer
+func CancelableStatuses(admin bool) []Status {
+	if admin {
+		return adminCancelable
+	}
+	return customerCancelable
+}
+
+// CanBeCanceled reports whether the order in the status may be canceled by the admin or by the customer
+func (status Status) CanBeCanceled(admin bool) bool {
+	for _, cancelable := range CancelableStatuses(admin) {
+		if cancelable == status {
+			return true
+		}
+	}
+	return false
+}
+
+// AddressChangeable reports whether the address of the order in the status may be changed,
+// that is the order is not shipped yet
+func (status Status) AddressChangeable() bool {
+	for _, changeable := range adminCancelable {
+		if changeable == status {
+			return true
+		}
+	}
+	return false
+}
+
+// manualTransitions is the statuses to which the admin moves the order by hand from the status.
+// The order is paid by the payment, canceled by the cancel and picked by the courier by the shipment,
+// the delivery is reported by the carrier or confirmed by the admin
+var manualTransitions = map[Status][]Status{
+	StatusPaid:       {StatusProcessing},
+	StatusProcessing: {StatusProcessed},
+	StatusProcessed:  {StatusReady},
+	StatusCourier:    {StatusShipped},
+}
+
+// CanChangeTo reports whether the admin may move the order from the status to the next one by hand
+func (status Status) CanChangeTo(next Status) bool {
+	for _, allowed := range manualTransitions[status] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Totals returns the sums of the order at the prices of the items saved with the order.
+// The shipping and the discount of the order with the shipping method are calculated at checkout
+func (order Order) Totals(pricing CartPricing) CartTotals {
+	if order.ShippingMethod == "" {
+		return Cart{Items: order.Items}.CalculateTotals(pricing)
+	}
+	totals := Cart{Items: order.Items}.CalculateTotals(CartPricing{ShippingCost: order.ShippingCost})
+	if totals.Subtotal == 0 {
+		return totals
+	}
+	totals.Discount = order.Discount
+	totals.Total = totals.Subtotal - totals.Discount + totals.Shipping
+	return totals
+}
+
+// Sort types of the list of orders
+const (
+	OrderSortCreatedAt = "created_at"
+	OrderSortTotal     = "total"
+	OrderSortStatus    = "status"
+	OrderSortEmail     = "email"
+)
+
+// OrderFilter is the parameters of search of orders by the admin,
+// zero values of fields mean no filter
+type OrderFilter struct {
+	Status Status
+	// From and To is the period of creation of orders, To is not included
+	From time.Time
+	To   time.Time
+	// Email is the part of the email of the customer
+	Email string
+	// City is the city of the address of the order, case insensitive
+	City string
+	// MinTotal and MaxTotal is the range of the totals of orders, nil means no bound
+	MinTotal *int64
+	MaxTotal *int64
+	// ItemId is the item which the order contains
+	ItemId uuid.UUID
+	// SortType is one of the sort types of orders, orders are sorted by the time of creation if it is empty
+	SortType string
+	SortDesc bool
+	Offset   int
+	Limit    int
+}
+
+// OrderSummary is the order in the list of orders with its total calculated by the pricing
+type OrderSummary struct {
+	ID             uuid.UUID
+	CreatedAt      time.Time
+	ShipmentTime   time.Time
+	User           User
+	Status         Status
+	ShippingMethod string
+	// ItemsQuantity is the quantity of all items of the order
+	ItemsQuantity int
+	Total         int64
+}
+
+// OrderList is the page of the list of orders with the quantity of orders satisfying
+// the filter and the quantities of such orders by status, regardless of the status filter
+type OrderList struct {
+	Orders       []OrderSummary
+	Quantity     int
+	StatusCounts map[Status]int
+}
+
+// OrderExportLine is the line of the order in the export of orders for accounting
+// with the totals of its order calculated by the pricing
+type OrderExportLine struct {
+	OrderId        uuid.UUID
+	CreatedAt      time.Time
+	Status         Status
+	User           User
+	Address        UserAddress
+	ShippingMethod string
+	Item           Item
+	// Price is the price of the item at the time of the order
+	Price    int32
+	Quantity int
+	Subtotal int64
+	Discount int64
+	Shipping int64
+	Total    int64
 }
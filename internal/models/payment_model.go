@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PaymentStatus string
+
+const (
+	// PaymentPending is the payment waiting for the confirmation of the customer
+	PaymentPending   PaymentStatus = "pending"
+	PaymentSucceeded PaymentStatus = "succeeded"
+	PaymentFailed    PaymentStatus = "failed"
+	// PaymentCanceled is the pending payment of the order canceled before it was paid
+	PaymentCanceled PaymentStatus = "canceled"
+	// PaymentRefunded is the payment refunded in full, partial refunds keep the status
+	PaymentRefunded PaymentStatus = "refunded"
+)
+
+// Payment is the payment for the order in the payment provider
+type Payment struct {
+	Id      uuid.UUID
+	OrderId uuid.UUID
+	// Provider is the name of the payment provider
+	Provider string
+	// ExternalId is the id of the payment in the provider
+	ExternalId   string
+	ClientSecret string
+	// Amount and RefundedAmount are in minor units of the currency
+	Amount         int64
+	RefundedAmount int64
+	Currency       string
+	Status         PaymentStatus
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
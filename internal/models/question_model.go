@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Question is a pre-sale question asked by a customer on the item page
+type Question struct {
+	Id        uuid.UUID
+	ItemId    uuid.UUID
+	UserId    uuid.UUID
+	Text      string
+	CreatedAt time.Time
+	Answers   []Answer
+}
+
+// Answer is an answer to the question given by an admin or by the seller of the item
+type Answer struct {
+	Id         uuid.UUID
+	QuestionId uuid.UUID
+	UserId     uuid.UUID
+	Text       string
+	Votes      int
+	CreatedAt  time.Time
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReturnStatus string
+
+const (
+	ReturnRequested ReturnStatus = "requested"
+	ReturnApproved  ReturnStatus = "approved"
+	ReturnRejected  ReturnStatus = "rejected"
+	// ReturnReceived is the return which goods came back to the shop and were restocked
+	ReturnReceived ReturnStatus = "received"
+	ReturnRefunded ReturnStatus = "refunded"
+)
+
+// returnTransitions is the statuses the return may move to from each status
+var returnTransitions = map[ReturnStatus][]ReturnStatus{
+	ReturnRequested: {ReturnApproved, ReturnRejected},
+	ReturnApproved:  {ReturnReceived, ReturnRejected},
+	ReturnReceived:  {ReturnRefunded},
+}
+
+// CanChangeTo reports whether the return in the status may move to the next status
+func (status ReturnStatus) CanChangeTo(next ReturnStatus) bool {
+	for _, allowed := range returnTransitions[status] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Return is the request of the customer to return lines of the delivered order
+type Return struct {
+	Id      uuid.UUID
+	OrderId uuid.UUID
+	UserId  uuid.UUID
+	Reason  string
+	Status  ReturnStatus
+	// TrackingNumber is the number of the parcel with returned goods sent by the customer
+	TrackingNumber string
+	// RefundAmount is the amount refunded for the return
+	RefundAmount int64
+	Items        []ReturnItem
+	History      []ReturnStatusChange
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// ReturnItem is the returned line of the order with the price of the item
+// at the time the return was requested
+type ReturnItem struct {
+	ItemId   uuid.UUID
+	Title    string
+	Price    int32
+	Quantity int
+}
+
+// ReturnStatusChange is the record of the status history of the return
+type ReturnStatusChange struct {
+	Status    ReturnStatus
+	Comment   string
+	ActorId   uuid.UUID
+	CreatedAt time.Time
+}
+
+// Total returns the sum of the returned lines
+func (ret Return) Total() int64 {
+	var total int64
+	for _, item := range ret.Items {
+		total += int64(item.Price) * int64(item.Quantity)
+	}
+	return total
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Shipment is the order handed over to the carrier with the tracking number given by the carrier
+type Shipment struct {
+	Id             uuid.UUID
+	OrderId        uuid.UUID
+	Carrier        string
+	TrackingNumber string
+	CreatedAt      time.Time
+	// DeliveredAt is zero until the carrier reports the delivery,
+	// CheckedAt is the time of the last request of tracking events, zero if they were not requested
+	DeliveredAt time.Time
+	CheckedAt   time.Time
+	// Events is the tracking events of the shipment, the oldest events go first
+	Events []TrackingEvent
+}
+
+// TrackingEvent is the step of the delivery of the shipment reported by the carrier
+type TrackingEvent struct {
+	Status      string
+	Description string
+	Location    string
+	Time        time.Time
+}
+
+// shippableStatuses is the statuses in which the order may be handed over to the carrier
+var shippableStatuses = []Status{StatusProcessed, StatusReady}
+
+// ShippableStatuses returns the statuses in which the order may be handed over to the carrier
+func ShippableStatuses() []Status {
+	return append([]Status{}, shippableStatuses...)
+}
@@ -14,4 +14,8 @@ type UserAddress struct {
 	Country string `json:"country,omitempty"`
 	City    string `json:"city,omitempty"`
 	Street  string `json:"street,omitempty"`
+	// Apartment, Recipient and Phone are optional details of the delivery
+	Apartment string `json:"apartment,omitempty"`
+	Recipient string `json:"recipient,omitempty"`
+	Phone     string `json:"phone,omitempty"`
 }
@@ -0,0 +1,138 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// SignatureHeader is the header with the signature of the request to the webhook of the mock provider
+const SignatureHeader = "X-Payment-Signature"
+
+var _ Provider = (*MockProvider)(nil)
+
+// MockProvider keeps payments in memory and charges nobody. Events are sent to the webhook
+// by hand or by tests with the body signed by Sign with the secret of the provider
+type MockProvider struct {
+	secret []byte
+	mu     sync.Mutex
+	// intents is the amounts of payments by id, the captured and refunded amounts are tracked
+	// to check the calls as the real provider does
+	intents map[string]*mockIntent
+	logger  *zap.Logger
+}
+
+type mockIntent struct {
+	amount   int64
+	captured bool
+	refunded int64
+}
+
+// mockEvent is the body of the request to the webhook
+type mockEvent struct {
+	Type     EventType `json:"type"`
+	IntentId string    `json:"intentId"`
+	Amount   int64     `json:"amount"`
+}
+
+func NewMockProvider(secret string, logger *zap.Logger) *MockProvider {
+	logger.Debug("Enter in NewMockProvider()")
+	return &MockProvider{
+		secret:  []byte(secret),
+		intents: make(map[string]*mockIntent),
+		logger:  logger,
+	}
+}
+
+func (provider *MockProvider) Name() string {
+	return "mock"
+}
+
+// CreateIntent creates the payment in memory
+func (provider *MockProvider) CreateIntent(ctx context.Context, amount int64, currency string, orderId string) (*Intent, error) {
+	provider.logger.Sugar().Debugf("Enter in payment MockProvider CreateIntent() with args: ctx, amount: %d, currency: %s, orderId: %s", amount, currency, orderId)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if amount <= 0 {
+		return nil, fmt.Errorf("amount of payment must be positive")
+	}
+	id := "mock_" + uuid.NewString()
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+	provider.intents[id] = &mockIntent{amount: amount}
+	return &Intent{Id: id, ClientSecret: id + "_secret"}, nil
+}
+
+// Capture marks the payment as charged
+func (provider *MockProvider) Capture(ctx context.Context, intentId string) error {
+	provider.logger.Sugar().Debugf("Enter in payment MockProvider Capture() with args: ctx, intentId: %s", intentId)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+	intent, ok := provider.intents[intentId]
+	if !ok {
+		return fmt.Errorf("payment %s not found", intentId)
+	}
+	intent.captured = true
+	return nil
+}
+
+// Refund returns the part of the charged amount
+func (provider *MockProvider) Refund(ctx context.Context, intentId string, amount int64) error {
+	provider.logger.Sugar().Debugf("Enter in payment MockProvider Refund() with args: ctx, intentId: %s, amount: %d", intentId, amount)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+	intent, ok := provider.intents[intentId]
+	if !ok {
+		return fmt.Errorf("payment %s not found", intentId)
+	}
+	if amount <= 0 || intent.refunded+amount > intent.amount {
+		return fmt.Errorf("refund of %d exceeds amount of payment %s", amount, intentId)
+	}
+	intent.refunded += amount
+	return nil
+}
+
+// Sign returns the signature of the body of the request to the webhook,
+// it is the hex encoded HMAC-SHA256 of the body with the secret
+func (provider *MockProvider) Sign(body []byte) string {
+	return hex.EncodeToString(provider.mac(body))
+}
+
+func (provider *MockProvider) mac(body []byte) []byte {
+	mac := hmac.New(sha256.New, provider.secret)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// ParseWebhook checks the signature in the header X-Payment-Signature and returns
+// the event from the JSON body like {"type": "payment.succeeded", "intentId": "mock_...", "amount": 100}
+func (provider *MockProvider) ParseWebhook(header http.Header, body []byte) (*Event, error) {
+	provider.logger.Debug("Enter in payment MockProvider ParseWebhook()")
+	signature, err := hex.DecodeString(header.Get(SignatureHeader))
+	if err != nil || !hmac.Equal(signature, provider.mac(body)) {
+		return nil, ErrInvalidSignature
+	}
+	event := mockEvent{}
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidEvent, err)
+	}
+	if event.IntentId == "" {
+		return nil, fmt.Errorf("%w: empty id of payment", ErrInvalidEvent)
+	}
+	return &Event{Type: event.Type, IntentId: event.IntentId, Amount: event.Amount}, nil
+}
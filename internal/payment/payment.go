@@ -0,0 +1,58 @@
+// Package payment takes payments for orders through a payment provider. For local
+// development and tests the mock provider is used, which does not charge anybody
+package payment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Types of events sent by the provider to the webhook
+const (
+	// EventAuthorized means that the customer confirmed the payment
+	// and the amount is held until it is captured
+	EventAuthorized EventType = "payment.authorized"
+	// EventSucceeded means that the amount of the payment is charged
+	EventSucceeded EventType = "payment.succeeded"
+	EventFailed    EventType = "payment.failed"
+	EventRefunded  EventType = "payment.refunded"
+)
+
+var (
+	// ErrInvalidSignature is returned if the signature of the webhook is not valid
+	ErrInvalidSignature = errors.New("invalid signature of webhook")
+	// ErrInvalidEvent is returned if the body of the webhook can't be parsed
+	ErrInvalidEvent = errors.New("invalid event of webhook")
+)
+
+type EventType string
+
+// Intent is the payment created by the provider which is confirmed by the customer
+type Intent struct {
+	// Id is the id of the payment in the provider
+	Id string
+	// ClientSecret is passed to the client to confirm the payment
+	ClientSecret string
+}
+
+// Event is the notification from the provider about the change of the payment
+type Event struct {
+	Type     EventType
+	IntentId string
+	Amount   int64
+}
+
+// Provider is the payment service which charges the customer
+type Provider interface {
+	// Name returns the name of the provider saved with payments
+	Name() string
+	// CreateIntent creates the payment of the amount in minor units of the currency
+	CreateIntent(ctx context.Context, amount int64, currency string, orderId string) (*Intent, error)
+	// Capture charges the amount held by the authorized payment
+	Capture(ctx context.Context, intentId string) error
+	// Refund returns the amount of the charged payment to the customer
+	Refund(ctx context.Context, intentId string, amount int64) error
+	// ParseWebhook checks the signature of the request to the webhook and returns the event
+	ParseWebhook(header http.Header, body []byte) (*Event, error)
+}
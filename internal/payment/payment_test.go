@@ -0,0 +1,54 @@
+package payment
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMockProvider(t *testing.T) {
+	provider := NewMockProvider("secret", zap.L())
+	ctx := context.Background()
+
+	_, err := provider.CreateIntent(ctx, 0, "RUB", "order")
+	require.Error(t, err)
+
+	intent, err := provider.CreateIntent(ctx, 1000, "RUB", "order")
+	require.NoError(t, err)
+	require.NotEmpty(t, intent.Id)
+	require.NotEmpty(t, intent.ClientSecret)
+
+	require.Error(t, provider.Capture(ctx, "unknown"))
+	require.NoError(t, provider.Capture(ctx, intent.Id))
+
+	require.NoError(t, provider.Refund(ctx, intent.Id, 600))
+	require.Error(t, provider.Refund(ctx, intent.Id, 500))
+	require.NoError(t, provider.Refund(ctx, intent.Id, 400))
+}
+
+func TestMockProviderParseWebhook(t *testing.T) {
+	provider := NewMockProvider("secret", zap.L())
+	body := []byte(`{"type": "payment.succeeded", "intentId": "mock_1", "amount": 1000}`)
+
+	header := make(http.Header)
+	_, err := provider.ParseWebhook(header, body)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+
+	// The signature with another secret is not valid
+	header.Set(SignatureHeader, NewMockProvider("other", zap.L()).Sign(body))
+	_, err = provider.ParseWebhook(header, body)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+
+	header.Set(SignatureHeader, provider.Sign(body))
+	event, err := provider.ParseWebhook(header, body)
+	require.NoError(t, err)
+	require.Equal(t, &Event{Type: EventSucceeded, IntentId: "mock_1", Amount: 1000}, event)
+
+	body = []byte(`{"type": "payment.succeeded"}`)
+	header.Set(SignatureHeader, provider.Sign(body))
+	_, err = provider.ParseWebhook(header, body)
+	require.ErrorIs(t, err, ErrInvalidEvent)
+}
@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+type address struct {
+	storage *PGres
+	logger  *zap.SugaredLogger
+}
+
+var _ AddressStore = (*address)(nil)
+
+func NewAddressRepo(storage *PGres, logger *zap.SugaredLogger) AddressStore {
+	return &address{
+		storage: storage,
+		logger:  logger,
+	}
+}
+
+// addressError converts errors of constraints of the address book: the duplicated name
+// of the address returns ErrorAlreadyExists and the unknown user returns ErrorNotFound
+func addressError(err error) error {
+	switch {
+	case strings.Contains(err.Error(), "user_addresses_name_key"):
+		return models.ErrorAlreadyExists{}
+	case strings.Contains(err.Error(), "fk_user_id"):
+		return models.ErrorNotFound{}
+	default:
+		return err
+	}
+}
+
+// clearDefaults unsets the default of other addresses of the user if it is set for the address
+func (a *address) clearDefaults(ctx context.Context, tx Querier, address *models.SavedAddress) error {
+	if !address.DefaultShipping {
+		return nil
+	}
+	_, err := tx.Exec(ctx, `UPDATE user_addresses SET default_shipping = false WHERE user_id = $1 AND id <> $2`,
+		address.UserId, address.Id)
+	if err != nil {
+		a.logger.Errorf("can't clear default addresses: %s", err)
+		return fmt.Errorf("can't clear default addresses: %w", err)
+	}
+	return nil
+}
+
+// CreateAddress saves the address to the address book of the user. The first address of the user
+// becomes the default for shipping, setting the default unsets it for other addresses
+func (a *address) CreateAddress(ctx context.Context, address *models.SavedAddress) (id uuid.UUID, err error) {
+	a.logger.Debugf("Enter in repository CreateAddress() with args: ctx, address: %v", address)
+	select {
+	case <-ctx.Done():
+		return uuid.Nil, fmt.Errorf("context closed")
+	default:
+	}
+	tx, err := a.storage.BeginTx(ctx)
+	if err != nil {
+		a.logger.Errorf("can't create transaction: %s", err)
+		return uuid.Nil, fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			a.logger.Errorf("transaction rolled back")
+			if err := tx.Rollback(ctx); err != nil {
+				a.logger.Errorf("can't rollback %s", err)
+			}
+			return
+		}
+		if err = tx.Commit(ctx); err != nil {
+			a.logger.Errorf("can't commit %s", err)
+			err = fmt.Errorf("can't commit transaction: %w", err)
+		}
+	}()
+	if err = a.clearDefaults(ctx, tx, address); err != nil {
+		return uuid.Nil, err
+	}
+	err = tx.QueryRow(ctx, `INSERT INTO user_addresses (user_id, name, zipcode, country, city, street, apartment, recipient, phone,
+	default_shipping)
+	SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10 OR NOT first.found
+	FROM (SELECT EXISTS (SELECT 1 FROM user_addresses WHERE user_id = $1) AS found) AS first
+	RETURNING id, default_shipping, created_at`,
+		address.UserId, address.Name, address.Address.Zipcode, address.Address.Country, address.Address.City, address.Address.Street,
+		address.Address.Apartment, address.Address.Recipient, address.Address.Phone, address.DefaultShipping,
+	).Scan(&address.Id, &address.DefaultShipping, &address.CreatedAt)
+	if err != nil {
+		a.logger.Errorf("can't create address: %s", err)
+		err = addressError(err)
+		return uuid.Nil, fmt.Errorf("can't create address: %w", err)
+	}
+	return address.Id, nil
+}
+
+const addressColumns = `id, user_id, name, zipcode, country, city, street, apartment, recipient, phone,
+	default_shipping, created_at`
+
+func scanAddress(row pgx.Row, address *models.SavedAddress) error {
+	return row.Scan(&address.Id, &address.UserId, &address.Name, &address.Address.Zipcode, &address.Address.Country,
+		&address.Address.City, &address.Address.Street, &address.Address.Apartment, &address.Address.Recipient,
+		&address.Address.Phone, &address.DefaultShipping, &address.CreatedAt)
+}
+
+// GetAddress returns the saved address by id
+func (a *address) GetAddress(ctx context.Context, id uuid.UUID) (*models.SavedAddress, error) {
+	a.logger.Debugf("Enter in repository GetAddress() with args: ctx, id: %v", id)
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("context closed")
+	default:
+	}
+	result := models.SavedAddress{}
+	err := scanAddress(a.storage.GetQuerier(ctx).QueryRow(ctx, `SELECT `+addressColumns+` FROM user_addresses WHERE id = $1`, id), &result)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		a.logger.Errorf("address with id: %v not found", id)
+		return nil, models.ErrorNotFound{}
+	}
+	if err != nil {
+		a.logger.Errorf("can't get address: %s", err)
+		return nil, fmt.Errorf("can't get address: %w", err)
+	}
+	return &result, nil
+}
+
+// GetAddresses returns the address book of the user, the default addresses go first
+func (a *address) GetAddresses(ctx context.Context, userId uuid.UUID) ([]models.SavedAddress, error) {
+	a.logger.Debugf("Enter in repository GetAddresses() with args: ctx, userId: %v", userId)
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("context closed")
+	default:
+	}
+	rows, err := a.storage.GetQuerier(ctx).Query(ctx, `SELECT `+addressColumns+` FROM user_addresses WHERE user_id = $1
+	ORDER BY default_shipping DESC, created_at, name`, userId)
+	if err != nil {
+		a.logger.Errorf("can't get addresses: %s", err)
+		return nil, fmt.Errorf("can't get addresses: %w", err)
+	}
+	defer rows.Close()
+	addresses := make([]models.SavedAddress, 0)
+	for rows.Next() {
+		var address models.SavedAddress
+		if err := scanAddress(rows, &address); err != nil {
+			a.logger.Errorf("can't scan address: %s", err)
+			return nil, fmt.Errorf("can't scan address: %w", err)
+		}
+		addresses = append(addresses, address)
+	}
+	if err := rows.Err(); err != nil {
+		a.logger.Errorf("can't get addresses: %s", err)
+		return nil, fmt.Errorf("can't get addresses: %w", err)
+	}
+	return addresses, nil
+}
+
+// UpdateAddress changes the name, the address and the defaults of the saved address,
+// setting the default unsets it for other addresses of the user
+func (a *address) UpdateAddress(ctx context.Context, address *models.SavedAddress) (err error) {
+	a.logger.Debugf("Enter in repository UpdateAddress() with args: ctx, address: %v", address)
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context closed")
+	default:
+	}
+	tx, err := a.storage.BeginTx(ctx)
+	if err != nil {
+		a.logger.Errorf("can't create transaction: %s", err)
+		return fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			a.logger.Errorf("transaction rolled back")
+			if err := tx.Rollback(ctx); err != nil {
+				a.logger.Errorf("can't rollback %s", err)
+			}
+			return
+		}
+		if err = tx.Commit(ctx); err != nil {
+			a.logger.Errorf("can't commit %s", err)
+			err = fmt.Errorf("can't commit transaction: %w", err)
+		}
+	}()
+	if err = a.clearDefaults(ctx, tx, address); err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx, `UPDATE user_addresses SET name = $1, zipcode = $2, country = $3, city = $4, street = $5,
+	apartment = $6, recipient = $7, phone = $8, default_shipping = $9 WHERE id = $10 AND user_id = $11`,
+		address.Name, address.Address.Zipcode, address.Address.Country, address.Address.City, address.Address.Street,
+		address.Address.Apartment, address.Address.Recipient, address.Address.Phone, address.DefaultShipping,
+		address.Id, address.UserId)
+	if err != nil {
+		a.logger.Errorf("can't update address: %s", err)
+		err = addressError(err)
+		return fmt.Errorf("can't update address: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		a.logger.Errorf("address with id: %v not found", address.Id)
+		err = models.ErrorNotFound{}
+		return err
+	}
+	return nil
+}
+
+// DeleteAddress removes the address from the address book, the user has no default address
+// if the deleted address was the default
+func (a *address) DeleteAddress(ctx context.Context, id uuid.UUID) error {
+	a.logger.Debugf("Enter in repository DeleteAddress() with args: ctx, id: %v", id)
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context closed")
+	default:
+	}
+	tag, err := a.storage.GetQuerier(ctx).Exec(ctx, `DELETE FROM user_addresses WHERE id = $1`, id)
+	if err != nil {
+		a.logger.Errorf("can't delete address: %s", err)
+		return fmt.Errorf("can't delete address: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		a.logger.Errorf("address with id: %v not found", id)
+		return models.ErrorNotFound{}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+type audit struct {
+	storage *PGres
+	logger  *zap.SugaredLogger
+}
+
+var _ AuditStore = (*audit)(nil)
+
+func NewAuditRepo(storage *PGres, logger *zap.SugaredLogger) AuditStore {
+	return &audit{
+		storage: storage,
+		logger:  logger,
+	}
+}
+
+// addAuditEntry writes the entry to the audit log in the transaction of the change,
+// so the change and its record are saved or rolled back together
+func addAuditEntry(ctx context.Context, tx pgx.Tx, entry *models.AuditEntry) error {
+	if entry.Empty() {
+		return nil
+	}
+	var actorId interface{}
+	if entry.Actor.UserId != uuid.Nil {
+		actorId = entry.Actor.UserId
+	}
+	_, err := tx.Exec(ctx, `INSERT INTO audit_log (actor_id, actor_email, actor_role, action, entity, entity_id, before, after)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		actorId,
+		entry.Actor.Email,
+		entry.Actor.Role,
+		entry.Action,
+		entry.Entity,
+		entry.EntityId,
+		entry.Before,
+		entry.After,
+	)
+	if err != nil {
+		return fmt.Errorf("can't add audit entry: %w", err)
+	}
+	return nil
+}
+
+// GetAuditLog returns entries of the audit log satisfying the filter, the newest entries go first
+func (a *audit) GetAuditLog(ctx context.Context, filter models.AuditFilter) (chan models.AuditEntry, error) {
+	a.logger.Debugf("Enter in repository GetAuditLog() with args: ctx, filter: %v", filter)
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("context closed")
+	default:
+	}
+	conditions := make([]string, 0, 6)
+	args := make([]interface{}, 0, 8)
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.ActorId != uuid.Nil {
+		addCondition("actor_id = $%d", filter.ActorId)
+	}
+	if filter.Action != "" {
+		addCondition("action = $%d", filter.Action)
+	}
+	if filter.Entity != "" {
+		addCondition("entity = $%d", filter.Entity)
+	}
+	if filter.EntityId != "" {
+		addCondition("entity_id = $%d", filter.EntityId)
+	}
+	if !filter.From.IsZero() {
+		addCondition("created_at >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		addCondition("created_at < $%d", filter.To)
+	}
+	query := `SELECT id, COALESCE(actor_id, '00000000-0000-0000-0000-000000000000'), actor_email, actor_role,
+	action, entity, entity_id, before, after, created_at FROM audit_log`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY created_at DESC"
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	rows, err := a.storage.GetPool().Query(ctx, query, args...)
+	if err != nil {
+		a.logger.Errorf("can't get audit log: %s", err)
+		return nil, fmt.Errorf("can't get audit log: %w", err)
+	}
+	entries := make(chan models.AuditEntry, 100)
+	go func() {
+		defer close(entries)
+		defer rows.Close()
+		for rows.Next() {
+			var entry models.AuditEntry
+			if err := rows.Scan(
+				&entry.Id,
+				&entry.Actor.UserId,
+				&entry.Actor.Email,
+				&entry.Actor.Role,
+				&entry.Action,
+				&entry.Entity,
+				&entry.EntityId,
+				&entry.Before,
+				&entry.After,
+				&entry.CreatedAt,
+			); err != nil {
+				a.logger.Errorf("can't scan audit entry: %s", err)
+				return
+			}
+			entries <- entry
+		}
+	}()
+	return entries, nil
+}
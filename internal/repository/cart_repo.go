@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4"
@@ -25,17 +26,21 @@ func NewCartStore(storage *PGres, logger *zap.SugaredLogger) CartStore {
 	}
 }
 
-// Create Shall we add items at the moment we create cart
+// Create Shall we add items at the moment we create cart.
+// Cart with empty userId is a guest cart
 func (c *cart) Create(ctx context.Context, userId uuid.UUID) (uuid.UUID, error) {
 	c.logger.Debugf("Enter in repository cart Create() with args: ctx, userId: %v", userId)
 	select {
 	case <-ctx.Done():
 		return uuid.Nil, fmt.Errorf("context closed")
 	default:
-		pool := c.storage.GetPool()
 		var cartId uuid.UUID
-		row := pool.QueryRow(ctx, `INSERT INTO carts (user_id) VALUES ($1) RETURNING id`,
-			userId)
+		var user interface{}
+		if userId != uuid.Nil {
+			user = userId
+		}
+		row := c.storage.GetQuerier(ctx).QueryRow(ctx, `INSERT INTO carts (user_id) VALUES ($1) RETURNING id`,
+			user)
 		err := row.Scan(&cartId)
 		if err != nil {
 			c.logger.Error(err)
@@ -46,34 +51,365 @@ func (c *cart) Create(ctx context.Context, userId uuid.UUID) (uuid.UUID, error)
 	}
 }
 
-// AddItemToCart Maybe add to item
-func (c *cart) AddItemToCart(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID) error {
-	c.logger.Debugf("Enter in repository cart AddItemToCart() with args: ctx, cartId: %v, itemId: %v", cartId, itemId)
+// AddItemToCart adds one unit of the item to the cart. The new quantity can't be greater than maxQuantity
+// and the stock of the item, it is checked and the line is changed in one transaction as SetItemQuantity does.
+// The price of the line is set to the current price of the item, the line saved for later is moved to the cart
+func (c *cart) AddItemToCart(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID, maxQuantity int) (err error) {
+	c.logger.Debugf("Enter in repository cart AddItemToCart() with args: ctx, cartId: %v, itemId: %v, maxQuantity: %d", cartId, itemId, maxQuantity)
 	select {
 	case <-ctx.Done():
 		c.logger.Error("context closed")
 		return fmt.Errorf("context closed")
+	default:
+	}
+	pool := c.storage.GetPool()
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		c.logger.Errorf("can't create transaction: %s", err)
+		return fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			c.logger.Errorf("transaction rolled back")
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				c.logger.Errorf("can't rollback %s", rbErr)
+			}
+		} else {
+			c.logger.Info("transaction commited")
+			if err = tx.Commit(ctx); err != nil {
+				c.logger.Errorf("can't commit %s", err)
+				err = fmt.Errorf("can't commit transaction: %w", err)
+			}
+		}
+	}()
+	// The cart is locked so that concurrent additions of the item are made one by one
+	err = tx.QueryRow(ctx, `SELECT id FROM carts WHERE id=$1 FOR UPDATE`, cartId).Scan(&cartId)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		c.logger.Errorf("can't add item to cart: cart %v not found", cartId)
+		err = models.ErrorNotFound{}
+		return err
+	}
+	if err != nil {
+		c.logger.Errorf("can't add item to cart: %s", err)
+		return fmt.Errorf("can't add item to cart: %w", err)
+	}
+	quantity := 0
+	err = tx.QueryRow(ctx, `SELECT item_quantity FROM cart_items WHERE cart_id=$1 AND item_id=$2`, cartId, itemId).Scan(&quantity)
+	if err != nil && !strings.Contains(err.Error(), "no rows in result set") {
+		c.logger.Errorf("can't add item to cart: %s", err)
+		return fmt.Errorf("can't add item to cart: %w", err)
+	}
+	quantity++
+	if quantity > maxQuantity {
+		c.logger.Errorf("can't add item to cart: maximum quantity of item %v is %d", itemId, maxQuantity)
+		err = fmt.Errorf("maximum quantity of item in cart is %d: %w", maxQuantity, models.ErrorQuantityExceeded{})
+		return err
+	}
+	price, err := c.checkStock(ctx, tx, itemId, quantity)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `INSERT INTO cart_items (cart_id, item_id, item_quantity, price) VALUES ($1, $2, $3, $4)
+	ON CONFLICT (cart_id, item_id) DO UPDATE SET item_quantity = EXCLUDED.item_quantity, price = EXCLUDED.price, saved = false`,
+		cartId, itemId, quantity, price)
+	if err != nil {
+		c.logger.Errorf("can't add item to cart: %s", err)
+		return fmt.Errorf("can't add item to cart: %w", err)
+	}
+	c.logger.Info("Add item to cart success")
+	return nil
+}
+
+// checkStock locks the item, so that its stock can't be changed until the end of transaction,
+// and returns its current price if the quantity of the item is in stock
+func (c *cart) checkStock(ctx context.Context, tx pgx.Tx, itemId uuid.UUID, quantity int) (int32, error) {
+	var stock *int
+	var price int32
+	err := tx.QueryRow(ctx, `SELECT stock, price FROM items WHERE id=$1 AND deleted_at is null FOR SHARE`, itemId).Scan(&stock, &price)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		c.logger.Errorf("can't check stock: item %v not found", itemId)
+		return 0, models.ErrorNotFound{}
+	}
+	if err != nil {
+		c.logger.Errorf("can't check stock: %s", err)
+		return 0, fmt.Errorf("can't check stock: %w", err)
+	}
+	if stock != nil && quantity > *stock {
+		c.logger.Errorf("can't check stock: only %d items %v in stock", *stock, itemId)
+		return 0, fmt.Errorf("only %d items in stock: %w", *stock, models.ErrorQuantityExceeded{})
+	}
+	return price, nil
+}
+
+// SetItemQuantity sets the quantity of the item in the cart, zero quantity removes the item from the cart.
+// The cart and the item are checked and the line is changed in one transaction
+func (c *cart) SetItemQuantity(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID, quantity int) (err error) {
+	c.logger.Debugf("Enter in repository cart SetItemQuantity() with args: ctx, cartId: %v, itemId: %v, quantity: %d", cartId, itemId, quantity)
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context closed")
+	default:
+	}
+	pool := c.storage.GetPool()
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		c.logger.Errorf("can't create transaction: %s", err)
+		return fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			c.logger.Errorf("transaction rolled back")
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				c.logger.Errorf("can't rollback %s", rbErr)
+			}
+
+		} else {
+			c.logger.Info("transaction commited")
+			if err = tx.Commit(ctx); err != nil {
+				c.logger.Errorf("can't commit %s", err)
+				err = fmt.Errorf("can't commit transaction: %w", err)
+			}
+		}
+	}()
+	// The cart is locked so that concurrent changes of its lines are made one by one
+	err = tx.QueryRow(ctx, `SELECT id FROM carts WHERE id=$1 FOR UPDATE`, cartId).Scan(&cartId)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		c.logger.Errorf("can't set item quantity: cart %v not found", cartId)
+		err = models.ErrorNotFound{}
+		return err
+	}
+	if err != nil {
+		c.logger.Errorf("can't set item quantity: %s", err)
+		return fmt.Errorf("can't set item quantity: %w", err)
+	}
+	if quantity == 0 {
+		_, err = tx.Exec(ctx, `DELETE FROM cart_items WHERE cart_id=$1 AND item_id=$2`, cartId, itemId)
+		if err != nil {
+			c.logger.Errorf("can't delete item from cart: %s", err)
+			return fmt.Errorf("can't delete item from cart: %w", err)
+		}
+		c.logger.Info("Delete item from cart success")
+		return nil
+	}
+	price, err := c.checkStock(ctx, tx, itemId, quantity)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `INSERT INTO cart_items (cart_id, item_id, item_quantity, price) VALUES ($1, $2, $3, $4)
+	ON CONFLICT (cart_id, item_id) DO UPDATE SET item_quantity = EXCLUDED.item_quantity, price = EXCLUDED.price`, cartId, itemId, quantity, price)
+	if err != nil {
+		c.logger.Errorf("can't set item quantity: %s", err)
+		return fmt.Errorf("can't set item quantity: %w", err)
+	}
+	c.logger.Info("Set item quantity in cart success")
+	return nil
+}
+
+// MergeCarts sets the quantities of lines in the cart of the user and deletes the guest cart in one transaction.
+// Lines already in the cart of the user stay in their lists, new lines are added to the lists they are saved in
+func (c *cart) MergeCarts(ctx context.Context, guestCartId uuid.UUID, userCartId uuid.UUID, lines []models.CartLine) (err error) {
+	c.logger.Debugf("Enter in repository cart MergeCarts() with args: ctx, guestCartId: %v, userCartId: %v, lines: %v", guestCartId, userCartId, lines)
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context closed")
+	default:
+	}
+	pool := c.storage.GetPool()
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		c.logger.Errorf("can't create transaction: %s", err)
+		return fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			c.logger.Errorf("transaction rolled back")
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				c.logger.Errorf("can't rollback %s", rbErr)
+			}
+
+		} else {
+			c.logger.Info("transaction commited")
+			if err = tx.Commit(ctx); err != nil {
+				c.logger.Errorf("can't commit %s", err)
+				err = fmt.Errorf("can't commit transaction: %w", err)
+			}
+		}
+	}()
+	// Both carts are locked, the guest cart must still belong to nobody
+	var owner *uuid.UUID
+	err = tx.QueryRow(ctx, `SELECT user_id FROM carts WHERE id=$1 FOR UPDATE`, guestCartId).Scan(&owner)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		c.logger.Errorf("can't merge carts: guest cart %v not found", guestCartId)
+		err = models.ErrorNotFound{}
+		return err
+	}
+	if err != nil {
+		c.logger.Errorf("can't merge carts: %s", err)
+		return fmt.Errorf("can't merge carts: %w", err)
+	}
+	if owner != nil {
+		c.logger.Errorf("can't merge carts: cart %v is not a guest cart", guestCartId)
+		err = models.ErrorForbidden{}
+		return err
+	}
+	err = tx.QueryRow(ctx, `SELECT id FROM carts WHERE id=$1 FOR UPDATE`, userCartId).Scan(&userCartId)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		c.logger.Errorf("can't merge carts: cart %v not found", userCartId)
+		err = models.ErrorNotFound{}
+		return err
+	}
+	if err != nil {
+		c.logger.Errorf("can't merge carts: %s", err)
+		return fmt.Errorf("can't merge carts: %w", err)
+	}
+	for _, line := range lines {
+		// Lines already in the cart of the user keep their prices and their lists, new lines are added
+		// to the cart or to the list saved for later
+		_, err = tx.Exec(ctx, `INSERT INTO cart_items (cart_id, item_id, item_quantity, price, saved)
+		SELECT $1, id, $3, price, $4 FROM items WHERE id = $2
+		ON CONFLICT (cart_id, item_id) DO UPDATE SET item_quantity = EXCLUDED.item_quantity`, userCartId, line.ItemId, line.Quantity, line.Saved)
+		if err != nil {
+			c.logger.Errorf("can't merge carts: %s", err)
+			return fmt.Errorf("can't merge carts: %w", err)
+		}
+	}
+	_, err = tx.Exec(ctx, `DELETE FROM cart_items WHERE cart_id=$1`, guestCartId)
+	if err != nil {
+		c.logger.Errorf("can't delete guest cart items: %s", err)
+		return fmt.Errorf("can't delete guest cart items: %w", err)
+	}
+	_, err = tx.Exec(ctx, `DELETE FROM carts WHERE id=$1`, guestCartId)
+	if err != nil {
+		c.logger.Errorf("can't delete guest cart: %s", err)
+		return fmt.Errorf("can't delete guest cart: %w", err)
+	}
+	c.logger.Infof("Guest cart %v merged into cart %v", guestCartId, userCartId)
+	return nil
+}
+
+// ExtendCart sets the expiration time of the not expired cart to ttl from now
+// and marks the cart as changed now
+func (c *cart) ExtendCart(ctx context.Context, cartId uuid.UUID, ttl time.Duration) error {
+	c.logger.Debugf("Enter in repository cart ExtendCart() with args: ctx, cartId: %v, ttl: %v", cartId, ttl)
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context closed")
+	default:
+		tag, err := c.storage.GetQuerier(ctx).Exec(ctx, `UPDATE carts SET expire_at = now() + make_interval(secs => $1), updated_at = now()
+		WHERE id = $2 AND expire_at > now()`, ttl.Seconds(), cartId)
+		if err != nil {
+			c.logger.Errorf("can't extend cart: %s", err)
+			return fmt.Errorf("can't extend cart: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return models.ErrorNotFound{}
+		}
+		return nil
+	}
+}
+
+// DeleteExpiredCarts deletes at most limit expired carts with their items and returns
+// the number of deleted carts. Carts locked by other transactions are skipped
+func (c *cart) DeleteExpiredCarts(ctx context.Context, limit int) (int, error) {
+	c.logger.Debugf("Enter in repository cart DeleteExpiredCarts() with args: ctx, limit: %d", limit)
+	select {
+	case <-ctx.Done():
+		return 0, fmt.Errorf("context closed")
 	default:
 		pool := c.storage.GetPool()
-		row := pool.QueryRow(ctx, `SELECT item_id from cart_items where item_id=$1 and cart_id=$2`, itemId, cartId)
-		var checkId uuid.UUID
-		err := row.Scan(&checkId)
+		tag, err := pool.Exec(ctx, `
+		WITH expired AS (
+			SELECT id FROM carts WHERE expire_at <= now() ORDER BY expire_at LIMIT $1 FOR UPDATE SKIP LOCKED
+		), deleted_items AS (
+			DELETE FROM cart_items WHERE cart_id IN (SELECT id FROM expired)
+		)
+		DELETE FROM carts WHERE id IN (SELECT id FROM expired)`, limit)
 		if err != nil {
-			c.logger.Errorf("error on row.Scan: %s", err)
+			c.logger.Errorf("can't delete expired carts: %s", err)
+			return 0, fmt.Errorf("can't delete expired carts: %w", err)
 		}
-		if checkId == uuid.Nil {
-			_, err := pool.Exec(ctx, `INSERT INTO cart_items (cart_id, item_id, item_quantity) VALUES ($1, $2, $3)`, cartId, itemId, 1)
-			if err != nil {
-				c.logger.Errorf("can't add item to cart: %s", err)
-				return fmt.Errorf("can't add item to cart: %w", err)
+		return int(tag.RowsAffected()), nil
+	}
+}
+
+// CountCarts returns the numbers of active and expired carts
+func (c *cart) CountCarts(ctx context.Context) (int, int, error) {
+	c.logger.Debug("Enter in repository cart CountCarts() with args: ctx")
+	select {
+	case <-ctx.Done():
+		return 0, 0, fmt.Errorf("context closed")
+	default:
+		pool := c.storage.GetPool()
+		var active, expired int
+		row := pool.QueryRow(ctx, `SELECT count(*) FILTER (WHERE expire_at > now()), count(*) FILTER (WHERE expire_at <= now()) FROM carts`)
+		if err := row.Scan(&active, &expired); err != nil {
+			c.logger.Errorf("can't count carts: %s", err)
+			return 0, 0, fmt.Errorf("can't count carts: %w", err)
+		}
+		return active, expired, nil
+	}
+}
+
+// SetItemSaved moves the line of the cart to the list saved for later or back to the cart
+func (c *cart) SetItemSaved(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID, saved bool) error {
+	c.logger.Debugf("Enter in repository cart SetItemSaved() with args: ctx, cartId: %v, itemId: %v, saved: %v", cartId, itemId, saved)
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context closed")
+	default:
+		pool := c.storage.GetPool()
+		tag, err := pool.Exec(ctx, `UPDATE cart_items SET saved = $3 WHERE cart_id = $1 AND item_id = $2`, cartId, itemId, saved)
+		if err != nil {
+			c.logger.Errorf("can't set item saved: %s", err)
+			return fmt.Errorf("can't set item saved: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			c.logger.Errorf("can't set item saved: item %v not found in cart %v", itemId, cartId)
+			return models.ErrorNotFound{}
+		}
+		return nil
+	}
+}
+
+// ClearCart deletes all lines of the cart except lines saved for later after the order is placed.
+// Reminders sent about the cart are marked as converted to the order in the same transaction
+func (c *cart) ClearCart(ctx context.Context, cartId uuid.UUID, orderId uuid.UUID) (err error) {
+	c.logger.Debugf("Enter in repository cart ClearCart() with args: ctx, cartId: %v, orderId: %v", cartId, orderId)
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context closed")
+	default:
+	}
+	tx, err := c.storage.BeginTx(ctx)
+	if err != nil {
+		c.logger.Errorf("can't create transaction: %s", err)
+		return fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			c.logger.Errorf("transaction rolled back")
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				c.logger.Errorf("can't rollback %s", rbErr)
 			}
+
 		} else {
-			_, err := pool.Exec(ctx, `UPDATE cart_items SET item_quantity = item_quantity + 1 WHERE cart_id=$1 and item_id=$2`, cartId, itemId)
-			if err != nil {
-				c.logger.Errorf("can't add item to cart: %s", err)
-				return fmt.Errorf("can't add item to cart: %w", err)
+			c.logger.Info("transaction commited")
+			if err = tx.Commit(ctx); err != nil {
+				c.logger.Errorf("can't commit %s", err)
+				err = fmt.Errorf("can't commit transaction: %w", err)
 			}
 		}
+	}()
+	_, err = tx.Exec(ctx, `DELETE FROM cart_items WHERE cart_id = $1 AND NOT saved`, cartId)
+	if err != nil {
+		c.logger.Errorf("can't clear cart: %s", err)
+		return fmt.Errorf("can't clear cart: %w", err)
+	}
+	_, err = tx.Exec(ctx, `UPDATE cart_reminders SET order_id = $2, converted_at = now()
+	WHERE cart_id = $1 AND order_id IS NULL`, cartId, orderId)
+	if err != nil {
+		c.logger.Errorf("can't mark cart reminders converted: %s", err)
+		return fmt.Errorf("can't mark cart reminders converted: %w", err)
 	}
 	return nil
 }
@@ -150,16 +486,62 @@ func (c *cart) DeleteItemFromCart(ctx context.Context, cartId uuid.UUID, itemId
 	}
 }
 
+// GetCartOwner returns id of the user who owns the not expired cart, guest carts have no owner
+func (c *cart) GetCartOwner(ctx context.Context, cartId uuid.UUID) (uuid.UUID, error) {
+	c.logger.Debugf("Enter in repository cart GetCartOwner() with args: ctx, cartId: %v", cartId)
+	select {
+	case <-ctx.Done():
+		return uuid.Nil, fmt.Errorf("context closed")
+	default:
+		pool := c.storage.GetPool()
+		var userId uuid.UUID
+		err := pool.QueryRow(ctx, `SELECT user_id FROM carts WHERE id = $1 AND expire_at > now()`, cartId).Scan(&userId)
+		if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+			c.logger.Errorf("can't get cart owner: cart %v not found", cartId)
+			return uuid.Nil, models.ErrorNotFound{}
+		}
+		if err != nil {
+			c.logger.Errorf("can't get cart owner: %s", err)
+			return uuid.Nil, fmt.Errorf("can't get cart owner: %w", err)
+		}
+		return userId, nil
+	}
+}
+
+// LockCart locks the not expired cart until the end of the transaction of the unit of work carried by ctx,
+// so the lines of the cart can't be changed until the transaction ends
+func (c *cart) LockCart(ctx context.Context, cartId uuid.UUID) error {
+	c.logger.Debugf("Enter in repository cart LockCart() with args: ctx, cartId: %v", cartId)
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context closed")
+	default:
+		err := c.storage.GetQuerier(ctx).QueryRow(ctx, `SELECT id FROM carts WHERE id = $1 AND expire_at > now() FOR UPDATE`,
+			cartId).Scan(&cartId)
+		if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+			c.logger.Errorf("can't lock cart: cart %v not found", cartId)
+			return models.ErrorNotFound{}
+		}
+		if err != nil {
+			c.logger.Errorf("can't lock cart: %s", err)
+			return fmt.Errorf("can't lock cart: %w", err)
+		}
+		return nil
+	}
+}
+
 func (c *cart) GetCart(ctx context.Context, cartId uuid.UUID) (*models.Cart, error) {
 	c.logger.Debug("Enter in repository cart GetCart() with args: ctx, cartId: %v", cartId)
 	select {
 	case <-ctx.Done():
 		return nil, fmt.Errorf("context closed")
 	default:
-		pool := c.storage.GetPool()
+		pool := c.storage.GetQuerier(ctx)
 		var userId uuid.UUID
-		row := pool.QueryRow(ctx, `SELECT user_id FROM carts WHERE id = $1`, cartId)
-		err := row.Scan(&userId)
+		var expireAt time.Time
+		// Expired carts wait for the cleanup worker and are not returned
+		row := pool.QueryRow(ctx, `SELECT user_id, expire_at FROM carts WHERE id = $1 AND expire_at > now()`, cartId)
+		err := row.Scan(&userId, &expireAt)
 		if err != nil && strings.Contains(err.Error(), "no rows in result set") {
 			c.logger.Error(err.Error())
 			return nil, models.ErrorNotFound{}
@@ -171,7 +553,7 @@ func (c *cart) GetCart(ctx context.Context, cartId uuid.UUID) (*models.Cart, err
 		c.logger.Debug("read user id success: %v", userId)
 		item := models.ItemWithQuantity{}
 		rows, err := pool.Query(ctx, `
-		SELECT 	i.id, i.name, i.description, i.category, cat.name, cat.description, cat.picture, i.price, i.vendor, i.pictures, c.item_quantity
+		SELECT 	i.id, i.name, i.description, i.category, cat.name, cat.description, cat.picture, i.price, i.vendor, i.pictures, i.stock, i.weight, c.item_quantity, c.price, i.deleted_at IS NOT NULL, c.saved
 		FROM cart_items c, items i, categories cat
 		WHERE c.cart_id=$1 and i.id = c.item_id and cat.id = i.category`, cartId)
 		if err != nil {
@@ -181,6 +563,8 @@ func (c *cart) GetCart(ctx context.Context, cartId uuid.UUID) (*models.Cart, err
 		defer rows.Close()
 		c.logger.Debug("read info from db in pool.Query success")
 		items := make([]models.ItemWithQuantity, 0, 100)
+		savedItems := make([]models.ItemWithQuantity, 0)
+		var saved bool
 		for rows.Next() {
 			err := rows.Scan(
 				&item.Id,
@@ -193,7 +577,12 @@ func (c *cart) GetCart(ctx context.Context, cartId uuid.UUID) (*models.Cart, err
 				&item.Price,
 				&item.Vendor,
 				&item.Images,
+				&item.Stock,
+				&item.Weight,
 				&item.Quantity,
+				&item.AddedPrice,
+				&item.Deleted,
+				&saved,
 			)
 			if err != nil && strings.Contains(err.Error(), "no rows in result set") {
 				c.logger.Error(err.Error())
@@ -204,14 +593,20 @@ func (c *cart) GetCart(ctx context.Context, cartId uuid.UUID) (*models.Cart, err
 				return nil, err
 			}
 
+			if saved {
+				savedItems = append(savedItems, item)
+				continue
+			}
 			items = append(items, item)
 		}
 		c.logger.Info("Select items from cart success")
 		c.logger.Info("Get cart success")
 		return &models.Cart{
-			Id:     cartId,
-			UserId: userId,
-			Items:  items,
+			Id:         cartId,
+			UserId:     userId,
+			Items:      items,
+			SavedItems: savedItems,
+			ExpireAt:   expireAt,
 		}, nil
 	}
 }
@@ -224,8 +619,10 @@ func (c *cart) GetCartByUserId(ctx context.Context, userId uuid.UUID) (*models.C
 	default:
 		pool := c.storage.GetPool()
 		var cartId uuid.UUID
-		row := pool.QueryRow(ctx, `SELECT id FROM carts WHERE user_id = $1`, userId)
-		err := row.Scan(&cartId)
+		var expireAt time.Time
+		row := pool.QueryRow(ctx, `SELECT id, expire_at FROM carts WHERE user_id = $1 AND expire_at > now()
+		ORDER BY expire_at DESC LIMIT 1`, userId)
+		err := row.Scan(&cartId, &expireAt)
 		if err != nil && strings.Contains(err.Error(), "no rows in result set") {
 			c.logger.Error(err.Error())
 			return nil, models.ErrorNotFound{}
@@ -237,7 +634,7 @@ func (c *cart) GetCartByUserId(ctx context.Context, userId uuid.UUID) (*models.C
 		c.logger.Debug("read cart id success: %v", userId)
 		item := models.ItemWithQuantity{}
 		rows, err := pool.Query(ctx, `
-		SELECT i.id, i.name, i.description, i.category, cat.name, cat.description, cat.picture, i.price, i.vendor, i.pictures, c.item_quantity
+		SELECT i.id, i.name, i.description, i.category, cat.name, cat.description, cat.picture, i.price, i.vendor, i.pictures, i.stock, i.weight, c.item_quantity, c.price, i.deleted_at IS NOT NULL, c.saved
 		FROM cart_items c, items i, categories cat
 		WHERE c.cart_id=$1 and i.id = c.item_id and cat.id = i.category`, cartId)
 		if err != nil {
@@ -247,6 +644,8 @@ func (c *cart) GetCartByUserId(ctx context.Context, userId uuid.UUID) (*models.C
 		defer rows.Close()
 		c.logger.Debug("read info from db in pool.Query success")
 		items := make([]models.ItemWithQuantity, 0, 100)
+		savedItems := make([]models.ItemWithQuantity, 0)
+		var saved bool
 		for rows.Next() {
 			err := rows.Scan(
 				&item.Id,
@@ -259,7 +658,12 @@ func (c *cart) GetCartByUserId(ctx context.Context, userId uuid.UUID) (*models.C
 				&item.Price,
 				&item.Vendor,
 				&item.Images,
+				&item.Stock,
+				&item.Weight,
 				&item.Quantity,
+				&item.AddedPrice,
+				&item.Deleted,
+				&saved,
 			)
 			if err != nil && strings.Contains(err.Error(), "no rows in result set") {
 				c.logger.Error(err.Error())
@@ -270,14 +674,20 @@ func (c *cart) GetCartByUserId(ctx context.Context, userId uuid.UUID) (*models.C
 				return nil, err
 			}
 
+			if saved {
+				savedItems = append(savedItems, item)
+				continue
+			}
 			items = append(items, item)
 		}
 		c.logger.Info("Select items from cart success")
 		c.logger.Info("Get cart success")
 		return &models.Cart{
-			Id:     cartId,
-			UserId: userId,
-			Items:  items,
+			Id:         cartId,
+			UserId:     userId,
+			Items:      items,
+			SavedItems: savedItems,
+			ExpireAt:   expireAt,
 		}, nil
 	}
 }
@@ -3,6 +3,7 @@ package cash
 import (
 	"OnlineShopBackend/internal/models"
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -15,6 +16,9 @@ type IItemsCash interface {
 	GetItemsQuantityCash(ctx context.Context, key string) (int, error)
 	CreateFavouriteItemsIdCash(ctx context.Context, res map[uuid.UUID]uuid.UUID, key string) error
 	GetFavouriteItemsIdCash(ctx context.Context, key string) (*map[uuid.UUID]uuid.UUID, error)
+	DeleteCash(ctx context.Context, keys ...string) error
+	GetCashVersion(ctx context.Context, key string) (int64, error)
+	IncrCashVersion(ctx context.Context, key string) error
 }
 
 type ICategoriesCash interface {
@@ -23,3 +27,16 @@ type ICategoriesCash interface {
 	GetCategoriesListCash(ctx context.Context, key string) ([]models.Category, error)
 	DeleteCash(ctx context.Context, key string) error
 }
+
+type IStatsCash interface {
+	IncrItemStat(ctx context.Context, itemId uuid.UUID, stat string) error
+	GetItemsStats(ctx context.Context) ([]models.ItemStats, error)
+	DeleteFlushedItemsStats(ctx context.Context) error
+}
+
+type IIdempotencyCash interface {
+	CreateIdempotentRequest(ctx context.Context, request *models.IdempotentRequest, ttl time.Duration) (bool, error)
+	GetIdempotentRequest(ctx context.Context, userId uuid.UUID, key string) (*models.IdempotentRequest, error)
+	SaveIdempotentRequest(ctx context.Context, request *models.IdempotentRequest, ttl time.Duration) error
+	DeleteIdempotentRequest(ctx context.Context, userId uuid.UUID, key string) error
+}
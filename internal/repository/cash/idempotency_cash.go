@@ -0,0 +1,90 @@
+package cash
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// idempotencyKeyPrefix is the prefix of keys of requests made with the idempotency key
+const idempotencyKeyPrefix = "Idempotency"
+
+var _ IIdempotencyCash = &IdempotencyCash{}
+
+type IdempotencyCash struct {
+	*RedisCash
+	logger *zap.Logger
+}
+
+func NewIdempotencyCash(cash *RedisCash, logger *zap.Logger) IIdempotencyCash {
+	logger.Debug("Enter in cash NewIdempotencyCash()")
+	return &IdempotencyCash{cash, logger}
+}
+
+// idempotencyKey returns the key of the request with the idempotency key of the user,
+// so the same idempotency key of different users does not collide
+func idempotencyKey(userId uuid.UUID, key string) string {
+	return fmt.Sprintf("%s:%s:%s", idempotencyKeyPrefix, userId, key)
+}
+
+// CreateIdempotentRequest saves the request if there is no request of the user with the same
+// idempotency key and returns true, otherwise it returns false and the saved request is not changed
+func (cash *IdempotencyCash) CreateIdempotentRequest(ctx context.Context, request *models.IdempotentRequest, ttl time.Duration) (bool, error) {
+	cash.logger.Sugar().Debugf("Enter in cash CreateIdempotentRequest() with args: ctx, userId: %v, key: %s, ttl: %v", request.UserId, request.Key, ttl)
+	data, err := json.Marshal(request)
+	if err != nil {
+		return false, fmt.Errorf("error on marshal idempotent request: %w", err)
+	}
+	created, err := cash.SetNX(ctx, idempotencyKey(request.UserId, request.Key), data, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("redis: error on create idempotent request: %w", err)
+	}
+	return created, nil
+}
+
+// GetIdempotentRequest returns the request of the user with the idempotency key
+func (cash *IdempotencyCash) GetIdempotentRequest(ctx context.Context, userId uuid.UUID, key string) (*models.IdempotentRequest, error) {
+	cash.logger.Sugar().Debugf("Enter in cash GetIdempotentRequest() with args: ctx, userId: %v, key: %s", userId, key)
+	data, err := cash.Get(ctx, idempotencyKey(userId, key)).Bytes()
+	if err == redis.Nil {
+		return nil, models.ErrorNotFound{}
+	} else if err != nil {
+		return nil, fmt.Errorf("redis: error on get idempotent request: %w", err)
+	}
+	request := models.IdempotentRequest{}
+	err = json.Unmarshal(data, &request)
+	if err != nil {
+		return nil, fmt.Errorf("error on unmarshal idempotent request: %w", err)
+	}
+	return &request, nil
+}
+
+// SaveIdempotentRequest replaces the request of the user with the idempotency key
+func (cash *IdempotencyCash) SaveIdempotentRequest(ctx context.Context, request *models.IdempotentRequest, ttl time.Duration) error {
+	cash.logger.Sugar().Debugf("Enter in cash SaveIdempotentRequest() with args: ctx, userId: %v, key: %s, ttl: %v", request.UserId, request.Key, ttl)
+	data, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("error on marshal idempotent request: %w", err)
+	}
+	err = cash.Set(ctx, idempotencyKey(request.UserId, request.Key), data, ttl).Err()
+	if err != nil {
+		return fmt.Errorf("redis: error on save idempotent request: %w", err)
+	}
+	return nil
+}
+
+// DeleteIdempotentRequest deletes the request of the user with the idempotency key
+func (cash *IdempotencyCash) DeleteIdempotentRequest(ctx context.Context, userId uuid.UUID, key string) error {
+	cash.logger.Sugar().Debugf("Enter in cash DeleteIdempotentRequest() with args: ctx, userId: %v, key: %s", userId, key)
+	err := cash.Del(ctx, idempotencyKey(userId, key)).Err()
+	if err != nil {
+		return fmt.Errorf("redis: error on delete idempotent request: %w", err)
+	}
+	return nil
+}
@@ -144,4 +144,38 @@ func (cash *ItemsCash) GetFavouriteItemsIdCash(ctx context.Context, key string)
 	}
 	cash.logger.Debug("Get cash success")
 	return &res, nil
-}
\ No newline at end of file
+}
+// DeleteCash deletes caches with the keys
+func (cash *ItemsCash) DeleteCash(ctx context.Context, keys ...string) error {
+	cash.logger.Sugar().Debugf("Enter in cash DeleteCash() with args: ctx, keys: %v", keys)
+	err := cash.Del(ctx, keys...).Err()
+	if err != nil {
+		return fmt.Errorf("redis: error on delete keys %v: %w", keys, err)
+	}
+	cash.logger.Sugar().Infof("Delete cash with keys: %v success", keys)
+	return nil
+}
+
+// GetCashVersion returns the version of caches stored in the key,
+// the version is zero until it is incremented for the first time
+func (cash *ItemsCash) GetCashVersion(ctx context.Context, key string) (int64, error) {
+	cash.logger.Sugar().Debugf("Enter in cash GetCashVersion() with args: ctx, key: %s", key)
+	version, err := cash.Get(ctx, key).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("redis: error on get key %s: %w", key, err)
+	}
+	return version, nil
+}
+
+// IncrCashVersion increments the version of caches stored in the key, so the caches
+// with the previous version are not read anymore and expire by TTL
+func (cash *ItemsCash) IncrCashVersion(ctx context.Context, key string) error {
+	cash.logger.Sugar().Debugf("Enter in cash IncrCashVersion() with args: ctx, key: %s", key)
+	err := cash.Incr(ctx, key).Err()
+	if err != nil {
+		return fmt.Errorf("redis: error on increment key %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package cash
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Names of the counters of actions with items
+const (
+	ViewsStat    = "views"
+	CartAddsStat = "cart_adds"
+)
+
+// Keys of hashes with counters, the counters are incremented in itemsStatsKey
+// and moved to flushedItemsStatsKey while writing them to the database
+const (
+	itemsStatsKey        = "ItemsStats"
+	flushedItemsStatsKey = "ItemsStatsFlushed"
+)
+
+var _ IStatsCash = &StatsCash{}
+
+type StatsCash struct {
+	*RedisCash
+	logger *zap.Logger
+}
+
+func NewStatsCash(cash *RedisCash, logger *zap.Logger) IStatsCash {
+	logger.Debug("Enter in cash NewStatsCash()")
+	return &StatsCash{cash, logger}
+}
+
+// IncrItemStat increments the counter of the item with given name
+func (cash *StatsCash) IncrItemStat(ctx context.Context, itemId uuid.UUID, stat string) error {
+	cash.logger.Sugar().Debugf("Enter in cash IncrItemStat() with args: ctx, itemId: %v, stat: %s", itemId, stat)
+	err := cash.HIncrBy(ctx, itemsStatsKey, itemId.String()+":"+stat, 1).Err()
+	if err != nil {
+		return fmt.Errorf("redis: error on increment %s of item %v: %w", stat, itemId, err)
+	}
+	return nil
+}
+
+// GetItemsStats returns the counters which are not written to the database yet.
+// If the counters of the previous call were not deleted by DeleteFlushedItemsStats,
+// the same counters are returned again, otherwise the current counters are returned
+// and the new ones are collected from zero
+func (cash *StatsCash) GetItemsStats(ctx context.Context) ([]models.ItemStats, error) {
+	cash.logger.Debug("Enter in cash GetItemsStats() with args: ctx")
+	err := cash.RenameNX(ctx, itemsStatsKey, flushedItemsStatsKey).Err()
+	if err != nil && !strings.Contains(err.Error(), "no such key") {
+		return nil, fmt.Errorf("redis: error on rename key %s: %w", itemsStatsKey, err)
+	}
+	data, err := cash.HGetAll(ctx, flushedItemsStatsKey).Result()
+	if err == redis.Nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("redis: error on get key %s: %w", flushedItemsStatsKey, err)
+	}
+	statsById := make(map[uuid.UUID]*models.ItemStats, len(data))
+	stats := make([]models.ItemStats, 0, len(data))
+	for field, value := range data {
+		id, stat, found := strings.Cut(field, ":")
+		itemId, err := uuid.Parse(id)
+		if !found || err != nil {
+			cash.logger.Sugar().Warnf("Unknown field of items stats: %s", field)
+			continue
+		}
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			cash.logger.Sugar().Warnf("Can't parse value of items stats field %s: %v", field, err)
+			continue
+		}
+		itemStats, ok := statsById[itemId]
+		if !ok {
+			itemStats = &models.ItemStats{ItemId: itemId}
+			statsById[itemId] = itemStats
+		}
+		switch stat {
+		case ViewsStat:
+			itemStats.Views += count
+		case CartAddsStat:
+			itemStats.CartAdds += count
+		}
+	}
+	for _, itemStats := range statsById {
+		stats = append(stats, *itemStats)
+	}
+	cash.logger.Debug("Get items stats success")
+	return stats, nil
+}
+
+// DeleteFlushedItemsStats deletes the counters returned by GetItemsStats
+// after they are written to the database
+func (cash *StatsCash) DeleteFlushedItemsStats(ctx context.Context) error {
+	cash.logger.Debug("Enter in cash DeleteFlushedItemsStats() with args: ctx")
+	err := cash.Del(ctx, flushedItemsStatsKey).Err()
+	if err != nil {
+		return fmt.Errorf("redis: error on delete key %s: %w", flushedItemsStatsKey, err)
+	}
+	return nil
+}
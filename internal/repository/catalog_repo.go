@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// catalogChangedChannel is a name of the channel for notifications
+// sent by triggers on the items and categories tables
+const catalogChangedChannel = "catalog_changed"
+
+type catalog struct {
+	storage *PGres
+	logger  *zap.SugaredLogger
+}
+
+var _ CatalogStore = (*catalog)(nil)
+
+func NewCatalogRepo(storage *PGres, logger *zap.SugaredLogger) CatalogStore {
+	return &catalog{
+		storage: storage,
+		logger:  logger,
+	}
+}
+
+// ListenCatalogChanges subscribes to notifications about changes of items and categories
+// and returns the channel which receives a value after every change. Several changes
+// made in a short time may be merged in one value. The channel is closed when ctx is done
+func (c *catalog) ListenCatalogChanges(ctx context.Context) (chan struct{}, error) {
+	c.logger.Debug("Enter in repository ListenCatalogChanges() with args: ctx")
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("context closed")
+	default:
+	}
+	changes := make(chan struct{}, 1)
+	go func() {
+		defer close(changes)
+		for {
+			err := c.listen(ctx, changes)
+			if ctx.Err() != nil {
+				return
+			}
+			c.logger.Errorf("listening of catalog changes interrupted: %s", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+		}
+	}()
+	return changes, nil
+}
+
+// listen holds a connection from the pool while waiting for notifications
+func (c *catalog) listen(ctx context.Context, changes chan struct{}) error {
+	conn, err := c.storage.GetPool().Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("can't acquire connection: %w", err)
+	}
+	defer func() {
+		// The connection goes back to the pool, so it must not receive notifications anymore
+		if _, err := conn.Exec(context.Background(), "UNLISTEN *"); err != nil {
+			c.logger.Debugf("can't unlisten: %s", err)
+		}
+		conn.Release()
+	}()
+	if _, err := conn.Exec(ctx, "LISTEN "+catalogChangedChannel); err != nil {
+		return fmt.Errorf("can't listen channel %s: %w", catalogChangedChannel, err)
+	}
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("error on wait for notification: %w", err)
+		}
+		c.logger.Debugf("catalog changed in table %s", notification.Payload)
+		select {
+		case changes <- struct{}{}:
+		default:
+		}
+	}
+}
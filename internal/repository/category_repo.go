@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"go.uber.org/zap"
 )
@@ -236,8 +237,28 @@ func (repo *categoryRepo) GetCategoryList(ctx context.Context) (chan models.Cate
 }
 
 // DeleteCategory changes the value of the deleted_at attribute in the deleted category for the current time
-func (repo *categoryRepo) DeleteCategory(ctx context.Context, id uuid.UUID) error {
-	repo.logger.Debugf("Enter in repository DeleteCategory() with args: ctx, id: %v", id)
+// and moves items of the deleted category to the target category. Category with items can't be deleted
+// without the target category
+func (repo *categoryRepo) DeleteCategory(ctx context.Context, id uuid.UUID, targetId uuid.UUID) error {
+	repo.logger.Debugf("Enter in repository DeleteCategory() with args: ctx, id: %v, targetId: %v", id, targetId)
+	return repo.removeCategory(ctx, id, targetId, models.AuditCategoryDelete)
+}
+
+// MergeCategories moves all items of the source category to the target category and deletes the source category
+func (repo *categoryRepo) MergeCategories(ctx context.Context, sourceId uuid.UUID, targetId uuid.UUID) error {
+	repo.logger.Debugf("Enter in repository MergeCategories() with args: ctx, sourceId: %v, targetId: %v", sourceId, targetId)
+	if targetId == uuid.Nil {
+		return fmt.Errorf("empty target category id")
+	}
+	return repo.removeCategory(ctx, sourceId, targetId, models.AuditCategoryMerge)
+}
+
+// removeCategory moves items and deletes the category in one transaction,
+// so the items never point at the deleted category
+func (repo *categoryRepo) removeCategory(ctx context.Context, id uuid.UUID, targetId uuid.UUID, action string) error {
+	if id == targetId {
+		return fmt.Errorf("category %s can't be moved to itself", id)
+	}
 	pool := repo.storage.GetPool()
 	// Removal operation is carried out in transaction
 	tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
@@ -260,16 +281,71 @@ func (repo *categoryRepo) DeleteCategory(ctx context.Context, id uuid.UUID) erro
 			}
 		}
 	}()
-	_, err = tx.Exec(ctx, `UPDATE categories SET deleted_at=$1 WHERE id=$2`,
-		time.Now(), id)
+	// The category before the change is locked until the end
+	// of transaction and written to the audit log
+	err = tx.QueryRow(ctx, `SELECT id FROM categories WHERE id=$1 AND deleted_at is null FOR UPDATE`, id).Scan(&id)
 	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
 		repo.logger.Errorf("Error on delete category %s: %s", id, err)
-		return models.ErrorNotFound{}
+		err = models.ErrorNotFound{}
+		return err
+	}
+	if err != nil {
+		repo.logger.Errorf("Error on delete category %s: %s", id, err)
+		return fmt.Errorf("error on delete category %s: %w", id, err)
+	}
+	var movedItems int64
+	if targetId == uuid.Nil {
+		// Without the target category only the category without items can be deleted
+		err = tx.QueryRow(ctx, `SELECT count(*) FROM items WHERE category=$1 AND deleted_at is null`, id).Scan(&movedItems)
+		if err != nil {
+			repo.logger.Errorf("Error on delete category %s: %s", id, err)
+			return fmt.Errorf("error on delete category %s: %w", id, err)
+		}
+		if movedItems > 0 {
+			repo.logger.Errorf("Error on delete category %s: category has %d items", id, movedItems)
+			err = models.ErrorNotEmpty{}
+			return err
+		}
+	} else {
+		// The target category is locked so that it can't be deleted before the items are moved
+		err = tx.QueryRow(ctx, `SELECT id FROM categories WHERE id=$1 AND deleted_at is null FOR SHARE`, targetId).Scan(&targetId)
+		if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+			repo.logger.Errorf("Error on delete category %s: target category %s not found", id, targetId)
+			err = models.ErrorNotFound{}
+			return err
+		}
+		if err != nil {
+			repo.logger.Errorf("Error on delete category %s: %s", id, err)
+			return fmt.Errorf("error on delete category %s: %w", id, err)
+		}
+		// Deleted items are moved too, so nothing refers to the deleted category
+		var tag pgconn.CommandTag
+		tag, err = tx.Exec(ctx, `UPDATE items SET category=$1 WHERE category=$2`, targetId, id)
+		if err != nil {
+			repo.logger.Errorf("Error on move items from category %s to category %s: %s", id, targetId, err)
+			return fmt.Errorf("error on move items from category %s to category %s: %w", id, targetId, err)
+		}
+		movedItems = tag.RowsAffected()
+	}
+	now := time.Now()
+	_, err = tx.Exec(ctx, `UPDATE categories SET deleted_at=$1 WHERE id=$2`,
+		now, id)
+	if err != nil {
+		repo.logger.Errorf("Error on delete category %s: %s", id, err)
+		return fmt.Errorf("error on delete category %s: %w", id, err)
+	}
+	before := map[string]interface{}{"deleted_at": nil}
+	after := map[string]interface{}{"deleted_at": now}
+	if targetId != uuid.Nil {
+		before["items_category_id"] = id
+		after["items_category_id"] = targetId
+		after["moved_items"] = movedItems
 	}
+	err = addAuditEntry(ctx, tx, models.NewAuditEntry(ctx, action, models.AuditEntityCategory, id.String(), before, after))
 	if err != nil {
 		repo.logger.Errorf("Error on delete category %s: %s", id, err)
 		return fmt.Errorf("error on delete category %s: %w", id, err)
 	}
-	repo.logger.Infof("Category with id: %s successfully deleted from database", id)
+	repo.logger.Infof("Category with id: %s successfully deleted from database, %d items moved", id, movedItems)
 	return nil
 }
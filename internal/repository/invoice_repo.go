@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type invoice struct {
+	storage *PGres
+	logger  *zap.SugaredLogger
+}
+
+var _ InvoiceStore = (*invoice)(nil)
+
+func NewInvoiceRepo(storage *PGres, logger *zap.SugaredLogger) InvoiceStore {
+	return &invoice{
+		storage: storage,
+		logger:  logger,
+	}
+}
+
+// GetOrCreateInvoice returns the invoice of the order, the invoice is created with the next number
+// if the order has no invoice yet. The table is locked while the number is given, so concurrent
+// requests can't take the same number and the numbers have no gaps
+func (i *invoice) GetOrCreateInvoice(ctx context.Context, orderId uuid.UUID) (res *models.Invoice, err error) {
+	i.logger.Debugf("Enter in repository GetOrCreateInvoice() with args: ctx, orderId: %v", orderId)
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("context closed")
+	default:
+	}
+	result := models.Invoice{}
+	err = i.storage.GetQuerier(ctx).QueryRow(ctx, `SELECT order_id, number, issued_at FROM invoices WHERE order_id = $1`,
+		orderId).Scan(&result.OrderId, &result.Number, &result.IssuedAt)
+	if err == nil {
+		return &result, nil
+	}
+	if err.Error() != "no rows in result set" {
+		i.logger.Errorf("can't get invoice: %s", err)
+		return nil, fmt.Errorf("can't get invoice: %w", err)
+	}
+
+	tx, err := i.storage.BeginTx(ctx)
+	if err != nil {
+		i.logger.Errorf("can't create transaction: %s", err)
+		return nil, fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			i.logger.Errorf("transaction rolled back")
+			if err := tx.Rollback(ctx); err != nil {
+				i.logger.Errorf("can't rollback %s", err)
+			}
+			return
+		}
+		if err = tx.Commit(ctx); err != nil {
+			i.logger.Errorf("can't commit %s", err)
+			err = fmt.Errorf("can't commit transaction: %w", err)
+		}
+	}()
+	_, err = tx.Exec(ctx, `LOCK TABLE invoices IN SHARE ROW EXCLUSIVE MODE`)
+	if err != nil {
+		i.logger.Errorf("can't lock invoices: %s", err)
+		return nil, fmt.Errorf("can't lock invoices: %w", err)
+	}
+	_, err = tx.Exec(ctx, `INSERT INTO invoices (order_id, number)
+	SELECT $1, coalesce(max(number), 0) + 1 FROM invoices
+	ON CONFLICT (order_id) DO NOTHING`, orderId)
+	if err != nil {
+		if strings.Contains(err.Error(), "fk_order_id") {
+			err = models.ErrorNotFound{}
+			return nil, err
+		}
+		i.logger.Errorf("can't create invoice: %s", err)
+		return nil, fmt.Errorf("can't create invoice: %w", err)
+	}
+	err = tx.QueryRow(ctx, `SELECT order_id, number, issued_at FROM invoices WHERE order_id = $1`,
+		orderId).Scan(&result.OrderId, &result.Number, &result.IssuedAt)
+	if err != nil {
+		i.logger.Errorf("can't get invoice: %s", err)
+		return nil, fmt.Errorf("can't get invoice: %w", err)
+	}
+	return &result, nil
+}
@@ -27,6 +27,19 @@ func NewItemRepo(storage *PGres, logger *zap.SugaredLogger) ItemStore {
 
 var _ ItemStore = (*itemRepo)(nil)
 
+// sellerId returns the seller of the item to write to the database, nil for the items sold by the shop
+func sellerId(item *models.Item) interface{} {
+	if item.SellerId == uuid.Nil {
+		return nil
+	}
+	return item.SellerId
+}
+
+// isUnknownSeller reports whether err is the violation of the reference of the item to its seller
+func isUnknownSeller(err error) bool {
+	return strings.Contains(err.Error(), "fk_seller_id")
+}
+
 // CreateItem insert new item in database
 func (repo *itemRepo) CreateItem(ctx context.Context, item *models.Item) (uuid.UUID, error) {
 	repo.logger.Debugf("Enter in repository CreateItem() with args: ctx, item: %v", item)
@@ -55,17 +68,24 @@ func (repo *itemRepo) CreateItem(ctx context.Context, item *models.Item) (uuid.U
 		}
 	}()
 	var id uuid.UUID
-	row := tx.QueryRow(ctx, `INSERT INTO items(name, category, description, price, vendor, pictures, deleted_at)
-	values ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
+	row := tx.QueryRow(ctx, `INSERT INTO items(name, category, description, price, vendor, pictures, stock, weight, seller_id, deleted_at)
+	values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`,
 		item.Title,
 		item.Category.Id,
 		item.Description,
 		item.Price,
 		item.Vendor,
 		item.Images,
+		item.Stock,
+		item.Weight,
+		sellerId(item),
 		nil,
 	)
 	err = row.Scan(&id)
+	if err != nil && isUnknownSeller(err) {
+		repo.logger.Errorf("can't create item %s", err)
+		return uuid.Nil, fmt.Errorf("can't create item with seller %v: %w", item.SellerId, models.ErrorUnknownSeller{})
+	}
 	if err != nil {
 		repo.logger.Errorf("can't create item %s", err)
 		return uuid.Nil, fmt.Errorf("can't create item %w", err)
@@ -102,14 +122,47 @@ func (repo *itemRepo) UpdateItem(ctx context.Context, item *models.Item) error {
 		}
 	}()
 
-	_, err = tx.Exec(ctx, `UPDATE items SET name=$1, category=$2, description=$3, price=$4, vendor=$5, pictures = $6 WHERE id=$7`,
+	// The values before the change are locked until the end
+	// of transaction and written to the audit log
+	before := models.Item{}
+	var beforeSeller *uuid.UUID
+	err = tx.QueryRow(ctx, `SELECT name, category, description, price, vendor, pictures, stock, weight, seller_id FROM items WHERE id=$1 AND deleted_at IS NULL FOR UPDATE`,
+		item.Id).Scan(
+		&before.Title,
+		&before.Category.Id,
+		&before.Description,
+		&before.Price,
+		&before.Vendor,
+		&before.Images,
+		&before.Stock,
+		&before.Weight,
+		&beforeSeller,
+	)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		repo.logger.Errorf("Error on update item %s: %s", item.Id, err)
+		return models.ErrorNotFound{}
+	} else if err != nil {
+		repo.logger.Errorf("Error on update item %s: %s", item.Id, err)
+		return fmt.Errorf("error on update item %s: %w", item.Id, err)
+	}
+	if beforeSeller != nil {
+		before.SellerId = *beforeSeller
+	}
+	_, err = tx.Exec(ctx, `UPDATE items SET name=$1, category=$2, description=$3, price=$4, vendor=$5, pictures = $6, stock = $7, weight = $8, seller_id = $9 WHERE id=$10`,
 		item.Title,
 		item.Category.Id,
 		item.Description,
 		item.Price,
 		item.Vendor,
 		item.Images,
+		item.Stock,
+		item.Weight,
+		sellerId(item),
 		item.Id)
+	if err != nil && isUnknownSeller(err) {
+		repo.logger.Errorf("Error on update item %s: %s", item.Id, err)
+		return fmt.Errorf("error on update item %s with seller %v: %w", item.Id, item.SellerId, models.ErrorUnknownSeller{})
+	}
 	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
 		repo.logger.Errorf("Error on update item %s: %s", item.Id, err)
 		return models.ErrorNotFound{}
@@ -117,10 +170,35 @@ func (repo *itemRepo) UpdateItem(ctx context.Context, item *models.Item) error {
 		repo.logger.Errorf("Error on update item %s: %s", item.Id, err)
 		return fmt.Errorf("error on update item %s: %w", item.Id, err)
 	}
+	err = addAuditEntry(ctx, tx, models.NewAuditEntry(ctx, models.AuditItemUpdate, models.AuditEntityItem, item.Id.String(),
+		auditItemFields(&before), auditItemFields(item)))
+	if err != nil {
+		repo.logger.Errorf("Error on update item %s: %s", item.Id, err)
+		return fmt.Errorf("error on update item %s: %w", item.Id, err)
+	}
 	repo.logger.Infof("Item %s successfully updated", item.Id)
 	return nil
 }
 
+// auditItemFields returns the fields of item recorded in the audit log
+func auditItemFields(item *models.Item) map[string]interface{} {
+	images := item.Images
+	if images == nil {
+		images = []string{}
+	}
+	return map[string]interface{}{
+		"title":       item.Title,
+		"category_id": item.Category.Id,
+		"description": item.Description,
+		"price":       item.Price,
+		"vendor":      item.Vendor,
+		"images":      images,
+		"stock":       item.Stock,
+		"weight":      item.Weight,
+		"seller_id":   sellerId(item),
+	}
+}
+
 // GetItem returns *models.Item by id or error
 func (repo *itemRepo) GetItem(ctx context.Context, id uuid.UUID) (*models.Item, error) {
 	repo.logger.Debug("Enter in repository GetItem() with args: ctx, id: %v", id)
@@ -128,6 +206,7 @@ func (repo *itemRepo) GetItem(ctx context.Context, id uuid.UUID) (*models.Item,
 	pool := repo.storage.GetPool()
 
 	item := models.Item{}
+	var seller *uuid.UUID
 	row := pool.QueryRow(ctx,`
 	SELECT 
 	items.id, 
@@ -139,7 +218,16 @@ func (repo *itemRepo) GetItem(ctx context.Context, id uuid.UUID) (*models.Item,
 	items.description, 
 	price, 
 	vendor, 
-	pictures 
+	pictures,
+	items.created_at,
+	items.views,
+	items.cart_adds,
+	items.stock,
+	items.weight,
+	items.seller_id,
+	(SELECT COUNT(1) FROM item_questions q 
+	WHERE q.item_id = items.id 
+	AND EXISTS (SELECT 1 FROM item_answers a WHERE a.question_id = q.id))
 	FROM items 
 	INNER JOIN categories 
 	ON category=categories.id 
@@ -158,6 +246,13 @@ func (repo *itemRepo) GetItem(ctx context.Context, id uuid.UUID) (*models.Item,
 		&item.Price,
 		&item.Vendor,
 		&item.Images,
+		&item.CreatedAt,
+		&item.Views,
+		&item.CartAdds,
+		&item.Stock,
+		&item.Weight,
+		&seller,
+		&item.AnsweredQuestions,
 	)
 	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
 		repo.logger.Errorf("Error in rows scan get item by id: %s", err)
@@ -166,6 +261,9 @@ func (repo *itemRepo) GetItem(ctx context.Context, id uuid.UUID) (*models.Item,
 		repo.logger.Errorf("Error in rows scan get item by id: %s", err)
 		return &models.Item{}, fmt.Errorf("error in rows scan get item by id: %w", err)
 	}
+	if seller != nil {
+		item.SellerId = *seller
+	}
 	repo.logger.Info("Get item success")
 	return &item, nil
 }
@@ -191,7 +289,12 @@ func (repo *itemRepo) ItemsList(ctx context.Context) (chan models.Item, error) {
 		items.description, 
 		price, 
 		vendor, 
-		pictures 
+		pictures, 
+		items.created_at, 
+		items.views, 
+		items.cart_adds,
+		items.stock,
+		items.offer_id
 		FROM items 
 		INNER JOIN categories 
 		ON category=categories.id 
@@ -206,6 +309,7 @@ func (repo *itemRepo) ItemsList(ctx context.Context) (chan models.Item, error) {
 		defer rows.Close()
 
 		for rows.Next() {
+			var stock *int
 			if err := rows.Scan(
 				&item.Id,
 				&item.Title,
@@ -217,10 +321,18 @@ func (repo *itemRepo) ItemsList(ctx context.Context) (chan models.Item, error) {
 				&item.Price,
 				&item.Vendor,
 				&item.Images,
+				&item.CreatedAt,
+				&item.Views,
+				&item.CartAdds,
+				&stock,
+				&item.OfferId,
 			); err != nil {
 				repo.logger.Error(err.Error())
 				return
 			}
+			// The stock is scanned into the new variable, so the items
+			// already sent don't share it with the next one
+			item.Stock = stock
 			itemChan <- *item
 		}
 	}()
@@ -247,7 +359,10 @@ func (repo *itemRepo) SearchLine(ctx context.Context, param string) (chan models
 		items.description, 
 		price, 
 		vendor, 
-		pictures 
+		pictures, 
+		items.created_at, 
+		items.views, 
+		items.cart_adds 
 		FROM items 
 		INNER JOIN categories 
 		ON category=categories.id 
@@ -277,6 +392,9 @@ func (repo *itemRepo) SearchLine(ctx context.Context, param string) (chan models
 				&item.Price,
 				&item.Vendor,
 				&item.Images,
+				&item.CreatedAt,
+				&item.Views,
+				&item.CartAdds,
 			); err != nil {
 				repo.logger.Error(err.Error())
 				return
@@ -306,7 +424,11 @@ func (repo *itemRepo) GetItemsByCategory(ctx context.Context, categoryName strin
 		items.description, 
 		price, 
 		vendor, 
-		pictures FROM items 
+		pictures, 
+		items.created_at, 
+		items.views, 
+		items.cart_adds 
+		FROM items 
 		INNER JOIN categories ON category=categories.id 
 		WHERE items.deleted_at is null 
 		AND categories.deleted_at is null 
@@ -331,6 +453,9 @@ func (repo *itemRepo) GetItemsByCategory(ctx context.Context, categoryName strin
 				&item.Price,
 				&item.Vendor,
 				&item.Images,
+				&item.CreatedAt,
+				&item.Views,
+				&item.CartAdds,
 			); err != nil {
 				repo.logger.Error(err.Error())
 				return
@@ -432,7 +557,10 @@ func (repo *itemRepo) GetFavouriteItems(ctx context.Context, userId uuid.UUID) (
 		cat.picture, 
 		i.price, 
 		i.vendor, 
-		i.pictures
+		i.pictures,
+		i.created_at,
+		i.views,
+		i.cart_adds
 		FROM favourite_items f, items i, categories cat
 		WHERE f.user_id=$1 
 		AND i.id = f.item_id 
@@ -457,6 +585,9 @@ func (repo *itemRepo) GetFavouriteItems(ctx context.Context, userId uuid.UUID) (
 				&item.Price,
 				&item.Vendor,
 				&item.Images,
+				&item.CreatedAt,
+				&item.Views,
+				&item.CartAdds,
 			); err != nil {
 				repo.logger.Error(err.Error())
 				return
@@ -583,3 +714,45 @@ func (repo *itemRepo) ItemsInFavouriteQuantity(ctx context.Context, userId uuid.
 	repo.logger.Info("Request for ItemsInFavouriteQuantity success")
 	return quantity, nil
 }
+
+// AddItemsStats adds the increments to the counters of views and adding to cart of items
+func (repo *itemRepo) AddItemsStats(ctx context.Context, stats []models.ItemStats) (err error) {
+	repo.logger.Debugf("Enter in repository AddItemsStats() with args: ctx, stats: %v", stats)
+	pool := repo.storage.GetPool()
+
+	// All counters are updated in one transaction, so the increments
+	// are either applied completely or can be flushed again
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		repo.logger.Errorf("Can't create transaction: %s", err)
+		return fmt.Errorf("can't create transaction: %w", err)
+	}
+	repo.logger.Debug("Transaction begin success")
+	defer func() {
+		if err != nil {
+			repo.logger.Errorf("Transaction rolled back")
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				repo.logger.Errorf("Can't rollback %s", rbErr)
+			}
+		} else {
+			repo.logger.Info("Transaction commited")
+			if err = tx.Commit(ctx); err != nil {
+				repo.logger.Errorf("Can't commit %s", err)
+				err = fmt.Errorf("can't commit transaction: %w", err)
+			}
+		}
+	}()
+	for _, itemStats := range stats {
+		_, err = tx.Exec(ctx, `UPDATE items SET views = views + $1, cart_adds = cart_adds + $2 WHERE id = $3`,
+			itemStats.Views,
+			itemStats.CartAdds,
+			itemStats.ItemId,
+		)
+		if err != nil {
+			repo.logger.Errorf("Error on add stats of item %s: %s", itemStats.ItemId, err)
+			return fmt.Errorf("error on add stats of item %s: %w", itemStats.ItemId, err)
+		}
+	}
+	repo.logger.Infof("Stats of %d items successfully added", len(stats))
+	return nil
+}
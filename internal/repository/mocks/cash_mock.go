@@ -8,6 +8,7 @@ import (
 	models "OnlineShopBackend/internal/models"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -92,6 +93,40 @@ func (mr *MockIItemsCashMockRecorder) CreateItemsQuantityCash(ctx, value, key in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItemsQuantityCash", reflect.TypeOf((*MockIItemsCash)(nil).CreateItemsQuantityCash), ctx, value, key)
 }
 
+// DeleteCash mocks base method.
+func (m *MockIItemsCash) DeleteCash(ctx context.Context, keys ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteCash", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCash indicates an expected call of DeleteCash.
+func (mr *MockIItemsCashMockRecorder) DeleteCash(ctx interface{}, keys ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCash", reflect.TypeOf((*MockIItemsCash)(nil).DeleteCash), varargs...)
+}
+
+// GetCashVersion mocks base method.
+func (m *MockIItemsCash) GetCashVersion(ctx context.Context, key string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCashVersion", ctx, key)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCashVersion indicates an expected call of GetCashVersion.
+func (mr *MockIItemsCashMockRecorder) GetCashVersion(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCashVersion", reflect.TypeOf((*MockIItemsCash)(nil).GetCashVersion), ctx, key)
+}
+
 // GetFavouriteItemsIdCash mocks base method.
 func (m *MockIItemsCash) GetFavouriteItemsIdCash(ctx context.Context, key string) (*map[uuid.UUID]uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -137,6 +172,20 @@ func (mr *MockIItemsCashMockRecorder) GetItemsQuantityCash(ctx, key interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemsQuantityCash", reflect.TypeOf((*MockIItemsCash)(nil).GetItemsQuantityCash), ctx, key)
 }
 
+// IncrCashVersion mocks base method.
+func (m *MockIItemsCash) IncrCashVersion(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrCashVersion", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrCashVersion indicates an expected call of IncrCashVersion.
+func (mr *MockIItemsCashMockRecorder) IncrCashVersion(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrCashVersion", reflect.TypeOf((*MockIItemsCash)(nil).IncrCashVersion), ctx, key)
+}
+
 // MockICategoriesCash is a mock of ICategoriesCash interface.
 type MockICategoriesCash struct {
 	ctrl     *gomock.Controller
@@ -216,3 +265,150 @@ func (mr *MockICategoriesCashMockRecorder) GetCategoriesListCash(ctx, key interf
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoriesListCash", reflect.TypeOf((*MockICategoriesCash)(nil).GetCategoriesListCash), ctx, key)
 }
+
+// MockIStatsCash is a mock of IStatsCash interface.
+type MockIStatsCash struct {
+	ctrl     *gomock.Controller
+	recorder *MockIStatsCashMockRecorder
+}
+
+// MockIStatsCashMockRecorder is the mock recorder for MockIStatsCash.
+type MockIStatsCashMockRecorder struct {
+	mock *MockIStatsCash
+}
+
+// NewMockIStatsCash creates a new mock instance.
+func NewMockIStatsCash(ctrl *gomock.Controller) *MockIStatsCash {
+	mock := &MockIStatsCash{ctrl: ctrl}
+	mock.recorder = &MockIStatsCashMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIStatsCash) EXPECT() *MockIStatsCashMockRecorder {
+	return m.recorder
+}
+
+// DeleteFlushedItemsStats mocks base method.
+func (m *MockIStatsCash) DeleteFlushedItemsStats(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFlushedItemsStats", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFlushedItemsStats indicates an expected call of DeleteFlushedItemsStats.
+func (mr *MockIStatsCashMockRecorder) DeleteFlushedItemsStats(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFlushedItemsStats", reflect.TypeOf((*MockIStatsCash)(nil).DeleteFlushedItemsStats), ctx)
+}
+
+// GetItemsStats mocks base method.
+func (m *MockIStatsCash) GetItemsStats(ctx context.Context) ([]models.ItemStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemsStats", ctx)
+	ret0, _ := ret[0].([]models.ItemStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemsStats indicates an expected call of GetItemsStats.
+func (mr *MockIStatsCashMockRecorder) GetItemsStats(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemsStats", reflect.TypeOf((*MockIStatsCash)(nil).GetItemsStats), ctx)
+}
+
+// IncrItemStat mocks base method.
+func (m *MockIStatsCash) IncrItemStat(ctx context.Context, itemId uuid.UUID, stat string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrItemStat", ctx, itemId, stat)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrItemStat indicates an expected call of IncrItemStat.
+func (mr *MockIStatsCashMockRecorder) IncrItemStat(ctx, itemId, stat interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrItemStat", reflect.TypeOf((*MockIStatsCash)(nil).IncrItemStat), ctx, itemId, stat)
+}
+
+// MockIIdempotencyCash is a mock of IIdempotencyCash interface.
+type MockIIdempotencyCash struct {
+	ctrl     *gomock.Controller
+	recorder *MockIIdempotencyCashMockRecorder
+}
+
+// MockIIdempotencyCashMockRecorder is the mock recorder for MockIIdempotencyCash.
+type MockIIdempotencyCashMockRecorder struct {
+	mock *MockIIdempotencyCash
+}
+
+// NewMockIIdempotencyCash creates a new mock instance.
+func NewMockIIdempotencyCash(ctrl *gomock.Controller) *MockIIdempotencyCash {
+	mock := &MockIIdempotencyCash{ctrl: ctrl}
+	mock.recorder = &MockIIdempotencyCashMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIIdempotencyCash) EXPECT() *MockIIdempotencyCashMockRecorder {
+	return m.recorder
+}
+
+// CreateIdempotentRequest mocks base method.
+func (m *MockIIdempotencyCash) CreateIdempotentRequest(ctx context.Context, request *models.IdempotentRequest, ttl time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIdempotentRequest", ctx, request, ttl)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIdempotentRequest indicates an expected call of CreateIdempotentRequest.
+func (mr *MockIIdempotencyCashMockRecorder) CreateIdempotentRequest(ctx, request, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIdempotentRequest", reflect.TypeOf((*MockIIdempotencyCash)(nil).CreateIdempotentRequest), ctx, request, ttl)
+}
+
+// DeleteIdempotentRequest mocks base method.
+func (m *MockIIdempotencyCash) DeleteIdempotentRequest(ctx context.Context, userId uuid.UUID, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIdempotentRequest", ctx, userId, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIdempotentRequest indicates an expected call of DeleteIdempotentRequest.
+func (mr *MockIIdempotencyCashMockRecorder) DeleteIdempotentRequest(ctx, userId, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIdempotentRequest", reflect.TypeOf((*MockIIdempotencyCash)(nil).DeleteIdempotentRequest), ctx, userId, key)
+}
+
+// GetIdempotentRequest mocks base method.
+func (m *MockIIdempotencyCash) GetIdempotentRequest(ctx context.Context, userId uuid.UUID, key string) (*models.IdempotentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdempotentRequest", ctx, userId, key)
+	ret0, _ := ret[0].(*models.IdempotentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdempotentRequest indicates an expected call of GetIdempotentRequest.
+func (mr *MockIIdempotencyCashMockRecorder) GetIdempotentRequest(ctx, userId, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdempotentRequest", reflect.TypeOf((*MockIIdempotencyCash)(nil).GetIdempotentRequest), ctx, userId, key)
+}
+
+// SaveIdempotentRequest mocks base method.
+func (m *MockIIdempotencyCash) SaveIdempotentRequest(ctx context.Context, request *models.IdempotentRequest, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveIdempotentRequest", ctx, request, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveIdempotentRequest indicates an expected call of SaveIdempotentRequest.
+func (mr *MockIIdempotencyCashMockRecorder) SaveIdempotentRequest(ctx, request, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveIdempotentRequest", reflect.TypeOf((*MockIIdempotencyCash)(nil).SaveIdempotentRequest), ctx, request, ttl)
+}
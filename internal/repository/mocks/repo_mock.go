@@ -8,11 +8,49 @@ import (
 	models "OnlineShopBackend/internal/models"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
 )
 
+// MockUnitOfWork is a mock of UnitOfWork interface.
+type MockUnitOfWork struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnitOfWorkMockRecorder
+}
+
+// MockUnitOfWorkMockRecorder is the mock recorder for MockUnitOfWork.
+type MockUnitOfWorkMockRecorder struct {
+	mock *MockUnitOfWork
+}
+
+// NewMockUnitOfWork creates a new mock instance.
+func NewMockUnitOfWork(ctrl *gomock.Controller) *MockUnitOfWork {
+	mock := &MockUnitOfWork{ctrl: ctrl}
+	mock.recorder = &MockUnitOfWorkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnitOfWork) EXPECT() *MockUnitOfWorkMockRecorder {
+	return m.recorder
+}
+
+// Do mocks base method.
+func (m *MockUnitOfWork) Do(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Do indicates an expected call of Do.
+func (mr *MockUnitOfWorkMockRecorder) Do(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockUnitOfWork)(nil).Do), ctx, fn)
+}
+
 // MockItemStore is a mock of ItemStore interface.
 type MockItemStore struct {
 	ctrl     *gomock.Controller
@@ -50,6 +88,20 @@ func (mr *MockItemStoreMockRecorder) AddFavouriteItem(ctx, userId, itemId interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFavouriteItem", reflect.TypeOf((*MockItemStore)(nil).AddFavouriteItem), ctx, userId, itemId)
 }
 
+// AddItemsStats mocks base method.
+func (m *MockItemStore) AddItemsStats(ctx context.Context, stats []models.ItemStats) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddItemsStats", ctx, stats)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddItemsStats indicates an expected call of AddItemsStats.
+func (mr *MockItemStoreMockRecorder) AddItemsStats(ctx, stats interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItemsStats", reflect.TypeOf((*MockItemStore)(nil).AddItemsStats), ctx, stats)
+}
+
 // CreateItem mocks base method.
 func (m *MockItemStore) CreateItem(ctx context.Context, item *models.Item) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -296,17 +348,17 @@ func (mr *MockCategoryStoreMockRecorder) CreateCategory(ctx, category interface{
 }
 
 // DeleteCategory mocks base method.
-func (m *MockCategoryStore) DeleteCategory(ctx context.Context, id uuid.UUID) error {
+func (m *MockCategoryStore) DeleteCategory(ctx context.Context, id, targetId uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCategory", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteCategory", ctx, id, targetId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteCategory indicates an expected call of DeleteCategory.
-func (mr *MockCategoryStoreMockRecorder) DeleteCategory(ctx, id interface{}) *gomock.Call {
+func (mr *MockCategoryStoreMockRecorder) DeleteCategory(ctx, id, targetId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategoryStore)(nil).DeleteCategory), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategoryStore)(nil).DeleteCategory), ctx, id, targetId)
 }
 
 // GetCategory mocks base method.
@@ -354,6 +406,20 @@ func (mr *MockCategoryStoreMockRecorder) GetCategoryList(ctx interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryList", reflect.TypeOf((*MockCategoryStore)(nil).GetCategoryList), ctx)
 }
 
+// MergeCategories mocks base method.
+func (m *MockCategoryStore) MergeCategories(ctx context.Context, sourceId, targetId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeCategories", ctx, sourceId, targetId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeCategories indicates an expected call of MergeCategories.
+func (mr *MockCategoryStoreMockRecorder) MergeCategories(ctx, sourceId, targetId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeCategories", reflect.TypeOf((*MockCategoryStore)(nil).MergeCategories), ctx, sourceId, targetId)
+}
+
 // UpdateCategory mocks base method.
 func (m *MockCategoryStore) UpdateCategory(ctx context.Context, category *models.Category) error {
 	m.ctrl.T.Helper()
@@ -533,17 +599,47 @@ func (m *MockCartStore) EXPECT() *MockCartStoreMockRecorder {
 }
 
 // AddItemToCart mocks base method.
-func (m *MockCartStore) AddItemToCart(ctx context.Context, cartId, itemId uuid.UUID) error {
+func (m *MockCartStore) AddItemToCart(ctx context.Context, cartId, itemId uuid.UUID, maxQuantity int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddItemToCart", ctx, cartId, itemId)
+	ret := m.ctrl.Call(m, "AddItemToCart", ctx, cartId, itemId, maxQuantity)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddItemToCart indicates an expected call of AddItemToCart.
-func (mr *MockCartStoreMockRecorder) AddItemToCart(ctx, cartId, itemId interface{}) *gomock.Call {
+func (mr *MockCartStoreMockRecorder) AddItemToCart(ctx, cartId, itemId, maxQuantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItemToCart", reflect.TypeOf((*MockCartStore)(nil).AddItemToCart), ctx, cartId, itemId, maxQuantity)
+}
+
+// ClearCart mocks base method.
+func (m *MockCartStore) ClearCart(ctx context.Context, cartId, orderId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearCart", ctx, cartId, orderId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearCart indicates an expected call of ClearCart.
+func (mr *MockCartStoreMockRecorder) ClearCart(ctx, cartId, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearCart", reflect.TypeOf((*MockCartStore)(nil).ClearCart), ctx, cartId, orderId)
+}
+
+// CountCarts mocks base method.
+func (m *MockCartStore) CountCarts(ctx context.Context) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCarts", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CountCarts indicates an expected call of CountCarts.
+func (mr *MockCartStoreMockRecorder) CountCarts(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItemToCart", reflect.TypeOf((*MockCartStore)(nil).AddItemToCart), ctx, cartId, itemId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCarts", reflect.TypeOf((*MockCartStore)(nil).CountCarts), ctx)
 }
 
 // Create mocks base method.
@@ -575,6 +671,21 @@ func (mr *MockCartStoreMockRecorder) DeleteCart(ctx, cartId interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCart", reflect.TypeOf((*MockCartStore)(nil).DeleteCart), ctx, cartId)
 }
 
+// DeleteExpiredCarts mocks base method.
+func (m *MockCartStore) DeleteExpiredCarts(ctx context.Context, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredCarts", ctx, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredCarts indicates an expected call of DeleteExpiredCarts.
+func (mr *MockCartStoreMockRecorder) DeleteExpiredCarts(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredCarts", reflect.TypeOf((*MockCartStore)(nil).DeleteExpiredCarts), ctx, limit)
+}
+
 // DeleteItemFromCart mocks base method.
 func (m *MockCartStore) DeleteItemFromCart(ctx context.Context, cartId, itemId uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -589,6 +700,20 @@ func (mr *MockCartStoreMockRecorder) DeleteItemFromCart(ctx, cartId, itemId inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItemFromCart", reflect.TypeOf((*MockCartStore)(nil).DeleteItemFromCart), ctx, cartId, itemId)
 }
 
+// ExtendCart mocks base method.
+func (m *MockCartStore) ExtendCart(ctx context.Context, cartId uuid.UUID, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtendCart", ctx, cartId, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExtendCart indicates an expected call of ExtendCart.
+func (mr *MockCartStoreMockRecorder) ExtendCart(ctx, cartId, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtendCart", reflect.TypeOf((*MockCartStore)(nil).ExtendCart), ctx, cartId, ttl)
+}
+
 // GetCart mocks base method.
 func (m *MockCartStore) GetCart(ctx context.Context, cartId uuid.UUID) (*models.Cart, error) {
 	m.ctrl.T.Helper()
@@ -619,6 +744,143 @@ func (mr *MockCartStoreMockRecorder) GetCartByUserId(ctx, userId interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartByUserId", reflect.TypeOf((*MockCartStore)(nil).GetCartByUserId), ctx, userId)
 }
 
+// GetCartOwner mocks base method.
+func (m *MockCartStore) GetCartOwner(ctx context.Context, cartId uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCartOwner", ctx, cartId)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCartOwner indicates an expected call of GetCartOwner.
+func (mr *MockCartStoreMockRecorder) GetCartOwner(ctx, cartId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartOwner", reflect.TypeOf((*MockCartStore)(nil).GetCartOwner), ctx, cartId)
+}
+
+// LockCart mocks base method.
+func (m *MockCartStore) LockCart(ctx context.Context, cartId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockCart", ctx, cartId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockCart indicates an expected call of LockCart.
+func (mr *MockCartStoreMockRecorder) LockCart(ctx, cartId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockCart", reflect.TypeOf((*MockCartStore)(nil).LockCart), ctx, cartId)
+}
+
+// MergeCarts mocks base method.
+func (m *MockCartStore) MergeCarts(ctx context.Context, guestCartId, userCartId uuid.UUID, lines []models.CartLine) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeCarts", ctx, guestCartId, userCartId, lines)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeCarts indicates an expected call of MergeCarts.
+func (mr *MockCartStoreMockRecorder) MergeCarts(ctx, guestCartId, userCartId, lines interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeCarts", reflect.TypeOf((*MockCartStore)(nil).MergeCarts), ctx, guestCartId, userCartId, lines)
+}
+
+// SetItemQuantity mocks base method.
+func (m *MockCartStore) SetItemQuantity(ctx context.Context, cartId, itemId uuid.UUID, quantity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetItemQuantity", ctx, cartId, itemId, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetItemQuantity indicates an expected call of SetItemQuantity.
+func (mr *MockCartStoreMockRecorder) SetItemQuantity(ctx, cartId, itemId, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetItemQuantity", reflect.TypeOf((*MockCartStore)(nil).SetItemQuantity), ctx, cartId, itemId, quantity)
+}
+
+// SetItemSaved mocks base method.
+func (m *MockCartStore) SetItemSaved(ctx context.Context, cartId, itemId uuid.UUID, saved bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetItemSaved", ctx, cartId, itemId, saved)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetItemSaved indicates an expected call of SetItemSaved.
+func (mr *MockCartStoreMockRecorder) SetItemSaved(ctx, cartId, itemId, saved interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetItemSaved", reflect.TypeOf((*MockCartStore)(nil).SetItemSaved), ctx, cartId, itemId, saved)
+}
+
+// MockReminderStore is a mock of ReminderStore interface.
+type MockReminderStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockReminderStoreMockRecorder
+}
+
+// MockReminderStoreMockRecorder is the mock recorder for MockReminderStore.
+type MockReminderStoreMockRecorder struct {
+	mock *MockReminderStore
+}
+
+// NewMockReminderStore creates a new mock instance.
+func NewMockReminderStore(ctrl *gomock.Controller) *MockReminderStore {
+	mock := &MockReminderStore{ctrl: ctrl}
+	mock.recorder = &MockReminderStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReminderStore) EXPECT() *MockReminderStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateReminder mocks base method.
+func (m *MockReminderStore) CreateReminder(ctx context.Context, reminder *models.CartReminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReminder", ctx, reminder)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateReminder indicates an expected call of CreateReminder.
+func (mr *MockReminderStoreMockRecorder) CreateReminder(ctx, reminder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReminder", reflect.TypeOf((*MockReminderStore)(nil).CreateReminder), ctx, reminder)
+}
+
+// GetAbandonedCarts mocks base method.
+func (m *MockReminderStore) GetAbandonedCarts(ctx context.Context, idle time.Duration, limit int) ([]models.AbandonedCart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAbandonedCarts", ctx, idle, limit)
+	ret0, _ := ret[0].([]models.AbandonedCart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAbandonedCarts indicates an expected call of GetAbandonedCarts.
+func (mr *MockReminderStoreMockRecorder) GetAbandonedCarts(ctx, idle, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAbandonedCarts", reflect.TypeOf((*MockReminderStore)(nil).GetAbandonedCarts), ctx, idle, limit)
+}
+
+// SaveReminderAttempt mocks base method.
+func (m *MockReminderStore) SaveReminderAttempt(ctx context.Context, attempt *models.CartReminderAttempt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveReminderAttempt", ctx, attempt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveReminderAttempt indicates an expected call of SaveReminderAttempt.
+func (mr *MockReminderStoreMockRecorder) SaveReminderAttempt(ctx, attempt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveReminderAttempt", reflect.TypeOf((*MockReminderStore)(nil).SaveReminderAttempt), ctx, attempt)
+}
+
 // MockOrderStore is a mock of OrderStore interface.
 type MockOrderStore struct {
 	ctrl     *gomock.Controller
@@ -642,6 +904,21 @@ func (m *MockOrderStore) EXPECT() *MockOrderStoreMockRecorder {
 	return m.recorder
 }
 
+// CancelOrder mocks base method.
+func (m *MockOrderStore) CancelOrder(ctx context.Context, id uuid.UUID, reason string, statuses []models.Status) (models.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrder", ctx, id, reason, statuses)
+	ret0, _ := ret[0].(models.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockOrderStoreMockRecorder) CancelOrder(ctx, id, reason, statuses interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockOrderStore)(nil).CancelOrder), ctx, id, reason, statuses)
+}
+
 // ChangeAddress mocks base method.
 func (m *MockOrderStore) ChangeAddress(ctx context.Context, order *models.Order, address models.UserAddress) error {
 	m.ctrl.T.Helper()
@@ -670,6 +947,21 @@ func (mr *MockOrderStoreMockRecorder) ChangeStatus(ctx, order, status interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeStatus", reflect.TypeOf((*MockOrderStore)(nil).ChangeStatus), ctx, order, status)
 }
 
+// CountOrdersByStatus mocks base method.
+func (m *MockOrderStore) CountOrdersByStatus(ctx context.Context, filter models.OrderFilter, pricing models.CartPricing) (map[models.Status]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOrdersByStatus", ctx, filter, pricing)
+	ret0, _ := ret[0].(map[models.Status]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOrdersByStatus indicates an expected call of CountOrdersByStatus.
+func (mr *MockOrderStoreMockRecorder) CountOrdersByStatus(ctx, filter, pricing interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOrdersByStatus", reflect.TypeOf((*MockOrderStore)(nil).CountOrdersByStatus), ctx, filter, pricing)
+}
+
 // Create mocks base method.
 func (m *MockOrderStore) Create(ctx context.Context, order *models.Order) (*models.Order, error) {
 	m.ctrl.T.Helper()
@@ -699,6 +991,22 @@ func (mr *MockOrderStoreMockRecorder) DeleteOrder(ctx, order interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrder", reflect.TypeOf((*MockOrderStore)(nil).DeleteOrder), ctx, order)
 }
 
+// ExportOrders mocks base method.
+func (m *MockOrderStore) ExportOrders(ctx context.Context, filter models.OrderFilter, pricing models.CartPricing) (chan models.OrderExportLine, chan error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportOrders", ctx, filter, pricing)
+	ret0, _ := ret[0].(chan models.OrderExportLine)
+	ret1, _ := ret[1].(chan error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ExportOrders indicates an expected call of ExportOrders.
+func (mr *MockOrderStoreMockRecorder) ExportOrders(ctx, filter, pricing interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportOrders", reflect.TypeOf((*MockOrderStore)(nil).ExportOrders), ctx, filter, pricing)
+}
+
 // GetOrderByID mocks base method.
 func (m *MockOrderStore) GetOrderByID(ctx context.Context, id uuid.UUID) (models.Order, error) {
 	m.ctrl.T.Helper()
@@ -714,6 +1022,21 @@ func (mr *MockOrderStoreMockRecorder) GetOrderByID(ctx, id interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderByID", reflect.TypeOf((*MockOrderStore)(nil).GetOrderByID), ctx, id)
 }
 
+// GetOrders mocks base method.
+func (m *MockOrderStore) GetOrders(ctx context.Context, filter models.OrderFilter, pricing models.CartPricing) ([]models.OrderSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrders", ctx, filter, pricing)
+	ret0, _ := ret[0].([]models.OrderSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrders indicates an expected call of GetOrders.
+func (mr *MockOrderStoreMockRecorder) GetOrders(ctx, filter, pricing interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrders", reflect.TypeOf((*MockOrderStore)(nil).GetOrders), ctx, filter, pricing)
+}
+
 // GetOrdersForUser mocks base method.
 func (m *MockOrderStore) GetOrdersForUser(ctx context.Context, user *models.User) (chan models.Order, error) {
 	m.ctrl.T.Helper()
@@ -728,3 +1051,768 @@ func (mr *MockOrderStoreMockRecorder) GetOrdersForUser(ctx, user interface{}) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersForUser", reflect.TypeOf((*MockOrderStore)(nil).GetOrdersForUser), ctx, user)
 }
+
+// MockPaymentStore is a mock of PaymentStore interface.
+type MockPaymentStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentStoreMockRecorder
+}
+
+// MockPaymentStoreMockRecorder is the mock recorder for MockPaymentStore.
+type MockPaymentStoreMockRecorder struct {
+	mock *MockPaymentStore
+}
+
+// NewMockPaymentStore creates a new mock instance.
+func NewMockPaymentStore(ctrl *gomock.Controller) *MockPaymentStore {
+	mock := &MockPaymentStore{ctrl: ctrl}
+	mock.recorder = &MockPaymentStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentStore) EXPECT() *MockPaymentStoreMockRecorder {
+	return m.recorder
+}
+
+// CancelUnpaidOrders mocks base method.
+func (m *MockPaymentStore) CancelUnpaidOrders(ctx context.Context, timeout time.Duration, limit int) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelUnpaidOrders", ctx, timeout, limit)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelUnpaidOrders indicates an expected call of CancelUnpaidOrders.
+func (mr *MockPaymentStoreMockRecorder) CancelUnpaidOrders(ctx, timeout, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelUnpaidOrders", reflect.TypeOf((*MockPaymentStore)(nil).CancelUnpaidOrders), ctx, timeout, limit)
+}
+
+// CreatePayment mocks base method.
+func (m *MockPaymentStore) CreatePayment(ctx context.Context, payment *models.Payment) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayment", ctx, payment)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePayment indicates an expected call of CreatePayment.
+func (mr *MockPaymentStoreMockRecorder) CreatePayment(ctx, payment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayment", reflect.TypeOf((*MockPaymentStore)(nil).CreatePayment), ctx, payment)
+}
+
+// GetPaymentByExternalId mocks base method.
+func (m *MockPaymentStore) GetPaymentByExternalId(ctx context.Context, provider, externalId string) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentByExternalId", ctx, provider, externalId)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentByExternalId indicates an expected call of GetPaymentByExternalId.
+func (mr *MockPaymentStoreMockRecorder) GetPaymentByExternalId(ctx, provider, externalId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentByExternalId", reflect.TypeOf((*MockPaymentStore)(nil).GetPaymentByExternalId), ctx, provider, externalId)
+}
+
+// GetPayments mocks base method.
+func (m *MockPaymentStore) GetPayments(ctx context.Context, orderId uuid.UUID) ([]models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayments", ctx, orderId)
+	ret0, _ := ret[0].([]models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayments indicates an expected call of GetPayments.
+func (mr *MockPaymentStoreMockRecorder) GetPayments(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayments", reflect.TypeOf((*MockPaymentStore)(nil).GetPayments), ctx, orderId)
+}
+
+// PayOrder mocks base method.
+func (m *MockPaymentStore) PayOrder(ctx context.Context, orderId uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PayOrder", ctx, orderId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PayOrder indicates an expected call of PayOrder.
+func (mr *MockPaymentStoreMockRecorder) PayOrder(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayOrder", reflect.TypeOf((*MockPaymentStore)(nil).PayOrder), ctx, orderId)
+}
+
+// UpdatePayment mocks base method.
+func (m *MockPaymentStore) UpdatePayment(ctx context.Context, payment *models.Payment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePayment", ctx, payment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePayment indicates an expected call of UpdatePayment.
+func (mr *MockPaymentStoreMockRecorder) UpdatePayment(ctx, payment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePayment", reflect.TypeOf((*MockPaymentStore)(nil).UpdatePayment), ctx, payment)
+}
+
+// MockReturnStore is a mock of ReturnStore interface.
+type MockReturnStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockReturnStoreMockRecorder
+}
+
+// MockReturnStoreMockRecorder is the mock recorder for MockReturnStore.
+type MockReturnStoreMockRecorder struct {
+	mock *MockReturnStore
+}
+
+// NewMockReturnStore creates a new mock instance.
+func NewMockReturnStore(ctrl *gomock.Controller) *MockReturnStore {
+	mock := &MockReturnStore{ctrl: ctrl}
+	mock.recorder = &MockReturnStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReturnStore) EXPECT() *MockReturnStoreMockRecorder {
+	return m.recorder
+}
+
+// ChangeReturnStatus mocks base method.
+func (m *MockReturnStore) ChangeReturnStatus(ctx context.Context, id uuid.UUID, from, to models.ReturnStatus, comment string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeReturnStatus", ctx, id, from, to, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeReturnStatus indicates an expected call of ChangeReturnStatus.
+func (mr *MockReturnStoreMockRecorder) ChangeReturnStatus(ctx, id, from, to, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeReturnStatus", reflect.TypeOf((*MockReturnStore)(nil).ChangeReturnStatus), ctx, id, from, to, comment)
+}
+
+// CreateReturn mocks base method.
+func (m *MockReturnStore) CreateReturn(ctx context.Context, ret *models.Return) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret_2 := m.ctrl.Call(m, "CreateReturn", ctx, ret)
+	ret0, _ := ret_2[0].(uuid.UUID)
+	ret1, _ := ret_2[1].(error)
+	return ret0, ret1
+}
+
+// CreateReturn indicates an expected call of CreateReturn.
+func (mr *MockReturnStoreMockRecorder) CreateReturn(ctx, ret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReturn", reflect.TypeOf((*MockReturnStore)(nil).CreateReturn), ctx, ret)
+}
+
+// GetOrderReturns mocks base method.
+func (m *MockReturnStore) GetOrderReturns(ctx context.Context, orderId uuid.UUID) ([]models.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderReturns", ctx, orderId)
+	ret0, _ := ret[0].([]models.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderReturns indicates an expected call of GetOrderReturns.
+func (mr *MockReturnStoreMockRecorder) GetOrderReturns(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderReturns", reflect.TypeOf((*MockReturnStore)(nil).GetOrderReturns), ctx, orderId)
+}
+
+// GetReturn mocks base method.
+func (m *MockReturnStore) GetReturn(ctx context.Context, id uuid.UUID) (*models.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReturn", ctx, id)
+	ret0, _ := ret[0].(*models.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReturn indicates an expected call of GetReturn.
+func (mr *MockReturnStoreMockRecorder) GetReturn(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturn", reflect.TypeOf((*MockReturnStore)(nil).GetReturn), ctx, id)
+}
+
+// GetReturnedQuantities mocks base method.
+func (m *MockReturnStore) GetReturnedQuantities(ctx context.Context, orderId uuid.UUID) (map[uuid.UUID]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReturnedQuantities", ctx, orderId)
+	ret0, _ := ret[0].(map[uuid.UUID]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReturnedQuantities indicates an expected call of GetReturnedQuantities.
+func (mr *MockReturnStoreMockRecorder) GetReturnedQuantities(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturnedQuantities", reflect.TypeOf((*MockReturnStore)(nil).GetReturnedQuantities), ctx, orderId)
+}
+
+// GetReturns mocks base method.
+func (m *MockReturnStore) GetReturns(ctx context.Context, status models.ReturnStatus, offset, limit int) ([]models.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReturns", ctx, status, offset, limit)
+	ret0, _ := ret[0].([]models.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReturns indicates an expected call of GetReturns.
+func (mr *MockReturnStoreMockRecorder) GetReturns(ctx, status, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturns", reflect.TypeOf((*MockReturnStore)(nil).GetReturns), ctx, status, offset, limit)
+}
+
+// RestockReturn mocks base method.
+func (m *MockReturnStore) RestockReturn(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestockReturn", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestockReturn indicates an expected call of RestockReturn.
+func (mr *MockReturnStoreMockRecorder) RestockReturn(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestockReturn", reflect.TypeOf((*MockReturnStore)(nil).RestockReturn), ctx, id)
+}
+
+// SetRefundAmount mocks base method.
+func (m *MockReturnStore) SetRefundAmount(ctx context.Context, id uuid.UUID, amount int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRefundAmount", ctx, id, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRefundAmount indicates an expected call of SetRefundAmount.
+func (mr *MockReturnStoreMockRecorder) SetRefundAmount(ctx, id, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRefundAmount", reflect.TypeOf((*MockReturnStore)(nil).SetRefundAmount), ctx, id, amount)
+}
+
+// SetTrackingNumber mocks base method.
+func (m *MockReturnStore) SetTrackingNumber(ctx context.Context, id uuid.UUID, trackingNumber string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTrackingNumber", ctx, id, trackingNumber)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTrackingNumber indicates an expected call of SetTrackingNumber.
+func (mr *MockReturnStoreMockRecorder) SetTrackingNumber(ctx, id, trackingNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrackingNumber", reflect.TypeOf((*MockReturnStore)(nil).SetTrackingNumber), ctx, id, trackingNumber)
+}
+
+// MockInvoiceStore is a mock of InvoiceStore interface.
+type MockInvoiceStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvoiceStoreMockRecorder
+}
+
+// MockInvoiceStoreMockRecorder is the mock recorder for MockInvoiceStore.
+type MockInvoiceStoreMockRecorder struct {
+	mock *MockInvoiceStore
+}
+
+// NewMockInvoiceStore creates a new mock instance.
+func NewMockInvoiceStore(ctrl *gomock.Controller) *MockInvoiceStore {
+	mock := &MockInvoiceStore{ctrl: ctrl}
+	mock.recorder = &MockInvoiceStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvoiceStore) EXPECT() *MockInvoiceStoreMockRecorder {
+	return m.recorder
+}
+
+// GetOrCreateInvoice mocks base method.
+func (m *MockInvoiceStore) GetOrCreateInvoice(ctx context.Context, orderId uuid.UUID) (*models.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrCreateInvoice", ctx, orderId)
+	ret0, _ := ret[0].(*models.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrCreateInvoice indicates an expected call of GetOrCreateInvoice.
+func (mr *MockInvoiceStoreMockRecorder) GetOrCreateInvoice(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrCreateInvoice", reflect.TypeOf((*MockInvoiceStore)(nil).GetOrCreateInvoice), ctx, orderId)
+}
+
+// MockQuestionStore is a mock of QuestionStore interface.
+type MockQuestionStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuestionStoreMockRecorder
+}
+
+// MockQuestionStoreMockRecorder is the mock recorder for MockQuestionStore.
+type MockQuestionStoreMockRecorder struct {
+	mock *MockQuestionStore
+}
+
+// NewMockQuestionStore creates a new mock instance.
+func NewMockQuestionStore(ctrl *gomock.Controller) *MockQuestionStore {
+	mock := &MockQuestionStore{ctrl: ctrl}
+	mock.recorder = &MockQuestionStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuestionStore) EXPECT() *MockQuestionStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateAnswer mocks base method.
+func (m *MockQuestionStore) CreateAnswer(ctx context.Context, answer *models.Answer) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAnswer", ctx, answer)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAnswer indicates an expected call of CreateAnswer.
+func (mr *MockQuestionStoreMockRecorder) CreateAnswer(ctx, answer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAnswer", reflect.TypeOf((*MockQuestionStore)(nil).CreateAnswer), ctx, answer)
+}
+
+// CreateQuestion mocks base method.
+func (m *MockQuestionStore) CreateQuestion(ctx context.Context, question *models.Question) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateQuestion", ctx, question)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateQuestion indicates an expected call of CreateQuestion.
+func (mr *MockQuestionStoreMockRecorder) CreateQuestion(ctx, question interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQuestion", reflect.TypeOf((*MockQuestionStore)(nil).CreateQuestion), ctx, question)
+}
+
+// GetItemSellerId mocks base method.
+func (m *MockQuestionStore) GetItemSellerId(ctx context.Context, itemId uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemSellerId", ctx, itemId)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemSellerId indicates an expected call of GetItemSellerId.
+func (mr *MockQuestionStoreMockRecorder) GetItemSellerId(ctx, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemSellerId", reflect.TypeOf((*MockQuestionStore)(nil).GetItemSellerId), ctx, itemId)
+}
+
+// GetQuestion mocks base method.
+func (m *MockQuestionStore) GetQuestion(ctx context.Context, id uuid.UUID) (*models.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestion", ctx, id)
+	ret0, _ := ret[0].(*models.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuestion indicates an expected call of GetQuestion.
+func (mr *MockQuestionStoreMockRecorder) GetQuestion(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestion", reflect.TypeOf((*MockQuestionStore)(nil).GetQuestion), ctx, id)
+}
+
+// GetQuestionsByItem mocks base method.
+func (m *MockQuestionStore) GetQuestionsByItem(ctx context.Context, itemId uuid.UUID) (chan models.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestionsByItem", ctx, itemId)
+	ret0, _ := ret[0].(chan models.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuestionsByItem indicates an expected call of GetQuestionsByItem.
+func (mr *MockQuestionStoreMockRecorder) GetQuestionsByItem(ctx, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestionsByItem", reflect.TypeOf((*MockQuestionStore)(nil).GetQuestionsByItem), ctx, itemId)
+}
+
+// GetUnansweredQuestions mocks base method.
+func (m *MockQuestionStore) GetUnansweredQuestions(ctx context.Context) (chan models.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnansweredQuestions", ctx)
+	ret0, _ := ret[0].(chan models.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnansweredQuestions indicates an expected call of GetUnansweredQuestions.
+func (mr *MockQuestionStoreMockRecorder) GetUnansweredQuestions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnansweredQuestions", reflect.TypeOf((*MockQuestionStore)(nil).GetUnansweredQuestions), ctx)
+}
+
+// UpvoteAnswer mocks base method.
+func (m *MockQuestionStore) UpvoteAnswer(ctx context.Context, answerId, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpvoteAnswer", ctx, answerId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpvoteAnswer indicates an expected call of UpvoteAnswer.
+func (mr *MockQuestionStoreMockRecorder) UpvoteAnswer(ctx, answerId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpvoteAnswer", reflect.TypeOf((*MockQuestionStore)(nil).UpvoteAnswer), ctx, answerId, userId)
+}
+
+// MockCatalogStore is a mock of CatalogStore interface.
+type MockCatalogStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogStoreMockRecorder
+}
+
+// MockCatalogStoreMockRecorder is the mock recorder for MockCatalogStore.
+type MockCatalogStoreMockRecorder struct {
+	mock *MockCatalogStore
+}
+
+// NewMockCatalogStore creates a new mock instance.
+func NewMockCatalogStore(ctrl *gomock.Controller) *MockCatalogStore {
+	mock := &MockCatalogStore{ctrl: ctrl}
+	mock.recorder = &MockCatalogStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalogStore) EXPECT() *MockCatalogStoreMockRecorder {
+	return m.recorder
+}
+
+// ListenCatalogChanges mocks base method.
+func (m *MockCatalogStore) ListenCatalogChanges(ctx context.Context) (chan struct{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListenCatalogChanges", ctx)
+	ret0, _ := ret[0].(chan struct{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListenCatalogChanges indicates an expected call of ListenCatalogChanges.
+func (mr *MockCatalogStoreMockRecorder) ListenCatalogChanges(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListenCatalogChanges", reflect.TypeOf((*MockCatalogStore)(nil).ListenCatalogChanges), ctx)
+}
+
+// MockAuditStore is a mock of AuditStore interface.
+type MockAuditStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditStoreMockRecorder
+}
+
+// MockAuditStoreMockRecorder is the mock recorder for MockAuditStore.
+type MockAuditStoreMockRecorder struct {
+	mock *MockAuditStore
+}
+
+// NewMockAuditStore creates a new mock instance.
+func NewMockAuditStore(ctrl *gomock.Controller) *MockAuditStore {
+	mock := &MockAuditStore{ctrl: ctrl}
+	mock.recorder = &MockAuditStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditStore) EXPECT() *MockAuditStoreMockRecorder {
+	return m.recorder
+}
+
+// GetAuditLog mocks base method.
+func (m *MockAuditStore) GetAuditLog(ctx context.Context, filter models.AuditFilter) (chan models.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLog", ctx, filter)
+	ret0, _ := ret[0].(chan models.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditLog indicates an expected call of GetAuditLog.
+func (mr *MockAuditStoreMockRecorder) GetAuditLog(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLog", reflect.TypeOf((*MockAuditStore)(nil).GetAuditLog), ctx, filter)
+}
+
+// MockAddressStore is a mock of AddressStore interface.
+type MockAddressStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockAddressStoreMockRecorder
+}
+
+// MockAddressStoreMockRecorder is the mock recorder for MockAddressStore.
+type MockAddressStoreMockRecorder struct {
+	mock *MockAddressStore
+}
+
+// NewMockAddressStore creates a new mock instance.
+func NewMockAddressStore(ctrl *gomock.Controller) *MockAddressStore {
+	mock := &MockAddressStore{ctrl: ctrl}
+	mock.recorder = &MockAddressStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAddressStore) EXPECT() *MockAddressStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateAddress mocks base method.
+func (m *MockAddressStore) CreateAddress(ctx context.Context, address *models.SavedAddress) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAddress", ctx, address)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAddress indicates an expected call of CreateAddress.
+func (mr *MockAddressStoreMockRecorder) CreateAddress(ctx, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAddress", reflect.TypeOf((*MockAddressStore)(nil).CreateAddress), ctx, address)
+}
+
+// DeleteAddress mocks base method.
+func (m *MockAddressStore) DeleteAddress(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAddress", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAddress indicates an expected call of DeleteAddress.
+func (mr *MockAddressStoreMockRecorder) DeleteAddress(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAddress", reflect.TypeOf((*MockAddressStore)(nil).DeleteAddress), ctx, id)
+}
+
+// GetAddress mocks base method.
+func (m *MockAddressStore) GetAddress(ctx context.Context, id uuid.UUID) (*models.SavedAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddress", ctx, id)
+	ret0, _ := ret[0].(*models.SavedAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddress indicates an expected call of GetAddress.
+func (mr *MockAddressStoreMockRecorder) GetAddress(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddress", reflect.TypeOf((*MockAddressStore)(nil).GetAddress), ctx, id)
+}
+
+// GetAddresses mocks base method.
+func (m *MockAddressStore) GetAddresses(ctx context.Context, userId uuid.UUID) ([]models.SavedAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddresses", ctx, userId)
+	ret0, _ := ret[0].([]models.SavedAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddresses indicates an expected call of GetAddresses.
+func (mr *MockAddressStoreMockRecorder) GetAddresses(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddresses", reflect.TypeOf((*MockAddressStore)(nil).GetAddresses), ctx, userId)
+}
+
+// UpdateAddress mocks base method.
+func (m *MockAddressStore) UpdateAddress(ctx context.Context, address *models.SavedAddress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAddress", ctx, address)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAddress indicates an expected call of UpdateAddress.
+func (mr *MockAddressStoreMockRecorder) UpdateAddress(ctx, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAddress", reflect.TypeOf((*MockAddressStore)(nil).UpdateAddress), ctx, address)
+}
+
+// MockNotificationStore is a mock of NotificationStore interface.
+type MockNotificationStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationStoreMockRecorder
+}
+
+// MockNotificationStoreMockRecorder is the mock recorder for MockNotificationStore.
+type MockNotificationStoreMockRecorder struct {
+	mock *MockNotificationStore
+}
+
+// NewMockNotificationStore creates a new mock instance.
+func NewMockNotificationStore(ctrl *gomock.Controller) *MockNotificationStore {
+	mock := &MockNotificationStore{ctrl: ctrl}
+	mock.recorder = &MockNotificationStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationStore) EXPECT() *MockNotificationStoreMockRecorder {
+	return m.recorder
+}
+
+// ClaimNotifications mocks base method.
+func (m *MockNotificationStore) ClaimNotifications(ctx context.Context, limit int, lease time.Duration) ([]models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimNotifications", ctx, limit, lease)
+	ret0, _ := ret[0].([]models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimNotifications indicates an expected call of ClaimNotifications.
+func (mr *MockNotificationStoreMockRecorder) ClaimNotifications(ctx, limit, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimNotifications", reflect.TypeOf((*MockNotificationStore)(nil).ClaimNotifications), ctx, limit, lease)
+}
+
+// GetNotificationPreferences mocks base method.
+func (m *MockNotificationStore) GetNotificationPreferences(ctx context.Context, userId uuid.UUID) (*models.NotificationPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationPreferences", ctx, userId)
+	ret0, _ := ret[0].(*models.NotificationPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationPreferences indicates an expected call of GetNotificationPreferences.
+func (mr *MockNotificationStoreMockRecorder) GetNotificationPreferences(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationPreferences", reflect.TypeOf((*MockNotificationStore)(nil).GetNotificationPreferences), ctx, userId)
+}
+
+// SetNotificationPreferences mocks base method.
+func (m *MockNotificationStore) SetNotificationPreferences(ctx context.Context, preferences *models.NotificationPreferences) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNotificationPreferences", ctx, preferences)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNotificationPreferences indicates an expected call of SetNotificationPreferences.
+func (mr *MockNotificationStoreMockRecorder) SetNotificationPreferences(ctx, preferences interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNotificationPreferences", reflect.TypeOf((*MockNotificationStore)(nil).SetNotificationPreferences), ctx, preferences)
+}
+
+// UpdateNotification mocks base method.
+func (m *MockNotificationStore) UpdateNotification(ctx context.Context, notification *models.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotification", ctx, notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNotification indicates an expected call of UpdateNotification.
+func (mr *MockNotificationStoreMockRecorder) UpdateNotification(ctx, notification interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotification", reflect.TypeOf((*MockNotificationStore)(nil).UpdateNotification), ctx, notification)
+}
+
+// MockShipmentStore is a mock of ShipmentStore interface.
+type MockShipmentStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockShipmentStoreMockRecorder
+}
+
+// MockShipmentStoreMockRecorder is the mock recorder for MockShipmentStore.
+type MockShipmentStoreMockRecorder struct {
+	mock *MockShipmentStore
+}
+
+// NewMockShipmentStore creates a new mock instance.
+func NewMockShipmentStore(ctrl *gomock.Controller) *MockShipmentStore {
+	mock := &MockShipmentStore{ctrl: ctrl}
+	mock.recorder = &MockShipmentStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShipmentStore) EXPECT() *MockShipmentStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateShipment mocks base method.
+func (m *MockShipmentStore) CreateShipment(ctx context.Context, shipment *models.Shipment, statuses []models.Status) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShipment", ctx, shipment, statuses)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateShipment indicates an expected call of CreateShipment.
+func (mr *MockShipmentStoreMockRecorder) CreateShipment(ctx, shipment, statuses interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShipment", reflect.TypeOf((*MockShipmentStore)(nil).CreateShipment), ctx, shipment, statuses)
+}
+
+// GetShipment mocks base method.
+func (m *MockShipmentStore) GetShipment(ctx context.Context, orderId uuid.UUID) (*models.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShipment", ctx, orderId)
+	ret0, _ := ret[0].(*models.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShipment indicates an expected call of GetShipment.
+func (mr *MockShipmentStoreMockRecorder) GetShipment(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShipment", reflect.TypeOf((*MockShipmentStore)(nil).GetShipment), ctx, orderId)
+}
+
+// GetShipmentsToTrack mocks base method.
+func (m *MockShipmentStore) GetShipmentsToTrack(ctx context.Context, carrier string, limit int) ([]models.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShipmentsToTrack", ctx, carrier, limit)
+	ret0, _ := ret[0].([]models.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShipmentsToTrack indicates an expected call of GetShipmentsToTrack.
+func (mr *MockShipmentStoreMockRecorder) GetShipmentsToTrack(ctx, carrier, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShipmentsToTrack", reflect.TypeOf((*MockShipmentStore)(nil).GetShipmentsToTrack), ctx, carrier, limit)
+}
+
+// UpdateTracking mocks base method.
+func (m *MockShipmentStore) UpdateTracking(ctx context.Context, shipment *models.Shipment, delivered bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTracking", ctx, shipment, delivered)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTracking indicates an expected call of UpdateTracking.
+func (mr *MockShipmentStoreMockRecorder) UpdateTracking(ctx, shipment, delivered interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTracking", reflect.TypeOf((*MockShipmentStore)(nil).UpdateTracking), ctx, shipment, delivered)
+}
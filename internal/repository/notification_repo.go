@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type notification struct {
+	storage *PGres
+	logger  *zap.SugaredLogger
+}
+
+var _ NotificationStore = (*notification)(nil)
+
+func NewNotificationRepo(storage *PGres, logger *zap.SugaredLogger) NotificationStore {
+	return &notification{
+		storage: storage,
+		logger:  logger,
+	}
+}
+
+// ClaimNotifications returns at most limit pending notifications due to be sent with the emails
+// and the preferences of their users, the oldest notifications go first. The next attempt of claimed
+// notifications is postponed for the lease, so other workers don't send them while they are sent
+func (n *notification) ClaimNotifications(ctx context.Context, limit int, lease time.Duration) ([]models.Notification, error) {
+	n.logger.Debugf("Enter in repository ClaimNotifications() with args: ctx, limit: %d, lease: %v", limit, lease)
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("context closed")
+	default:
+	}
+	rows, err := n.storage.GetPool().Query(ctx, `
+	WITH claimed AS (
+		UPDATE notifications SET next_attempt_at = now() + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT id FROM notifications WHERE state = $3 AND next_attempt_at <= now()
+			ORDER BY next_attempt_at LIMIT $1 FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, kind, user_id, order_id, status, state, attempts, last_error, next_attempt_at, created_at
+	)
+	SELECT c.id, c.kind, c.user_id, c.order_id, coalesce(c.status, ''), c.state, c.attempts, c.last_error,
+	c.next_attempt_at, c.created_at, u.email, u.name, coalesce(p.language, ''), coalesce(p.order_placed, true),
+	coalesce(p.order_status, true), coalesce(p.order_shipped, true)
+	FROM claimed c
+	JOIN users u ON u.id = c.user_id
+	LEFT JOIN notification_preferences p ON p.user_id = c.user_id
+	ORDER BY c.created_at`, limit, lease.Seconds(), models.NotificationPending)
+	if err != nil {
+		n.logger.Errorf("can't claim notifications: %s", err)
+		return nil, fmt.Errorf("can't claim notifications: %w", err)
+	}
+	defer rows.Close()
+	notifications := make([]models.Notification, 0, limit)
+	for rows.Next() {
+		var notification models.Notification
+		var orderId *uuid.UUID
+		if err := rows.Scan(
+			&notification.Id,
+			&notification.Kind,
+			&notification.UserId,
+			&orderId,
+			&notification.Status,
+			&notification.State,
+			&notification.Attempts,
+			&notification.LastError,
+			&notification.NextAttemptAt,
+			&notification.CreatedAt,
+			&notification.Email,
+			&notification.Name,
+			&notification.Preferences.Language,
+			&notification.Preferences.OrderPlaced,
+			&notification.Preferences.OrderStatus,
+			&notification.Preferences.OrderShipped,
+		); err != nil {
+			n.logger.Errorf("can't scan notification: %s", err)
+			return nil, fmt.Errorf("can't scan notification: %w", err)
+		}
+		if orderId != nil {
+			notification.OrderId = *orderId
+		}
+		notification.Preferences.UserId = notification.UserId
+		notifications = append(notifications, notification)
+	}
+	if err := rows.Err(); err != nil {
+		n.logger.Errorf("can't read notifications: %s", err)
+		return nil, fmt.Errorf("can't read notifications: %w", err)
+	}
+	return notifications, nil
+}
+
+// UpdateNotification saves the state of sending of the notification
+func (n *notification) UpdateNotification(ctx context.Context, notification *models.Notification) error {
+	n.logger.Debugf("Enter in repository UpdateNotification() with args: ctx, notification: %v", notification.Id)
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context closed")
+	default:
+	}
+	var sentAt interface{}
+	if !notification.SentAt.IsZero() {
+		sentAt = notification.SentAt
+	}
+	tag, err := n.storage.GetQuerier(ctx).Exec(ctx, `UPDATE notifications SET state = $2, attempts = $3, last_error = $4,
+	next_attempt_at = $5, sent_at = $6 WHERE id = $1`,
+		notification.Id, notification.State, notification.Attempts, notification.LastError, notification.NextAttemptAt, sentAt)
+	if err != nil {
+		n.logger.Errorf("can't update notification: %s", err)
+		return fmt.Errorf("can't update notification: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		n.logger.Errorf("notification with id: %v not found", notification.Id)
+		return models.ErrorNotFound{}
+	}
+	return nil
+}
+
+// GetNotificationPreferences returns the preferences of the user,
+// the user who has not changed them gets the default preferences
+func (n *notification) GetNotificationPreferences(ctx context.Context, userId uuid.UUID) (*models.NotificationPreferences, error) {
+	n.logger.Debugf("Enter in repository GetNotificationPreferences() with args: ctx, userId: %v", userId)
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("context closed")
+	default:
+	}
+	preferences := models.NotificationPreferences{UserId: userId}
+	err := n.storage.GetQuerier(ctx).QueryRow(ctx, `SELECT language, order_placed, order_status, order_shipped
+	FROM notification_preferences WHERE user_id = $1`, userId).Scan(
+		&preferences.Language,
+		&preferences.OrderPlaced,
+		&preferences.OrderStatus,
+		&preferences.OrderShipped,
+	)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		preferences = models.DefaultNotificationPreferences(userId)
+		return &preferences, nil
+	}
+	if err != nil {
+		n.logger.Errorf("can't get notification preferences: %s", err)
+		return nil, fmt.Errorf("can't get notification preferences: %w", err)
+	}
+	return &preferences, nil
+}
+
+// SetNotificationPreferences saves the preferences of the user
+func (n *notification) SetNotificationPreferences(ctx context.Context, preferences *models.NotificationPreferences) error {
+	n.logger.Debugf("Enter in repository SetNotificationPreferences() with args: ctx, preferences: %v", preferences)
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context closed")
+	default:
+	}
+	_, err := n.storage.GetQuerier(ctx).Exec(ctx, `INSERT INTO notification_preferences
+	(user_id, language, order_placed, order_status, order_shipped) VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (user_id) DO UPDATE SET language = EXCLUDED.language, order_placed = EXCLUDED.order_placed,
+	order_status = EXCLUDED.order_status, order_shipped = EXCLUDED.order_shipped, updated_at = now()`,
+		preferences.UserId, preferences.Language, preferences.OrderPlaced, preferences.OrderStatus, preferences.OrderShipped)
+	if err != nil && strings.Contains(err.Error(), "fk_user_id") {
+		n.logger.Errorf("user with id: %v not found", preferences.UserId)
+		return models.ErrorNotFound{}
+	}
+	if err != nil {
+		n.logger.Errorf("can't set notification preferences: %s", err)
+		return fmt.Errorf("can't set notification preferences: %w", err)
+	}
+	return nil
+}
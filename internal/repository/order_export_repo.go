@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"fmt"
+)
+
+// ExportOrders returns lines of orders satisfying the filter with the totals of their orders calculated
+// by the pricing, ordered by the time of creation of orders. Lines are read from the database
+// while they are received from the channel, the sort type and the page of the filter are ignored.
+// The error of the reading is sent to the channel of errors, which is closed before the channel of lines
+func (o *order) ExportOrders(ctx context.Context, filter models.OrderFilter, pricing models.CartPricing) (chan models.OrderExportLine, chan error, error) {
+	o.logger.Debugf("Enter in repository ExportOrders() with args: ctx, filter: %v", filter)
+	select {
+	case <-ctx.Done():
+		return nil, nil, fmt.Errorf("context closed")
+	default:
+	}
+	ordersWhere, totalsWhere, args := orderConditions(filter, pricing, true)
+	query := orderTotalsQuery(ordersWhere) + `SELECT totals.id, totals.created_at, totals.status, users.id, users.name, coalesce(users.lastname, ''),
+	totals.email, orders.zipcode, orders.country, orders.city, orders.street, orders.apartment, orders.recipient, orders.phone,
+	totals.shipping_method, items.id, items.name, order_items.price, order_items.item_quantity,
+	totals.subtotal, totals.discount, CASE WHEN totals.subtotal = 0 THEN 0 ELSE totals.total - totals.subtotal + totals.discount END,
+	totals.total
+	FROM (SELECT * FROM totals` + totalsWhere + `) AS totals
+	INNER JOIN orders ON orders.id = totals.id
+	INNER JOIN users ON users.id = totals.user_id
+	INNER JOIN order_items ON order_items.order_id = totals.id
+	INNER JOIN items ON items.id = order_items.item_id
+	ORDER BY totals.created_at, totals.id, items.name`
+	rows, err := o.storage.GetPool().Query(ctx, query, args...)
+	if err != nil {
+		o.logger.Errorf("can't export orders: %s", err)
+		return nil, nil, fmt.Errorf("can't export orders: %w", err)
+	}
+	lines := make(chan models.OrderExportLine, 100)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(lines)
+		defer close(errs)
+		defer rows.Close()
+		for rows.Next() {
+			var line models.OrderExportLine
+			if err := rows.Scan(
+				&line.OrderId,
+				&line.CreatedAt,
+				&line.Status,
+				&line.User.ID,
+				&line.User.Firstname,
+				&line.User.Lastname,
+				&line.User.Email,
+				&line.Address.Zipcode,
+				&line.Address.Country,
+				&line.Address.City,
+				&line.Address.Street,
+				&line.Address.Apartment,
+				&line.Address.Recipient,
+				&line.Address.Phone,
+				&line.ShippingMethod,
+				&line.Item.Id,
+				&line.Item.Title,
+				&line.Price,
+				&line.Quantity,
+				&line.Subtotal,
+				&line.Discount,
+				&line.Shipping,
+				&line.Total,
+			); err != nil {
+				o.logger.Errorf("can't scan order line: %s", err)
+				errs <- fmt.Errorf("can't scan order line: %w", err)
+				return
+			}
+			select {
+			case lines <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			o.logger.Errorf("can't export orders: %s", err)
+			errs <- fmt.Errorf("can't export orders: %w", err)
+		}
+	}()
+	return lines, errs, nil
+}
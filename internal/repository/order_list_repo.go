@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// orderTotalsQuery selects orders satisfying the conditions on orders with their totals calculated
+// as models.Order.Totals does, the pricing of orders without the shipping method is passed in
+// $1 - discount threshold, $2 - discount percent, $3 - shipping cost and $4 - free shipping threshold.
+// Orders are filtered before their sums are calculated, so only totals are calculated for all orders
+func orderTotalsQuery(where string) string {
+	return `WITH sums AS (
+	SELECT orders.id, orders.created_at, orders.shipment_time, orders.user_id, users.email, orders.status,
+	orders.city, orders.shipping_method, orders.shipping_cost, orders.discount AS order_discount,
+	coalesce(sum(order_items.price::bigint * order_items.item_quantity), 0) AS subtotal,
+	coalesce(sum(order_items.item_quantity), 0) AS quantity
+	FROM orders INNER JOIN users ON users.id = orders.user_id
+	LEFT JOIN order_items ON order_items.order_id = orders.id` + where + `
+	GROUP BY orders.id, users.email
+), discounts AS (
+	SELECT sums.*, CASE
+		WHEN subtotal = 0 THEN 0
+		WHEN shipping_method <> '' THEN order_discount
+		WHEN $2::bigint > 0 AND subtotal >= $1::bigint THEN subtotal * $2::bigint / 100
+		ELSE 0
+	END AS discount
+	FROM sums
+), totals AS (
+	SELECT discounts.*, CASE
+		WHEN subtotal = 0 THEN 0
+		WHEN shipping_method <> '' THEN subtotal - discount + shipping_cost
+		WHEN $4::bigint > 0 AND subtotal - discount >= $4::bigint THEN subtotal - discount
+		ELSE subtotal - discount + $3::bigint
+	END AS total
+	FROM discounts
+)
+`
+}
+
+// orderSortColumns is the columns of totals by sort types of orders
+var orderSortColumns = map[string]string{
+	models.OrderSortCreatedAt: "created_at",
+	models.OrderSortTotal:     "total",
+	models.OrderSortStatus:    "status",
+	models.OrderSortEmail:     "email",
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// orderConditions returns the conditions of the filter on orders for orderTotalsQuery, the conditions
+// on totals and the arguments of the query starting with the pricing. The status is not filtered if withStatus is false
+func orderConditions(filter models.OrderFilter, pricing models.CartPricing, withStatus bool) (string, string, []interface{}) {
+	orderConditions := make([]string, 0, 6)
+	totalConditions := make([]string, 0, 2)
+	args := []interface{}{pricing.DiscountThreshold, pricing.DiscountPercent, pricing.ShippingCost, pricing.FreeShippingThreshold}
+	addCondition := func(conditions *[]string, condition string, arg interface{}) {
+		args = append(args, arg)
+		*conditions = append(*conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if withStatus && filter.Status != "" {
+		addCondition(&orderConditions, "orders.status = $%d", filter.Status)
+	}
+	if !filter.From.IsZero() {
+		addCondition(&orderConditions, "orders.created_at >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		addCondition(&orderConditions, "orders.created_at < $%d", filter.To)
+	}
+	if filter.Email != "" {
+		addCondition(&orderConditions, "users.email ILIKE $%d", "%"+likeEscaper.Replace(filter.Email)+"%")
+	}
+	if filter.City != "" {
+		addCondition(&orderConditions, "lower(orders.city) = lower($%d)", filter.City)
+	}
+	if filter.ItemId != uuid.Nil {
+		addCondition(&orderConditions, "EXISTS (SELECT 1 FROM order_items AS ordered WHERE ordered.order_id = orders.id AND ordered.item_id = $%d)", filter.ItemId)
+	}
+	if filter.MinTotal != nil {
+		addCondition(&totalConditions, "total >= $%d", *filter.MinTotal)
+	}
+	if filter.MaxTotal != nil {
+		addCondition(&totalConditions, "total <= $%d", *filter.MaxTotal)
+	}
+	return where(orderConditions), where(totalConditions), args
+}
+
+// where returns the WHERE clause of the conditions or the empty string if there are no conditions
+func where(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// GetOrders returns the page of orders satisfying the filter with their totals calculated by the pricing,
+// orders are sorted by the sort type of the filter or by the time of creation if it is empty
+func (o *order) GetOrders(ctx context.Context, filter models.OrderFilter, pricing models.CartPricing) ([]models.OrderSummary, error) {
+	o.logger.Debugf("Enter in repository GetOrders() with args: ctx, filter: %v", filter)
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("context closed")
+	default:
+	}
+	ordersWhere, totalsWhere, args := orderConditions(filter, pricing, true)
+	column, ok := orderSortColumns[filter.SortType]
+	if !ok {
+		column = orderSortColumns[models.OrderSortCreatedAt]
+	}
+	direction := "ASC"
+	if filter.SortDesc {
+		direction = "DESC"
+	}
+	query := orderTotalsQuery(ordersWhere) + `SELECT id, created_at, shipment_time, user_id, email, status, shipping_method, quantity, total
+	FROM totals` + totalsWhere + fmt.Sprintf(" ORDER BY %s %s, id %s", column, direction, direction)
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+	rows, err := o.storage.GetQuerier(ctx).Query(ctx, query, args...)
+	if err != nil {
+		o.logger.Errorf("can't get orders: %s", err)
+		return nil, fmt.Errorf("can't get orders: %w", err)
+	}
+	defer rows.Close()
+	orders := make([]models.OrderSummary, 0, filter.Limit)
+	for rows.Next() {
+		var summary models.OrderSummary
+		if err := rows.Scan(
+			&summary.ID,
+			&summary.CreatedAt,
+			&summary.ShipmentTime,
+			&summary.User.ID,
+			&summary.User.Email,
+			&summary.Status,
+			&summary.ShippingMethod,
+			&summary.ItemsQuantity,
+			&summary.Total,
+		); err != nil {
+			o.logger.Errorf("can't scan order: %s", err)
+			return nil, fmt.Errorf("can't scan order: %w", err)
+		}
+		orders = append(orders, summary)
+	}
+	if err := rows.Err(); err != nil {
+		o.logger.Errorf("can't get orders: %s", err)
+		return nil, fmt.Errorf("can't get orders: %w", err)
+	}
+	return orders, nil
+}
+
+// CountOrdersByStatus returns quantities of orders satisfying the filter by status,
+// the status of the filter is not taken into account
+func (o *order) CountOrdersByStatus(ctx context.Context, filter models.OrderFilter, pricing models.CartPricing) (map[models.Status]int, error) {
+	o.logger.Debugf("Enter in repository CountOrdersByStatus() with args: ctx, filter: %v", filter)
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("context closed")
+	default:
+	}
+	ordersWhere, totalsWhere, args := orderConditions(filter, pricing, false)
+	rows, err := o.storage.GetQuerier(ctx).Query(ctx, orderTotalsQuery(ordersWhere)+`SELECT status, count(1) FROM totals`+totalsWhere+` GROUP BY status`, args...)
+	if err != nil {
+		o.logger.Errorf("can't count orders: %s", err)
+		return nil, fmt.Errorf("can't count orders: %w", err)
+	}
+	defer rows.Close()
+	counts := make(map[models.Status]int)
+	for rows.Next() {
+		var status models.Status
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			o.logger.Errorf("can't scan count of orders: %s", err)
+			return nil, fmt.Errorf("can't scan count of orders: %w", err)
+		}
+		counts[status] = count
+	}
+	if err := rows.Err(); err != nil {
+		o.logger.Errorf("can't count orders: %s", err)
+		return nil, fmt.Errorf("can't count orders: %w", err)
+	}
+	return counts, nil
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"go.uber.org/zap"
 )
@@ -25,14 +26,18 @@ func NewOrderRepo(store *PGres, log *zap.SugaredLogger) OrderStore {
 	}
 }
 
-func (o *order) Create(ctx context.Context, order *models.Order) (*models.Order, error) {
+func (o *order) Create(ctx context.Context, order *models.Order) (res *models.Order, err error) {
 	o.logger.Debug("Enter in repository order Create with args: ctx, order: %v", order)
 	select {
 	case <-ctx.Done():
 		return nil, fmt.Errorf("stopped with context")
 	default:
-		pool := o.storage.GetPool()
-		tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+		if len(order.Items) == 0 {
+			o.logger.Errorf("can't add new order: order has no items")
+			return nil, fmt.Errorf("can't add new order: %w", models.ErrorEmptyCart{})
+		}
+		var tx pgx.Tx
+		tx, err = o.storage.BeginTx(ctx)
 		if err != nil {
 			o.logger.Errorf("can't create transaction: %s", err)
 			return nil, fmt.Errorf("can't create transaction: %w", err)
@@ -40,29 +45,36 @@ func (o *order) Create(ctx context.Context, order *models.Order) (*models.Order,
 		defer func() {
 			if err != nil {
 				o.logger.Errorf("transaction rolled back")
-				if err = tx.Rollback(ctx); err != nil {
-					o.logger.Errorf("can't rollback %s", err)
+				if rbErr := tx.Rollback(ctx); rbErr != nil {
+					o.logger.Errorf("can't rollback %s", rbErr)
 				}
 
 			} else {
 				o.logger.Info("transaction commited")
-				if err != tx.Commit(ctx) {
+				if err = tx.Commit(ctx); err != nil {
 					o.logger.Errorf("can't commit %s", err)
+					err = fmt.Errorf("can't commit transaction: %w", err)
+					res = nil
 				}
 			}
 		}()
-		row := tx.QueryRow(ctx, `INSERT INTO orders (created_at, shipment_time, user_id, status, address) 
-		VALUES ($1, $2, $3, $4, $5) RETURNING id`, order.CreatedAt, order.ShipmentTime, order.User.ID, order.Status,
-			fmt.Sprintf("%s -> %s -> %s -> %s", order.Address.Zipcode, order.Address.Country, order.Address.City, order.Address.Street))
+		row := tx.QueryRow(ctx, `INSERT INTO orders (created_at, shipment_time, user_id, status, zipcode, country, city, street,
+		apartment, recipient, phone, stock_reserved, shipping_method, shipping_cost, discount)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, true, $12, $13, $14) RETURNING id`, order.CreatedAt, order.ShipmentTime, order.User.ID, order.Status,
+			order.Address.Zipcode, order.Address.Country, order.Address.City, order.Address.Street,
+			order.Address.Apartment, order.Address.Recipient, order.Address.Phone,
+			order.ShippingMethod, order.ShippingCost, order.Discount)
 		err = row.Scan(&order.ID)
 		if err != nil {
 			o.logger.Errorf("can't add new order: %w", err)
 			return nil, fmt.Errorf("can't add new order: %w", err)
 		}
-		query := `INSERT INTO order_items (order_id, item_id, item_quantity) VALUES`
+		// The price of the item is saved with the order, so the order keeps it when the catalog changes
+		query := `INSERT INTO order_items (order_id, item_id, item_quantity, price) VALUES`
 		itemsString := ""
 		for _, item := range order.Items {
-			itemsString += fmt.Sprintf("('%s', '%s', '%d'),", order.ID.String(), item.Id.String(), item.Quantity)
+			itemsString += fmt.Sprintf("('%s', '%s', '%d', (SELECT price FROM items WHERE id = '%s')),",
+				order.ID.String(), item.Id.String(), item.Quantity, item.Id.String())
 		}
 		itemsString = itemsString[:len(itemsString)-1]
 		_, err = tx.Exec(ctx, fmt.Sprintf("%s %s;", query, itemsString))
@@ -70,32 +82,61 @@ func (o *order) Create(ctx context.Context, order *models.Order) (*models.Order,
 			o.logger.Errorf("can't add items to order: %s", err)
 			return nil, fmt.Errorf("can't add items to order: %w", err)
 		}
+		// The stock of the ordered items is reserved, the stock which is not tracked stays NULL
+		for _, item := range order.Items {
+			var tag pgconn.CommandTag
+			tag, err = tx.Exec(ctx, `UPDATE items SET stock = stock - $2 WHERE id = $1 AND (stock IS NULL OR stock >= $2)`,
+				item.Id, item.Quantity)
+			if err != nil {
+				o.logger.Errorf("can't reserve stock: %s", err)
+				return nil, fmt.Errorf("can't reserve stock: %w", err)
+			}
+			if tag.RowsAffected() == 0 {
+				o.logger.Errorf("can't reserve stock: not enough items %v in stock", item.Id)
+				err = fmt.Errorf("not enough items %v in stock: %w", item.Id, models.ErrorQuantityExceeded{})
+				return nil, err
+			}
+		}
 		return order, nil
 	}
 }
 
-func (o *order) DeleteOrder(ctx context.Context, order *models.Order) error {
+func (o *order) DeleteOrder(ctx context.Context, order *models.Order) (err error) {
 	o.logger.Debug("Enter in repository DeleteOrder() with args: ctx, order: %v", order)
 	select {
 	case <-ctx.Done():
 		return fmt.Errorf("context closed")
 	default:
 		pool := o.storage.GetPool()
-		tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+		var tx pgx.Tx
+		tx, err = pool.BeginTx(ctx, pgx.TxOptions{})
+		if err != nil {
+			o.logger.Errorf("can't create transaction: %s", err)
+			return fmt.Errorf("can't create transaction: %w", err)
+		}
 		defer func() {
 			if err != nil {
 				o.logger.Errorf("transaction rolled back")
-				if err = tx.Rollback(ctx); err != nil {
-					o.logger.Errorf("can't rollback %s", err)
+				if rbErr := tx.Rollback(ctx); rbErr != nil {
+					o.logger.Errorf("can't rollback %s", rbErr)
 				}
 
 			} else {
 				o.logger.Info("transaction commited")
-				if err != tx.Commit(ctx) {
+				if err = tx.Commit(ctx); err != nil {
 					o.logger.Errorf("can't commit %s", err)
+					err = fmt.Errorf("can't commit transaction: %w", err)
 				}
 			}
 		}()
+		// The stock reserved by the order which is not shipped yet is released
+		if order.Status.CanBeCanceled(true) {
+			err = releaseStock(ctx, tx, []string{order.ID.String()})
+			if err != nil {
+				o.logger.Errorf("can't release stock of order: %s", err)
+				return fmt.Errorf("can't release stock of order: %w", err)
+			}
+		}
 		_, err = tx.Exec(ctx, `DELETE FROM order_items WHERE order_id=$1`, order.ID)
 		if err != nil {
 			o.logger.Errorf("can't delete order items from order: %s", err)
@@ -109,6 +150,8 @@ func (o *order) DeleteOrder(ctx context.Context, order *models.Order) error {
 		return nil
 	}
 }
+// ChangeAddress changes the address of the order if the order is still in the status in which it was read,
+// otherwise ErrorWrongStatus is returned
 func (o *order) ChangeAddress(ctx context.Context, order *models.Order, address models.UserAddress) error {
 	o.logger.Debug("Enter in repository order ChangeAddress() with args: ctx, order: %v, address: %v", order, address)
 	select {
@@ -116,23 +159,74 @@ func (o *order) ChangeAddress(ctx context.Context, order *models.Order, address
 		return fmt.Errorf("context closed")
 	default:
 		pool := o.storage.GetPool()
-		_, err := pool.Exec(ctx, `UPDATE orders SET address=$1 WHERE id=$2`,
-			fmt.Sprintf("%s -> %s -> %s -> %s", address.Zipcode, address.Country, address.City, address.Street), order.ID)
+		tag, err := pool.Exec(ctx, `UPDATE orders SET zipcode=$1, country=$2, city=$3, street=$4, apartment=$5, recipient=$6, phone=$7
+			WHERE id=$8 AND status=$9`, address.Zipcode, address.Country, address.City, address.Street,
+			address.Apartment, address.Recipient, address.Phone, order.ID, order.Status)
 		if err != nil {
 			o.logger.Errorf("can't update address: %s", err)
 			return fmt.Errorf("can't update address: %w", err)
 		}
+		if tag.RowsAffected() == 0 {
+			o.logger.Errorf("can't update address: order %v is not in status %q", order.ID, order.Status)
+			return fmt.Errorf("order %v is not in status %q: %w", order.ID, order.Status, models.ErrorWrongStatus{})
+		}
 		return nil
 	}
 }
-func (o *order) ChangeStatus(ctx context.Context, order *models.Order, status models.Status) error {
+func (o *order) ChangeStatus(ctx context.Context, order *models.Order, status models.Status) (err error) {
 	o.logger.Debug("Enter in repository order ChangeStatus() with args: ctx, order: %v, status: %v", order, status)
 	select {
 	case <-ctx.Done():
 		return fmt.Errorf("context closed")
 	default:
 		pool := o.storage.GetPool()
-		_, err := pool.Exec(ctx, `UPDATE orders SET status=$1 WHERE id=$2`, status, order.ID)
+		var tx pgx.Tx
+		tx, err = pool.BeginTx(ctx, pgx.TxOptions{})
+		if err != nil {
+			o.logger.Errorf("can't create transaction: %s", err)
+			return fmt.Errorf("can't create transaction: %w", err)
+		}
+		defer func() {
+			if err != nil {
+				o.logger.Errorf("transaction rolled back")
+				if rbErr := tx.Rollback(ctx); rbErr != nil {
+					o.logger.Errorf("can't rollback %s", rbErr)
+				}
+
+			} else {
+				o.logger.Info("transaction commited")
+				if err = tx.Commit(ctx); err != nil {
+					o.logger.Errorf("can't commit %s", err)
+					err = fmt.Errorf("can't commit transaction: %w", err)
+				}
+			}
+		}()
+		// The status before the change is locked until the end
+		// of transaction and written to the audit log
+		var before models.Status
+		err = tx.QueryRow(ctx, `SELECT status FROM orders WHERE id=$1 FOR UPDATE`, order.ID).Scan(&before)
+		if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+			o.logger.Errorf("can't update status: %s", err)
+			return models.ErrorNotFound{}
+		} else if err != nil {
+			o.logger.Errorf("can't update status: %s", err)
+			return fmt.Errorf("can't update status: %w", err)
+		}
+		_, err = tx.Exec(ctx, `UPDATE orders SET status=$1 WHERE id=$2`, status, order.ID)
+		if err != nil {
+			o.logger.Errorf("can't update status: %s", err)
+			return fmt.Errorf("can't update status: %w", err)
+		}
+		// The order canceled by the change of the status releases the stock as the cancel does
+		if status == models.StatusCanceled && before.CanBeCanceled(true) {
+			err = releaseStock(ctx, tx, []string{order.ID.String()})
+			if err != nil {
+				o.logger.Errorf("can't release stock of order: %s", err)
+				return fmt.Errorf("can't release stock of order: %w", err)
+			}
+		}
+		err = addAuditEntry(ctx, tx, models.NewAuditEntry(ctx, models.AuditOrderStatusChange, models.AuditEntityOrder, order.ID.String(),
+			map[string]interface{}{"status": before}, map[string]interface{}{"status": status}))
 		if err != nil {
 			o.logger.Errorf("can't update status: %s", err)
 			return fmt.Errorf("can't update status: %w", err)
@@ -140,6 +234,90 @@ func (o *order) ChangeStatus(ctx context.Context, order *models.Order, status mo
 		return nil
 	}
 }
+
+// CancelOrder cancels the order in one of the statuses with the reason, releases the stock reserved by the order
+// and returns the status of the order before the cancel. If the order is in another status, ErrorWrongStatus is returned
+func (o *order) CancelOrder(ctx context.Context, id uuid.UUID, reason string, statuses []models.Status) (before models.Status, err error) {
+	o.logger.Debugf("Enter in repository CancelOrder() with args: ctx, id: %v, reason: %s, statuses: %v", id, reason, statuses)
+	select {
+	case <-ctx.Done():
+		return "", fmt.Errorf("context closed")
+	default:
+	}
+	tx, err := o.storage.BeginTx(ctx)
+	if err != nil {
+		o.logger.Errorf("can't create transaction: %s", err)
+		return "", fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			o.logger.Errorf("transaction rolled back")
+			if err := tx.Rollback(ctx); err != nil {
+				o.logger.Errorf("can't rollback %s", err)
+			}
+			return
+		}
+		if err = tx.Commit(ctx); err != nil {
+			o.logger.Errorf("can't commit %s", err)
+			err = fmt.Errorf("can't commit transaction: %w", err)
+		}
+	}()
+	err = tx.QueryRow(ctx, `SELECT status FROM orders WHERE id=$1 FOR UPDATE`, id).Scan(&before)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		o.logger.Errorf("can't cancel order: %s", err)
+		err = models.ErrorNotFound{}
+		return "", err
+	} else if err != nil {
+		o.logger.Errorf("can't cancel order: %s", err)
+		return "", fmt.Errorf("can't cancel order: %w", err)
+	}
+	cancelable := false
+	for _, status := range statuses {
+		if status == before {
+			cancelable = true
+			break
+		}
+	}
+	if !cancelable {
+		err = fmt.Errorf("order %v in status %q can't be canceled: %w", id, before, models.ErrorWrongStatus{})
+		return "", err
+	}
+	_, err = tx.Exec(ctx, `UPDATE orders SET status=$1, cancel_reason=$2 WHERE id=$3`, models.StatusCanceled, reason, id)
+	if err != nil {
+		o.logger.Errorf("can't cancel order: %s", err)
+		return "", fmt.Errorf("can't cancel order: %w", err)
+	}
+	err = releaseStock(ctx, tx, []string{id.String()})
+	if err != nil {
+		o.logger.Errorf("can't release stock of order: %s", err)
+		return "", fmt.Errorf("can't release stock of order: %w", err)
+	}
+	err = addAuditEntry(ctx, tx, models.NewAuditEntry(ctx, models.AuditOrderStatusChange, models.AuditEntityOrder, id.String(),
+		map[string]interface{}{"status": before}, map[string]interface{}{"status": models.StatusCanceled, "reason": reason}))
+	if err != nil {
+		o.logger.Errorf("can't cancel order: %s", err)
+		return "", fmt.Errorf("can't cancel order: %w", err)
+	}
+	return before, nil
+}
+
+// releaseStock puts the stock reserved by the orders back. The stock of every order is released once,
+// orders placed before the reservation of the stock are skipped
+func releaseStock(ctx context.Context, tx pgx.Tx, orderIds []string) error {
+	_, err := tx.Exec(ctx, `
+	WITH released AS (
+		UPDATE orders SET stock_reserved = false WHERE id = ANY($1::uuid[]) AND stock_reserved RETURNING id
+	)
+	UPDATE items SET stock = items.stock + reserved.quantity
+	FROM (
+		SELECT oi.item_id, sum(oi.item_quantity) AS quantity
+		FROM order_items oi JOIN released ON released.id = oi.order_id
+		GROUP BY oi.item_id
+	) reserved
+	WHERE items.id = reserved.item_id AND items.stock IS NOT NULL`, orderIds)
+	return err
+}
+
 func (o *order) GetOrderByID(ctx context.Context, id uuid.UUID) (models.Order, error) {
 	o.logger.Debug("Enter in repository GetOrderByID() with args: ctx, id: %v", id)
 	select {
@@ -152,31 +330,33 @@ func (o *order) GetOrderByID(ctx context.Context, id uuid.UUID) (models.Order, e
 			Items: make([]models.ItemWithQuantity, 0),
 		}
 		rows, err := pool.Query(ctx, `SELECT items.id, items.name, categories.id, categories.name, categories.description, categories.picture,
-				items.description, items.price, items.vendor, items.pictures, orders.id, orders.user_id, orders.status, orders.created_at, orders.shipment_time,
-				orders.status, orders.address, order_items.item_quantity from items INNER JOIN categories ON categories.id=category  INNER JOIN order_items ON
-				items.id=order_items.item_id INNER JOIN orders ON orders.id=order_items.order_id and orders.id = $1 ORDER BY order_id ASC`, id)
+				items.description, order_items.price, items.vendor, items.pictures, orders.id, orders.user_id, orders.status, orders.created_at, orders.shipment_time,
+				orders.status, orders.zipcode, orders.country, orders.city, orders.street, orders.apartment, orders.recipient, orders.phone,
+				orders.cancel_reason, order_items.item_quantity, users.name, coalesce(users.lastname, ''), users.email,
+				orders.shipping_method, orders.shipping_cost, orders.discount, items.weight
+				from items INNER JOIN categories ON categories.id=category  INNER JOIN order_items ON
+				items.id=order_items.item_id INNER JOIN orders ON orders.id=order_items.order_id and orders.id = $1
+				INNER JOIN users ON users.id=orders.user_id ORDER BY order_id ASC`, id)
 		if err != nil {
 			o.logger.Errorf("can't get order from db: %s", err)
 			return ordr, fmt.Errorf("can't get order from db: %w", err)
 		}
 		defer rows.Close()
-		var address string
 		for rows.Next() {
 			item := models.ItemWithQuantity{}
 			if err := rows.Scan(&item.Id, &item.Title, &item.Category.Id, &item.Category.Name, &item.Category.Description, &item.Category.Image,
-				&item.Description, &item.Price, &item.Vendor, &item.Images, &ordr.ID, &ordr.User.ID, &ordr.Status, &ordr.CreatedAt, &ordr.ShipmentTime, &ordr.Status, &address, &item.Quantity); err != nil {
+				&item.Description, &item.Price, &item.Vendor, &item.Images, &ordr.ID, &ordr.User.ID, &ordr.Status, &ordr.CreatedAt, &ordr.ShipmentTime, &ordr.Status,
+				&ordr.Address.Zipcode, &ordr.Address.Country, &ordr.Address.City, &ordr.Address.Street,
+				&ordr.Address.Apartment, &ordr.Address.Recipient, &ordr.Address.Phone, &ordr.CancelReason, &item.Quantity,
+				&ordr.User.Firstname, &ordr.User.Lastname, &ordr.User.Email, &ordr.ShippingMethod, &ordr.ShippingCost, &ordr.Discount, &item.Weight); err != nil {
 				o.logger.Errorf("can't scan data to order object: %w", err)
 				return models.Order{}, err
 			}
 			ordr.Items = append(ordr.Items, item)
 		}
-		o.logger.Debug(address)
-		splitted := strings.Split(address, " -> ")
-		ordr.Address = models.UserAddress{
-			Zipcode: splitted[0],
-			Country: splitted[1],
-			City:    splitted[2],
-			Street:  splitted[3],
+		if ordr.ID == uuid.Nil {
+			o.logger.Errorf("can't get order: order %v not found", id)
+			return models.Order{}, models.ErrorNotFound{}
 		}
 		return ordr, nil
 	}
@@ -195,8 +375,9 @@ func (o *order) GetOrdersForUser(ctx context.Context, user *models.User) (chan m
 		go func() {
 			defer close(resChan)
 			rows, err := pool.Query(ctx, `SELECT items.id, items.name, categories.id, categories.name, categories.description, categories.picture,
-			items.description, items.price, items.vendor, items.pictures, orders.id, orders.user_id, orders.status, orders.created_at, orders.shipment_time,
-			orders.status, orders.address, order_items.item_quantity from items INNER JOIN categories ON categories.id=category  INNER JOIN order_items ON
+			items.description, order_items.price, items.vendor, items.pictures, orders.id, orders.user_id, orders.status, orders.created_at, orders.shipment_time,
+			orders.status, orders.zipcode, orders.country, orders.city, orders.street, orders.apartment, orders.recipient, orders.phone,
+			order_items.item_quantity, orders.shipping_method, orders.shipping_cost, orders.discount from items INNER JOIN categories ON categories.id=category  INNER JOIN order_items ON
 			items.id=order_items.item_id INNER JOIN orders ON orders.id=order_items.order_id and orders.user_id = $1 ORDER BY order_id ASC`, user.ID)
 			if err != nil {
 				o.logger.Errorf("can't get order from db: %s", err)
@@ -207,11 +388,13 @@ func (o *order) GetOrdersForUser(ctx context.Context, user *models.User) (chan m
 				Items: make([]models.ItemWithQuantity, 0),
 			}
 			for rows.Next() {
-				var address string
 				item := models.ItemWithQuantity{}
 				order := models.Order{}
 				if err := rows.Scan(&item.Id, &item.Title, &item.Category.Id, &item.Category.Name, &item.Category.Description, &item.Category.Image,
-					&item.Description, &item.Price, &item.Vendor, &item.Images, &order.ID, &order.User.ID, &order.Status, &order.CreatedAt, &order.ShipmentTime, &order.Status, &address, &item.Quantity); err != nil {
+					&item.Description, &item.Price, &item.Vendor, &item.Images, &order.ID, &order.User.ID, &order.Status, &order.CreatedAt, &order.ShipmentTime, &order.Status,
+					&order.Address.Zipcode, &order.Address.Country, &order.Address.City, &order.Address.Street,
+					&order.Address.Apartment, &order.Address.Recipient, &order.Address.Phone, &item.Quantity,
+					&order.ShippingMethod, &order.ShippingCost, &order.Discount); err != nil {
 					o.logger.Errorf("can't scan data to order object: %w", err)
 					return
 				}
@@ -222,14 +405,6 @@ func (o *order) GetOrdersForUser(ctx context.Context, user *models.User) (chan m
 					resChan <- prevOrder
 					prevOrder = order
 				}
-				o.logger.Debug(address)
-				splitted := strings.Split(address, " -> ")
-				prevOrder.Address = models.UserAddress{
-					Zipcode: splitted[0],
-					Country: splitted[1],
-					City:    splitted[2],
-					Street:  splitted[3],
-				}
 				prevOrder.Items = append(prevOrder.Items, item)
 
 			}
@@ -0,0 +1,271 @@
+package repository
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+type payment struct {
+	storage *PGres
+	logger  *zap.SugaredLogger
+}
+
+var _ PaymentStore = (*payment)(nil)
+
+func NewPaymentRepo(storage *PGres, logger *zap.SugaredLogger) PaymentStore {
+	return &payment{
+		storage: storage,
+		logger:  logger,
+	}
+}
+
+const paymentColumns = `id, order_id, provider, external_id, client_secret, amount, refunded_amount, currency, status, created_at, updated_at`
+
+func scanPayment(row pgx.Row, payment *models.Payment) error {
+	return row.Scan(
+		&payment.Id,
+		&payment.OrderId,
+		&payment.Provider,
+		&payment.ExternalId,
+		&payment.ClientSecret,
+		&payment.Amount,
+		&payment.RefundedAmount,
+		&payment.Currency,
+		&payment.Status,
+		&payment.CreatedAt,
+		&payment.UpdatedAt,
+	)
+}
+
+// CreatePayment saves the new payment for the order and returns its id
+func (p *payment) CreatePayment(ctx context.Context, payment *models.Payment) (uuid.UUID, error) {
+	p.logger.Debugf("Enter in repository CreatePayment() with args: ctx, orderId: %v, provider: %s, externalId: %s", payment.OrderId, payment.Provider, payment.ExternalId)
+	select {
+	case <-ctx.Done():
+		return uuid.Nil, fmt.Errorf("context closed")
+	default:
+	}
+	var id uuid.UUID
+	row := p.storage.GetQuerier(ctx).QueryRow(ctx, `INSERT INTO payments (order_id, provider, external_id, client_secret, amount, currency, status)
+	VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
+		payment.OrderId,
+		payment.Provider,
+		payment.ExternalId,
+		payment.ClientSecret,
+		payment.Amount,
+		payment.Currency,
+		payment.Status,
+	)
+	if err := row.Scan(&id); err != nil {
+		p.logger.Errorf("can't create payment: %s", err)
+		return uuid.Nil, fmt.Errorf("can't create payment: %w", err)
+	}
+	return id, nil
+}
+
+// GetPayments returns payments of the order, the newest payments go first.
+// In the unit of work payments are locked until the end of it
+func (p *payment) GetPayments(ctx context.Context, orderId uuid.UUID) ([]models.Payment, error) {
+	p.logger.Debugf("Enter in repository GetPayments() with args: ctx, orderId: %v", orderId)
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("context closed")
+	default:
+	}
+	rows, err := p.storage.GetQuerier(ctx).Query(ctx, `SELECT `+paymentColumns+` FROM payments
+	WHERE order_id = $1 ORDER BY created_at DESC FOR UPDATE`, orderId)
+	if err != nil {
+		p.logger.Errorf("can't get payments: %s", err)
+		return nil, fmt.Errorf("can't get payments: %w", err)
+	}
+	defer rows.Close()
+	payments := make([]models.Payment, 0, 1)
+	for rows.Next() {
+		payment := models.Payment{}
+		if err := scanPayment(rows, &payment); err != nil {
+			p.logger.Error(err.Error())
+			return nil, fmt.Errorf("can't scan payment: %w", err)
+		}
+		payments = append(payments, payment)
+	}
+	if err := rows.Err(); err != nil {
+		p.logger.Error(err.Error())
+		return nil, fmt.Errorf("can't read payments: %w", err)
+	}
+	return payments, nil
+}
+
+// GetPaymentByExternalId returns the payment by its id in the provider.
+// In the unit of work the payment is locked until the end of it
+func (p *payment) GetPaymentByExternalId(ctx context.Context, provider string, externalId string) (*models.Payment, error) {
+	p.logger.Debugf("Enter in repository GetPaymentByExternalId() with args: ctx, provider: %s, externalId: %s", provider, externalId)
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("context closed")
+	default:
+	}
+	payment := models.Payment{}
+	row := p.storage.GetQuerier(ctx).QueryRow(ctx, `SELECT `+paymentColumns+` FROM payments
+	WHERE provider = $1 AND external_id = $2 FOR UPDATE`, provider, externalId)
+	err := scanPayment(row, &payment)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		return nil, models.ErrorNotFound{}
+	}
+	if err != nil {
+		p.logger.Errorf("can't get payment: %s", err)
+		return nil, fmt.Errorf("can't get payment: %w", err)
+	}
+	return &payment, nil
+}
+
+// UpdatePayment saves the status and the refunded amount of the payment
+func (p *payment) UpdatePayment(ctx context.Context, payment *models.Payment) error {
+	p.logger.Debugf("Enter in repository UpdatePayment() with args: ctx, id: %v, status: %s, refundedAmount: %d", payment.Id, payment.Status, payment.RefundedAmount)
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context closed")
+	default:
+	}
+	tag, err := p.storage.GetQuerier(ctx).Exec(ctx, `UPDATE payments SET status = $2, refunded_amount = $3, updated_at = now() WHERE id = $1`,
+		payment.Id, payment.Status, payment.RefundedAmount)
+	if err != nil {
+		p.logger.Errorf("can't update payment: %s", err)
+		return fmt.Errorf("can't update payment: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrorNotFound{}
+	}
+	return nil
+}
+
+// PayOrder moves the order waiting for the payment to the paid status. It returns false
+// if the order has another status, for example if it was canceled before the payment succeeded
+func (p *payment) PayOrder(ctx context.Context, orderId uuid.UUID) (paid bool, err error) {
+	p.logger.Debugf("Enter in repository PayOrder() with args: ctx, orderId: %v", orderId)
+	select {
+	case <-ctx.Done():
+		return false, fmt.Errorf("context closed")
+	default:
+	}
+	tx, err := p.storage.BeginTx(ctx)
+	if err != nil {
+		p.logger.Errorf("can't create transaction: %s", err)
+		return false, fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			p.logger.Errorf("transaction rolled back")
+			if err := tx.Rollback(ctx); err != nil {
+				p.logger.Errorf("can't rollback %s", err)
+			}
+			return
+		}
+		if err = tx.Commit(ctx); err != nil {
+			p.logger.Errorf("can't commit %s", err)
+			err = fmt.Errorf("can't commit transaction: %w", err)
+		}
+	}()
+	tag, err := tx.Exec(ctx, `UPDATE orders SET status = $2 WHERE id = $1 AND status = $3`, orderId, models.StatusPaid, models.StatusCreated)
+	if err != nil {
+		p.logger.Errorf("can't pay order: %s", err)
+		return false, fmt.Errorf("can't pay order: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+	err = addAuditEntry(ctx, tx, models.NewAuditEntry(ctx, models.AuditOrderStatusChange, models.AuditEntityOrder, orderId.String(),
+		map[string]interface{}{"status": models.StatusCreated}, map[string]interface{}{"status": models.StatusPaid}))
+	if err != nil {
+		p.logger.Errorf("can't pay order: %s", err)
+		return false, fmt.Errorf("can't pay order: %w", err)
+	}
+	return true, nil
+}
+
+// CancelUnpaidOrders cancels at most limit orders which have been waiting for the payment
+// longer than timeout and returns their ids. Pending payments of the orders are canceled too.
+// Orders locked by other transactions, for example by the webhook of the payment, are skipped
+func (p *payment) CancelUnpaidOrders(ctx context.Context, timeout time.Duration, limit int) (canceled []uuid.UUID, err error) {
+	p.logger.Debugf("Enter in repository CancelUnpaidOrders() with args: ctx, timeout: %v, limit: %d", timeout, limit)
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("context closed")
+	default:
+	}
+	tx, err := p.storage.BeginTx(ctx)
+	if err != nil {
+		p.logger.Errorf("can't create transaction: %s", err)
+		return nil, fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			p.logger.Errorf("transaction rolled back")
+			if err := tx.Rollback(ctx); err != nil {
+				p.logger.Errorf("can't rollback %s", err)
+			}
+			return
+		}
+		if err = tx.Commit(ctx); err != nil {
+			p.logger.Errorf("can't commit %s", err)
+			err = fmt.Errorf("can't commit transaction: %w", err)
+		}
+	}()
+	rows, err := tx.Query(ctx, `
+	UPDATE orders SET status = $1
+	WHERE id IN (
+		SELECT id FROM orders WHERE status = $2 AND created_at < now() - make_interval(secs => $3)
+		ORDER BY created_at LIMIT $4 FOR UPDATE SKIP LOCKED
+	)
+	RETURNING id`, models.StatusCanceled, models.StatusCreated, timeout.Seconds(), limit)
+	if err != nil {
+		p.logger.Errorf("can't cancel unpaid orders: %s", err)
+		return nil, fmt.Errorf("can't cancel unpaid orders: %w", err)
+	}
+	ids := make([]uuid.UUID, 0, limit)
+	orderIds := make([]string, 0, limit)
+	for rows.Next() {
+		var id uuid.UUID
+		if err = rows.Scan(&id); err != nil {
+			rows.Close()
+			p.logger.Error(err.Error())
+			return nil, fmt.Errorf("can't scan order id: %w", err)
+		}
+		ids = append(ids, id)
+		orderIds = append(orderIds, id.String())
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		p.logger.Error(err.Error())
+		return nil, fmt.Errorf("can't read canceled orders: %w", err)
+	}
+	if len(ids) == 0 {
+		return ids, nil
+	}
+	_, err = tx.Exec(ctx, `UPDATE payments SET status = $1, updated_at = now() WHERE order_id = ANY($2::uuid[]) AND status = $3`,
+		models.PaymentCanceled, orderIds, models.PaymentPending)
+	if err != nil {
+		p.logger.Errorf("can't cancel payments: %s", err)
+		return nil, fmt.Errorf("can't cancel payments: %w", err)
+	}
+	err = releaseStock(ctx, tx, orderIds)
+	if err != nil {
+		p.logger.Errorf("can't release stock of orders: %s", err)
+		return nil, fmt.Errorf("can't release stock of orders: %w", err)
+	}
+	for _, id := range ids {
+		err = addAuditEntry(ctx, tx, models.NewAuditEntry(ctx, models.AuditOrderStatusChange, models.AuditEntityOrder, id.String(),
+			map[string]interface{}{"status": models.StatusCreated}, map[string]interface{}{"status": models.StatusCanceled}))
+		if err != nil {
+			p.logger.Errorf("can't cancel unpaid orders: %s", err)
+			return nil, fmt.Errorf("can't cancel unpaid orders: %w", err)
+		}
+	}
+	return ids, nil
+}
@@ -6,6 +6,8 @@ import (
 	"net"
 	"time"
 
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	_ "github.com/jackc/pgx/v4/stdlib"
 	"go.uber.org/zap"
@@ -81,6 +83,30 @@ func (pg *PGres) GetPool() *pgxpool.Pool {
 	return pg.pool
 }
 
+// Querier is a common part of the pool and the transaction to make queries
+type Querier interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// GetQuerier returns the transaction of the unit of work if ctx carries it, otherwise the pool
+func (pg *PGres) GetQuerier(ctx context.Context) Querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return pg.pool
+}
+
+// BeginTx begins the transaction. If ctx carries the transaction of the unit of work,
+// the nested transaction is begun in it, so the changes are committed with the unit of work
+func (pg *PGres) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx.Begin(ctx)
+	}
+	return pg.pool.BeginTx(ctx, pgx.TxOptions{})
+}
+
 // ShutDown close connection with database
 func (pg *PGres) ShutDown(timeout int) error{
 	pg.logger.Debug("Enter in pgrepo ShutDown()")
@@ -0,0 +1,317 @@
+package repository
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+type question struct {
+	storage *PGres
+	logger  *zap.SugaredLogger
+}
+
+var _ QuestionStore = (*question)(nil)
+
+func NewQuestionRepo(storage *PGres, logger *zap.SugaredLogger) QuestionStore {
+	return &question{
+		storage: storage,
+		logger:  logger,
+	}
+}
+
+// CreateQuestion insert new question about item in database
+func (q *question) CreateQuestion(ctx context.Context, question *models.Question) (uuid.UUID, error) {
+	q.logger.Debugf("Enter in repository CreateQuestion() with args: ctx, question: %v", question)
+	select {
+	case <-ctx.Done():
+		return uuid.Nil, fmt.Errorf("context closed")
+	default:
+		pool := q.storage.GetPool()
+		var id uuid.UUID
+		row := pool.QueryRow(ctx, `INSERT INTO item_questions (item_id, user_id, text)
+		SELECT $1, $2, $3 FROM items WHERE id=$1 AND deleted_at IS NULL RETURNING id`,
+			question.ItemId, question.UserId, question.Text)
+		err := row.Scan(&id)
+		if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+			q.logger.Errorf("item with id: %v not found", question.ItemId)
+			return uuid.Nil, models.ErrorNotFound{}
+		}
+		if err != nil {
+			q.logger.Errorf("can't create question: %s", err)
+			return uuid.Nil, fmt.Errorf("can't create question: %w", err)
+		}
+		q.logger.Info("Create question success")
+		return id, nil
+	}
+}
+
+// GetQuestion returns question with all its answers by id or error
+func (q *question) GetQuestion(ctx context.Context, id uuid.UUID) (*models.Question, error) {
+	q.logger.Debugf("Enter in repository GetQuestion() with args: ctx, id: %v", id)
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("context closed")
+	default:
+		pool := q.storage.GetPool()
+		result := models.Question{}
+		row := pool.QueryRow(ctx, `SELECT id, item_id, user_id, text, created_at FROM item_questions WHERE id=$1`, id)
+		err := row.Scan(
+			&result.Id,
+			&result.ItemId,
+			&result.UserId,
+			&result.Text,
+			&result.CreatedAt,
+		)
+		if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+			q.logger.Errorf("question with id: %v not found", id)
+			return nil, models.ErrorNotFound{}
+		}
+		if err != nil {
+			q.logger.Errorf("can't get question: %s", err)
+			return nil, fmt.Errorf("can't get question: %w", err)
+		}
+		answers, err := q.getAnswers(ctx, []uuid.UUID{id})
+		if err != nil {
+			return nil, err
+		}
+		result.Answers = answers[id]
+		q.logger.Info("Get question success")
+		return &result, nil
+	}
+}
+
+// GetQuestionsByItem reads all questions about item with their answers from the database
+// and writes them to the output channel
+func (q *question) GetQuestionsByItem(ctx context.Context, itemId uuid.UUID) (chan models.Question, error) {
+	q.logger.Debugf("Enter in repository GetQuestionsByItem() with args: ctx, itemId: %v", itemId)
+	questions, err := q.selectQuestions(ctx, `
+	SELECT id, item_id, user_id, text, created_at
+	FROM item_questions
+	WHERE item_id=$1
+	ORDER BY created_at DESC`, itemId)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, len(questions))
+	for i, question := range questions {
+		ids[i] = question.Id
+	}
+	answers, err := q.getAnswers(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	questionChan := make(chan models.Question, 100)
+	go func() {
+		defer close(questionChan)
+		for _, question := range questions {
+			question.Answers = answers[question.Id]
+			questionChan <- question
+		}
+	}()
+	return questionChan, nil
+}
+
+// GetUnansweredQuestions reads from the database all questions without answers
+// and writes them to the output channel
+func (q *question) GetUnansweredQuestions(ctx context.Context) (chan models.Question, error) {
+	q.logger.Debug("Enter in repository GetUnansweredQuestions() with args: ctx")
+	questions, err := q.selectQuestions(ctx, `
+	SELECT q.id, q.item_id, q.user_id, q.text, q.created_at
+	FROM item_questions q
+	INNER JOIN items i ON i.id = q.item_id
+	WHERE i.deleted_at IS NULL
+	AND NOT EXISTS (SELECT 1 FROM item_answers a WHERE a.question_id = q.id)
+	ORDER BY q.created_at`)
+	if err != nil {
+		return nil, err
+	}
+	questionChan := make(chan models.Question, 100)
+	go func() {
+		defer close(questionChan)
+		for _, question := range questions {
+			questionChan <- question
+		}
+	}()
+	return questionChan, nil
+}
+
+// CreateAnswer insert new answer to the question in database
+func (q *question) CreateAnswer(ctx context.Context, answer *models.Answer) (uuid.UUID, error) {
+	q.logger.Debugf("Enter in repository CreateAnswer() with args: ctx, answer: %v", answer)
+	select {
+	case <-ctx.Done():
+		return uuid.Nil, fmt.Errorf("context closed")
+	default:
+		pool := q.storage.GetPool()
+		var id uuid.UUID
+		row := pool.QueryRow(ctx, `INSERT INTO item_answers (question_id, user_id, text)
+		SELECT $1, $2, $3 FROM item_questions WHERE id=$1 RETURNING id`,
+			answer.QuestionId, answer.UserId, answer.Text)
+		err := row.Scan(&id)
+		if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+			q.logger.Errorf("question with id: %v not found", answer.QuestionId)
+			return uuid.Nil, models.ErrorNotFound{}
+		}
+		if err != nil {
+			q.logger.Errorf("can't create answer: %s", err)
+			return uuid.Nil, fmt.Errorf("can't create answer: %w", err)
+		}
+		q.logger.Info("Create answer success")
+		return id, nil
+	}
+}
+
+// UpvoteAnswer adds the vote of user to the answer, every user can vote for the answer only once
+func (q *question) UpvoteAnswer(ctx context.Context, answerId uuid.UUID, userId uuid.UUID) (err error) {
+	q.logger.Debugf("Enter in repository UpvoteAnswer() with args: ctx, answerId: %v, userId: %v", answerId, userId)
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context closed")
+	default:
+		pool := q.storage.GetPool()
+		var tx pgx.Tx
+		tx, err = pool.BeginTx(ctx, pgx.TxOptions{})
+		if err != nil {
+			q.logger.Errorf("can't create transaction: %s", err)
+			return fmt.Errorf("can't create transaction: %w", err)
+		}
+		defer func() {
+			if err != nil {
+				q.logger.Errorf("transaction rolled back")
+				if rbErr := tx.Rollback(ctx); rbErr != nil {
+					q.logger.Errorf("can't rollback %s", rbErr)
+				}
+			} else {
+				q.logger.Info("transaction commited")
+				if err = tx.Commit(ctx); err != nil {
+					q.logger.Errorf("can't commit %s", err)
+					err = fmt.Errorf("can't commit transaction: %w", err)
+				}
+			}
+		}()
+		var id uuid.UUID
+		err = tx.QueryRow(ctx, `SELECT id FROM item_answers WHERE id=$1 FOR UPDATE`, answerId).Scan(&id)
+		if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+			q.logger.Errorf("answer with id: %v not found", answerId)
+			return models.ErrorNotFound{}
+		}
+		if err != nil {
+			q.logger.Errorf("can't get answer: %s", err)
+			return fmt.Errorf("can't get answer: %w", err)
+		}
+		var tag pgconn.CommandTag
+		tag, err = tx.Exec(ctx, `INSERT INTO answer_votes (answer_id, user_id) VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`, answerId, userId)
+		if err != nil {
+			q.logger.Errorf("can't add vote: %s", err)
+			return fmt.Errorf("can't add vote: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			q.logger.Infof("user %v already voted for answer %v", userId, answerId)
+			err = models.ErrorAlreadyExists{}
+			return err
+		}
+		_, err = tx.Exec(ctx, `UPDATE item_answers SET votes = votes + 1 WHERE id=$1`, answerId)
+		if err != nil {
+			q.logger.Errorf("can't update votes: %s", err)
+			return fmt.Errorf("can't update votes: %w", err)
+		}
+		q.logger.Info("Upvote answer success")
+		return nil
+	}
+}
+
+// GetItemSellerId returns id of the user who sells the item or uuid.Nil
+// if the seller of the item is not set
+func (q *question) GetItemSellerId(ctx context.Context, itemId uuid.UUID) (uuid.UUID, error) {
+	q.logger.Debugf("Enter in repository GetItemSellerId() with args: ctx, itemId: %v", itemId)
+	pool := q.storage.GetPool()
+	var sellerId *uuid.UUID
+	err := pool.QueryRow(ctx, `SELECT seller_id FROM items WHERE id=$1`, itemId).Scan(&sellerId)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		q.logger.Errorf("item with id: %v not found", itemId)
+		return uuid.Nil, models.ErrorNotFound{}
+	}
+	if err != nil {
+		q.logger.Errorf("can't get seller of item: %s", err)
+		return uuid.Nil, fmt.Errorf("can't get seller of item: %w", err)
+	}
+	if sellerId == nil {
+		return uuid.Nil, nil
+	}
+	return *sellerId, nil
+}
+
+// selectQuestions reads questions returned by query without answers
+func (q *question) selectQuestions(ctx context.Context, query string, args ...interface{}) ([]models.Question, error) {
+	pool := q.storage.GetPool()
+	rows, err := pool.Query(ctx, query, args...)
+	if err != nil {
+		q.logger.Errorf("can't select questions: %s", err)
+		return nil, fmt.Errorf("can't select questions: %w", err)
+	}
+	defer rows.Close()
+	questions := make([]models.Question, 0, 10)
+	for rows.Next() {
+		question := models.Question{}
+		if err := rows.Scan(
+			&question.Id,
+			&question.ItemId,
+			&question.UserId,
+			&question.Text,
+			&question.CreatedAt,
+		); err != nil {
+			q.logger.Error(err.Error())
+			return nil, fmt.Errorf("can't scan question: %w", err)
+		}
+		questions = append(questions, question)
+	}
+	return questions, nil
+}
+
+// getAnswers reads answers to the questions and groups them by question id,
+// the most voted answers go first
+func (q *question) getAnswers(ctx context.Context, questionIds []uuid.UUID) (map[uuid.UUID][]models.Answer, error) {
+	result := make(map[uuid.UUID][]models.Answer, len(questionIds))
+	if len(questionIds) == 0 {
+		return result, nil
+	}
+	ids := make([]string, len(questionIds))
+	for i, id := range questionIds {
+		ids[i] = id.String()
+	}
+	pool := q.storage.GetPool()
+	rows, err := pool.Query(ctx, `
+	SELECT id, question_id, user_id, text, votes, created_at
+	FROM item_answers
+	WHERE question_id = ANY($1::uuid[])
+	ORDER BY votes DESC, created_at`, ids)
+	if err != nil {
+		q.logger.Errorf("can't select answers: %s", err)
+		return nil, fmt.Errorf("can't select answers: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		answer := models.Answer{}
+		if err := rows.Scan(
+			&answer.Id,
+			&answer.QuestionId,
+			&answer.UserId,
+			&answer.Text,
+			&answer.Votes,
+			&answer.CreatedAt,
+		); err != nil {
+			q.logger.Error(err.Error())
+			return nil, fmt.Errorf("can't scan answer: %w", err)
+		}
+		result[answer.QuestionId] = append(result[answer.QuestionId], answer)
+	}
+	return result, nil
+}
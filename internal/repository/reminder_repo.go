@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type reminder struct {
+	storage *PGres
+	logger  *zap.SugaredLogger
+}
+
+var _ ReminderStore = (*reminder)(nil)
+
+func NewReminderRepo(storage *PGres, logger *zap.SugaredLogger) ReminderStore {
+	return &reminder{
+		storage: storage,
+		logger:  logger,
+	}
+}
+
+// GetAbandonedCarts returns at most limit not expired carts of users which were not changed
+// longer than idle and have items in them. Carts already reminded about after their last
+// change are skipped as well as carts which reminders failed or wait for the next attempt,
+// the longest abandoned carts go first
+func (r *reminder) GetAbandonedCarts(ctx context.Context, idle time.Duration, limit int) ([]models.AbandonedCart, error) {
+	r.logger.Debugf("Enter in repository GetAbandonedCarts() with args: ctx, idle: %v, limit: %d", idle, limit)
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("context closed")
+	default:
+	}
+	pool := r.storage.GetPool()
+	rows, err := pool.Query(ctx, `
+	SELECT c.id, c.user_id, u.email, u.name, c.updated_at, coalesce(a.attempts, 0)
+	FROM carts c
+	JOIN users u ON u.id = c.user_id
+	LEFT JOIN cart_reminder_attempts a ON a.cart_id = c.id AND a.cart_updated_at >= c.updated_at
+	WHERE c.expire_at > now() AND c.updated_at < now() - make_interval(secs => $1)
+	AND EXISTS (
+		SELECT 1 FROM cart_items ci JOIN items i ON i.id = ci.item_id
+		WHERE ci.cart_id = c.id AND NOT ci.saved AND i.deleted_at IS NULL
+	)
+	AND NOT EXISTS (
+		SELECT 1 FROM cart_reminders cr WHERE cr.cart_id = c.id AND cr.sent_at >= c.updated_at
+	)
+	AND (a.cart_id IS NULL OR NOT a.failed AND a.next_attempt_at <= now())
+	ORDER BY c.updated_at
+	LIMIT $2`, idle.Seconds(), limit)
+	if err != nil {
+		r.logger.Errorf("can't select abandoned carts: %s", err)
+		return nil, fmt.Errorf("can't select abandoned carts: %w", err)
+	}
+	defer rows.Close()
+	carts := make([]models.AbandonedCart, 0, limit)
+	cartIds := make([]string, 0, limit)
+	for rows.Next() {
+		cart := models.AbandonedCart{}
+		if err := rows.Scan(
+			&cart.CartId,
+			&cart.UserId,
+			&cart.Email,
+			&cart.Name,
+			&cart.UpdatedAt,
+			&cart.Attempts,
+		); err != nil {
+			r.logger.Error(err.Error())
+			return nil, fmt.Errorf("can't scan abandoned cart: %w", err)
+		}
+		carts = append(carts, cart)
+		cartIds = append(cartIds, cart.CartId.String())
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error(err.Error())
+		return nil, fmt.Errorf("can't read abandoned carts: %w", err)
+	}
+	if len(carts) == 0 {
+		return carts, nil
+	}
+	items, err := r.getCartItems(ctx, cartIds)
+	if err != nil {
+		return nil, err
+	}
+	for i := range carts {
+		carts[i].Items = items[carts[i].CartId]
+	}
+	return carts, nil
+}
+
+// getCartItems reads not saved lines of the carts with not deleted items and groups them by cart id
+func (r *reminder) getCartItems(ctx context.Context, cartIds []string) (map[uuid.UUID][]models.ItemWithQuantity, error) {
+	pool := r.storage.GetPool()
+	rows, err := pool.Query(ctx, `
+	SELECT ci.cart_id, i.id, i.name, i.price, ci.item_quantity
+	FROM cart_items ci
+	JOIN items i ON i.id = ci.item_id
+	WHERE ci.cart_id = ANY($1::uuid[]) AND NOT ci.saved AND i.deleted_at IS NULL
+	ORDER BY i.name`, cartIds)
+	if err != nil {
+		r.logger.Errorf("can't select items of abandoned carts: %s", err)
+		return nil, fmt.Errorf("can't select items of abandoned carts: %w", err)
+	}
+	defer rows.Close()
+	result := make(map[uuid.UUID][]models.ItemWithQuantity, len(cartIds))
+	for rows.Next() {
+		var cartId uuid.UUID
+		item := models.ItemWithQuantity{}
+		if err := rows.Scan(
+			&cartId,
+			&item.Id,
+			&item.Title,
+			&item.Price,
+			&item.Quantity,
+		); err != nil {
+			r.logger.Error(err.Error())
+			return nil, fmt.Errorf("can't scan item of abandoned cart: %w", err)
+		}
+		result[cartId] = append(result[cartId], item)
+	}
+	return result, rows.Err()
+}
+
+// CreateReminder records the reminder sent about the cart
+func (r *reminder) CreateReminder(ctx context.Context, reminder *models.CartReminder) error {
+	r.logger.Debugf("Enter in repository CreateReminder() with args: ctx, reminder: %v", reminder)
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context closed")
+	default:
+		pool := r.storage.GetPool()
+		err := pool.QueryRow(ctx, `INSERT INTO cart_reminders (cart_id, user_id, email)
+		VALUES ($1, $2, $3) RETURNING id, sent_at`,
+			reminder.CartId,
+			reminder.UserId,
+			reminder.Email,
+		).Scan(&reminder.Id, &reminder.SentAt)
+		if err != nil {
+			r.logger.Errorf("can't create cart reminder: %s", err)
+			return fmt.Errorf("can't create cart reminder: %w", err)
+		}
+		return nil
+	}
+}
+
+// SaveReminderAttempt records the failed attempt to send the reminder about the cart,
+// the attempts recorded before the last change of the cart are replaced
+func (r *reminder) SaveReminderAttempt(ctx context.Context, attempt *models.CartReminderAttempt) error {
+	r.logger.Debugf("Enter in repository SaveReminderAttempt() with args: ctx, attempt: %v", attempt)
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context closed")
+	default:
+		pool := r.storage.GetPool()
+		_, err := pool.Exec(ctx, `INSERT INTO cart_reminder_attempts (cart_id, cart_updated_at, attempts, last_error, next_attempt_at, failed)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (cart_id) DO UPDATE SET cart_updated_at = EXCLUDED.cart_updated_at, attempts = EXCLUDED.attempts,
+		last_error = EXCLUDED.last_error, next_attempt_at = EXCLUDED.next_attempt_at, failed = EXCLUDED.failed`,
+			attempt.CartId,
+			attempt.CartUpdatedAt,
+			attempt.Attempts,
+			attempt.LastError,
+			attempt.NextAttemptAt,
+			attempt.Failed,
+		)
+		if err != nil {
+			r.logger.Errorf("can't save cart reminder attempt: %s", err)
+			return fmt.Errorf("can't save cart reminder attempt: %w", err)
+		}
+		return nil
+	}
+}
@@ -3,10 +3,16 @@ package repository
 import (
 	"OnlineShopBackend/internal/models"
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// UnitOfWork runs changes of several repositories in one transaction
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type ItemStore interface {
 	CreateItem(ctx context.Context, item *models.Item) (uuid.UUID, error)
 	UpdateItem(ctx context.Context, item *models.Item) error
@@ -23,6 +29,7 @@ type ItemStore interface {
 	ItemsByCategoryQuantity(ctx context.Context, categoryName string) (int, error)
 	ItemsInSearchQuantity(ctx context.Context, searchRequest string) (int, error)
 	ItemsInFavouriteQuantity(ctx context.Context, userId uuid.UUID) (int, error)
+	AddItemsStats(ctx context.Context, stats []models.ItemStats) error
 }
 
 type CategoryStore interface {
@@ -30,7 +37,8 @@ type CategoryStore interface {
 	UpdateCategory(ctx context.Context, category *models.Category) error
 	GetCategory(ctx context.Context, id uuid.UUID) (*models.Category, error)
 	GetCategoryList(ctx context.Context) (chan models.Category, error)
-	DeleteCategory(ctx context.Context, id uuid.UUID) error
+	DeleteCategory(ctx context.Context, id uuid.UUID, targetId uuid.UUID) error
+	MergeCategories(ctx context.Context, sourceId uuid.UUID, targetId uuid.UUID) error
 	GetCategoryByName(ctx context.Context, name string) (*models.Category, error)
 }
 
@@ -47,18 +55,102 @@ type UserStore interface {
 
 type CartStore interface {
 	Create(ctx context.Context, userId uuid.UUID) (uuid.UUID, error)
-	AddItemToCart(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID) error
+	AddItemToCart(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID, maxQuantity int) error
+	SetItemQuantity(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID, quantity int) error
 	DeleteCart(ctx context.Context, cartId uuid.UUID) error
+	MergeCarts(ctx context.Context, guestCartId uuid.UUID, userCartId uuid.UUID, lines []models.CartLine) error
+	ExtendCart(ctx context.Context, cartId uuid.UUID, ttl time.Duration) error
+	SetItemSaved(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID, saved bool) error
+	ClearCart(ctx context.Context, cartId uuid.UUID, orderId uuid.UUID) error
+	LockCart(ctx context.Context, cartId uuid.UUID) error
+	DeleteExpiredCarts(ctx context.Context, limit int) (int, error)
+	CountCarts(ctx context.Context) (int, int, error)
 	DeleteItemFromCart(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID) error
 	GetCart(ctx context.Context, cartId uuid.UUID) (*models.Cart, error)
+	GetCartOwner(ctx context.Context, cartId uuid.UUID) (uuid.UUID, error)
 	GetCartByUserId(ctx context.Context, userId uuid.UUID) (*models.Cart, error)
 }
 
+type ReminderStore interface {
+	GetAbandonedCarts(ctx context.Context, idle time.Duration, limit int) ([]models.AbandonedCart, error)
+	CreateReminder(ctx context.Context, reminder *models.CartReminder) error
+	SaveReminderAttempt(ctx context.Context, attempt *models.CartReminderAttempt) error
+}
+
 type OrderStore interface {
 	Create(ctx context.Context, order *models.Order) (*models.Order, error)
 	DeleteOrder(ctx context.Context, order *models.Order) error
 	ChangeAddress(ctx context.Context, order *models.Order, address models.UserAddress) error
 	ChangeStatus(ctx context.Context, order *models.Order, status models.Status) error
+	CancelOrder(ctx context.Context, id uuid.UUID, reason string, statuses []models.Status) (models.Status, error)
 	GetOrderByID(ctx context.Context, id uuid.UUID) (models.Order, error)
 	GetOrdersForUser(ctx context.Context, user *models.User) (chan models.Order, error)
+	GetOrders(ctx context.Context, filter models.OrderFilter, pricing models.CartPricing) ([]models.OrderSummary, error)
+	CountOrdersByStatus(ctx context.Context, filter models.OrderFilter, pricing models.CartPricing) (map[models.Status]int, error)
+	ExportOrders(ctx context.Context, filter models.OrderFilter, pricing models.CartPricing) (chan models.OrderExportLine, chan error, error)
+}
+
+type PaymentStore interface {
+	CreatePayment(ctx context.Context, payment *models.Payment) (uuid.UUID, error)
+	GetPayments(ctx context.Context, orderId uuid.UUID) ([]models.Payment, error)
+	GetPaymentByExternalId(ctx context.Context, provider string, externalId string) (*models.Payment, error)
+	UpdatePayment(ctx context.Context, payment *models.Payment) error
+	PayOrder(ctx context.Context, orderId uuid.UUID) (bool, error)
+	CancelUnpaidOrders(ctx context.Context, timeout time.Duration, limit int) ([]uuid.UUID, error)
+}
+
+type ReturnStore interface {
+	CreateReturn(ctx context.Context, ret *models.Return) (uuid.UUID, error)
+	GetReturn(ctx context.Context, id uuid.UUID) (*models.Return, error)
+	GetOrderReturns(ctx context.Context, orderId uuid.UUID) ([]models.Return, error)
+	GetReturns(ctx context.Context, status models.ReturnStatus, offset int, limit int) ([]models.Return, error)
+	GetReturnedQuantities(ctx context.Context, orderId uuid.UUID) (map[uuid.UUID]int, error)
+	ChangeReturnStatus(ctx context.Context, id uuid.UUID, from models.ReturnStatus, to models.ReturnStatus, comment string) error
+	SetTrackingNumber(ctx context.Context, id uuid.UUID, trackingNumber string) error
+	SetRefundAmount(ctx context.Context, id uuid.UUID, amount int64) error
+	RestockReturn(ctx context.Context, id uuid.UUID) error
+}
+
+type InvoiceStore interface {
+	GetOrCreateInvoice(ctx context.Context, orderId uuid.UUID) (*models.Invoice, error)
+}
+
+type QuestionStore interface {
+	CreateQuestion(ctx context.Context, question *models.Question) (uuid.UUID, error)
+	GetQuestion(ctx context.Context, id uuid.UUID) (*models.Question, error)
+	GetQuestionsByItem(ctx context.Context, itemId uuid.UUID) (chan models.Question, error)
+	GetUnansweredQuestions(ctx context.Context) (chan models.Question, error)
+	CreateAnswer(ctx context.Context, answer *models.Answer) (uuid.UUID, error)
+	UpvoteAnswer(ctx context.Context, answerId uuid.UUID, userId uuid.UUID) error
+	GetItemSellerId(ctx context.Context, itemId uuid.UUID) (uuid.UUID, error)
+}
+
+type CatalogStore interface {
+	ListenCatalogChanges(ctx context.Context) (chan struct{}, error)
+}
+
+type AuditStore interface {
+	GetAuditLog(ctx context.Context, filter models.AuditFilter) (chan models.AuditEntry, error)
+}
+
+type AddressStore interface {
+	CreateAddress(ctx context.Context, address *models.SavedAddress) (uuid.UUID, error)
+	GetAddress(ctx context.Context, id uuid.UUID) (*models.SavedAddress, error)
+	GetAddresses(ctx context.Context, userId uuid.UUID) ([]models.SavedAddress, error)
+	UpdateAddress(ctx context.Context, address *models.SavedAddress) error
+	DeleteAddress(ctx context.Context, id uuid.UUID) error
+}
+
+type NotificationStore interface {
+	ClaimNotifications(ctx context.Context, limit int, lease time.Duration) ([]models.Notification, error)
+	UpdateNotification(ctx context.Context, notification *models.Notification) error
+	GetNotificationPreferences(ctx context.Context, userId uuid.UUID) (*models.NotificationPreferences, error)
+	SetNotificationPreferences(ctx context.Context, preferences *models.NotificationPreferences) error
+}
+
+type ShipmentStore interface {
+	CreateShipment(ctx context.Context, shipment *models.Shipment, statuses []models.Status) error
+	GetShipment(ctx context.Context, orderId uuid.UUID) (*models.Shipment, error)
+	GetShipmentsToTrack(ctx context.Context, carrier string, limit int) ([]models.Shipment, error)
+	UpdateTracking(ctx context.Context, shipment *models.Shipment, delivered bool) error
 }
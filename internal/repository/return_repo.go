@@ -0,0 +1,353 @@
+package repository
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+type returns struct {
+	storage *PGres
+	logger  *zap.SugaredLogger
+}
+
+var _ ReturnStore = (*returns)(nil)
+
+func NewReturnRepo(storage *PGres, logger *zap.SugaredLogger) ReturnStore {
+	return &returns{
+		storage: storage,
+		logger:  logger,
+	}
+}
+
+const returnColumns = `id, order_id, user_id, reason, status, tracking_number, refund_amount, created_at, updated_at`
+
+func scanReturn(row pgx.Row, ret *models.Return) error {
+	return row.Scan(
+		&ret.Id,
+		&ret.OrderId,
+		&ret.UserId,
+		&ret.Reason,
+		&ret.Status,
+		&ret.TrackingNumber,
+		&ret.RefundAmount,
+		&ret.CreatedAt,
+		&ret.UpdatedAt,
+	)
+}
+
+// actorId returns the id of the user from ctx to record in the status history, nil for the service itself
+func actorId(ctx context.Context) interface{} {
+	if actor, ok := models.ActorFromContext(ctx); ok && actor.UserId != uuid.Nil {
+		return actor.UserId
+	}
+	return nil
+}
+
+// CreateReturn saves the return requested by the customer with its lines and the first record
+// of the status history. Prices of lines are the prices of the items saved with the order,
+// the return must have one line of the item
+func (r *returns) CreateReturn(ctx context.Context, ret *models.Return) (id uuid.UUID, err error) {
+	r.logger.Debugf("Enter in repository CreateReturn() with args: ctx, orderId: %v, items: %v", ret.OrderId, ret.Items)
+	select {
+	case <-ctx.Done():
+		return uuid.Nil, fmt.Errorf("context closed")
+	default:
+	}
+	tx, err := r.storage.BeginTx(ctx)
+	if err != nil {
+		r.logger.Errorf("can't create transaction: %s", err)
+		return uuid.Nil, fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			r.logger.Errorf("transaction rolled back")
+			if err := tx.Rollback(ctx); err != nil {
+				r.logger.Errorf("can't rollback %s", err)
+			}
+			return
+		}
+		if err = tx.Commit(ctx); err != nil {
+			r.logger.Errorf("can't commit %s", err)
+			err = fmt.Errorf("can't commit transaction: %w", err)
+		}
+	}()
+	err = tx.QueryRow(ctx, `INSERT INTO returns (order_id, user_id, reason, status) VALUES ($1, $2, $3, $4) RETURNING id`,
+		ret.OrderId, ret.UserId, ret.Reason, ret.Status).Scan(&id)
+	if err != nil {
+		r.logger.Errorf("can't create return: %s", err)
+		return uuid.Nil, fmt.Errorf("can't create return: %w", err)
+	}
+	for _, item := range ret.Items {
+		_, err = tx.Exec(ctx, `INSERT INTO return_items (return_id, item_id, quantity, price)
+		SELECT $1, item_id, $3, price FROM order_items WHERE order_id = $4 AND item_id = $2`, id, item.ItemId, item.Quantity, ret.OrderId)
+		if err != nil {
+			r.logger.Errorf("can't add item to return: %s", err)
+			return uuid.Nil, fmt.Errorf("can't add item to return: %w", err)
+		}
+	}
+	_, err = tx.Exec(ctx, `INSERT INTO return_status_history (return_id, status, comment, actor_id) VALUES ($1, $2, $3, $4)`,
+		id, ret.Status, ret.Reason, actorId(ctx))
+	if err != nil {
+		r.logger.Errorf("can't add return status: %s", err)
+		return uuid.Nil, fmt.Errorf("can't add return status: %w", err)
+	}
+	return id, nil
+}
+
+// GetReturn returns the return with its lines and status history
+func (r *returns) GetReturn(ctx context.Context, id uuid.UUID) (*models.Return, error) {
+	r.logger.Debugf("Enter in repository GetReturn() with args: ctx, id: %v", id)
+	res, err := r.selectReturns(ctx, `WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, models.ErrorNotFound{}
+	}
+	return &res[0], nil
+}
+
+// GetOrderReturns returns returns of the order, the oldest returns go first
+func (r *returns) GetOrderReturns(ctx context.Context, orderId uuid.UUID) ([]models.Return, error) {
+	r.logger.Debugf("Enter in repository GetOrderReturns() with args: ctx, orderId: %v", orderId)
+	return r.selectReturns(ctx, `WHERE order_id = $1 ORDER BY created_at`, orderId)
+}
+
+// GetReturns returns returns in the status, empty status means all returns. The oldest returns go first
+func (r *returns) GetReturns(ctx context.Context, status models.ReturnStatus, offset int, limit int) ([]models.Return, error) {
+	r.logger.Debugf("Enter in repository GetReturns() with args: ctx, status: %s, offset: %d, limit: %d", status, offset, limit)
+	return r.selectReturns(ctx, `WHERE $1 = '' OR status = $1 ORDER BY created_at OFFSET $2 LIMIT $3`, status, offset, limit)
+}
+
+// selectReturns reads returns by the condition and fills their lines and status history
+func (r *returns) selectReturns(ctx context.Context, condition string, args ...interface{}) ([]models.Return, error) {
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("context closed")
+	default:
+	}
+	querier := r.storage.GetQuerier(ctx)
+	rows, err := querier.Query(ctx, `SELECT `+returnColumns+` FROM returns `+condition, args...)
+	if err != nil {
+		r.logger.Errorf("can't select returns: %s", err)
+		return nil, fmt.Errorf("can't select returns: %w", err)
+	}
+	defer rows.Close()
+	res := make([]models.Return, 0)
+	ids := make([]string, 0)
+	for rows.Next() {
+		ret := models.Return{}
+		if err := scanReturn(rows, &ret); err != nil {
+			r.logger.Error(err.Error())
+			return nil, fmt.Errorf("can't scan return: %w", err)
+		}
+		res = append(res, ret)
+		ids = append(ids, ret.Id.String())
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error(err.Error())
+		return nil, fmt.Errorf("can't read returns: %w", err)
+	}
+	rows.Close()
+	if len(res) == 0 {
+		return res, nil
+	}
+	items, err := r.getReturnItems(ctx, querier, ids)
+	if err != nil {
+		return nil, err
+	}
+	history, err := r.getReturnHistory(ctx, querier, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range res {
+		res[i].Items = items[res[i].Id]
+		res[i].History = history[res[i].Id]
+	}
+	return res, nil
+}
+
+// getReturnItems reads lines of the returns and groups them by return id
+func (r *returns) getReturnItems(ctx context.Context, querier Querier, ids []string) (map[uuid.UUID][]models.ReturnItem, error) {
+	rows, err := querier.Query(ctx, `
+	SELECT ri.return_id, ri.item_id, i.name, ri.price, ri.quantity
+	FROM return_items ri
+	JOIN items i ON i.id = ri.item_id
+	WHERE ri.return_id = ANY($1::uuid[])
+	ORDER BY i.name`, ids)
+	if err != nil {
+		r.logger.Errorf("can't select items of returns: %s", err)
+		return nil, fmt.Errorf("can't select items of returns: %w", err)
+	}
+	defer rows.Close()
+	result := make(map[uuid.UUID][]models.ReturnItem, len(ids))
+	for rows.Next() {
+		var returnId uuid.UUID
+		item := models.ReturnItem{}
+		if err := rows.Scan(&returnId, &item.ItemId, &item.Title, &item.Price, &item.Quantity); err != nil {
+			r.logger.Error(err.Error())
+			return nil, fmt.Errorf("can't scan item of return: %w", err)
+		}
+		result[returnId] = append(result[returnId], item)
+	}
+	return result, rows.Err()
+}
+
+// getReturnHistory reads status histories of the returns and groups them by return id
+func (r *returns) getReturnHistory(ctx context.Context, querier Querier, ids []string) (map[uuid.UUID][]models.ReturnStatusChange, error) {
+	rows, err := querier.Query(ctx, `
+	SELECT return_id, status, comment, actor_id, created_at
+	FROM return_status_history
+	WHERE return_id = ANY($1::uuid[])
+	ORDER BY created_at`, ids)
+	if err != nil {
+		r.logger.Errorf("can't select status history of returns: %s", err)
+		return nil, fmt.Errorf("can't select status history of returns: %w", err)
+	}
+	defer rows.Close()
+	result := make(map[uuid.UUID][]models.ReturnStatusChange, len(ids))
+	for rows.Next() {
+		var returnId uuid.UUID
+		var actor *uuid.UUID
+		change := models.ReturnStatusChange{}
+		if err := rows.Scan(&returnId, &change.Status, &change.Comment, &actor, &change.CreatedAt); err != nil {
+			r.logger.Error(err.Error())
+			return nil, fmt.Errorf("can't scan status of return: %w", err)
+		}
+		if actor != nil {
+			change.ActorId = *actor
+		}
+		result[returnId] = append(result[returnId], change)
+	}
+	return result, rows.Err()
+}
+
+// GetReturnedQuantities returns quantities of items of the order in returns which are not rejected
+func (r *returns) GetReturnedQuantities(ctx context.Context, orderId uuid.UUID) (map[uuid.UUID]int, error) {
+	r.logger.Debugf("Enter in repository GetReturnedQuantities() with args: ctx, orderId: %v", orderId)
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("context closed")
+	default:
+	}
+	rows, err := r.storage.GetQuerier(ctx).Query(ctx, `
+	SELECT ri.item_id, sum(ri.quantity)
+	FROM return_items ri
+	JOIN returns ret ON ret.id = ri.return_id
+	WHERE ret.order_id = $1 AND ret.status <> $2
+	GROUP BY ri.item_id`, orderId, models.ReturnRejected)
+	if err != nil {
+		r.logger.Errorf("can't select returned quantities: %s", err)
+		return nil, fmt.Errorf("can't select returned quantities: %w", err)
+	}
+	defer rows.Close()
+	result := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var itemId uuid.UUID
+		var quantity int
+		if err := rows.Scan(&itemId, &quantity); err != nil {
+			r.logger.Error(err.Error())
+			return nil, fmt.Errorf("can't scan returned quantity: %w", err)
+		}
+		result[itemId] = quantity
+	}
+	return result, rows.Err()
+}
+
+// ChangeReturnStatus moves the return from the status to another one and records the change
+// in the status history. If the return is not in the status from, ErrorWrongStatus is returned
+func (r *returns) ChangeReturnStatus(ctx context.Context, id uuid.UUID, from models.ReturnStatus, to models.ReturnStatus, comment string) (err error) {
+	r.logger.Debugf("Enter in repository ChangeReturnStatus() with args: ctx, id: %v, from: %s, to: %s, comment: %s", id, from, to, comment)
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context closed")
+	default:
+	}
+	tx, err := r.storage.BeginTx(ctx)
+	if err != nil {
+		r.logger.Errorf("can't create transaction: %s", err)
+		return fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			r.logger.Errorf("transaction rolled back")
+			if err := tx.Rollback(ctx); err != nil {
+				r.logger.Errorf("can't rollback %s", err)
+			}
+			return
+		}
+		if err = tx.Commit(ctx); err != nil {
+			r.logger.Errorf("can't commit %s", err)
+			err = fmt.Errorf("can't commit transaction: %w", err)
+		}
+	}()
+	tag, err := tx.Exec(ctx, `UPDATE returns SET status = $3, updated_at = now() WHERE id = $1 AND status = $2`, id, from, to)
+	if err != nil {
+		r.logger.Errorf("can't change return status: %s", err)
+		return fmt.Errorf("can't change return status: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		err = fmt.Errorf("return %v is not %s: %w", id, from, models.ErrorWrongStatus{})
+		return err
+	}
+	_, err = tx.Exec(ctx, `INSERT INTO return_status_history (return_id, status, comment, actor_id) VALUES ($1, $2, $3, $4)`,
+		id, to, comment, actorId(ctx))
+	if err != nil {
+		r.logger.Errorf("can't add return status: %s", err)
+		return fmt.Errorf("can't add return status: %w", err)
+	}
+	return nil
+}
+
+// SetTrackingNumber saves the number of the parcel with returned goods
+func (r *returns) SetTrackingNumber(ctx context.Context, id uuid.UUID, trackingNumber string) error {
+	r.logger.Debugf("Enter in repository SetTrackingNumber() with args: ctx, id: %v, trackingNumber: %s", id, trackingNumber)
+	return r.update(ctx, `UPDATE returns SET tracking_number = $2, updated_at = now() WHERE id = $1`, id, trackingNumber)
+}
+
+// SetRefundAmount saves the amount refunded for the return
+func (r *returns) SetRefundAmount(ctx context.Context, id uuid.UUID, amount int64) error {
+	r.logger.Debugf("Enter in repository SetRefundAmount() with args: ctx, id: %v, amount: %d", id, amount)
+	return r.update(ctx, `UPDATE returns SET refund_amount = $2, updated_at = now() WHERE id = $1`, id, amount)
+}
+
+func (r *returns) update(ctx context.Context, query string, id uuid.UUID, value interface{}) error {
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context closed")
+	default:
+	}
+	tag, err := r.storage.GetQuerier(ctx).Exec(ctx, query, id, value)
+	if err != nil {
+		r.logger.Errorf("can't update return: %s", err)
+		return fmt.Errorf("can't update return: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrorNotFound{}
+	}
+	return nil
+}
+
+// RestockReturn puts returned items back to stock, items which stock is not tracked are skipped
+func (r *returns) RestockReturn(ctx context.Context, id uuid.UUID) error {
+	r.logger.Debugf("Enter in repository RestockReturn() with args: ctx, id: %v", id)
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context closed")
+	default:
+	}
+	_, err := r.storage.GetQuerier(ctx).Exec(ctx, `
+	UPDATE items SET stock = items.stock + ri.quantity
+	FROM return_items ri
+	WHERE ri.return_id = $1 AND items.id = ri.item_id AND items.stock IS NOT NULL`, id)
+	if err != nil {
+		r.logger.Errorf("can't restock return: %s", err)
+		return fmt.Errorf("can't restock return: %w", err)
+	}
+	return nil
+}
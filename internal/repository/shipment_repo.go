@@ -0,0 +1,264 @@
+package repository
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type shipment struct {
+	storage *PGres
+	logger  *zap.SugaredLogger
+}
+
+var _ ShipmentStore = (*shipment)(nil)
+
+func NewShipmentRepo(storage *PGres, logger *zap.SugaredLogger) ShipmentStore {
+	return &shipment{
+		storage: storage,
+		logger:  logger,
+	}
+}
+
+// CreateShipment saves the shipment of the order in one of the statuses and hands the order over to the courier.
+// If the order is in another status, ErrorWrongStatus is returned, if the order already has the shipment
+// or the carrier already has the tracking number, ErrorAlreadyExists is returned
+func (s *shipment) CreateShipment(ctx context.Context, shipment *models.Shipment, statuses []models.Status) (err error) {
+	s.logger.Debugf("Enter in repository CreateShipment() with args: ctx, shipment: %v, statuses: %v", shipment, statuses)
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context closed")
+	default:
+	}
+	tx, err := s.storage.BeginTx(ctx)
+	if err != nil {
+		s.logger.Errorf("can't create transaction: %s", err)
+		return fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			s.logger.Errorf("transaction rolled back")
+			if err := tx.Rollback(ctx); err != nil {
+				s.logger.Errorf("can't rollback %s", err)
+			}
+			return
+		}
+		if err = tx.Commit(ctx); err != nil {
+			s.logger.Errorf("can't commit %s", err)
+			err = fmt.Errorf("can't commit transaction: %w", err)
+		}
+	}()
+	var before models.Status
+	err = tx.QueryRow(ctx, `SELECT status FROM orders WHERE id=$1 FOR UPDATE`, shipment.OrderId).Scan(&before)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		s.logger.Errorf("can't create shipment: %s", err)
+		err = models.ErrorNotFound{}
+		return err
+	} else if err != nil {
+		s.logger.Errorf("can't create shipment: %s", err)
+		return fmt.Errorf("can't create shipment: %w", err)
+	}
+	shippable := false
+	for _, status := range statuses {
+		if status == before {
+			shippable = true
+			break
+		}
+	}
+	if !shippable {
+		err = fmt.Errorf("order %v in status %q can't be shipped: %w", shipment.OrderId, before, models.ErrorWrongStatus{})
+		return err
+	}
+	err = tx.QueryRow(ctx, `INSERT INTO shipments (order_id, carrier, tracking_number) VALUES ($1, $2, $3)
+	RETURNING id, created_at`, shipment.OrderId, shipment.Carrier, shipment.TrackingNumber).Scan(&shipment.Id, &shipment.CreatedAt)
+	if err != nil && (strings.Contains(err.Error(), "shipments_order_key") || strings.Contains(err.Error(), "shipments_tracking_number_key")) {
+		s.logger.Errorf("can't create shipment: %s", err)
+		err = models.ErrorAlreadyExists{}
+		return err
+	} else if err != nil {
+		s.logger.Errorf("can't create shipment: %s", err)
+		return fmt.Errorf("can't create shipment: %w", err)
+	}
+	_, err = tx.Exec(ctx, `UPDATE orders SET status=$1 WHERE id=$2`, models.StatusCourier, shipment.OrderId)
+	if err != nil {
+		s.logger.Errorf("can't update status: %s", err)
+		return fmt.Errorf("can't update status: %w", err)
+	}
+	err = addAuditEntry(ctx, tx, models.NewAuditEntry(ctx, models.AuditOrderStatusChange, models.AuditEntityOrder, shipment.OrderId.String(),
+		map[string]interface{}{"status": before},
+		map[string]interface{}{"status": models.StatusCourier, "carrier": shipment.Carrier, "tracking_number": shipment.TrackingNumber}))
+	if err != nil {
+		s.logger.Errorf("can't create shipment: %s", err)
+		return fmt.Errorf("can't create shipment: %w", err)
+	}
+	return nil
+}
+
+// GetShipment returns the shipment of the order with its tracking events
+func (s *shipment) GetShipment(ctx context.Context, orderId uuid.UUID) (*models.Shipment, error) {
+	s.logger.Debugf("Enter in repository GetShipment() with args: ctx, orderId: %v", orderId)
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("context closed")
+	default:
+	}
+	querier := s.storage.GetQuerier(ctx)
+	result := models.Shipment{OrderId: orderId}
+	var deliveredAt, checkedAt *time.Time
+	err := querier.QueryRow(ctx, `SELECT id, carrier, tracking_number, created_at, delivered_at, checked_at
+	FROM shipments WHERE order_id = $1`, orderId).Scan(
+		&result.Id,
+		&result.Carrier,
+		&result.TrackingNumber,
+		&result.CreatedAt,
+		&deliveredAt,
+		&checkedAt,
+	)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		s.logger.Errorf("shipment of order with id: %v not found", orderId)
+		return nil, models.ErrorNotFound{}
+	}
+	if err != nil {
+		s.logger.Errorf("can't get shipment: %s", err)
+		return nil, fmt.Errorf("can't get shipment: %w", err)
+	}
+	if deliveredAt != nil {
+		result.DeliveredAt = *deliveredAt
+	}
+	if checkedAt != nil {
+		result.CheckedAt = *checkedAt
+	}
+	rows, err := querier.Query(ctx, `SELECT status, description, location, occurred_at FROM shipment_events
+	WHERE shipment_id = $1 ORDER BY occurred_at, status`, result.Id)
+	if err != nil {
+		s.logger.Errorf("can't get tracking events: %s", err)
+		return nil, fmt.Errorf("can't get tracking events: %w", err)
+	}
+	defer rows.Close()
+	result.Events = make([]models.TrackingEvent, 0)
+	for rows.Next() {
+		var event models.TrackingEvent
+		if err := rows.Scan(&event.Status, &event.Description, &event.Location, &event.Time); err != nil {
+			s.logger.Error(err.Error())
+			return nil, fmt.Errorf("can't read tracking events: %w", err)
+		}
+		result.Events = append(result.Events, event)
+	}
+	if err := rows.Err(); err != nil {
+		s.logger.Error(err.Error())
+		return nil, fmt.Errorf("can't read tracking events: %w", err)
+	}
+	return &result, nil
+}
+
+// GetShipmentsToTrack returns at most limit shipments of the carrier which are not delivered yet,
+// shipments which were not checked for the longest time go first
+func (s *shipment) GetShipmentsToTrack(ctx context.Context, carrier string, limit int) ([]models.Shipment, error) {
+	s.logger.Debugf("Enter in repository GetShipmentsToTrack() with args: ctx, carrier: %s, limit: %d", carrier, limit)
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("context closed")
+	default:
+	}
+	rows, err := s.storage.GetQuerier(ctx).Query(ctx, `SELECT id, order_id, tracking_number, created_at, checked_at
+	FROM shipments WHERE carrier = $1 AND delivered_at IS NULL ORDER BY checked_at NULLS FIRST LIMIT $2`, carrier, limit)
+	if err != nil {
+		s.logger.Errorf("can't get shipments to track: %s", err)
+		return nil, fmt.Errorf("can't get shipments to track: %w", err)
+	}
+	defer rows.Close()
+	shipments := make([]models.Shipment, 0, limit)
+	for rows.Next() {
+		shipment := models.Shipment{Carrier: carrier}
+		var checkedAt *time.Time
+		if err := rows.Scan(&shipment.Id, &shipment.OrderId, &shipment.TrackingNumber, &shipment.CreatedAt, &checkedAt); err != nil {
+			s.logger.Error(err.Error())
+			return nil, fmt.Errorf("can't read shipments: %w", err)
+		}
+		if checkedAt != nil {
+			shipment.CheckedAt = *checkedAt
+		}
+		shipments = append(shipments, shipment)
+	}
+	if err := rows.Err(); err != nil {
+		s.logger.Error(err.Error())
+		return nil, fmt.Errorf("can't read shipments: %w", err)
+	}
+	return shipments, nil
+}
+
+// UpdateTracking saves new tracking events of the shipment and the time of the check. If the shipment
+// is delivered, the order picked by the courier becomes delivered
+func (s *shipment) UpdateTracking(ctx context.Context, shipment *models.Shipment, delivered bool) (err error) {
+	s.logger.Debugf("Enter in repository UpdateTracking() with args: ctx, shipment: %v, delivered: %t", shipment.Id, delivered)
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context closed")
+	default:
+	}
+	tx, err := s.storage.BeginTx(ctx)
+	if err != nil {
+		s.logger.Errorf("can't create transaction: %s", err)
+		return fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			s.logger.Errorf("transaction rolled back")
+			if err := tx.Rollback(ctx); err != nil {
+				s.logger.Errorf("can't rollback %s", err)
+			}
+			return
+		}
+		if err = tx.Commit(ctx); err != nil {
+			s.logger.Errorf("can't commit %s", err)
+			err = fmt.Errorf("can't commit transaction: %w", err)
+		}
+	}()
+	for _, event := range shipment.Events {
+		_, err = tx.Exec(ctx, `INSERT INTO shipment_events (shipment_id, status, description, location, occurred_at)
+		VALUES ($1, $2, $3, $4, $5) ON CONFLICT DO NOTHING`, shipment.Id, event.Status, event.Description, event.Location, event.Time)
+		if err != nil {
+			s.logger.Errorf("can't add tracking event: %s", err)
+			return fmt.Errorf("can't add tracking event: %w", err)
+		}
+	}
+	query := `UPDATE shipments SET checked_at = now() WHERE id = $1 RETURNING checked_at`
+	if delivered {
+		query = `UPDATE shipments SET checked_at = now(), delivered_at = now() WHERE id = $1 RETURNING checked_at`
+	}
+	err = tx.QueryRow(ctx, query, shipment.Id).Scan(&shipment.CheckedAt)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		s.logger.Errorf("shipment with id: %v not found", shipment.Id)
+		err = models.ErrorNotFound{}
+		return err
+	} else if err != nil {
+		s.logger.Errorf("can't update shipment: %s", err)
+		return fmt.Errorf("can't update shipment: %w", err)
+	}
+	if !delivered {
+		return nil
+	}
+	shipment.DeliveredAt = shipment.CheckedAt
+	// The order canceled or changed by the admin after the handover keeps its status
+	tag, err := tx.Exec(ctx, `UPDATE orders SET status=$1 WHERE id=$2 AND status=$3`,
+		models.StatusShipped, shipment.OrderId, models.StatusCourier)
+	if err != nil {
+		s.logger.Errorf("can't update status: %s", err)
+		return fmt.Errorf("can't update status: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil
+	}
+	err = addAuditEntry(ctx, tx, models.NewAuditEntry(ctx, models.AuditOrderStatusChange, models.AuditEntityOrder, shipment.OrderId.String(),
+		map[string]interface{}{"status": models.StatusCourier}, map[string]interface{}{"status": models.StatusShipped}))
+	if err != nil {
+		s.logger.Errorf("can't update tracking: %s", err)
+		return fmt.Errorf("can't update tracking: %w", err)
+	}
+	return nil
+}
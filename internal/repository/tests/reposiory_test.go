@@ -343,9 +343,13 @@ func TestItemItemsList(t *testing.T) {
 	itm := repository.NewItemRepo(store, logger)
 	ch, err := itm.ItemsList(context.Background())
 	assert.NoError(t, err)
+	offerIds := make(map[int64]bool)
 	for r := range ch {
 		assert.Contains(t, item1.Title, r.Title)
 		assert.Equal(t, item1.Description, r.Description)
+		assert.NotZero(t, r.OfferId)
+		assert.False(t, offerIds[r.OfferId])
+		offerIds[r.OfferId] = true
 	}
 
 }
@@ -529,9 +533,11 @@ func TestCartAddItem(t *testing.T) {
 	require.NoError(t, err)
 	defer store.GetPool().Exec(context.Background(), `DELETE from carts`)
 	crt := repository.NewCartStore(store, logger)
-	err = crt.AddItemToCart(context.Background(), cartMdl.Id, item2.Id)
+	err = crt.AddItemToCart(context.Background(), cartMdl.Id, item2.Id, 1)
 	defer store.GetPool().Exec(context.Background(), `DELETE from cart_items`)
 	require.NoError(t, err)
+	err = crt.AddItemToCart(context.Background(), cartMdl.Id, item2.Id, 1)
+	require.ErrorIs(t, err, models.ErrorQuantityExceeded{})
 	row = store.GetPool().QueryRow(context.Background(), `SELECT COUNT(cart_id) FROM cart_items`)
 	var count int
 	err = row.Scan(&count)
@@ -727,7 +733,7 @@ func TestCartDeleteItem(t *testing.T) {
 	err = row.Scan(&cartMdl.Id)
 	require.NoError(t, err)
 	defer store.GetPool().Exec(context.Background(), `DELETE from carts`)
-	store.GetPool().Exec(context.Background(), `INSERT INTO cart_items (cart_id, item_id, item_quantity) VALUES ($1, $2, $3)`, cartMdl.Id, item1.Id, cartMdl.Items[0].Quantity)
+	store.GetPool().Exec(context.Background(), `INSERT INTO cart_items (cart_id, item_id, item_quantity, price) VALUES ($1, $2, $3, $4)`, cartMdl.Id, item1.Id, cartMdl.Items[0].Quantity, item1.Price)
 	crt := repository.NewCartStore(store, logger)
 	err = crt.DeleteItemFromCart(context.Background(), cartMdl.Id, item1.Id)
 	require.NoError(t, err)
@@ -1378,3 +1384,822 @@ func TestOrdersGetOrders(t *testing.T) {
 	// require.Equal(t, order2.ID, res[1].ID)
 	require.Equal(t, order2.Address, res[1].Address)
 }
+
+func TestUnitOfWork(t *testing.T) {
+	uow := repository.NewUnitOfWork(store, logger)
+	crt := repository.NewCartStore(store, logger)
+	defer store.GetPool().Exec(context.Background(), `DELETE FROM carts`)
+
+	// Changes are rolled back if the function fails
+	var cartId uuid.UUID
+	err := uow.Do(context.Background(), func(ctx context.Context) error {
+		var err error
+		cartId, err = crt.Create(ctx, uuid.Nil)
+		require.NoError(t, err)
+		return fmt.Errorf("error")
+	})
+	require.Error(t, err)
+	_, err = crt.GetCart(context.Background(), cartId)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	err = uow.Do(context.Background(), func(ctx context.Context) error {
+		var err error
+		cartId, err = crt.Create(ctx, uuid.Nil)
+		if err != nil {
+			return err
+		}
+		return crt.ExtendCart(ctx, cartId, time.Hour)
+	})
+	require.NoError(t, err)
+	_, err = crt.GetCart(context.Background(), cartId)
+	require.NoError(t, err)
+}
+
+func TestPayments(t *testing.T) {
+	ctx := context.Background()
+	var rightsId, userId uuid.UUID
+	err := store.GetPool().QueryRow(ctx, `INSERT INTO rights (name, rules) VALUES ('customer', $1) RETURNING id`, []string{}).Scan(&rightsId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM rights`)
+	err = store.GetPool().QueryRow(ctx, `INSERT INTO users (name, lastname, password, email, rights) VALUES
+	('Name', 'Lastname', '123', 'pay@mail.ru', $1) RETURNING id`, rightsId).Scan(&userId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM users`)
+	newOrder := func(createdAt time.Time) uuid.UUID {
+		var id uuid.UUID
+		err := store.GetPool().QueryRow(ctx, `INSERT INTO orders (created_at, shipment_time, user_id, status, address)
+		VALUES ($1, $1, $2, $3, '') RETURNING id`, createdAt, userId, models.StatusCreated).Scan(&id)
+		require.NoError(t, err)
+		return id
+	}
+	defer store.GetPool().Exec(ctx, `DELETE FROM audit_log`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM orders`)
+	paidId := newOrder(time.Now().Add(-2 * time.Hour))
+	unpaidId := newOrder(time.Now().Add(-2 * time.Hour))
+	newId := newOrder(time.Now())
+
+	payments := repository.NewPaymentRepo(store, logger)
+	for _, orderId := range []uuid.UUID{paidId, unpaidId} {
+		_, err = payments.CreatePayment(ctx, &models.Payment{
+			OrderId:    orderId,
+			Provider:   "mock",
+			ExternalId: "mock_" + orderId.String(),
+			Amount:     1000,
+			Currency:   "RUB",
+			Status:     models.PaymentPending,
+		})
+		require.NoError(t, err)
+	}
+	pay, err := payments.GetPaymentByExternalId(ctx, "mock", "mock_"+paidId.String())
+	require.NoError(t, err)
+	require.Equal(t, paidId, pay.OrderId)
+	require.Equal(t, int64(1000), pay.Amount)
+	_, err = payments.GetPaymentByExternalId(ctx, "mock", "unknown")
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	paid, err := payments.PayOrder(ctx, paidId)
+	require.NoError(t, err)
+	require.True(t, paid)
+	paid, err = payments.PayOrder(ctx, paidId)
+	require.NoError(t, err)
+	require.False(t, paid)
+	pay.Status = models.PaymentSucceeded
+	require.NoError(t, payments.UpdatePayment(ctx, pay))
+
+	// Only the old unpaid order is canceled with its pending payment
+	ids, err := payments.CancelUnpaidOrders(ctx, time.Hour, 10)
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{unpaidId}, ids)
+	res, err := payments.GetPayments(ctx, unpaidId)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.Equal(t, models.PaymentCanceled, res[0].Status)
+	res, err = payments.GetPayments(ctx, paidId)
+	require.NoError(t, err)
+	require.Equal(t, models.PaymentSucceeded, res[0].Status)
+	res, err = payments.GetPayments(ctx, newId)
+	require.NoError(t, err)
+	require.Empty(t, res)
+}
+
+func TestReturns(t *testing.T) {
+	ctx := context.Background()
+	var rightsId, userId, categoryId, itemId, orderId uuid.UUID
+	err := store.GetPool().QueryRow(ctx, `INSERT INTO rights (name, rules) VALUES ('customer', $1) RETURNING id`, []string{}).Scan(&rightsId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM rights`)
+	err = store.GetPool().QueryRow(ctx, `INSERT INTO users (name, lastname, password, email, rights) VALUES
+	('Name', 'Lastname', '123', 'return@mail.ru', $1) RETURNING id`, rightsId).Scan(&userId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM users`)
+	err = store.GetPool().QueryRow(ctx, `INSERT INTO categories (name, description) VALUES ('1', '1des') RETURNING id`).Scan(&categoryId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM categories`)
+	err = store.GetPool().QueryRow(ctx, `INSERT INTO items (name, category, description, price, vendor, stock)
+	VALUES ('testItem', $1, 'desc', 500, 'vendor', 1) RETURNING id`, categoryId).Scan(&itemId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM items`)
+	err = store.GetPool().QueryRow(ctx, `INSERT INTO orders (created_at, shipment_time, user_id, status, address)
+	VALUES (now(), now(), $1, $2, '') RETURNING id`, userId, models.StatusShipped).Scan(&orderId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM orders`)
+
+	returns := repository.NewReturnRepo(store, logger)
+	actorCtx := models.ContextWithActor(ctx, models.Actor{UserId: userId, Role: models.Customer})
+	returnId, err := returns.CreateReturn(actorCtx, &models.Return{
+		OrderId: orderId,
+		UserId:  userId,
+		Reason:  "Wrong size",
+		Status:  models.ReturnRequested,
+		Items:   []models.ReturnItem{{ItemId: itemId, Quantity: 2}},
+	})
+	require.NoError(t, err)
+
+	ret, err := returns.GetReturn(ctx, returnId)
+	require.NoError(t, err)
+	require.Equal(t, models.ReturnRequested, ret.Status)
+	require.Equal(t, []models.ReturnItem{{ItemId: itemId, Title: "testItem", Price: 500, Quantity: 2}}, ret.Items)
+	require.Len(t, ret.History, 1)
+	require.Equal(t, userId, ret.History[0].ActorId)
+	require.Equal(t, int64(1000), ret.Total())
+	_, err = returns.GetReturn(ctx, uuid.New())
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	quantities, err := returns.GetReturnedQuantities(ctx, orderId)
+	require.NoError(t, err)
+	require.Equal(t, map[uuid.UUID]int{itemId: 2}, quantities)
+
+	// The change from another status than the current one is refused
+	err = returns.ChangeReturnStatus(ctx, returnId, models.ReturnApproved, models.ReturnReceived, "")
+	require.ErrorIs(t, err, models.ErrorWrongStatus{})
+	require.NoError(t, returns.ChangeReturnStatus(ctx, returnId, models.ReturnRequested, models.ReturnApproved, "Send it back"))
+	require.NoError(t, returns.SetTrackingNumber(ctx, returnId, "RA123456789RU"))
+	require.NoError(t, returns.ChangeReturnStatus(ctx, returnId, models.ReturnApproved, models.ReturnReceived, ""))
+	require.NoError(t, returns.RestockReturn(ctx, returnId))
+	var stock int
+	require.NoError(t, store.GetPool().QueryRow(ctx, `SELECT stock FROM items WHERE id = $1`, itemId).Scan(&stock))
+	require.Equal(t, 3, stock)
+	require.NoError(t, returns.SetRefundAmount(ctx, returnId, 700))
+
+	list, err := returns.GetOrderReturns(ctx, orderId)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, models.ReturnReceived, list[0].Status)
+	require.Equal(t, "RA123456789RU", list[0].TrackingNumber)
+	require.Equal(t, int64(700), list[0].RefundAmount)
+	require.Len(t, list[0].History, 3)
+	require.Equal(t, "Send it back", list[0].History[1].Comment)
+
+	list, err = returns.GetReturns(ctx, models.ReturnRequested, 0, 10)
+	require.NoError(t, err)
+	require.Empty(t, list)
+	list, err = returns.GetReturns(ctx, "", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+}
+
+func TestCancelOrder(t *testing.T) {
+	ctx := context.Background()
+	var rightsId, userId, categoryId, itemId uuid.UUID
+	err := store.GetPool().QueryRow(ctx, `INSERT INTO rights (name, rules) VALUES ('customer', $1) RETURNING id`, []string{}).Scan(&rightsId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM rights`)
+	err = store.GetPool().QueryRow(ctx, `INSERT INTO users (name, lastname, password, email, rights) VALUES
+	('Name', 'Lastname', '123', 'cancel@mail.ru', $1) RETURNING id`, rightsId).Scan(&userId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM users`)
+	err = store.GetPool().QueryRow(ctx, `INSERT INTO categories (name, description) VALUES ('1', '1des') RETURNING id`).Scan(&categoryId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM categories`)
+	err = store.GetPool().QueryRow(ctx, `INSERT INTO items (name, category, description, price, vendor, stock)
+	VALUES ('testItem', $1, 'desc', 500, 'vendor', 3) RETURNING id`, categoryId).Scan(&itemId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM items`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM audit_log`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM orders`)
+	stock := func() int {
+		var stock int
+		require.NoError(t, store.GetPool().QueryRow(ctx, `SELECT stock FROM items WHERE id = $1`, itemId).Scan(&stock))
+		return stock
+	}
+
+	orders := repository.NewOrderRepo(store, logger)
+	newOrder := func(quantity int) (*models.Order, error) {
+		return orders.Create(ctx, &models.Order{
+			User:   models.User{ID: userId},
+			Status: models.StatusCreated,
+			Items:  []models.ItemWithQuantity{{Item: models.Item{Id: itemId}, Quantity: quantity}},
+		})
+	}
+	// The stock is reserved by the order
+	order, err := newOrder(2)
+	require.NoError(t, err)
+	require.Equal(t, 1, stock())
+	_, err = newOrder(2)
+	require.ErrorIs(t, err, models.ErrorQuantityExceeded{})
+	require.Equal(t, 1, stock())
+
+	_, err = orders.CancelOrder(ctx, order.ID, "Changed my mind", []models.Status{models.StatusPaid})
+	require.ErrorIs(t, err, models.ErrorWrongStatus{})
+	_, err = orders.CancelOrder(ctx, uuid.New(), "Changed my mind", models.CancelableStatuses(false))
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	before, err := orders.CancelOrder(ctx, order.ID, "Changed my mind", models.CancelableStatuses(false))
+	require.NoError(t, err)
+	require.Equal(t, models.StatusCreated, before)
+	require.Equal(t, 3, stock())
+	res, err := orders.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.StatusCanceled, res.Status)
+	require.Equal(t, "Changed my mind", res.CancelReason)
+
+	// The stock of the canceled order is not released again when the order is deleted
+	require.NoError(t, orders.DeleteOrder(ctx, &res))
+	require.Equal(t, 3, stock())
+	order, err = newOrder(1)
+	require.NoError(t, err)
+	require.Equal(t, 2, stock())
+	require.NoError(t, orders.DeleteOrder(ctx, order))
+	require.Equal(t, 3, stock())
+}
+
+func TestInvoices(t *testing.T) {
+	ctx := context.Background()
+	var rightsId, userId, categoryId, itemId uuid.UUID
+	err := store.GetPool().QueryRow(ctx, `INSERT INTO rights (name, rules) VALUES ('customer', $1) RETURNING id`, []string{}).Scan(&rightsId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM rights`)
+	err = store.GetPool().QueryRow(ctx, `INSERT INTO users (name, lastname, password, email, rights) VALUES
+	('Name', 'Lastname', '123', 'invoice@mail.ru', $1) RETURNING id`, rightsId).Scan(&userId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM users`)
+	err = store.GetPool().QueryRow(ctx, `INSERT INTO categories (name, description) VALUES ('1', '1des') RETURNING id`).Scan(&categoryId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM categories`)
+	err = store.GetPool().QueryRow(ctx, `INSERT INTO items (name, category, description, price, vendor)
+	VALUES ('testItem', $1, 'desc', 500, 'vendor') RETURNING id`, categoryId).Scan(&itemId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM items`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM orders`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM order_items`)
+
+	orders := repository.NewOrderRepo(store, logger)
+	invoices := repository.NewInvoiceRepo(store, logger)
+	newOrder := func() *models.Order {
+		order, err := orders.Create(ctx, &models.Order{
+			User:   models.User{ID: userId},
+			Status: models.StatusCreated,
+			Items:  []models.ItemWithQuantity{{Item: models.Item{Id: itemId}, Quantity: 1}},
+		})
+		require.NoError(t, err)
+		return order
+	}
+	first, second := newOrder(), newOrder()
+
+	// The price of the placed order doesn't change with the catalog
+	_, err = store.GetPool().Exec(ctx, `UPDATE items SET price = 700 WHERE id = $1`, itemId)
+	require.NoError(t, err)
+	res, err := orders.GetOrderByID(ctx, first.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(500), res.Items[0].Price)
+	require.Equal(t, "invoice@mail.ru", res.User.Email)
+
+	_, err = invoices.GetOrCreateInvoice(ctx, uuid.New())
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	// The numbers are given in the order of requests and kept
+	invoice, err := invoices.GetOrCreateInvoice(ctx, second.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), invoice.Number)
+	invoice, err = invoices.GetOrCreateInvoice(ctx, first.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), invoice.Number)
+	invoice, err = invoices.GetOrCreateInvoice(ctx, second.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), invoice.Number)
+}
+
+func TestOrderShipping(t *testing.T) {
+	ctx := context.Background()
+	var rightsId, userId, categoryId uuid.UUID
+	err := store.GetPool().QueryRow(ctx, `INSERT INTO rights (name, rules) VALUES ('customer', $1) RETURNING id`, []string{}).Scan(&rightsId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM rights`)
+	err = store.GetPool().QueryRow(ctx, `INSERT INTO users (name, lastname, password, email, rights) VALUES
+	('Name', 'Lastname', '123', 'shipping@mail.ru', $1) RETURNING id`, rightsId).Scan(&userId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM users`)
+	err = store.GetPool().QueryRow(ctx, `INSERT INTO categories (name, description) VALUES ('1', '1des') RETURNING id`).Scan(&categoryId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM categories`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM items`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM audit_log`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM orders`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM order_items`)
+
+	items := repository.NewItemRepo(store, logger)
+	itemId, err := items.CreateItem(ctx, &models.Item{Title: "Heavy", Category: models.Category{Id: categoryId}, Price: 500, Weight: 2500})
+	require.NoError(t, err)
+	item, err := items.GetItem(ctx, itemId)
+	require.NoError(t, err)
+	require.Equal(t, int32(2500), item.Weight)
+
+	orders := repository.NewOrderRepo(store, logger)
+	order, err := orders.Create(ctx, &models.Order{
+		User:           models.User{ID: userId},
+		Status:         models.StatusCreated,
+		Items:          []models.ItemWithQuantity{{Item: models.Item{Id: itemId}, Quantity: 1}},
+		ShippingMethod: "courier",
+		ShippingCost:   300,
+		Discount:       50,
+	})
+	require.NoError(t, err)
+	res, err := orders.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	require.Equal(t, "courier", res.ShippingMethod)
+	require.Equal(t, int64(300), res.ShippingCost)
+	require.Equal(t, int64(50), res.Discount)
+	require.Equal(t, int64(750), res.Totals(models.CartPricing{ShippingCost: 100, DiscountPercent: 50}).Total)
+}
+
+func TestGetOrders(t *testing.T) {
+	ctx := context.Background()
+	var rightsId, userId, otherUserId, categoryId uuid.UUID
+	err := store.GetPool().QueryRow(ctx, `INSERT INTO rights (name, rules) VALUES ('customer', $1) RETURNING id`, []string{}).Scan(&rightsId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM rights`)
+	err = store.GetPool().QueryRow(ctx, `INSERT INTO users (name, lastname, password, email, rights) VALUES
+	('Name', 'Lastname', '123', 'first_buyer@mail.ru', $1) RETURNING id`, rightsId).Scan(&userId)
+	require.NoError(t, err)
+	err = store.GetPool().QueryRow(ctx, `INSERT INTO users (name, lastname, password, email, rights) VALUES
+	('Other', 'Lastname', '123', 'second@gmail.com', $1) RETURNING id`, rightsId).Scan(&otherUserId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM users`)
+	err = store.GetPool().QueryRow(ctx, `INSERT INTO categories (name, description) VALUES ('1', '1des') RETURNING id`).Scan(&categoryId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM categories`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM items`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM audit_log`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM orders`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM order_items`)
+
+	items := repository.NewItemRepo(store, logger)
+	cheapId, err := items.CreateItem(ctx, &models.Item{Title: "Cheap", Category: models.Category{Id: categoryId}, Price: 100})
+	require.NoError(t, err)
+	expensiveId, err := items.CreateItem(ctx, &models.Item{Title: "Expensive", Category: models.Category{Id: categoryId}, Price: 2000})
+	require.NoError(t, err)
+
+	orders := repository.NewOrderRepo(store, logger)
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	first, err := orders.Create(ctx, &models.Order{
+		CreatedAt: start,
+		User:      models.User{ID: userId},
+		Status:    models.StatusCreated,
+		Items:     []models.ItemWithQuantity{{Item: models.Item{Id: cheapId}, Quantity: 3}},
+	})
+	require.NoError(t, err)
+	second, err := orders.Create(ctx, &models.Order{
+		CreatedAt: start.Add(24 * time.Hour),
+		User:      models.User{ID: otherUserId},
+		Status:    models.StatusPaid,
+		Items: []models.ItemWithQuantity{
+			{Item: models.Item{Id: cheapId}, Quantity: 1},
+			{Item: models.Item{Id: expensiveId}, Quantity: 1},
+		},
+		ShippingMethod: "pickup",
+		Discount:       210,
+	})
+	require.NoError(t, err)
+	third, err := orders.Create(ctx, &models.Order{
+		CreatedAt: start.Add(48 * time.Hour),
+		User:      models.User{ID: userId},
+		Status:    models.StatusPaid,
+		Items:     []models.ItemWithQuantity{{Item: models.Item{Id: expensiveId}, Quantity: 1}},
+	})
+	require.NoError(t, err)
+
+	pricing := models.CartPricing{DiscountThreshold: 2000, DiscountPercent: 10, ShippingCost: 300, FreeShippingThreshold: 3000}
+	list, err := orders.GetOrders(ctx, models.OrderFilter{SortDesc: true}, pricing)
+	require.NoError(t, err)
+	require.Len(t, list, 3)
+	require.Equal(t, []uuid.UUID{third.ID, second.ID, first.ID}, []uuid.UUID{list[0].ID, list[1].ID, list[2].ID})
+	require.Equal(t, "first_buyer@mail.ru", list[0].User.Email)
+	require.Equal(t, 3, list[2].ItemsQuantity)
+	for _, summary := range list {
+		order, err := orders.GetOrderByID(ctx, summary.ID)
+		require.NoError(t, err)
+		require.Equal(t, order.Totals(pricing).Total, summary.Total)
+	}
+
+	minTotal := int64(1000)
+	list, err = orders.GetOrders(ctx, models.OrderFilter{MinTotal: &minTotal, SortType: models.OrderSortTotal}, pricing)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, second.ID, list[0].ID)
+	require.Equal(t, int64(1890), list[0].Total)
+	require.Equal(t, third.ID, list[1].ID)
+	require.Equal(t, int64(2100), list[1].Total)
+
+	list, err = orders.GetOrders(ctx, models.OrderFilter{Email: "FIRST_", ItemId: expensiveId}, pricing)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, third.ID, list[0].ID)
+
+	filter := models.OrderFilter{Status: models.StatusPaid, From: start.Add(time.Hour), To: start.Add(48 * time.Hour), Limit: 1}
+	list, err = orders.GetOrders(ctx, filter, pricing)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, second.ID, list[0].ID)
+	counts, err := orders.CountOrdersByStatus(ctx, filter, pricing)
+	require.NoError(t, err)
+	require.Equal(t, map[models.Status]int{models.StatusPaid: 1}, counts)
+
+	counts, err = orders.CountOrdersByStatus(ctx, models.OrderFilter{Status: models.StatusPaid}, pricing)
+	require.NoError(t, err)
+	require.Equal(t, map[models.Status]int{models.StatusPaid: 2, models.StatusCreated: 1}, counts)
+}
+
+func TestExportOrders(t *testing.T) {
+	ctx := context.Background()
+	var rightsId, userId, categoryId uuid.UUID
+	err := store.GetPool().QueryRow(ctx, `INSERT INTO rights (name, rules) VALUES ('customer', $1) RETURNING id`, []string{}).Scan(&rightsId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM rights`)
+	err = store.GetPool().QueryRow(ctx, `INSERT INTO users (name, lastname, password, email, rights) VALUES
+	('Name', 'Lastname', '123', 'export@mail.ru', $1) RETURNING id`, rightsId).Scan(&userId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM users`)
+	err = store.GetPool().QueryRow(ctx, `INSERT INTO categories (name, description) VALUES ('1', '1des') RETURNING id`).Scan(&categoryId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM categories`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM items`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM audit_log`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM orders`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM order_items`)
+
+	items := repository.NewItemRepo(store, logger)
+	firstId, err := items.CreateItem(ctx, &models.Item{Title: "A first", Category: models.Category{Id: categoryId}, Price: 1500})
+	require.NoError(t, err)
+	secondId, err := items.CreateItem(ctx, &models.Item{Title: "B second", Category: models.Category{Id: categoryId}, Price: 200})
+	require.NoError(t, err)
+
+	orders := repository.NewOrderRepo(store, logger)
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	address := models.UserAddress{Zipcode: "123456", Country: "Russia", City: "Moscow", Street: "Lenina, 1"}
+	order, err := orders.Create(ctx, &models.Order{
+		CreatedAt: start,
+		User:      models.User{ID: userId},
+		Address:   address,
+		Status:    models.StatusPaid,
+		Items: []models.ItemWithQuantity{
+			{Item: models.Item{Id: secondId}, Quantity: 2},
+			{Item: models.Item{Id: firstId}, Quantity: 1},
+		},
+		ShippingMethod: "courier",
+		ShippingCost:   300,
+		Discount:       190,
+	})
+	require.NoError(t, err)
+	_, err = orders.Create(ctx, &models.Order{
+		CreatedAt: start.Add(31 * 24 * time.Hour),
+		User:      models.User{ID: userId},
+		Address:   address,
+		Status:    models.StatusCreated,
+		Items:     []models.ItemWithQuantity{{Item: models.Item{Id: firstId}, Quantity: 1}},
+	})
+	require.NoError(t, err)
+
+	pricing := models.CartPricing{DiscountThreshold: 1000, DiscountPercent: 10}
+	linesChan, errs, err := orders.ExportOrders(ctx, models.OrderFilter{From: start, To: start.Add(24 * time.Hour)}, pricing)
+	require.NoError(t, err)
+	lines := make([]models.OrderExportLine, 0, 2)
+	for line := range linesChan {
+		lines = append(lines, line)
+	}
+	require.NoError(t, <-errs)
+	require.Len(t, lines, 2)
+	require.Equal(t, order.ID, lines[0].OrderId)
+	require.Equal(t, "A first", lines[0].Item.Title)
+	require.Equal(t, "B second", lines[1].Item.Title)
+	require.Equal(t, int32(200), lines[1].Price)
+	require.Equal(t, 2, lines[1].Quantity)
+	require.Equal(t, address, lines[1].Address)
+	require.Equal(t, "export@mail.ru", lines[1].User.Email)
+	require.Equal(t, "Lastname", lines[1].User.Lastname)
+	require.Equal(t, int64(1900), lines[1].Subtotal)
+	require.Equal(t, int64(190), lines[1].Discount)
+	require.Equal(t, int64(300), lines[1].Shipping)
+	require.Equal(t, int64(2010), lines[1].Total)
+}
+
+func TestOrderAddress(t *testing.T) {
+	ctx := context.Background()
+	var rightsId, userId, categoryId uuid.UUID
+	err := store.GetPool().QueryRow(ctx, `INSERT INTO rights (name, rules) VALUES ('customer', $1) RETURNING id`, []string{}).Scan(&rightsId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM rights`)
+	err = store.GetPool().QueryRow(ctx, `INSERT INTO users (name, lastname, password, email, rights) VALUES
+	('Name', 'Lastname', '123', 'address@mail.ru', $1) RETURNING id`, rightsId).Scan(&userId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM users`)
+	err = store.GetPool().QueryRow(ctx, `INSERT INTO categories (name, description) VALUES ('1', '1des') RETURNING id`).Scan(&categoryId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM categories`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM items`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM audit_log`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM orders`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM order_items`)
+
+	itemId, err := repository.NewItemRepo(store, logger).CreateItem(ctx, &models.Item{Title: "Item", Category: models.Category{Id: categoryId}, Price: 100})
+	require.NoError(t, err)
+
+	orders := repository.NewOrderRepo(store, logger)
+	address := models.UserAddress{Zipcode: "123456", Country: "Russia", City: "Moscow", Street: "Lenina -> Pushkina, 1"}
+	order, err := orders.Create(ctx, &models.Order{
+		User:    models.User{ID: userId},
+		Address: address,
+		Status:  models.StatusCreated,
+		Items:   []models.ItemWithQuantity{{Item: models.Item{Id: itemId}, Quantity: 1}},
+	})
+	require.NoError(t, err)
+	res, err := orders.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	require.Equal(t, address, res.Address)
+
+	newAddress := models.UserAddress{Zipcode: "190000", Country: "Russia", City: "Saint Petersburg", Street: "Nevsky, 1",
+		Apartment: "12", Recipient: "Ivan Ivanov", Phone: "+79001234567"}
+	require.NoError(t, orders.ChangeAddress(ctx, order, newAddress))
+	res, err = orders.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	require.Equal(t, newAddress, res.Address)
+
+	list, err := orders.GetOrders(ctx, models.OrderFilter{City: "saint petersburg"}, models.CartPricing{})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	list, err = orders.GetOrders(ctx, models.OrderFilter{City: "Moscow"}, models.CartPricing{})
+	require.NoError(t, err)
+	require.Len(t, list, 0)
+}
+
+func TestAddressBook(t *testing.T) {
+	ctx := context.Background()
+	var rightsId, userId uuid.UUID
+	err := store.GetPool().QueryRow(ctx, `INSERT INTO rights (name, rules) VALUES ('customer', $1) RETURNING id`, []string{}).Scan(&rightsId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM rights`)
+	err = store.GetPool().QueryRow(ctx, `INSERT INTO users (name, lastname, password, email, rights) VALUES
+	('Name', 'Lastname', '123', 'addressbook@mail.ru', $1) RETURNING id`, rightsId).Scan(&userId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM users`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM user_addresses`)
+
+	addresses := repository.NewAddressRepo(store, logger)
+	_, err = addresses.CreateAddress(ctx, &models.SavedAddress{UserId: uuid.New(), Name: "home"})
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	// The first address becomes the default for shipping
+	home := models.SavedAddress{UserId: userId, Name: "home",
+		Address: models.UserAddress{Zipcode: "123456", Country: "Russia", City: "Moscow", Street: "Lenina, 1"}}
+	homeId, err := addresses.CreateAddress(ctx, &home)
+	require.NoError(t, err)
+	require.True(t, home.DefaultShipping)
+
+	_, err = addresses.CreateAddress(ctx, &models.SavedAddress{UserId: userId, Name: "home"})
+	require.ErrorIs(t, err, models.ErrorAlreadyExists{})
+
+	// The new default shipping address unsets the default of the first address
+	work := models.SavedAddress{UserId: userId, Name: "work", DefaultShipping: true,
+		Address: models.UserAddress{Zipcode: "190000", City: "Saint Petersburg", Street: "Nevsky, 1", Apartment: "12"}}
+	workId, err := addresses.CreateAddress(ctx, &work)
+	require.NoError(t, err)
+	res, err := addresses.GetAddress(ctx, homeId)
+	require.NoError(t, err)
+	require.False(t, res.DefaultShipping)
+	require.Equal(t, home.Address, res.Address)
+
+	list, err := addresses.GetAddresses(ctx, userId)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, workId, list[0].Id)
+	require.Equal(t, work.Address, list[0].Address)
+
+	work.Name = "home"
+	require.ErrorIs(t, addresses.UpdateAddress(ctx, &work), models.ErrorAlreadyExists{})
+	// The default set by the change of the address unsets the default of other addresses
+	home.DefaultShipping = true
+	require.NoError(t, addresses.UpdateAddress(ctx, &home))
+	res, err = addresses.GetAddress(ctx, workId)
+	require.NoError(t, err)
+	require.False(t, res.DefaultShipping)
+	work.Name = "office"
+	require.NoError(t, addresses.UpdateAddress(ctx, &work))
+	res, err = addresses.GetAddress(ctx, homeId)
+	require.NoError(t, err)
+	require.False(t, res.DefaultShipping)
+	res, err = addresses.GetAddress(ctx, workId)
+	require.NoError(t, err)
+	require.Equal(t, "office", res.Name)
+	require.True(t, res.DefaultShipping)
+
+	another := work
+	another.UserId = uuid.New()
+	require.ErrorIs(t, addresses.UpdateAddress(ctx, &another), models.ErrorNotFound{})
+
+	require.NoError(t, addresses.DeleteAddress(ctx, workId))
+	require.ErrorIs(t, addresses.DeleteAddress(ctx, workId), models.ErrorNotFound{})
+	_, err = addresses.GetAddress(ctx, workId)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+	list, err = addresses.GetAddresses(ctx, userId)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.False(t, list[0].DefaultShipping)
+}
+
+func TestNotifications(t *testing.T) {
+	ctx := context.Background()
+	var rightsId, userId, categoryId uuid.UUID
+	err := store.GetPool().QueryRow(ctx, `INSERT INTO rights (name, rules) VALUES ('customer', $1) RETURNING id`, []string{}).Scan(&rightsId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM rights`)
+	err = store.GetPool().QueryRow(ctx, `INSERT INTO users (name, lastname, password, email, rights) VALUES
+	('Name', 'Lastname', '123', 'notifications@mail.ru', $1) RETURNING id`, rightsId).Scan(&userId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM users`)
+	err = store.GetPool().QueryRow(ctx, `INSERT INTO categories (name, description) VALUES ('1', '1des') RETURNING id`).Scan(&categoryId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM categories`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM items`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM audit_log`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM orders`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM order_items`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM notifications`)
+
+	itemId, err := repository.NewItemRepo(store, logger).CreateItem(ctx, &models.Item{Title: "Item", Category: models.Category{Id: categoryId}, Price: 100})
+	require.NoError(t, err)
+	orders := repository.NewOrderRepo(store, logger)
+	order, err := orders.Create(ctx, &models.Order{
+		User:    models.User{ID: userId},
+		Address: models.UserAddress{Zipcode: "123456", City: "Moscow", Street: "Lenina, 1"},
+		Status:  models.StatusCreated,
+		Items:   []models.ItemWithQuantity{{Item: models.Item{Id: itemId}, Quantity: 1}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, orders.ChangeStatus(ctx, order, models.StatusCourier))
+	// The status which is not changed is not reported
+	require.NoError(t, orders.ChangeStatus(ctx, order, models.StatusCourier))
+
+	notifications := repository.NewNotificationRepo(store, logger)
+	preferences, err := notifications.GetNotificationPreferences(ctx, userId)
+	require.NoError(t, err)
+	require.Equal(t, models.DefaultNotificationPreferences(userId), *preferences)
+	preferences.Language = "ru"
+	preferences.OrderStatus = false
+	require.NoError(t, notifications.SetNotificationPreferences(ctx, preferences))
+	res, err := notifications.GetNotificationPreferences(ctx, userId)
+	require.NoError(t, err)
+	require.Equal(t, preferences, res)
+	require.ErrorIs(t, notifications.SetNotificationPreferences(ctx, &models.NotificationPreferences{UserId: uuid.New()}),
+		models.ErrorNotFound{})
+
+	// Emails are queued by the registration, the placed order and the shipment
+	claimed, err := notifications.ClaimNotifications(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 3)
+	require.Equal(t, models.NotificationRegistration, claimed[0].Kind)
+	require.Equal(t, uuid.Nil, claimed[0].OrderId)
+	require.Equal(t, "notifications@mail.ru", claimed[0].Email)
+	require.Equal(t, *preferences, claimed[0].Preferences)
+	require.Equal(t, models.NotificationOrderPlaced, claimed[1].Kind)
+	require.Equal(t, order.ID, claimed[1].OrderId)
+	require.Equal(t, models.StatusCreated, claimed[1].Status)
+	require.Equal(t, models.NotificationOrderShipped, claimed[2].Kind)
+	require.Equal(t, models.StatusCourier, claimed[2].Status)
+
+	// Claimed notifications are not claimed again until the lease ends
+	again, err := notifications.ClaimNotifications(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, again, 0)
+
+	claimed[0].State = models.NotificationSent
+	claimed[0].Attempts = 1
+	claimed[0].SentAt = time.Now()
+	require.NoError(t, notifications.UpdateNotification(ctx, &claimed[0]))
+	claimed[1].Attempts = 1
+	claimed[1].LastError = "error"
+	claimed[1].NextAttemptAt = time.Now().Add(-time.Second)
+	require.NoError(t, notifications.UpdateNotification(ctx, &claimed[1]))
+	again, err = notifications.ClaimNotifications(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, again, 1)
+	require.Equal(t, claimed[1].Id, again[0].Id)
+	require.Equal(t, 1, again[0].Attempts)
+	require.Equal(t, "error", again[0].LastError)
+
+	require.ErrorIs(t, notifications.UpdateNotification(ctx, &models.Notification{Id: uuid.New()}), models.ErrorNotFound{})
+}
+
+func TestShipments(t *testing.T) {
+	ctx := context.Background()
+	var rightsId, userId, categoryId uuid.UUID
+	err := store.GetPool().QueryRow(ctx, `INSERT INTO rights (name, rules) VALUES ('customer', $1) RETURNING id`, []string{}).Scan(&rightsId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM rights`)
+	err = store.GetPool().QueryRow(ctx, `INSERT INTO users (name, lastname, password, email, rights) VALUES
+	('Name', 'Lastname', '123', 'shipments@mail.ru', $1) RETURNING id`, rightsId).Scan(&userId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM users`)
+	err = store.GetPool().QueryRow(ctx, `INSERT INTO categories (name, description) VALUES ('1', '1des') RETURNING id`).Scan(&categoryId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM categories`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM items`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM audit_log`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM orders`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM order_items`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM notifications`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM shipments`)
+
+	itemId, err := repository.NewItemRepo(store, logger).CreateItem(ctx, &models.Item{Title: "Item", Category: models.Category{Id: categoryId}, Price: 100})
+	require.NoError(t, err)
+	orders := repository.NewOrderRepo(store, logger)
+	order, err := orders.Create(ctx, &models.Order{
+		User:    models.User{ID: userId},
+		Address: models.UserAddress{Zipcode: "123456", City: "Moscow", Street: "Lenina, 1"},
+		Status:  models.StatusPaid,
+		Items:   []models.ItemWithQuantity{{Item: models.Item{Id: itemId}, Quantity: 1}},
+	})
+	require.NoError(t, err)
+
+	shipments := repository.NewShipmentRepo(store, logger)
+	_, err = shipments.GetShipment(ctx, order.ID)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+	shipment := &models.Shipment{OrderId: order.ID, Carrier: "fake", TrackingNumber: "FAKE-1"}
+	require.ErrorIs(t, shipments.CreateShipment(ctx, shipment, models.ShippableStatuses()), models.ErrorWrongStatus{})
+	require.ErrorIs(t, shipments.CreateShipment(ctx, &models.Shipment{OrderId: uuid.New(), Carrier: "fake", TrackingNumber: "FAKE-2"},
+		models.ShippableStatuses()), models.ErrorNotFound{})
+
+	// The shipped order is picked by the courier
+	require.NoError(t, orders.ChangeStatus(ctx, order, models.StatusReady))
+	require.NoError(t, shipments.CreateShipment(ctx, shipment, models.ShippableStatuses()))
+	require.NotEqual(t, uuid.Nil, shipment.Id)
+	res, err := orders.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.StatusCourier, res.Status)
+	require.ErrorIs(t, shipments.CreateShipment(ctx, &models.Shipment{OrderId: order.ID, Carrier: "fake", TrackingNumber: "FAKE-2"},
+		[]models.Status{models.StatusCourier}), models.ErrorAlreadyExists{})
+
+	toTrack, err := shipments.GetShipmentsToTrack(ctx, "fake", 10)
+	require.NoError(t, err)
+	require.Len(t, toTrack, 1)
+	require.Equal(t, shipment.Id, toTrack[0].Id)
+	require.True(t, toTrack[0].CheckedAt.IsZero())
+	toTrack, err = shipments.GetShipmentsToTrack(ctx, "another", 10)
+	require.NoError(t, err)
+	require.Len(t, toTrack, 0)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	shipment.Events = []models.TrackingEvent{{Status: "accepted", Time: now.Add(-time.Hour)}}
+	require.NoError(t, shipments.UpdateTracking(ctx, shipment, false))
+	// Events saved before are not duplicated
+	shipment.Events = append(shipment.Events, models.TrackingEvent{Status: "delivered", Location: "Moscow", Time: now})
+	require.NoError(t, shipments.UpdateTracking(ctx, shipment, true))
+
+	saved, err := shipments.GetShipment(ctx, order.ID)
+	require.NoError(t, err)
+	require.Equal(t, "FAKE-1", saved.TrackingNumber)
+	require.False(t, saved.DeliveredAt.IsZero())
+	require.False(t, saved.CheckedAt.IsZero())
+	require.Len(t, saved.Events, 2)
+	require.Equal(t, "delivered", saved.Events[1].Status)
+	require.Equal(t, "Moscow", saved.Events[1].Location)
+	require.True(t, now.Equal(saved.Events[1].Time))
+	res, err = orders.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.StatusShipped, res.Status)
+
+	// Delivered shipments are not tracked
+	toTrack, err = shipments.GetShipmentsToTrack(ctx, "fake", 10)
+	require.NoError(t, err)
+	require.Len(t, toTrack, 0)
+	require.ErrorIs(t, shipments.UpdateTracking(ctx, &models.Shipment{Id: uuid.New()}, false), models.ErrorNotFound{})
+}
+
+func TestUpdateUserRoleWithoutRights(t *testing.T) {
+	ctx := context.Background()
+	var rightsId uuid.UUID
+	err := store.GetPool().QueryRow(ctx, `INSERT INTO rights (name, rules) VALUES ('Seller', $1) RETURNING id`, []string{}).Scan(&rightsId)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM rights`)
+	_, err = store.GetPool().Exec(ctx, `INSERT INTO users (name, lastname, password, email) VALUES
+	('Name', 'Lastname', '123', 'norights@mail.ru')`)
+	require.NoError(t, err)
+	defer store.GetPool().Exec(ctx, `DELETE FROM users`)
+	defer store.GetPool().Exec(ctx, `DELETE FROM audit_log`)
+
+	// The user registered without the role gets the role
+	users := repository.NewUser(store, logger)
+	require.NoError(t, users.UpdateUserRole(ctx, rightsId, "norights@mail.ru"))
+	var stored uuid.UUID
+	err = store.GetPool().QueryRow(ctx, `SELECT rights FROM users WHERE email = 'norights@mail.ru'`).Scan(&stored)
+	require.NoError(t, err)
+	require.Equal(t, rightsId, stored)
+}
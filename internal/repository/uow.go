@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+type txKey struct{}
+
+type unitOfWork struct {
+	storage *PGres
+	logger  *zap.SugaredLogger
+}
+
+var _ UnitOfWork = (*unitOfWork)(nil)
+
+func NewUnitOfWork(storage *PGres, logger *zap.SugaredLogger) UnitOfWork {
+	return &unitOfWork{
+		storage: storage,
+		logger:  logger,
+	}
+}
+
+// Do begins the transaction and calls fn with the context which carries it. Repositories
+// called with this context work in the transaction, which is committed if fn returns nil
+// and rolled back otherwise. Nested calls of Do work in the transaction of the outer call
+func (u *unitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	u.logger.Debug("Enter in repository unit of work Do() with args: ctx, fn")
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+	tx, err := u.storage.GetPool().BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		u.logger.Errorf("can't create transaction: %s", err)
+		return fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(ctx)
+			panic(p)
+		}
+		if err != nil {
+			u.logger.Errorf("transaction rolled back")
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				u.logger.Errorf("can't rollback %s", rbErr)
+			}
+			return
+		}
+		if err = tx.Commit(ctx); err != nil {
+			u.logger.Errorf("can't commit %s", err)
+			err = fmt.Errorf("can't commit transaction: %w", err)
+			return
+		}
+		u.logger.Info("transaction commited")
+	}()
+	return fn(context.WithValue(ctx, txKey{}, tx))
+}
@@ -4,6 +4,7 @@ import (
 	"OnlineShopBackend/internal/models"
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4"
@@ -138,6 +139,33 @@ func (u *user) UpdateUserRole(ctx context.Context, roleId uuid.UUID, email strin
 			}
 		}()
 
+		// The role before the change is locked until the end
+		// of transaction and written to the audit log, the user
+		// may have no role yet, then the role before is nil
+		var userId uuid.UUID
+		var beforeRoleId *uuid.UUID
+		var beforeRole, afterRole string
+		err = tx.QueryRow(ctx, `SELECT users.id, users.rights, COALESCE(rights.name, '') FROM users
+		LEFT JOIN rights ON rights.id = users.rights WHERE users.email=$1 FOR UPDATE OF users`, email).Scan(
+			&userId,
+			&beforeRoleId,
+			&beforeRole,
+		)
+		if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+			u.logger.Errorf("error on update user %s: %s", email, err)
+			return models.ErrorNotFound{}
+		} else if err != nil {
+			u.logger.Errorf("error on update user %s: %s", email, err)
+			return fmt.Errorf("error on update user %s: %w", email, err)
+		}
+		err = tx.QueryRow(ctx, `SELECT name FROM rights WHERE id=$1`, roleId).Scan(&afterRole)
+		if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+			u.logger.Errorf("error on update user %s: %s", email, err)
+			return models.ErrorNotFound{}
+		} else if err != nil {
+			u.logger.Errorf("error on update user %s: %s", email, err)
+			return fmt.Errorf("error on update user %s: %w", email, err)
+		}
 		_, err = tx.Exec(ctx, `UPDATE users SET rights=$1 WHERE email=$2`,
 			roleId,
 			email)
@@ -145,6 +173,13 @@ func (u *user) UpdateUserRole(ctx context.Context, roleId uuid.UUID, email strin
 			u.logger.Errorf("error on update user %s: %s", email, err)
 			return fmt.Errorf("error on update user %s: %w", email, err)
 		}
+		err = addAuditEntry(ctx, tx, models.NewAuditEntry(ctx, models.AuditUserRoleUpdate, models.AuditEntityUser, userId.String(),
+			map[string]interface{}{"rights_id": beforeRoleId, "role": beforeRole},
+			map[string]interface{}{"rights_id": roleId, "role": afterRole}))
+		if err != nil {
+			u.logger.Errorf("error on update user %s: %s", email, err)
+			return fmt.Errorf("error on update user %s: %w", email, err)
+		}
 		u.logger.Infof("user role was successfully updated %s", email)
 		return nil
 	}
@@ -0,0 +1,237 @@
+// Package shipping calculates the cost and the period of delivery of orders
+// by the shipping methods of the shop: courier, pickup and post
+package shipping
+
+import (
+	"OnlineShopBackend/internal/models"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Codes of the default shipping methods
+const (
+	Courier = "courier"
+	Pickup  = "pickup"
+	Post    = "post"
+)
+
+const day = 24 * time.Hour
+
+// Zone is the area of delivery. The address is in the zone if it matches all non-empty
+// lists of the zone: its country and city are in the lists and its zipcode starts with
+// one of the prefixes. The zone with empty lists contains any address
+type Zone struct {
+	Code      string   `json:"code"`
+	Countries []string `json:"countries,omitempty"`
+	Cities    []string `json:"cities,omitempty"`
+	Zipcodes  []string `json:"zipcodes,omitempty"`
+}
+
+// Contains reports whether the address is in the zone
+func (zone Zone) Contains(address models.UserAddress) bool {
+	if len(zone.Countries) > 0 && !containsFold(zone.Countries, address.Country) {
+		return false
+	}
+	if len(zone.Cities) > 0 && !containsFold(zone.Cities, address.City) {
+		return false
+	}
+	if len(zone.Zipcodes) > 0 {
+		for _, prefix := range zone.Zipcodes {
+			if strings.HasPrefix(strings.TrimSpace(address.Zipcode), prefix) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func containsFold(list []string, value string) bool {
+	for _, element := range list {
+		if strings.EqualFold(strings.TrimSpace(element), strings.TrimSpace(value)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Rate is the cost of delivery to the zone of the orders up to the weight in grams,
+// zero weight means any weight. The delivery is free if the value of the order
+// is at least FreeFrom, zero FreeFrom means that the delivery is never free
+type Rate struct {
+	Zone      string `json:"zone"`
+	MaxWeight int    `json:"max_weight,omitempty"`
+	Cost      int64  `json:"cost"`
+	FreeFrom  int64  `json:"free_from,omitempty"`
+}
+
+// Method is the shipping method. The first rate matching the zone of the address
+// and the weight of the order is used, the method is unavailable if none matches
+type Method struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+	// Days is the period of delivery of the order
+	Days  int    `json:"days"`
+	Rates []Rate `json:"rates"`
+}
+
+// Period returns the period of delivery of the order by the method
+func (method Method) Period() time.Duration {
+	return time.Duration(method.Days) * day
+}
+
+// Quote is the cost and the period of delivery of the order by the method
+type Quote struct {
+	Method string
+	Name   string
+	Cost   int64
+	Period time.Duration
+}
+
+// Rules is the zones and the shipping methods of the shop. Methods are offered in the order of the list
+type Rules struct {
+	Zones   []Zone   `json:"zones"`
+	Methods []Method `json:"methods"`
+}
+
+// Default returns the rules used if the shop has no rules file. The courier delivers in the home zone
+// at the cost and free threshold of the cart, the pickup from the shop is free in the home zone
+// and the post delivers anywhere in the prolonged period
+func Default(cost int64, freeFrom int64) Rules {
+	return Rules{
+		Zones: []Zone{
+			{Code: "home", Countries: []string{"Россия", "Russia", "RU"}},
+			{Code: "world"},
+		},
+		Methods: []Method{
+			{
+				Code:  Courier,
+				Name:  "Courier",
+				Days:  int(models.StandardShipmentPeriod / day),
+				Rates: []Rate{{Zone: "home", MaxWeight: 20000, Cost: cost, FreeFrom: freeFrom}},
+			},
+			{
+				Code:  Pickup,
+				Name:  "Pickup from the shop",
+				Days:  1,
+				Rates: []Rate{{Zone: "home"}},
+			},
+			{
+				Code: Post,
+				Name: "Post",
+				Days: int(models.ProlongedShipmentPeriod / day),
+				Rates: []Rate{
+					{Zone: "home", MaxWeight: 30000, Cost: cost, FreeFrom: freeFrom},
+					{Zone: "world", MaxWeight: 30000, Cost: 4 * cost},
+				},
+			},
+		},
+	}
+}
+
+// Load reads the rules from the JSON file, the rules are checked before they are returned
+func Load(path string) (Rules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Rules{}, fmt.Errorf("can't read shipping rules: %w", err)
+	}
+	var rules Rules
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return Rules{}, fmt.Errorf("can't parse shipping rules: %w", err)
+	}
+	if err := rules.Validate(); err != nil {
+		return Rules{}, err
+	}
+	return rules, nil
+}
+
+// Validate checks that codes are unique and rates refer to existing zones
+func (rules Rules) Validate() error {
+	if len(rules.Methods) == 0 {
+		return fmt.Errorf("no shipping methods")
+	}
+	zones := make(map[string]struct{}, len(rules.Zones))
+	for _, zone := range rules.Zones {
+		if zone.Code == "" {
+			return fmt.Errorf("shipping zone without code")
+		}
+		if _, ok := zones[zone.Code]; ok {
+			return fmt.Errorf("shipping zone %q is duplicated", zone.Code)
+		}
+		zones[zone.Code] = struct{}{}
+	}
+	methods := make(map[string]struct{}, len(rules.Methods))
+	for _, method := range rules.Methods {
+		if method.Code == "" {
+			return fmt.Errorf("shipping method without code")
+		}
+		if _, ok := methods[method.Code]; ok {
+			return fmt.Errorf("shipping method %q is duplicated", method.Code)
+		}
+		methods[method.Code] = struct{}{}
+		if method.Days < 0 {
+			return fmt.Errorf("negative period of shipping method %q", method.Code)
+		}
+		for _, rate := range method.Rates {
+			if _, ok := zones[rate.Zone]; !ok {
+				return fmt.Errorf("shipping method %q has rate for unknown zone %q", method.Code, rate.Zone)
+			}
+			if rate.Cost < 0 || rate.MaxWeight < 0 || rate.FreeFrom < 0 {
+				return fmt.Errorf("shipping method %q has negative rate", method.Code)
+			}
+		}
+	}
+	return nil
+}
+
+// Quote returns the cost and the period of delivery of the order of the weight in grams
+// and the value to the address by the method. Unknown method or method which doesn't deliver
+// the order to the address returns ErrorShippingUnavailable
+func (rules Rules) Quote(code string, address models.UserAddress, weight int, value int64) (Quote, error) {
+	for _, method := range rules.Methods {
+		if method.Code != code {
+			continue
+		}
+		rate, ok := rules.rate(method, address, weight)
+		if !ok {
+			return Quote{}, fmt.Errorf("shipping method %q doesn't deliver the order: %w", code, models.ErrorShippingUnavailable{})
+		}
+		cost := rate.Cost
+		if rate.FreeFrom > 0 && value >= rate.FreeFrom {
+			cost = 0
+		}
+		return Quote{Method: method.Code, Name: method.Name, Cost: cost, Period: method.Period()}, nil
+	}
+	return Quote{}, fmt.Errorf("unknown shipping method %q: %w", code, models.ErrorShippingUnavailable{})
+}
+
+// Quotes returns quotes of all methods delivering the order to the address
+func (rules Rules) Quotes(address models.UserAddress, weight int, value int64) []Quote {
+	quotes := make([]Quote, 0, len(rules.Methods))
+	for _, method := range rules.Methods {
+		quote, err := rules.Quote(method.Code, address, weight, value)
+		if err != nil {
+			continue
+		}
+		quotes = append(quotes, quote)
+	}
+	return quotes
+}
+
+// rate returns the first rate of the method matching the address and the weight
+func (rules Rules) rate(method Method, address models.UserAddress, weight int) (Rate, bool) {
+	for _, rate := range method.Rates {
+		if rate.MaxWeight > 0 && weight > rate.MaxWeight {
+			continue
+		}
+		for _, zone := range rules.Zones {
+			if zone.Code == rate.Zone && zone.Contains(address) {
+				return rate, true
+			}
+		}
+	}
+	return Rate{}, false
+}
@@ -0,0 +1,96 @@
+package shipping
+
+import (
+	"OnlineShopBackend/internal/models"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestZoneContains(t *testing.T) {
+	address := models.UserAddress{Zipcode: "190000", Country: "Russia", City: "Saint Petersburg"}
+	require.True(t, Zone{Code: "any"}.Contains(address))
+	require.True(t, Zone{Code: "ru", Countries: []string{"RU", "russia "}}.Contains(address))
+	require.False(t, Zone{Code: "kz", Countries: []string{"Kazakhstan"}}.Contains(address))
+	require.True(t, Zone{Code: "spb", Countries: []string{"Russia"}, Zipcodes: []string{"19"}}.Contains(address))
+	require.False(t, Zone{Code: "msk", Countries: []string{"Russia"}, Zipcodes: []string{"10", "11"}}.Contains(address))
+	require.False(t, Zone{Code: "msk", Cities: []string{"Moscow"}}.Contains(address))
+}
+
+func TestQuote(t *testing.T) {
+	rules := Rules{
+		Zones: []Zone{
+			{Code: "city", Cities: []string{"Moscow"}},
+			{Code: "country", Countries: []string{"Russia"}},
+		},
+		Methods: []Method{
+			{Code: Courier, Name: "Courier", Days: 2, Rates: []Rate{
+				{Zone: "city", MaxWeight: 5000, Cost: 200, FreeFrom: 3000},
+				{Zone: "city", Cost: 500},
+				{Zone: "country", MaxWeight: 5000, Cost: 400},
+			}},
+		},
+	}
+	require.NoError(t, rules.Validate())
+	moscow := models.UserAddress{Country: "Russia", City: "Moscow"}
+	kazan := models.UserAddress{Country: "Russia", City: "Kazan"}
+
+	quote, err := rules.Quote(Courier, moscow, 1000, 1000)
+	require.NoError(t, err)
+	require.Equal(t, Quote{Method: Courier, Name: "Courier", Cost: 200, Period: 48 * time.Hour}, quote)
+	quote, err = rules.Quote(Courier, moscow, 1000, 3000)
+	require.NoError(t, err)
+	require.Zero(t, quote.Cost)
+	// The heavy order takes the next rate of the zone which is never free
+	quote, err = rules.Quote(Courier, moscow, 8000, 3000)
+	require.NoError(t, err)
+	require.Equal(t, int64(500), quote.Cost)
+	quote, err = rules.Quote(Courier, kazan, 1000, 3000)
+	require.NoError(t, err)
+	require.Equal(t, int64(400), quote.Cost)
+
+	_, err = rules.Quote(Courier, kazan, 8000, 1000)
+	require.ErrorIs(t, err, models.ErrorShippingUnavailable{})
+	_, err = rules.Quote(Post, moscow, 1000, 1000)
+	require.ErrorIs(t, err, models.ErrorShippingUnavailable{})
+	require.Empty(t, rules.Quotes(kazan, 8000, 1000))
+}
+
+func TestDefault(t *testing.T) {
+	rules := Default(300, 5000)
+	require.NoError(t, rules.Validate())
+	quotes := rules.Quotes(models.UserAddress{Country: "Россия", City: "Москва"}, 1000, 1000)
+	require.Len(t, quotes, 3)
+	require.Equal(t, models.StandardShipmentPeriod, quotes[0].Period)
+	require.Equal(t, models.ProlongedShipmentPeriod, quotes[2].Period)
+	quotes = rules.Quotes(models.UserAddress{Country: "Israel", City: "Haifa"}, 1000, 10000)
+	require.Len(t, quotes, 1)
+	require.Equal(t, Post, quotes[0].Method)
+	require.Equal(t, int64(1200), quotes[0].Cost)
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "shipping.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"zones": [{"code": "home", "countries": ["Russia"]}],
+		"methods": [{"code": "pickup", "name": "Pickup", "days": 1, "rates": [{"zone": "home"}]}]
+	}`), 0600))
+	rules, err := Load(path)
+	require.NoError(t, err)
+	require.Len(t, rules.Methods, 1)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"zones": [], "methods": [{"code": "pickup", "rates": [{"zone": "home"}]}]}`), 0600))
+	_, err = Load(path)
+	require.Error(t, err)
+	require.NoError(t, os.WriteFile(path, []byte(`{"methods": []}`), 0600))
+	_, err = Load(path)
+	require.Error(t, err)
+	_, err = Load(filepath.Join(dir, "missing.json"))
+	require.Error(t, err)
+	require.Error(t, Rules{Zones: []Zone{{Code: "a"}, {Code: "a"}}, Methods: []Method{{Code: "m"}}}.Validate())
+	require.Error(t, Rules{Methods: []Method{{Code: "m"}, {Code: "m"}}}.Validate())
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ IAddressUsecase = &AddressUsecase{}
+
+type AddressUsecase struct {
+	addressStore repository.AddressStore
+	logger       *zap.Logger
+}
+
+func NewAddressUsecase(addressStore repository.AddressStore, logger *zap.Logger) IAddressUsecase {
+	logger.Debug("Enter in usecase NewAddressUsecase()")
+	return &AddressUsecase{addressStore: addressStore, logger: logger}
+}
+
+// CreateAddress saves the address to the address book of its user,
+// only the user or an admin may add addresses to the address book
+func (usecase *AddressUsecase) CreateAddress(ctx context.Context, address *models.SavedAddress) (*models.SavedAddress, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase CreateAddress() with args: ctx, address: %v", address)
+	if err := checkOwner(ctx, address.UserId); err != nil {
+		return nil, fmt.Errorf("address book of another user: %w", err)
+	}
+	if _, err := usecase.addressStore.CreateAddress(ctx, address); err != nil {
+		return nil, fmt.Errorf("error on create address: %w", err)
+	}
+	return address, nil
+}
+
+// GetAddress returns the saved address, addresses of other users are not found
+func (usecase *AddressUsecase) GetAddress(ctx context.Context, id uuid.UUID) (*models.SavedAddress, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase GetAddress() with args: ctx, id: %v", id)
+	address, err := usecase.addressStore.GetAddress(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error on get address: %w", err)
+	}
+	if err := checkOwner(ctx, address.UserId); err != nil {
+		return nil, fmt.Errorf("address %v of another user: %w", id, err)
+	}
+	return address, nil
+}
+
+// GetAddresses returns the address book of the user, the default addresses go first
+func (usecase *AddressUsecase) GetAddresses(ctx context.Context, userId uuid.UUID) ([]models.SavedAddress, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase GetAddresses() with args: ctx, userId: %v", userId)
+	if err := checkOwner(ctx, userId); err != nil {
+		return nil, fmt.Errorf("address book of another user: %w", err)
+	}
+	addresses, err := usecase.addressStore.GetAddresses(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("error on get addresses: %w", err)
+	}
+	return addresses, nil
+}
+
+// UpdateAddress changes the saved address, the address keeps its user
+func (usecase *AddressUsecase) UpdateAddress(ctx context.Context, address *models.SavedAddress) (*models.SavedAddress, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase UpdateAddress() with args: ctx, address: %v", address)
+	stored, err := usecase.GetAddress(ctx, address.Id)
+	if err != nil {
+		return nil, err
+	}
+	address.UserId = stored.UserId
+	address.CreatedAt = stored.CreatedAt
+	if err := usecase.addressStore.UpdateAddress(ctx, address); err != nil {
+		return nil, fmt.Errorf("error on update address: %w", err)
+	}
+	return address, nil
+}
+
+// DeleteAddress removes the address from the address book of its user
+func (usecase *AddressUsecase) DeleteAddress(ctx context.Context, id uuid.UUID) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase DeleteAddress() with args: ctx, id: %v", id)
+	if _, err := usecase.GetAddress(ctx, id); err != nil {
+		return err
+	}
+	if err := usecase.addressStore.DeleteAddress(ctx, id); err != nil {
+		return fmt.Errorf("error on delete address: %w", err)
+	}
+	return nil
+}
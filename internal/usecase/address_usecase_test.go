@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/mocks"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestAddressBook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	addressRepo := mocks.NewMockAddressStore(ctrl)
+	usecase := NewAddressUsecase(addressRepo, zap.L())
+	ctx := models.ContextWithActor(context.Background(), testActor)
+	addressId := uuid.New()
+	home := models.SavedAddress{
+		Id:      addressId,
+		UserId:  testActor.UserId,
+		Name:    "home",
+		Address: models.UserAddress{Zipcode: "123456", City: "Moscow", Street: "Lenina, 1"},
+	}
+
+	_, err := usecase.CreateAddress(ctx, &models.SavedAddress{UserId: uuid.New(), Name: "home"})
+	require.True(t, errors.Is(err, models.ErrorNotFound{}))
+
+	newAddress := home
+	addressRepo.EXPECT().CreateAddress(ctx, &newAddress).DoAndReturn(func(ctx context.Context, address *models.SavedAddress) (uuid.UUID, error) {
+		address.DefaultShipping = true
+		return address.Id, nil
+	})
+	res, err := usecase.CreateAddress(ctx, &newAddress)
+	require.NoError(t, err)
+	require.True(t, res.DefaultShipping)
+
+	addressRepo.EXPECT().GetAddress(ctx, addressId).Return(&models.SavedAddress{Id: addressId, UserId: uuid.New()}, nil)
+	_, err = usecase.GetAddress(ctx, addressId)
+	require.True(t, errors.Is(err, models.ErrorNotFound{}))
+
+	_, err = usecase.GetAddresses(ctx, uuid.New())
+	require.True(t, errors.Is(err, models.ErrorNotFound{}))
+
+	update := models.SavedAddress{Id: addressId, UserId: uuid.New(), Name: "office", DefaultShipping: true}
+	addressRepo.EXPECT().GetAddress(ctx, addressId).Return(&home, nil)
+	addressRepo.EXPECT().UpdateAddress(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, address *models.SavedAddress) error {
+		require.Equal(t, testActor.UserId, address.UserId)
+		return models.ErrorAlreadyExists{}
+	})
+	_, err = usecase.UpdateAddress(ctx, &update)
+	require.True(t, errors.Is(err, models.ErrorAlreadyExists{}))
+
+	addressRepo.EXPECT().GetAddress(ctx, addressId).Return(&home, nil)
+	addressRepo.EXPECT().DeleteAddress(ctx, addressId).Return(fmt.Errorf("error"))
+	require.Error(t, usecase.DeleteAddress(ctx, addressId))
+
+	addressRepo.EXPECT().GetAddress(ctx, addressId).Return(&home, nil)
+	addressRepo.EXPECT().DeleteAddress(ctx, addressId).Return(nil)
+	require.NoError(t, usecase.DeleteAddress(ctx, addressId))
+}
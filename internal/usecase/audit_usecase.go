@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository"
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+var _ IAuditUsecase = &AuditUsecase{}
+
+// Limits of quantity of audit log entries returned by one request
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+type AuditUsecase struct {
+	auditStore repository.AuditStore
+	logger     *zap.Logger
+}
+
+func NewAuditUsecase(auditStore repository.AuditStore, logger *zap.Logger) IAuditUsecase {
+	logger.Debug("Enter in usecase NewAuditUsecase()")
+	return &AuditUsecase{auditStore: auditStore, logger: logger}
+}
+
+// GetAuditLog returns entries of the audit log satisfying the filter, the newest entries go first
+func (usecase *AuditUsecase) GetAuditLog(ctx context.Context, filter models.AuditFilter) ([]models.AuditEntry, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase GetAuditLog() with args: ctx, filter: %v", filter)
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.From.After(filter.To) {
+		return nil, fmt.Errorf("error on get audit log: start of period is after its end")
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditLimit
+	}
+	if filter.Limit > maxAuditLimit {
+		filter.Limit = maxAuditLimit
+	}
+	entriesChan, err := usecase.auditStore.GetAuditLog(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error on get audit log: %w", err)
+	}
+	entries := make([]models.AuditEntry, 0, filter.Limit)
+	for entry := range entriesChan {
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
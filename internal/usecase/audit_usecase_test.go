@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/mocks"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestGetAuditLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	auditRepo := mocks.NewMockAuditStore(ctrl)
+	usecase := NewAuditUsecase(auditRepo, logger)
+
+	now := time.Now()
+	_, err := usecase.GetAuditLog(ctx, models.AuditFilter{From: now, To: now.Add(-time.Hour)})
+	require.Error(t, err)
+
+	auditRepo.EXPECT().GetAuditLog(ctx, models.AuditFilter{Limit: defaultAuditLimit}).Return(nil, fmt.Errorf("error"))
+	_, err = usecase.GetAuditLog(ctx, models.AuditFilter{})
+	require.Error(t, err)
+
+	entriesChan := make(chan models.AuditEntry, 1)
+	entriesChan <- models.AuditEntry{Id: testId, Action: models.AuditItemUpdate}
+	close(entriesChan)
+	auditRepo.EXPECT().GetAuditLog(ctx, models.AuditFilter{Entity: models.AuditEntityItem, Limit: maxAuditLimit}).Return(entriesChan, nil)
+	entries, err := usecase.GetAuditLog(ctx, models.AuditFilter{Entity: models.AuditEntityItem, Limit: 5000})
+	require.NoError(t, err)
+	require.Equal(t, []models.AuditEntry{{Id: testId, Action: models.AuditItemUpdate}}, entries)
+}
+
+func TestNewAuditEntry(t *testing.T) {
+	actor := models.Actor{UserId: testId, Email: "admin@mail.ru", Role: models.Admin}
+	ctx := models.ContextWithActor(context.Background(), actor)
+	entry := models.NewAuditEntry(ctx, models.AuditItemUpdate, models.AuditEntityItem, testId.String(),
+		map[string]interface{}{"title": "old", "price": int32(10), "images": []string{}},
+		map[string]interface{}{"title": "new", "price": int32(10), "images": []string{}},
+	)
+	require.False(t, entry.Empty())
+	require.Equal(t, actor, entry.Actor)
+	require.Equal(t, map[string]interface{}{"title": "old"}, entry.Before)
+	require.Equal(t, map[string]interface{}{"title": "new"}, entry.After)
+
+	entry = models.NewAuditEntry(context.Background(), models.AuditItemUpdate, models.AuditEntityItem, testId.String(),
+		map[string]interface{}{"price": int32(10)},
+		map[string]interface{}{"price": int32(10)},
+	)
+	require.True(t, entry.Empty())
+}
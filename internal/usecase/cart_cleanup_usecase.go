@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/metrics"
+	"OnlineShopBackend/internal/repository"
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var _ ICartCleanupUsecase = &CartCleanupUsecase{}
+
+type CartCleanupUsecase struct {
+	store repository.CartStore
+	// period is the interval between runs of the cleanup
+	period time.Duration
+	// batchSize is the maximum number of carts deleted in one transaction
+	batchSize int
+	logger    *zap.Logger
+}
+
+func NewCartCleanupUsecase(store repository.CartStore, period time.Duration, batchSize int, logger *zap.Logger) ICartCleanupUsecase {
+	logger.Debug("Enter in usecase NewCartCleanupUsecase()")
+	return &CartCleanupUsecase{
+		store:     store,
+		period:    period,
+		batchSize: batchSize,
+		logger:    logger,
+	}
+}
+
+// PurgeExpiredCarts deletes expired carts batch by batch until there are no more
+// expired carts and returns the number of deleted carts
+func (usecase *CartCleanupUsecase) PurgeExpiredCarts(ctx context.Context) (int, error) {
+	usecase.logger.Debug("Enter in usecase PurgeExpiredCarts() with args: ctx")
+	total := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return total, ctx.Err()
+		default:
+		}
+		deleted, err := usecase.store.DeleteExpiredCarts(ctx, usecase.batchSize)
+		if err != nil {
+			return total, fmt.Errorf("error on delete expired carts: %w", err)
+		}
+		total += deleted
+		metrics.CartsMetrics.CartsPurgedTotal.Add(float64(deleted))
+		if deleted < usecase.batchSize {
+			break
+		}
+	}
+	active, expired, err := usecase.store.CountCarts(ctx)
+	if err != nil {
+		return total, fmt.Errorf("error on count carts: %w", err)
+	}
+	metrics.CartsMetrics.CartsActive.Set(float64(active))
+	metrics.CartsMetrics.CartsExpired.Set(float64(expired))
+	if total > 0 {
+		usecase.logger.Sugar().Infof("%d expired carts deleted", total)
+	}
+	return total, nil
+}
+
+// Run deletes expired carts periodically until ctx is done
+func (usecase *CartCleanupUsecase) Run(ctx context.Context) {
+	usecase.logger.Debug("Enter in usecase cart cleanup Run() with args: ctx")
+	ticker := time.NewTicker(usecase.period)
+	defer ticker.Stop()
+	for {
+		if _, err := usecase.PurgeExpiredCarts(ctx); err != nil {
+			metrics.CartsMetrics.CleanupErrorsTotal.Inc()
+			usecase.logger.Error(err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/repository/mocks"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPurgeExpiredCarts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	cartRepo := mocks.NewMockCartStore(ctrl)
+	usecase := NewCartCleanupUsecase(cartRepo, time.Minute, 2, logger)
+	ctx := context.Background()
+
+	cartRepo.EXPECT().DeleteExpiredCarts(ctx, 2).Return(0, fmt.Errorf("error"))
+	res, err := usecase.PurgeExpiredCarts(ctx)
+	require.Error(t, err)
+	require.Equal(t, 0, res)
+
+	// Batches are deleted until a batch is not full
+	gomock.InOrder(
+		cartRepo.EXPECT().DeleteExpiredCarts(ctx, 2).Return(2, nil),
+		cartRepo.EXPECT().DeleteExpiredCarts(ctx, 2).Return(2, nil),
+		cartRepo.EXPECT().DeleteExpiredCarts(ctx, 2).Return(1, nil),
+		cartRepo.EXPECT().CountCarts(ctx).Return(10, 0, nil),
+	)
+	res, err = usecase.PurgeExpiredCarts(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 5, res)
+
+	cartRepo.EXPECT().DeleteExpiredCarts(ctx, 2).Return(0, nil)
+	cartRepo.EXPECT().CountCarts(ctx).Return(0, 0, fmt.Errorf("error"))
+	res, err = usecase.PurgeExpiredCarts(ctx)
+	require.Error(t, err)
+	require.Equal(t, 0, res)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	res, err = usecase.PurgeExpiredCarts(canceled)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 0, res)
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/feed"
+	"OnlineShopBackend/internal/mail"
+	"OnlineShopBackend/internal/metrics"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var _ ICartReminderUsecase = &CartReminderUsecase{}
+
+type CartReminderUsecase struct {
+	store  repository.ReminderStore
+	sender mail.Sender
+	shop   feed.Shop
+	// idle is the time after the last change of the cart when the cart is abandoned
+	idle time.Duration
+	// period is the interval between runs of the reminder
+	period time.Duration
+	// batchSize is the maximum number of reminders sent in one run
+	batchSize int
+	// maxAttempts is the number of attempts to send the reminder before it fails,
+	// retryDelay is the delay after the first failed attempt which doubles after every next one
+	maxAttempts int
+	retryDelay  time.Duration
+	logger      *zap.Logger
+}
+
+func NewCartReminderUsecase(store repository.ReminderStore, sender mail.Sender, shop feed.Shop, idle time.Duration, period time.Duration,
+	batchSize int, maxAttempts int, retryDelay time.Duration, logger *zap.Logger) ICartReminderUsecase {
+	logger.Debug("Enter in usecase NewCartReminderUsecase()")
+	return &CartReminderUsecase{
+		store:       store,
+		sender:      sender,
+		shop:        shop,
+		idle:        idle,
+		period:      period,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+		retryDelay:  retryDelay,
+		logger:      logger,
+	}
+}
+
+// SendReminders sends reminders to users about abandoned carts and returns the number
+// of sent reminders. The reminder is recorded only after it is sent, the failed attempt is recorded
+// and the reminder is retried later until the last attempt
+func (usecase *CartReminderUsecase) SendReminders(ctx context.Context) (int, error) {
+	usecase.logger.Debug("Enter in usecase SendReminders() with args: ctx")
+	carts, err := usecase.store.GetAbandonedCarts(ctx, usecase.idle, usecase.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("error on get abandoned carts: %w", err)
+	}
+	sent := 0
+	for _, cart := range carts {
+		select {
+		case <-ctx.Done():
+			return sent, ctx.Err()
+		default:
+		}
+		message, err := mail.NewMessage(cart.Email, mail.CartReminderTemplate, usecase.reminderData(cart))
+		if err != nil {
+			return sent, fmt.Errorf("error on create reminder message: %w", err)
+		}
+		if err := usecase.sender.Send(ctx, message); err != nil {
+			if err := usecase.store.SaveReminderAttempt(ctx, usecase.failedAttempt(cart, err)); err != nil {
+				return sent, fmt.Errorf("error on save reminder attempt: %w", err)
+			}
+			continue
+		}
+		err = usecase.store.CreateReminder(ctx, &models.CartReminder{
+			CartId: cart.CartId,
+			UserId: cart.UserId,
+			Email:  cart.Email,
+		})
+		if err != nil {
+			return sent, fmt.Errorf("error on create reminder: %w", err)
+		}
+		metrics.CartRemindersMetrics.RemindersSentTotal.Inc()
+		sent++
+	}
+	if sent > 0 {
+		usecase.logger.Sugar().Infof("%d reminders about abandoned carts sent", sent)
+	}
+	return sent, nil
+}
+
+// failedAttempt returns the record about the failed attempt to send the reminder about the cart
+// with the time of the next attempt or failed after the last attempt
+func (usecase *CartReminderUsecase) failedAttempt(cart models.AbandonedCart, err error) *models.CartReminderAttempt {
+	attempt := &models.CartReminderAttempt{
+		CartId:        cart.CartId,
+		CartUpdatedAt: cart.UpdatedAt,
+		Attempts:      cart.Attempts + 1,
+		LastError:     err.Error(),
+	}
+	if attempt.Attempts >= usecase.maxAttempts {
+		metrics.CartRemindersMetrics.RemindersFailedTotal.Inc()
+		usecase.logger.Sugar().Errorf("can't send reminder about cart %v after %d attempts: %s", cart.CartId, attempt.Attempts, err)
+		attempt.Failed = true
+		attempt.NextAttemptAt = time.Now()
+		return attempt
+	}
+	metrics.CartRemindersMetrics.RemindersRetriedTotal.Inc()
+	usecase.logger.Sugar().Warnf("can't send reminder about cart %v, attempt %d: %s", cart.CartId, attempt.Attempts, err)
+	attempt.NextAttemptAt = time.Now().Add(usecase.retryDelay << (attempt.Attempts - 1))
+	return attempt
+}
+
+func (usecase *CartReminderUsecase) reminderData(cart models.AbandonedCart) mail.CartReminderData {
+	data := mail.CartReminderData{
+		Name:     cart.Name,
+		ShopName: usecase.shop.Name,
+		Currency: usecase.shop.Currency,
+		CartURL:  strings.TrimSuffix(usecase.shop.SiteURL, "/") + "/cart",
+		Items:    make([]mail.CartReminderItem, 0, len(cart.Items)),
+	}
+	for _, item := range cart.Items {
+		data.Items = append(data.Items, mail.CartReminderItem{
+			Title:    item.Title,
+			Quantity: item.Quantity,
+			Price:    item.Price,
+		})
+	}
+	return data
+}
+
+// Run sends reminders about abandoned carts periodically until ctx is done
+func (usecase *CartReminderUsecase) Run(ctx context.Context) {
+	usecase.logger.Debug("Enter in usecase cart reminder Run() with args: ctx")
+	ticker := time.NewTicker(usecase.period)
+	defer ticker.Stop()
+	for {
+		if _, err := usecase.SendReminders(ctx); err != nil {
+			usecase.logger.Error(err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/feed"
+	"OnlineShopBackend/internal/mail"
+	mailmocks "OnlineShopBackend/internal/mail/mocks"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/mocks"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSendReminders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	reminderRepo := mocks.NewMockReminderStore(ctrl)
+	sender := mailmocks.NewMockSender(ctrl)
+	shop := feed.Shop{Name: "Shop", SiteURL: "http://localhost:3000/", Currency: "RUB"}
+	usecase := NewCartReminderUsecase(reminderRepo, sender, shop, 24*time.Hour, time.Minute, 10, 3, time.Minute, logger)
+	ctx := context.Background()
+
+	reminderRepo.EXPECT().GetAbandonedCarts(ctx, 24*time.Hour, 10).Return(nil, fmt.Errorf("error"))
+	res, err := usecase.SendReminders(ctx)
+	require.Error(t, err)
+	require.Equal(t, 0, res)
+
+	item := models.ItemWithQuantity{Item: models.Item{Title: "smartphone", Price: 10000}, Quantity: 2}
+	carts := []models.AbandonedCart{
+		{CartId: uuid.New(), UserId: uuid.New(), Email: "first@mail.ru", Name: "First", Items: []models.ItemWithQuantity{item}},
+		{CartId: uuid.New(), UserId: uuid.New(), Email: "second@mail.ru", Name: "Second", Items: []models.ItemWithQuantity{item}},
+	}
+	// The failed reminder is not recorded, the failed attempt is recorded to retry it later
+	reminderRepo.EXPECT().GetAbandonedCarts(ctx, 24*time.Hour, 10).Return(carts, nil)
+	sender.EXPECT().Send(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, message mail.Message) error {
+		require.Equal(t, "first@mail.ru", message.To)
+		require.Contains(t, message.Text, "Hello, First!")
+		require.Contains(t, message.Text, "smartphone x 2: 10000 RUB")
+		require.Contains(t, message.Text, "http://localhost:3000/cart")
+		return fmt.Errorf("error")
+	})
+	reminderRepo.EXPECT().SaveReminderAttempt(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, attempt *models.CartReminderAttempt) error {
+		require.Equal(t, carts[0].CartId, attempt.CartId)
+		require.Equal(t, 1, attempt.Attempts)
+		require.Equal(t, "error", attempt.LastError)
+		require.False(t, attempt.Failed)
+		require.WithinDuration(t, time.Now().Add(time.Minute), attempt.NextAttemptAt, time.Second)
+		return nil
+	})
+	sender.EXPECT().Send(ctx, gomock.Any()).Return(nil)
+	reminderRepo.EXPECT().CreateReminder(ctx, &models.CartReminder{
+		CartId: carts[1].CartId,
+		UserId: carts[1].UserId,
+		Email:  "second@mail.ru",
+	}).Return(nil)
+	res, err = usecase.SendReminders(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, res)
+
+	reminderRepo.EXPECT().GetAbandonedCarts(ctx, 24*time.Hour, 10).Return(carts[:1], nil)
+	sender.EXPECT().Send(ctx, gomock.Any()).Return(nil)
+	reminderRepo.EXPECT().CreateReminder(ctx, gomock.Any()).Return(fmt.Errorf("error"))
+	res, err = usecase.SendReminders(ctx)
+	require.Error(t, err)
+	require.Equal(t, 0, res)
+
+	// The delay doubles after every failed attempt and the reminder fails after the last attempt
+	retried := []models.AbandonedCart{carts[0]}
+	retried[0].Attempts = 1
+	reminderRepo.EXPECT().GetAbandonedCarts(ctx, 24*time.Hour, 10).Return(retried, nil)
+	sender.EXPECT().Send(ctx, gomock.Any()).Return(fmt.Errorf("error"))
+	reminderRepo.EXPECT().SaveReminderAttempt(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, attempt *models.CartReminderAttempt) error {
+		require.Equal(t, 2, attempt.Attempts)
+		require.False(t, attempt.Failed)
+		require.WithinDuration(t, time.Now().Add(2*time.Minute), attempt.NextAttemptAt, time.Second)
+		return nil
+	})
+	res, err = usecase.SendReminders(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, res)
+
+	retried[0].Attempts = 2
+	reminderRepo.EXPECT().GetAbandonedCarts(ctx, 24*time.Hour, 10).Return(retried, nil)
+	sender.EXPECT().Send(ctx, gomock.Any()).Return(fmt.Errorf("error"))
+	reminderRepo.EXPECT().SaveReminderAttempt(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, attempt *models.CartReminderAttempt) error {
+		require.Equal(t, 3, attempt.Attempts)
+		require.True(t, attempt.Failed)
+		return nil
+	})
+	res, err = usecase.SendReminders(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, res)
+
+	reminderRepo.EXPECT().GetAbandonedCarts(ctx, 24*time.Hour, 10).Return(retried, nil)
+	sender.EXPECT().Send(ctx, gomock.Any()).Return(fmt.Errorf("error"))
+	reminderRepo.EXPECT().SaveReminderAttempt(ctx, gomock.Any()).Return(fmt.Errorf("error"))
+	res, err = usecase.SendReminders(ctx)
+	require.Error(t, err)
+	require.Equal(t, 0, res)
+}
@@ -4,6 +4,10 @@ import (
 	"OnlineShopBackend/internal/models"
 	"OnlineShopBackend/internal/repository"
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -12,72 +16,278 @@ import (
 var _ ICartUsecase = &CartUseCase{}
 
 type CartUseCase struct {
-	store  repository.CartStore
-	logger *zap.Logger
+	store repository.CartStore
+	// maxQuantity is the maximum quantity of one item in the cart
+	maxQuantity int
+	// ttl is the time the cart lives after the last change
+	ttl time.Duration
+	// pricing is the rules of calculation of the totals of the cart
+	pricing models.CartPricing
+	logger  *zap.Logger
 }
 
-func NewCartUseCase(store repository.CartStore, logger *zap.Logger) ICartUsecase {
+func NewCartUseCase(store repository.CartStore, maxQuantity int, ttl time.Duration, pricing models.CartPricing, logger *zap.Logger) ICartUsecase {
 	logger.Debug("Enter in usecase NewCartUseCase()")
-	cart := &CartUseCase{store: store, logger: logger}
+	cart := &CartUseCase{store: store, maxQuantity: maxQuantity, ttl: ttl, pricing: pricing, logger: logger}
 	return cart
 }
 
-// GetCart creates request in db and returns cart or error
+// extendCart moves the expiration time of the changed cart. The change itself is already done,
+// so an error is only logged and the cart expires at the previous time
+func (c *CartUseCase) extendCart(ctx context.Context, cartId uuid.UUID) {
+	if err := c.store.ExtendCart(ctx, cartId, c.ttl); err != nil {
+		c.logger.Sugar().Warnf("can't extend cart %v: %v", cartId, err)
+	}
+}
+
+// checkCart returns ErrorNotFound if the cart belongs to another user. Guest carts have no owner,
+// they are accessed only with the signed cart token of the same cart
+func (c *CartUseCase) checkCart(ctx context.Context, cartId uuid.UUID) error {
+	ownerId, err := c.store.GetCartOwner(ctx, cartId)
+	if err != nil {
+		return err
+	}
+	if ownerId == uuid.Nil {
+		return checkGuestCart(ctx, cartId)
+	}
+	return checkOwner(ctx, ownerId)
+}
+
+// GetCart creates request in db and returns cart with totals or error
 func (c *CartUseCase) GetCart(ctx context.Context, cartId uuid.UUID) (*models.Cart, error) {
 	c.logger.Sugar().Debugf("Enter in usecase GetCart() with args: ctx, cartId: %v", cartId)
 	cart, err := c.store.GetCart(ctx, cartId)
 	if err != nil {
 		return nil, err
 	}
+	if cart.UserId == uuid.Nil {
+		err = checkGuestCart(ctx, cartId)
+	} else {
+		err = checkOwner(ctx, cart.UserId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	cart.Totals = cart.CalculateTotals(c.pricing)
 	return cart, nil
 }
 
-// GetCart creates request in db and returns cart or error
+// GetCart creates request in db and returns cart with totals or error
 func (c *CartUseCase) GetCartByUserId(ctx context.Context, userId uuid.UUID) (*models.Cart, error) {
 	c.logger.Sugar().Debugf("Enter in usecase GetCart() with args: ctx, userId: %v", userId)
+	if err := checkOwner(ctx, userId); err != nil {
+		return nil, err
+	}
 	cart, err := c.store.GetCartByUserId(ctx, userId)
 	if err != nil {
 		return nil, err
 	}
+	cart.Totals = cart.CalculateTotals(c.pricing)
 	return cart, nil
 }
 
 // DeleteItemFromCart delete item from cart
 func (c *CartUseCase) DeleteItemFromCart(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID) error {
 	c.logger.Sugar().Debugf("Enter in usecase DeleteItemFromCart() with args: ctx, cartId: %v, itemId: %v", cartId, itemId)
+	if err := c.checkCart(ctx, cartId); err != nil {
+		return err
+	}
 	err := c.store.DeleteItemFromCart(ctx, cartId, itemId)
 	if err != nil {
 		return err
 	}
+	c.extendCart(ctx, cartId)
 	return nil
 }
 
 // Create create new cart
 func (c *CartUseCase) Create(ctx context.Context, userId uuid.UUID) (uuid.UUID, error) {
 	c.logger.Sugar().Debugf("Enter in usecase cart Create() with args: ctx, userId: %v", userId)
+	// Guest carts are created without owner
+	if userId != uuid.Nil {
+		if err := checkOwner(ctx, userId); err != nil {
+			return uuid.Nil, err
+		}
+	}
 	cartId, err := c.store.Create(ctx, userId)
 	if err != nil {
 		return cartId, err
 	}
+	c.extendCart(ctx, cartId)
 	return cartId, nil
 }
 
-// AddItemToCart add item to cart
+// AddItemToCart adds one unit of the item to the cart, the quantity can't be greater than
+// maximum quantity of one item in cart and quantity of item in stock
 func (c *CartUseCase) AddItemToCart(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID) error {
 	c.logger.Sugar().Debugf("Enter in usecase AddItemToCart() with args: ctx, cartId: %v, itemId: %v", cartId, itemId)
-	err := c.store.AddItemToCart(ctx, cartId, itemId)
+	if err := c.checkCart(ctx, cartId); err != nil {
+		return err
+	}
+	err := c.store.AddItemToCart(ctx, cartId, itemId, c.maxQuantity)
 	if err != nil {
+		return fmt.Errorf("error on add item to cart: %w", err)
+	}
+	c.extendCart(ctx, cartId)
+	return nil
+}
+
+// SetItemQuantity sets the quantity of the item in the cart, zero quantity removes the item from the cart
+func (c *CartUseCase) SetItemQuantity(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID, quantity int) error {
+	c.logger.Sugar().Debugf("Enter in usecase SetItemQuantity() with args: ctx, cartId: %v, itemId: %v, quantity: %d", cartId, itemId, quantity)
+	if quantity < 0 {
+		return fmt.Errorf("negative quantity %d: %w", quantity, models.ErrorQuantityExceeded{})
+	}
+	if quantity > c.maxQuantity {
+		return fmt.Errorf("maximum quantity of item in cart is %d: %w", c.maxQuantity, models.ErrorQuantityExceeded{})
+	}
+	if err := c.checkCart(ctx, cartId); err != nil {
+		return fmt.Errorf("error on set item quantity: %w", err)
+	}
+	err := c.store.SetItemQuantity(ctx, cartId, itemId, quantity)
+	if err != nil {
+		return fmt.Errorf("error on set item quantity: %w", err)
+	}
+	c.extendCart(ctx, cartId)
+	return nil
+}
+
+// SetItemSaved moves the item of the cart to the list saved for later or back to the cart
+func (c *CartUseCase) SetItemSaved(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID, saved bool) error {
+	c.logger.Sugar().Debugf("Enter in usecase SetItemSaved() with args: ctx, cartId: %v, itemId: %v, saved: %v", cartId, itemId, saved)
+	if err := c.checkCart(ctx, cartId); err != nil {
 		return err
 	}
+	err := c.store.SetItemSaved(ctx, cartId, itemId, saved)
+	if err != nil {
+		return fmt.Errorf("error on set item saved: %w", err)
+	}
+	c.extendCart(ctx, cartId)
+	return nil
+}
+
+// ClearCart deletes items of the cart after the order is placed, items saved for later stay in the cart.
+// Reminders sent about the cart are counted as converted to the order
+func (c *CartUseCase) ClearCart(ctx context.Context, cartId uuid.UUID, orderId uuid.UUID) error {
+	c.logger.Sugar().Debugf("Enter in usecase ClearCart() with args: ctx, cartId: %v, orderId: %v", cartId, orderId)
+	if err := c.checkCart(ctx, cartId); err != nil {
+		return err
+	}
+	err := c.store.ClearCart(ctx, cartId, orderId)
+	if err != nil {
+		return fmt.Errorf("error on clear cart: %w", err)
+	}
+	c.extendCart(ctx, cartId)
 	return nil
 }
 
 // DeleteCart delete cart from db
 func (c *CartUseCase) DeleteCart(ctx context.Context, cartId uuid.UUID) error {
 	c.logger.Sugar().Debugf("Enter in usecase DeleteCart() with args: ctx, cartId: %v", cartId)
+	if err := c.checkCart(ctx, cartId); err != nil {
+		return err
+	}
 	err := c.store.DeleteCart(ctx, cartId)
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+// MergeGuestCart moves the items of the guest cart to the cart of the user and returns id of the cart of the user.
+// The cart of the user is created if the user has no cart yet
+func (c *CartUseCase) MergeGuestCart(ctx context.Context, guestCartId uuid.UUID, userId uuid.UUID) (uuid.UUID, error) {
+	c.logger.Sugar().Debugf("Enter in usecase MergeGuestCart() with args: ctx, guestCartId: %v, userId: %v", guestCartId, userId)
+	if err := checkOwner(ctx, userId); err != nil {
+		return uuid.Nil, err
+	}
+	guestCart, err := c.store.GetCart(ctx, guestCartId)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error on get guest cart: %w", err)
+	}
+	if guestCart.UserId == userId {
+		return guestCart.Id, nil
+	}
+	if guestCart.UserId != uuid.Nil {
+		return uuid.Nil, fmt.Errorf("cart %v is not a guest cart: %w", guestCartId, models.ErrorForbidden{})
+	}
+	if err := checkGuestCart(ctx, guestCartId); err != nil {
+		return uuid.Nil, fmt.Errorf("guest cart %v without its token: %w", guestCartId, err)
+	}
+	userCart, err := c.store.GetCartByUserId(ctx, userId)
+	switch {
+	case err == nil:
+	case errors.Is(err, models.ErrorNotFound{}):
+		userCart = &models.Cart{UserId: userId}
+		userCart.Id, err = c.store.Create(ctx, userId)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("error on create cart: %w", err)
+		}
+	default:
+		return uuid.Nil, fmt.Errorf("error on get user cart: %w", err)
+	}
+	userCartId := userCart.Id
+	lines := mergeCartItems(userCart, guestCart, c.maxQuantity)
+	err = c.store.MergeCarts(ctx, guestCartId, userCartId, lines)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error on merge carts: %w", err)
+	}
+	c.extendCart(ctx, userCartId)
+	return userCartId, nil
+}
+
+// mergeCartItems returns the lines of the cart of the user which have to be changed after merge with the guest cart.
+// Deleted items of the guest cart are skipped.
+// Quantities of the same item are summed and limited by maxQuantity and by the stock of the item,
+// but the quantity the user already has in the cart is never reduced. The line of the user keeps its list,
+// so the item saved for later by the user stays saved. Lines saved for later in the guest cart are added
+// to the list saved for later of the user if the user has no line of the item. Lines are sorted by item id
+func mergeCartItems(userCart *models.Cart, guestCart *models.Cart, maxQuantity int) []models.CartLine {
+	quantities := make(map[uuid.UUID]int, len(userCart.Items)+len(userCart.SavedItems))
+	saved := make(map[uuid.UUID]bool, len(userCart.SavedItems))
+	for _, item := range userCart.Items {
+		quantities[item.Id] += item.Quantity
+	}
+	for _, item := range userCart.SavedItems {
+		quantities[item.Id] += item.Quantity
+		saved[item.Id] = true
+	}
+	merged := make(map[uuid.UUID]int, len(guestCart.Items))
+	for _, item := range guestCart.Items {
+		if item.Quantity <= 0 || item.Deleted {
+			continue
+		}
+		current, ok := merged[item.Id]
+		if !ok {
+			current = quantities[item.Id]
+		}
+		merged[item.Id] = current + item.Quantity
+		limit := maxQuantity
+		if item.Stock != nil && *item.Stock < limit {
+			limit = *item.Stock
+		}
+		if merged[item.Id] > limit {
+			merged[item.Id] = limit
+		}
+		if merged[item.Id] < quantities[item.Id] {
+			merged[item.Id] = quantities[item.Id]
+		}
+	}
+	lines := make([]models.CartLine, 0, len(merged))
+	for id, quantity := range merged {
+		if quantity <= 0 || quantity == quantities[id] {
+			continue
+		}
+		lines = append(lines, models.CartLine{ItemId: id, Quantity: quantity, Saved: saved[id]})
+	}
+	for _, item := range guestCart.SavedItems {
+		if _, ok := quantities[item.Id]; ok || item.Quantity <= 0 || item.Deleted {
+			continue
+		}
+		lines = append(lines, models.CartLine{ItemId: item.Id, Quantity: item.Quantity, Saved: true})
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		return lines[i].ItemId.String() < lines[j].ItemId.String()
+	})
+	return lines
+}
@@ -4,7 +4,9 @@ import (
 	"OnlineShopBackend/internal/models"
 	"OnlineShopBackend/internal/repository/mocks"
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -12,6 +14,18 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	testCartMaxQuantity = 10
+	testCartTTL         = time.Hour
+)
+
+var testCartPricing = models.CartPricing{
+	DiscountThreshold:     1000,
+	DiscountPercent:       10,
+	ShippingCost:          300,
+	FreeShippingThreshold: 2000,
+}
+
 var (
 	testModelsCart = &models.Cart{
 		Id:    testId,
@@ -26,6 +40,8 @@ var (
 	testItems = []models.ItemWithQuantity{
 		testItem,
 	}
+	// testActor is the owner of the carts and orders in tests
+	testActor = models.Actor{UserId: testId, Role: models.Customer}
 )
 
 func TestGetCart(t *testing.T) {
@@ -33,8 +49,8 @@ func TestGetCart(t *testing.T) {
 	defer ctrl.Finish()
 	logger := zap.L()
 	cartRepo := mocks.NewMockCartStore(ctrl)
-	usecase := NewCartUseCase(cartRepo, logger)
-	ctx := context.Background()
+	usecase := NewCartUseCase(cartRepo, testCartMaxQuantity, testCartTTL, testCartPricing, logger)
+	ctx := models.ContextWithGuestCart(context.Background(), testId)
 
 	cartRepo.EXPECT().GetCart(ctx, testId).Return(nil, err)
 	res, err := usecase.GetCart(ctx, testId)
@@ -53,8 +69,8 @@ func TestGetCartByUserId(t *testing.T) {
 	defer ctrl.Finish()
 	logger := zap.L()
 	cartRepo := mocks.NewMockCartStore(ctrl)
-	usecase := NewCartUseCase(cartRepo, logger)
-	ctx := context.Background()
+	usecase := NewCartUseCase(cartRepo, testCartMaxQuantity, testCartTTL, testCartPricing, logger)
+	ctx := models.ContextWithActor(context.Background(), testActor)
 
 	cartRepo.EXPECT().GetCartByUserId(ctx, testId).Return(nil, err)
 	res, err := usecase.GetCartByUserId(ctx, testId)
@@ -73,14 +89,16 @@ func TestDeleteItemFromCart(t *testing.T) {
 	defer ctrl.Finish()
 	logger := zap.L()
 	cartRepo := mocks.NewMockCartStore(ctrl)
-	usecase := NewCartUseCase(cartRepo, logger)
-	ctx := context.Background()
+	usecase := NewCartUseCase(cartRepo, testCartMaxQuantity, testCartTTL, testCartPricing, logger)
+	ctx := models.ContextWithActor(context.Background(), testActor)
+	cartRepo.EXPECT().GetCartOwner(ctx, testId).Return(testId, nil).AnyTimes()
 
 	cartRepo.EXPECT().DeleteItemFromCart(ctx, testId, testId).Return(err)
 	err := usecase.DeleteItemFromCart(ctx, testId, testId)
 	require.Error(t, err)
 
 	cartRepo.EXPECT().DeleteItemFromCart(ctx, testId, testId).Return(nil)
+	cartRepo.EXPECT().ExtendCart(ctx, testId, testCartTTL).Return(nil)
 	err = usecase.DeleteItemFromCart(ctx, testId, testId)
 	require.NoError(t, err)
 }
@@ -90,8 +108,8 @@ func TestCreate(t *testing.T) {
 	defer ctrl.Finish()
 	logger := zap.L()
 	cartRepo := mocks.NewMockCartStore(ctrl)
-	usecase := NewCartUseCase(cartRepo, logger)
-	ctx := context.Background()
+	usecase := NewCartUseCase(cartRepo, testCartMaxQuantity, testCartTTL, testCartPricing, logger)
+	ctx := models.ContextWithActor(context.Background(), testActor)
 
 	cartRepo.EXPECT().Create(ctx, testId).Return(uuid.Nil, err)
 	res, err := usecase.Create(ctx, testId)
@@ -99,6 +117,14 @@ func TestCreate(t *testing.T) {
 	require.Equal(t, res, uuid.Nil)
 
 	cartRepo.EXPECT().Create(ctx, testId).Return(testId, nil)
+	cartRepo.EXPECT().ExtendCart(ctx, testId, testCartTTL).Return(nil)
+	res, err = usecase.Create(ctx, testId)
+	require.NoError(t, err)
+	require.Equal(t, res, testId)
+
+	// Cart is created even if its expiration time is not extended
+	cartRepo.EXPECT().Create(ctx, testId).Return(testId, nil)
+	cartRepo.EXPECT().ExtendCart(ctx, testId, testCartTTL).Return(fmt.Errorf("error"))
 	res, err = usecase.Create(ctx, testId)
 	require.NoError(t, err)
 	require.Equal(t, res, testId)
@@ -109,14 +135,20 @@ func TestAddItemToCart(t *testing.T) {
 	defer ctrl.Finish()
 	logger := zap.L()
 	cartRepo := mocks.NewMockCartStore(ctrl)
-	usecase := NewCartUseCase(cartRepo, logger)
-	ctx := context.Background()
+	usecase := NewCartUseCase(cartRepo, testCartMaxQuantity, testCartTTL, testCartPricing, logger)
+	ctx := models.ContextWithActor(context.Background(), testActor)
+	cartRepo.EXPECT().GetCartOwner(ctx, testId).Return(testId, nil).AnyTimes()
 
-	cartRepo.EXPECT().AddItemToCart(ctx, testId, testId).Return(err)
+	cartRepo.EXPECT().AddItemToCart(ctx, testId, testId, testCartMaxQuantity).Return(err)
 	err := usecase.AddItemToCart(ctx, testId, testId)
 	require.Error(t, err)
 
-	cartRepo.EXPECT().AddItemToCart(ctx, testId, testId).Return(nil)
+	cartRepo.EXPECT().AddItemToCart(ctx, testId, testId, testCartMaxQuantity).Return(models.ErrorQuantityExceeded{})
+	err = usecase.AddItemToCart(ctx, testId, testId)
+	require.ErrorIs(t, err, models.ErrorQuantityExceeded{})
+
+	cartRepo.EXPECT().AddItemToCart(ctx, testId, testId, testCartMaxQuantity).Return(nil)
+	cartRepo.EXPECT().ExtendCart(ctx, testId, testCartTTL).Return(nil)
 	err = usecase.AddItemToCart(ctx, testId, testId)
 	require.NoError(t, err)
 }
@@ -126,8 +158,9 @@ func TestDeleteCart(t *testing.T) {
 	defer ctrl.Finish()
 	logger := zap.L()
 	cartRepo := mocks.NewMockCartStore(ctrl)
-	usecase := NewCartUseCase(cartRepo, logger)
-	ctx := context.Background()
+	usecase := NewCartUseCase(cartRepo, testCartMaxQuantity, testCartTTL, testCartPricing, logger)
+	ctx := models.ContextWithActor(context.Background(), testActor)
+	cartRepo.EXPECT().GetCartOwner(ctx, testId).Return(testId, nil).AnyTimes()
 
 	cartRepo.EXPECT().DeleteCart(ctx, testId).Return(err)
 	err := usecase.DeleteCart(ctx, testId)
@@ -137,3 +170,434 @@ func TestDeleteCart(t *testing.T) {
 	err = usecase.DeleteCart(ctx, testId)
 	require.NoError(t, err)
 }
+
+func TestSetItemQuantity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	cartRepo := mocks.NewMockCartStore(ctrl)
+	usecase := NewCartUseCase(cartRepo, testCartMaxQuantity, testCartTTL, testCartPricing, logger)
+	ctx := models.ContextWithActor(context.Background(), testActor)
+	cartRepo.EXPECT().GetCartOwner(ctx, testId).Return(testId, nil).AnyTimes()
+
+	err := usecase.SetItemQuantity(ctx, testId, testId, -1)
+	require.ErrorIs(t, err, models.ErrorQuantityExceeded{})
+
+	err = usecase.SetItemQuantity(ctx, testId, testId, testCartMaxQuantity+1)
+	require.ErrorIs(t, err, models.ErrorQuantityExceeded{})
+
+	cartRepo.EXPECT().SetItemQuantity(ctx, testId, testId, 3).Return(models.ErrorNotFound{})
+	err = usecase.SetItemQuantity(ctx, testId, testId, 3)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	cartRepo.EXPECT().SetItemQuantity(ctx, testId, testId, 0).Return(nil)
+	cartRepo.EXPECT().ExtendCart(ctx, testId, testCartTTL).Return(nil)
+	err = usecase.SetItemQuantity(ctx, testId, testId, 0)
+	require.NoError(t, err)
+
+	cartRepo.EXPECT().SetItemQuantity(ctx, testId, testId, testCartMaxQuantity).Return(nil)
+	cartRepo.EXPECT().ExtendCart(ctx, testId, testCartTTL).Return(nil)
+	err = usecase.SetItemQuantity(ctx, testId, testId, testCartMaxQuantity)
+	require.NoError(t, err)
+}
+
+func TestMergeCartItems(t *testing.T) {
+	first := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	second := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	third := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+	two, five := 2, 5
+	withQuantity := func(id uuid.UUID, quantity int, stock *int) models.ItemWithQuantity {
+		return models.ItemWithQuantity{Item: models.Item{Id: id, Stock: stock}, Quantity: quantity}
+	}
+
+	tests := []struct {
+		name       string
+		user       []models.ItemWithQuantity
+		userSaved  []models.ItemWithQuantity
+		guest      []models.ItemWithQuantity
+		guestSaved []models.ItemWithQuantity
+		want       []models.CartLine
+	}{
+		{
+			name:  "empty guest cart",
+			user:  []models.ItemWithQuantity{withQuantity(first, 1, nil)},
+			guest: nil,
+			want:  []models.CartLine{},
+		},
+		{
+			name:  "new items sorted by id",
+			user:  nil,
+			guest: []models.ItemWithQuantity{withQuantity(third, 1, nil), withQuantity(first, 2, nil)},
+			want:  []models.CartLine{{ItemId: first, Quantity: 2}, {ItemId: third, Quantity: 1}},
+		},
+		{
+			name:  "quantities are summed",
+			user:  []models.ItemWithQuantity{withQuantity(first, 3, nil), withQuantity(second, 1, nil)},
+			guest: []models.ItemWithQuantity{withQuantity(first, 4, nil)},
+			want:  []models.CartLine{{ItemId: first, Quantity: 7}},
+		},
+		{
+			name:  "sum is limited by max quantity",
+			user:  []models.ItemWithQuantity{withQuantity(first, 8, nil)},
+			guest: []models.ItemWithQuantity{withQuantity(first, 8, nil)},
+			want:  []models.CartLine{{ItemId: first, Quantity: testCartMaxQuantity}},
+		},
+		{
+			name:  "sum is limited by stock",
+			user:  []models.ItemWithQuantity{withQuantity(first, 1, &five)},
+			guest: []models.ItemWithQuantity{withQuantity(first, 6, &five)},
+			want:  []models.CartLine{{ItemId: first, Quantity: 5}},
+		},
+		{
+			name:  "quantity of user is never reduced",
+			user:  []models.ItemWithQuantity{withQuantity(first, 4, &two)},
+			guest: []models.ItemWithQuantity{withQuantity(first, 1, &two), withQuantity(second, 1, &two)},
+			want:  []models.CartLine{{ItemId: second, Quantity: 1}},
+		},
+		{
+			name:  "deleted item is skipped",
+			user:  nil,
+			guest: []models.ItemWithQuantity{{Item: models.Item{Id: first}, Quantity: 1, Deleted: true}},
+			want:  []models.CartLine{},
+		},
+		{
+			name:  "out of stock item is skipped",
+			user:  nil,
+			guest: []models.ItemWithQuantity{withQuantity(first, 1, new(int))},
+			want:  []models.CartLine{},
+		},
+		{
+			name:      "line saved by user stays saved",
+			userSaved: []models.ItemWithQuantity{withQuantity(first, 1, nil)},
+			guest:     []models.ItemWithQuantity{withQuantity(first, 2, nil)},
+			want:      []models.CartLine{{ItemId: first, Quantity: 3, Saved: true}},
+		},
+		{
+			name:       "lines saved by guest are added to saved lines",
+			user:       []models.ItemWithQuantity{withQuantity(first, 1, nil)},
+			userSaved:  []models.ItemWithQuantity{withQuantity(second, 1, nil)},
+			guestSaved: []models.ItemWithQuantity{withQuantity(first, 2, nil), withQuantity(second, 2, nil), withQuantity(third, 2, new(int))},
+			want:       []models.CartLine{{ItemId: third, Quantity: 2, Saved: true}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &models.Cart{Items: tt.user, SavedItems: tt.userSaved}
+			guest := &models.Cart{Items: tt.guest, SavedItems: tt.guestSaved}
+			require.Equal(t, tt.want, mergeCartItems(user, guest, testCartMaxQuantity))
+		})
+	}
+}
+
+func TestMergeGuestCart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	cartRepo := mocks.NewMockCartStore(ctrl)
+	usecase := NewCartUseCase(cartRepo, testCartMaxQuantity, testCartTTL, testCartPricing, logger)
+	guestCartId, userCartId, userId := uuid.New(), uuid.New(), uuid.New()
+	ctx := models.ContextWithGuestCart(models.ContextWithActor(context.Background(), models.Actor{UserId: userId}), guestCartId)
+	itemId := uuid.New()
+	guestCart := &models.Cart{
+		Id:    guestCartId,
+		Items: []models.ItemWithQuantity{{Item: models.Item{Id: itemId}, Quantity: 2}},
+	}
+	userCart := &models.Cart{
+		Id:     userCartId,
+		UserId: userId,
+		Items:  []models.ItemWithQuantity{{Item: models.Item{Id: itemId}, Quantity: 1}},
+	}
+
+	cartRepo.EXPECT().GetCart(ctx, guestCartId).Return(nil, models.ErrorNotFound{})
+	res, err := usecase.MergeGuestCart(ctx, guestCartId, userId)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+	require.Equal(t, uuid.Nil, res)
+
+	cartRepo.EXPECT().GetCart(ctx, guestCartId).Return(&models.Cart{Id: guestCartId, UserId: userId}, nil)
+	res, err = usecase.MergeGuestCart(ctx, guestCartId, userId)
+	require.NoError(t, err)
+	require.Equal(t, guestCartId, res)
+
+	cartRepo.EXPECT().GetCart(ctx, guestCartId).Return(&models.Cart{Id: guestCartId, UserId: uuid.New()}, nil)
+	res, err = usecase.MergeGuestCart(ctx, guestCartId, userId)
+	require.ErrorIs(t, err, models.ErrorForbidden{})
+	require.Equal(t, uuid.Nil, res)
+
+	// The guest cart is merged only with its token
+	otherCtx := models.ContextWithGuestCart(ctx, uuid.New())
+	cartRepo.EXPECT().GetCart(otherCtx, guestCartId).Return(guestCart, nil)
+	res, err = usecase.MergeGuestCart(otherCtx, guestCartId, userId)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+	require.Equal(t, uuid.Nil, res)
+
+	cartRepo.EXPECT().GetCart(ctx, guestCartId).Return(guestCart, nil)
+	cartRepo.EXPECT().GetCartByUserId(ctx, userId).Return(nil, fmt.Errorf("error"))
+	res, err = usecase.MergeGuestCart(ctx, guestCartId, userId)
+	require.Error(t, err)
+	require.Equal(t, uuid.Nil, res)
+
+	cartRepo.EXPECT().GetCart(ctx, guestCartId).Return(guestCart, nil)
+	cartRepo.EXPECT().GetCartByUserId(ctx, userId).Return(nil, models.ErrorNotFound{})
+	cartRepo.EXPECT().Create(ctx, userId).Return(userCartId, nil)
+	cartRepo.EXPECT().MergeCarts(ctx, guestCartId, userCartId, []models.CartLine{{ItemId: itemId, Quantity: 2}}).Return(nil)
+	cartRepo.EXPECT().ExtendCart(ctx, userCartId, testCartTTL).Return(nil)
+	res, err = usecase.MergeGuestCart(ctx, guestCartId, userId)
+	require.NoError(t, err)
+	require.Equal(t, userCartId, res)
+
+	cartRepo.EXPECT().GetCart(ctx, guestCartId).Return(guestCart, nil)
+	cartRepo.EXPECT().GetCartByUserId(ctx, userId).Return(userCart, nil)
+	cartRepo.EXPECT().MergeCarts(ctx, guestCartId, userCartId, []models.CartLine{{ItemId: itemId, Quantity: 3}}).Return(fmt.Errorf("error"))
+	res, err = usecase.MergeGuestCart(ctx, guestCartId, userId)
+	require.Error(t, err)
+	require.Equal(t, uuid.Nil, res)
+
+	cartRepo.EXPECT().GetCart(ctx, guestCartId).Return(guestCart, nil)
+	cartRepo.EXPECT().GetCartByUserId(ctx, userId).Return(userCart, nil)
+	cartRepo.EXPECT().MergeCarts(ctx, guestCartId, userCartId, []models.CartLine{{ItemId: itemId, Quantity: 3}}).Return(nil)
+	cartRepo.EXPECT().ExtendCart(ctx, userCartId, testCartTTL).Return(nil)
+	res, err = usecase.MergeGuestCart(ctx, guestCartId, userId)
+	require.NoError(t, err)
+	require.Equal(t, userCartId, res)
+}
+
+func TestGetCartTotals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	cartRepo := mocks.NewMockCartStore(ctrl)
+	usecase := NewCartUseCase(cartRepo, testCartMaxQuantity, testCartTTL, testCartPricing, logger)
+	ctx := models.ContextWithGuestCart(context.Background(), testId)
+	first := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	second := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	third := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+	zero, one := 0, 1
+	line := func(id uuid.UUID, price int32, addedPrice int32, quantity int, stock *int, deleted bool) models.ItemWithQuantity {
+		return models.ItemWithQuantity{
+			Item:       models.Item{Id: id, Title: id.String(), Price: price, Stock: stock},
+			Quantity:   quantity,
+			AddedPrice: addedPrice,
+			Deleted:    deleted,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		items   []models.ItemWithQuantity
+		lines   map[uuid.UUID]int64
+		totals  [4]int64
+		notices []models.CartNotice
+	}{
+		{
+			name:    "empty cart",
+			items:   nil,
+			lines:   map[uuid.UUID]int64{},
+			totals:  [4]int64{0, 0, 0, 0},
+			notices: []models.CartNotice{},
+		},
+		{
+			name:    "shipping below discount threshold",
+			items:   []models.ItemWithQuantity{line(first, 200, 200, 2, nil, false), line(second, 100, 100, 1, &one, false)},
+			lines:   map[uuid.UUID]int64{first: 400, second: 100},
+			totals:  [4]int64{500, 0, 300, 800},
+			notices: []models.CartNotice{},
+		},
+		{
+			name:    "discount with shipping",
+			items:   []models.ItemWithQuantity{line(first, 500, 500, 3, nil, false)},
+			lines:   map[uuid.UUID]int64{first: 1500},
+			totals:  [4]int64{1500, 150, 300, 1650},
+			notices: []models.CartNotice{},
+		},
+		{
+			name:    "free shipping after discount",
+			items:   []models.ItemWithQuantity{line(first, 1200, 1200, 2, nil, false)},
+			lines:   map[uuid.UUID]int64{first: 2400},
+			totals:  [4]int64{2400, 240, 0, 2160},
+			notices: []models.CartNotice{},
+		},
+		{
+			name: "changed, deleted and unavailable items",
+			items: []models.ItemWithQuantity{
+				line(third, 300, 300, 2, &one, false),
+				line(second, 100, 100, 1, nil, true),
+				line(first, 250, 200, 2, &zero, false),
+			},
+			lines:  map[uuid.UUID]int64{},
+			totals: [4]int64{0, 0, 0, 0},
+			notices: []models.CartNotice{
+				{ItemId: first, Title: first.String(), Type: models.CartNoticePriceChanged, OldPrice: 200, NewPrice: 250},
+				{ItemId: first, Title: first.String(), Type: models.CartNoticeUnavailable, NewPrice: 250, Available: 0},
+				{ItemId: second, Title: second.String(), Type: models.CartNoticeDeleted, OldPrice: 100},
+				{ItemId: third, Title: third.String(), Type: models.CartNoticeUnavailable, NewPrice: 300, Available: 1},
+			},
+		},
+		{
+			name:   "repriced item is counted at the current price",
+			items:  []models.ItemWithQuantity{line(first, 150, 100, 2, nil, false), line(second, 100, 100, 1, nil, true)},
+			lines:  map[uuid.UUID]int64{first: 300},
+			totals: [4]int64{300, 0, 300, 600},
+			notices: []models.CartNotice{
+				{ItemId: first, Title: first.String(), Type: models.CartNoticePriceChanged, OldPrice: 100, NewPrice: 150},
+				{ItemId: second, Title: second.String(), Type: models.CartNoticeDeleted, OldPrice: 100},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cartRepo.EXPECT().GetCart(ctx, testId).Return(&models.Cart{Id: testId, Items: tt.items}, nil)
+			res, err := usecase.GetCart(ctx, testId)
+			require.NoError(t, err)
+			require.Equal(t, tt.lines, res.Totals.LineTotals)
+			require.Equal(t, tt.totals, [4]int64{res.Totals.Subtotal, res.Totals.Discount, res.Totals.Shipping, res.Totals.Total})
+			require.Equal(t, tt.notices, res.Totals.Notices)
+		})
+	}
+}
+
+func TestSetItemSaved(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	cartRepo := mocks.NewMockCartStore(ctrl)
+	usecase := NewCartUseCase(cartRepo, testCartMaxQuantity, testCartTTL, testCartPricing, logger)
+	ctx := models.ContextWithActor(context.Background(), testActor)
+	cartRepo.EXPECT().GetCartOwner(ctx, testId).Return(testId, nil).AnyTimes()
+
+	cartRepo.EXPECT().SetItemSaved(ctx, testId, testId, true).Return(models.ErrorNotFound{})
+	err := usecase.SetItemSaved(ctx, testId, testId, true)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	cartRepo.EXPECT().SetItemSaved(ctx, testId, testId, true).Return(nil)
+	cartRepo.EXPECT().ExtendCart(ctx, testId, testCartTTL).Return(nil)
+	err = usecase.SetItemSaved(ctx, testId, testId, true)
+	require.NoError(t, err)
+
+	cartRepo.EXPECT().SetItemSaved(ctx, testId, testId, false).Return(nil)
+	cartRepo.EXPECT().ExtendCart(ctx, testId, testCartTTL).Return(nil)
+	err = usecase.SetItemSaved(ctx, testId, testId, false)
+	require.NoError(t, err)
+}
+
+func TestClearCart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	cartRepo := mocks.NewMockCartStore(ctrl)
+	usecase := NewCartUseCase(cartRepo, testCartMaxQuantity, testCartTTL, testCartPricing, logger)
+	ctx := models.ContextWithActor(context.Background(), testActor)
+	cartRepo.EXPECT().GetCartOwner(ctx, testId).Return(testId, nil).AnyTimes()
+
+	cartRepo.EXPECT().ClearCart(ctx, testId, testId).Return(fmt.Errorf("error"))
+	err := usecase.ClearCart(ctx, testId, testId)
+	require.Error(t, err)
+
+	cartRepo.EXPECT().ClearCart(ctx, testId, testId).Return(nil)
+	cartRepo.EXPECT().ExtendCart(ctx, testId, testCartTTL).Return(nil)
+	err = usecase.ClearCart(ctx, testId, testId)
+	require.NoError(t, err)
+}
+
+func TestGetCartTotalsWithSavedItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	cartRepo := mocks.NewMockCartStore(ctrl)
+	usecase := NewCartUseCase(cartRepo, testCartMaxQuantity, testCartTTL, testCartPricing, logger)
+	ctx := models.ContextWithGuestCart(context.Background(), testId)
+	savedId := uuid.New()
+
+	cartRepo.EXPECT().GetCart(ctx, testId).Return(&models.Cart{
+		Id:    testId,
+		Items: []models.ItemWithQuantity{{Item: models.Item{Id: testId, Price: 100}, Quantity: 2, AddedPrice: 100}},
+		SavedItems: []models.ItemWithQuantity{
+			{Item: models.Item{Id: savedId, Price: 500}, Quantity: 1, AddedPrice: 400, Deleted: true},
+		},
+	}, nil)
+	res, err := usecase.GetCart(ctx, testId)
+	require.NoError(t, err)
+	require.Equal(t, map[uuid.UUID]int64{testId: 200}, res.Totals.LineTotals)
+	require.Equal(t, int64(200), res.Totals.Subtotal)
+	require.Equal(t, int64(500), res.Totals.Total)
+	require.Empty(t, res.Totals.Notices)
+}
+
+func TestCartOwnership(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	cartRepo := mocks.NewMockCartStore(ctrl)
+	usecase := NewCartUseCase(cartRepo, testCartMaxQuantity, testCartTTL, testCartPricing, logger)
+	ownerId, otherId := uuid.New(), uuid.New()
+	ownerCtx := models.ContextWithActor(context.Background(), models.Actor{UserId: ownerId, Role: models.Customer})
+	otherCtx := models.ContextWithActor(context.Background(), models.Actor{UserId: otherId, Role: models.Customer})
+	adminCtx := models.ContextWithActor(context.Background(), models.Actor{UserId: otherId, Role: models.Admin})
+	guestCtx := models.ContextWithGuestCart(context.Background(), testId)
+	ownerCart := &models.Cart{Id: testId, UserId: ownerId}
+
+	// Carts of other users are not found
+	cartRepo.EXPECT().GetCart(otherCtx, testId).Return(ownerCart, nil)
+	res, err := usecase.GetCart(otherCtx, testId)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+	require.Nil(t, res)
+
+	cartRepo.EXPECT().GetCart(guestCtx, testId).Return(ownerCart, nil)
+	_, err = usecase.GetCart(guestCtx, testId)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	_, err = usecase.GetCartByUserId(otherCtx, ownerId)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	_, err = usecase.Create(otherCtx, ownerId)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	_, err = usecase.MergeGuestCart(otherCtx, testId, ownerId)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	cartRepo.EXPECT().GetCartOwner(otherCtx, testId).Return(ownerId, nil).Times(6)
+	require.ErrorIs(t, usecase.AddItemToCart(otherCtx, testId, testId), models.ErrorNotFound{})
+	require.ErrorIs(t, usecase.SetItemQuantity(otherCtx, testId, testId, 1), models.ErrorNotFound{})
+	require.ErrorIs(t, usecase.SetItemSaved(otherCtx, testId, testId, true), models.ErrorNotFound{})
+	require.ErrorIs(t, usecase.DeleteItemFromCart(otherCtx, testId, testId), models.ErrorNotFound{})
+	require.ErrorIs(t, usecase.ClearCart(otherCtx, testId, testId), models.ErrorNotFound{})
+	require.ErrorIs(t, usecase.DeleteCart(otherCtx, testId), models.ErrorNotFound{})
+
+	// The owner and admins have access to the cart
+	cartRepo.EXPECT().GetCart(ownerCtx, testId).Return(ownerCart, nil)
+	res, err = usecase.GetCart(ownerCtx, testId)
+	require.NoError(t, err)
+	require.Equal(t, testId, res.Id)
+
+	cartRepo.EXPECT().GetCartOwner(adminCtx, testId).Return(ownerId, nil)
+	cartRepo.EXPECT().DeleteCart(adminCtx, testId).Return(nil)
+	require.NoError(t, usecase.DeleteCart(adminCtx, testId))
+
+	// Guest carts have no owner and are available without claims
+	cartRepo.EXPECT().Create(guestCtx, uuid.Nil).Return(testId, nil)
+	cartRepo.EXPECT().ExtendCart(guestCtx, testId, testCartTTL).Return(nil)
+	_, err = usecase.Create(guestCtx, uuid.Nil)
+	require.NoError(t, err)
+
+	cartRepo.EXPECT().GetCartOwner(guestCtx, testId).Return(uuid.Nil, nil)
+	cartRepo.EXPECT().SetItemQuantity(guestCtx, testId, testId, 1).Return(nil)
+	cartRepo.EXPECT().ExtendCart(guestCtx, testId, testCartTTL).Return(nil)
+	require.NoError(t, usecase.SetItemQuantity(guestCtx, testId, testId, 1))
+
+	cartRepo.EXPECT().GetCart(guestCtx, testId).Return(&models.Cart{Id: testId}, nil)
+	_, err = usecase.GetCart(guestCtx, testId)
+	require.NoError(t, err)
+
+	// Guest carts are not available by their ids without their tokens
+	cartRepo.EXPECT().GetCartOwner(ownerCtx, testId).Return(uuid.Nil, nil).Times(2)
+	require.ErrorIs(t, usecase.SetItemQuantity(ownerCtx, testId, testId, 1), models.ErrorNotFound{})
+	require.ErrorIs(t, usecase.DeleteCart(ownerCtx, testId), models.ErrorNotFound{})
+	cartRepo.EXPECT().GetCart(ownerCtx, testId).Return(&models.Cart{Id: testId}, nil)
+	_, err = usecase.GetCart(ownerCtx, testId)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+	anotherGuestCtx := models.ContextWithGuestCart(context.Background(), uuid.New())
+	cartRepo.EXPECT().GetCartOwner(anotherGuestCtx, testId).Return(uuid.Nil, nil)
+	require.ErrorIs(t, usecase.SetItemQuantity(anotherGuestCtx, testId, testId, 1), models.ErrorNotFound{})
+
+	cartRepo.EXPECT().GetCartOwner(ownerCtx, testId).Return(uuid.Nil, models.ErrorNotFound{})
+	require.ErrorIs(t, usecase.DeleteCart(ownerCtx, testId), models.ErrorNotFound{})
+}
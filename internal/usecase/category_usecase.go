@@ -1,10 +1,12 @@
 package usecase
 
 import (
+	"OnlineShopBackend/internal/filestorage"
 	"OnlineShopBackend/internal/models"
 	"OnlineShopBackend/internal/repository"
 	"OnlineShopBackend/internal/repository/cash"
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"time"
@@ -22,12 +24,16 @@ var (
 type CategoryUsecase struct {
 	categoryStore  repository.CategoryStore
 	categoriesCash cash.ICategoriesCash
-	logger         *zap.Logger
+	// itemUsecase keeps caches of items moved from deleted categories in line with the database
+	itemUsecase IItemUsecase
+	filestorage filestorage.FileStorager
+	logger      *zap.Logger
 }
 
-func NewCategoryUsecase(store repository.CategoryStore, cash cash.ICategoriesCash, logger *zap.Logger) ICategoryUsecase {
+func NewCategoryUsecase(store repository.CategoryStore, cash cash.ICategoriesCash, itemUsecase IItemUsecase,
+	filestorage filestorage.FileStorager, logger *zap.Logger) ICategoryUsecase {
 	logger.Debug("Enter in usecase NewCategoryUsecase()")
-	return &CategoryUsecase{categoryStore: store, categoriesCash: cash, logger: logger}
+	return &CategoryUsecase{categoryStore: store, categoriesCash: cash, itemUsecase: itemUsecase, filestorage: filestorage, logger: logger}
 }
 
 // / CreateCategory call database method and returns id of created category or error
@@ -121,21 +127,147 @@ func (usecase *CategoryUsecase) GetCategoryList(ctx context.Context) ([]models.C
 	return categories, nil
 }
 
-// DeleteCategory call database method for deleting category
-func (usecase *CategoryUsecase) DeleteCategory(ctx context.Context, id uuid.UUID) error {
-	usecase.logger.Sugar().Debugf("Enter in usecase DeleteCategory() with args: ctx, id: %v", id)
-	err := usecase.categoryStore.DeleteCategory(ctx, id)
+// DeleteCategory deletes the category with moving its items to the category with targetId or to the system
+// category for items from deleted categories if uncategorized is true. The category with items can't be deleted
+// without the target category and the system category can't be deleted at all
+func (usecase *CategoryUsecase) DeleteCategory(ctx context.Context, id uuid.UUID, targetId uuid.UUID, uncategorized bool) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase DeleteCategory() with args: ctx, id: %v, targetId: %v, uncategorized: %t", id, targetId, uncategorized)
+	deletedCategory, err := usecase.GetCategory(ctx, id)
 	if err != nil {
 		return err
 	}
-	err = usecase.UpdateCash(ctx, id, "delete")
+	if deletedCategory.Name == models.UncategorizedName {
+		return fmt.Errorf("category %s: %w", deletedCategory.Name, models.ErrorSystemCategory{})
+	}
+	var targetCategory *models.Category
+	switch {
+	case targetId != uuid.Nil:
+		targetCategory, err = usecase.GetCategory(ctx, targetId)
+		if err != nil {
+			return fmt.Errorf("target category %v: %w", targetId, err)
+		}
+	case uncategorized:
+		targetCategory, err = usecase.GetUncategorized(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return usecase.removeCategory(ctx, deletedCategory, targetCategory, usecase.categoryStore.DeleteCategory)
+}
+
+// MergeCategories moves all items of the source category to the target category
+// and deletes the source category, the system category can't be the source category
+func (usecase *CategoryUsecase) MergeCategories(ctx context.Context, sourceId uuid.UUID, targetId uuid.UUID) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase MergeCategories() with args: ctx, sourceId: %v, targetId: %v", sourceId, targetId)
+	sourceCategory, err := usecase.GetCategory(ctx, sourceId)
+	if err != nil {
+		return err
+	}
+	targetCategory, err := usecase.GetCategory(ctx, targetId)
+	if err != nil {
+		return fmt.Errorf("target category %v: %w", targetId, err)
+	}
+	if sourceCategory.Name == models.UncategorizedName {
+		return fmt.Errorf("category %s: %w", sourceCategory.Name, models.ErrorSystemCategory{})
+	}
+	return usecase.removeCategory(ctx, sourceCategory, targetCategory, usecase.categoryStore.MergeCategories)
+}
+
+// removeCategory deletes the category by the database method remove with moving its items to the target category
+// and brings the caches of both categories and of moved items in line with the database
+func (usecase *CategoryUsecase) removeCategory(ctx context.Context, deletedCategory *models.Category, targetCategory *models.Category,
+	remove func(ctx context.Context, id uuid.UUID, targetId uuid.UUID) error) error {
+	// Requesting the number of items in the category to be deleted
+	quantity, err := usecase.itemUsecase.ItemsQuantityInCategory(ctx, deletedCategory.Name)
 	if err != nil {
+		return fmt.Errorf("error on get quantity of items in category: %w", err)
+	}
+	if quantity > 0 && targetCategory == nil {
+		return fmt.Errorf("category %s has %d items, choose the target category for them: %w", deletedCategory.Name, quantity, models.ErrorNotEmpty{})
+	}
+	var items []models.Item
+	// If the quantity is greater than zero, we request a list of products from this category
+	if quantity > 0 {
+		limitOptions := map[string]int{"offset": 0, "limit": quantity}
+		sortOptions := map[string]string{"sortType": "name", "sortOrder": "asc"}
+		items, err = usecase.itemUsecase.GetItemsByCategory(ctx, deletedCategory.Name, limitOptions, sortOptions)
+		if err != nil {
+			return fmt.Errorf("error on get items of category: %w", err)
+		}
+	}
+
+	// Deleting a category with moving its items in one transaction,
+	// items can be added to the category after the quantity was requested
+	targetId := uuid.Nil
+	if targetCategory != nil {
+		targetId = targetCategory.Id
+	}
+	if err := remove(ctx, deletedCategory.Id, targetId); err != nil {
+		return fmt.Errorf("error on delete category %s: %w", deletedCategory.Name, err)
+	}
+	if err := usecase.UpdateCash(ctx, deletedCategory.Id, "delete"); err != nil {
 		usecase.logger.Error(fmt.Sprintf("error on update cash: %v", err))
 	}
-	usecase.logger.Info("Delete category success")
+
+	// Deleting the cache of the list of products from this category
+	if err := usecase.DeleteCategoryCash(ctx, deletedCategory.Name); err != nil {
+		usecase.logger.Error(fmt.Sprintf("error on delete category cash: %v", err))
+	}
+
+	// If the category has a picture, delete this picture
+	if deletedCategory.Image != "" {
+		if err := usecase.filestorage.DeleteCategoryImageById(deletedCategory.Id.String()); err != nil {
+			usecase.logger.Error(err.Error())
+		}
+	}
+
+	// The moved items are added to the caches of the target category and updated in the cache of all items
+	for _, item := range items {
+		item.Category = *targetCategory
+		if err := usecase.itemUsecase.UpdateCash(ctx, item.Id, "update"); err != nil {
+			usecase.logger.Debug(fmt.Sprintf("error on update items list cash: %v", err))
+		}
+		if err := usecase.itemUsecase.UpdateItemsInCategoryCash(ctx, &item, "create"); err != nil {
+			usecase.logger.Error(fmt.Sprintf("error on update cash of category %s: %v", targetCategory.Name, err))
+			// The cache of the target category is deleted so as not to leave it incomplete,
+			// it will be created again on the next request
+			if err := usecase.DeleteCategoryCash(ctx, targetCategory.Name); err != nil {
+				usecase.logger.Error(fmt.Sprintf("error on delete category cash: %v", err))
+			}
+			break
+		}
+	}
+	if len(items) > 0 {
+		// Lists sorted by popularity and novelty are not updated item by item
+		usecase.itemUsecase.DeleteStatsSortedCash(ctx, targetCategory.Name)
+	}
+	usecase.logger.Sugar().Infof("Category with id: %s deleted success", deletedCategory.Id)
 	return nil
 }
 
+// GetUncategorized returns the system category for items from deleted categories,
+// the category is created if it does not exist
+func (usecase *CategoryUsecase) GetUncategorized(ctx context.Context) (*models.Category, error) {
+	usecase.logger.Debug("Enter in usecase GetUncategorized() with args: ctx")
+	category, err := usecase.categoryStore.GetCategoryByName(ctx, models.UncategorizedName)
+	if err == nil {
+		return category, nil
+	}
+	if !errors.Is(err, models.ErrorNotFound{}) {
+		return nil, fmt.Errorf("error on get uncategorized category: %w", err)
+	}
+	category = &models.Category{
+		Name:        models.UncategorizedName,
+		Description: "Category for items from deleting categories",
+	}
+	id, err := usecase.CreateCategory(ctx, category)
+	if err != nil {
+		return nil, fmt.Errorf("error on create uncategorized category: %w", err)
+	}
+	category.Id = id
+	return category, nil
+}
+
 // GetCategoryByName call database method for get category by name
 func (usecase *CategoryUsecase) GetCategoryByName(ctx context.Context, name string) (*models.Category, error) {
 	usecase.logger.Sugar().Debugf("Enter in usecase GetCategoryByName() with args: ctx, name: %s", name)
@@ -205,7 +337,8 @@ func (usecase *CategoryUsecase) UpdateCash(ctx context.Context, id uuid.UUID, op
 // DeleteCategoryCash deleted cash after deleting categories
 func (usecase *CategoryUsecase) DeleteCategoryCash(ctx context.Context, name string) error {
 	usecase.logger.Debug(fmt.Sprintf("Enter in usecase DeleteCategoryCash() with args: ctx, name: %s", name))
-	// keys is a list of cache keys with items in deleted category sorting by name and price
+	// keys is a list of cache keys with items in deleted category sorting by name and price,
+	// caches sorted by popularity and novelty are deleted by the item usecase which knows their keys
 	keys := []string{name + "nameasc", name + "namedesc", name + "priceasc", name + "pricedesc"}
 	for _, key := range keys {
 		// For each key from list delete cache
@@ -215,6 +348,7 @@ func (usecase *CategoryUsecase) DeleteCategoryCash(ctx context.Context, name str
 			return err
 		}
 	}
+	usecase.itemUsecase.DeleteStatsSortedCash(ctx, name)
 	// Delete cache with quantity of items in deleted category
 	err := usecase.categoriesCash.DeleteCash(ctx, name+"Quantity")
 	if err != nil {
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	fs "OnlineShopBackend/internal/filestorage/mocks"
 	"OnlineShopBackend/internal/models"
 	"OnlineShopBackend/internal/repository/mocks"
 	"context"
@@ -35,7 +36,7 @@ func TestCreateCategory(t *testing.T) {
 	logger := zap.L()
 	categoryRepo := mocks.NewMockCategoryStore(ctrl)
 	cash := mocks.NewMockICategoriesCash(ctrl)
-	usecase := NewCategoryUsecase(categoryRepo, cash, logger)
+	usecase := NewCategoryUsecase(categoryRepo, cash, nil, nil, logger)
 
 	categoryRepo.EXPECT().CreateCategory(ctx, testModelCategory).Return(testId, nil)
 	cash.EXPECT().CheckCash(ctx, categoriesListKey).Return(false)
@@ -70,7 +71,7 @@ func TestUpdateCategory(t *testing.T) {
 	logger := zap.L()
 	categoryRepo := mocks.NewMockCategoryStore(ctrl)
 	cash := mocks.NewMockICategoriesCash(ctrl)
-	usecase := NewCategoryUsecase(categoryRepo, cash, logger)
+	usecase := NewCategoryUsecase(categoryRepo, cash, nil, nil, logger)
 
 	categoryRepo.EXPECT().UpdateCategory(ctx, testModelCategoryWithId).Return(nil)
 	cash.EXPECT().CheckCash(ctx, categoriesListKey).Return(false)
@@ -98,7 +99,7 @@ func TestGetCategory(t *testing.T) {
 	logger := zap.L()
 	categoryRepo := mocks.NewMockCategoryStore(ctrl)
 	cash := mocks.NewMockICategoriesCash(ctrl)
-	usecase := NewCategoryUsecase(categoryRepo, cash, logger)
+	usecase := NewCategoryUsecase(categoryRepo, cash, nil, nil, logger)
 
 	categoryRepo.EXPECT().GetCategory(ctx, testId).Return(testModelCategoryWithId, nil)
 	res, err := usecase.GetCategory(ctx, testId)
@@ -118,7 +119,7 @@ func TestGetCategoryList(t *testing.T) {
 	logger := zap.L()
 	categoryRepo := mocks.NewMockCategoryStore(ctrl)
 	cash := mocks.NewMockICategoriesCash(ctrl)
-	usecase := NewCategoryUsecase(categoryRepo, cash, logger)
+	usecase := NewCategoryUsecase(categoryRepo, cash, nil, nil, logger)
 
 	cash.EXPECT().CheckCash(ctx, categoriesListKey).Return(true)
 	cash.EXPECT().GetCategoriesListCash(ctx, categoriesListKey).Return(categories, nil)
@@ -178,7 +179,7 @@ func TestUpdateCategoryCash(t *testing.T) {
 	logger := zap.L()
 	categoryRepo := mocks.NewMockCategoryStore(ctrl)
 	cash := mocks.NewMockICategoriesCash(ctrl)
-	usecase := NewCategoryUsecase(categoryRepo, cash, logger)
+	usecase := NewCategoryUsecase(categoryRepo, cash, nil, nil, logger)
 
 	cash.EXPECT().CheckCash(ctx, categoriesListKey).Return(false)
 	err := usecase.UpdateCash(ctx, testId, "create")
@@ -210,31 +211,182 @@ func TestUpdateCategoryCash(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// categoryItems is the item usecase with items of the deleted category which records updates of caches,
+// the mock of the usecase can't be used here because of the import cycle
+type categoryItems struct {
+	IItemUsecase
+	items       []models.Item
+	quantityErr error
+	cashErr     error
+	updated     []uuid.UUID
+	moved       []models.Item
+	statsSorted []string
+}
+
+func (i *categoryItems) ItemsQuantityInCategory(ctx context.Context, categoryName string) (int, error) {
+	return len(i.items), i.quantityErr
+}
+
+func (i *categoryItems) GetItemsByCategory(ctx context.Context, categoryName string, limitOptions map[string]int, sortOptions map[string]string) ([]models.Item, error) {
+	return i.items, nil
+}
+
+func (i *categoryItems) UpdateCash(ctx context.Context, id uuid.UUID, op string) error {
+	i.updated = append(i.updated, id)
+	return nil
+}
+
+func (i *categoryItems) UpdateItemsInCategoryCash(ctx context.Context, item *models.Item, op string) error {
+	i.moved = append(i.moved, *item)
+	return i.cashErr
+}
+
+func (i *categoryItems) DeleteStatsSortedCash(ctx context.Context, categoryName string) {
+	i.statsSorted = append(i.statsSorted, categoryName)
+}
+
 func TestDeleteCategory(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	ctx := context.Background()
-	logger := zap.L()
 	categoryRepo := mocks.NewMockCategoryStore(ctrl)
 	cash := mocks.NewMockICategoriesCash(ctrl)
-	usecase := NewCategoryUsecase(categoryRepo, cash, logger)
+	filestorage := fs.NewMockFileStorager(ctrl)
+	items := &categoryItems{}
+	usecase := NewCategoryUsecase(categoryRepo, cash, items, filestorage, zap.L())
+	// Caches of categories are checked and deleted after every deleting
+	cash.EXPECT().CheckCash(ctx, categoriesListKey).Return(false).AnyTimes()
+	cash.EXPECT().DeleteCash(ctx, gomock.Any()).Return(nil).AnyTimes()
+	deleted := &models.Category{Id: testId, Name: "deleted", Image: "image.jpg"}
+	uncategorized := &models.Category{Id: uuid.New(), Name: models.UncategorizedName}
+	item := models.Item{Id: uuid.New(), Title: "item", Category: *deleted}
+
+	categoryRepo.EXPECT().GetCategory(ctx, testId).Return(nil, models.ErrorNotFound{})
+	err := usecase.DeleteCategory(ctx, testId, uuid.Nil, false)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	// The system category can't be deleted
+	categoryRepo.EXPECT().GetCategory(ctx, testId).Return(&models.Category{Id: testId, Name: models.UncategorizedName}, nil)
+	err = usecase.DeleteCategory(ctx, testId, uuid.Nil, true)
+	require.ErrorIs(t, err, models.ErrorSystemCategory{})
+
+	targetId := uuid.New()
+	categoryRepo.EXPECT().GetCategory(ctx, testId).Return(deleted, nil)
+	categoryRepo.EXPECT().GetCategory(ctx, targetId).Return(nil, models.ErrorNotFound{})
+	err = usecase.DeleteCategory(ctx, testId, targetId, false)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	items.quantityErr = fmt.Errorf("error")
+	categoryRepo.EXPECT().GetCategory(ctx, testId).Return(deleted, nil)
+	err = usecase.DeleteCategory(ctx, testId, uuid.Nil, false)
+	require.Error(t, err)
+	items.quantityErr = nil
+
+	// The category with items can't be deleted without the target category
+	items.items = []models.Item{item}
+	categoryRepo.EXPECT().GetCategory(ctx, testId).Return(deleted, nil)
+	err = usecase.DeleteCategory(ctx, testId, uuid.Nil, false)
+	require.ErrorIs(t, err, models.ErrorNotEmpty{})
+
+	// Items are added to the category after the quantity was requested
+	items.items = nil
+	categoryRepo.EXPECT().GetCategory(ctx, testId).Return(deleted, nil)
+	categoryRepo.EXPECT().DeleteCategory(ctx, testId, uuid.Nil).Return(models.ErrorNotEmpty{})
+	err = usecase.DeleteCategory(ctx, testId, uuid.Nil, false)
+	require.ErrorIs(t, err, models.ErrorNotEmpty{})
+
+	categoryRepo.EXPECT().GetCategory(ctx, testId).Return(deleted, nil)
+	categoryRepo.EXPECT().DeleteCategory(ctx, testId, uuid.Nil).Return(nil)
+	filestorage.EXPECT().DeleteCategoryImageById(testId.String()).Return(fmt.Errorf("error"))
+	err = usecase.DeleteCategory(ctx, testId, uuid.Nil, false)
+	require.NoError(t, err)
 
-	categoryRepo.EXPECT().DeleteCategory(ctx, testId).Return(fmt.Errorf("error"))
-	err := usecase.DeleteCategory(ctx, testId)
+	// Items are moved to the system category and added to its caches
+	items.items = []models.Item{item}
+	categoryRepo.EXPECT().GetCategory(ctx, testId).Return(deleted, nil)
+	categoryRepo.EXPECT().GetCategoryByName(ctx, models.UncategorizedName).Return(uncategorized, nil)
+	categoryRepo.EXPECT().DeleteCategory(ctx, testId, uncategorized.Id).Return(nil)
+	filestorage.EXPECT().DeleteCategoryImageById(testId.String()).Return(nil)
+	err = usecase.DeleteCategory(ctx, testId, uuid.Nil, true)
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{item.Id}, items.updated)
+	require.Len(t, items.moved, 1)
+	require.Equal(t, *uncategorized, items.moved[0].Category)
+	// Sorted caches of the deleted category are deleted after both deletes,
+	// the sorted caches of the target category after moving the items
+	require.Equal(t, []string{deleted.Name, deleted.Name, models.UncategorizedName}, items.statsSorted)
+}
+
+func TestMergeCategories(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	categoryRepo := mocks.NewMockCategoryStore(ctrl)
+	cash := mocks.NewMockICategoriesCash(ctrl)
+	items := &categoryItems{}
+	usecase := NewCategoryUsecase(categoryRepo, cash, items, nil, zap.L())
+	source := &models.Category{Id: testId, Name: "source"}
+	target := &models.Category{Id: uuid.New(), Name: "target"}
+
+	categoryRepo.EXPECT().GetCategory(ctx, testId).Return(source, nil)
+	categoryRepo.EXPECT().GetCategory(ctx, target.Id).Return(nil, models.ErrorNotFound{})
+	err := usecase.MergeCategories(ctx, testId, target.Id)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	categoryRepo.EXPECT().GetCategory(ctx, testId).Return(&models.Category{Id: testId, Name: models.UncategorizedName}, nil)
+	categoryRepo.EXPECT().GetCategory(ctx, target.Id).Return(target, nil)
+	err = usecase.MergeCategories(ctx, testId, target.Id)
+	require.ErrorIs(t, err, models.ErrorSystemCategory{})
+
+	categoryRepo.EXPECT().GetCategory(ctx, testId).Return(source, nil)
+	categoryRepo.EXPECT().GetCategory(ctx, target.Id).Return(target, nil)
+	categoryRepo.EXPECT().MergeCategories(ctx, testId, target.Id).Return(fmt.Errorf("error"))
+	err = usecase.MergeCategories(ctx, testId, target.Id)
 	require.Error(t, err)
 
-	categoryRepo.EXPECT().DeleteCategory(ctx, testId).Return(nil)
+	// The merged category is removed from the cache of the list of categories
+	categoryRepo.EXPECT().GetCategory(ctx, testId).Return(source, nil)
+	categoryRepo.EXPECT().GetCategory(ctx, target.Id).Return(target, nil)
+	categoryRepo.EXPECT().MergeCategories(ctx, testId, target.Id).Return(nil)
 	cash.EXPECT().CheckCash(ctx, categoriesListKey).Return(true)
-	categoryRepo.EXPECT().GetCategory(ctx, testId).Return(testModelCategoryWithId, nil)
-	cash.EXPECT().GetCategoriesListCash(ctx, categoriesListKey).Return(categories, nil)
+	categoryRepo.EXPECT().GetCategory(ctx, testId).Return(nil, models.ErrorNotFound{})
+	cash.EXPECT().GetCategoriesListCash(ctx, categoriesListKey).Return([]models.Category{*testModelCategoryWithId}, nil)
 	cash.EXPECT().CreateCategoriesListCash(ctx, []models.Category{}, categoriesListKey).Return(nil)
-	err = usecase.DeleteCategory(ctx, testId)
+	cash.EXPECT().DeleteCash(ctx, gomock.Any()).Return(nil).Times(5)
+	err = usecase.MergeCategories(ctx, testId, target.Id)
 	require.NoError(t, err)
+	require.Equal(t, []string{source.Name}, items.statsSorted)
+}
 
-	categoryRepo.EXPECT().DeleteCategory(ctx, testId).Return(nil)
+func TestGetUncategorized(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	categoryRepo := mocks.NewMockCategoryStore(ctrl)
+	cash := mocks.NewMockICategoriesCash(ctrl)
+	usecase := NewCategoryUsecase(categoryRepo, cash, nil, nil, logger)
+	noCategory := &models.Category{
+		Name:        models.UncategorizedName,
+		Description: "Category for items from deleting categories",
+	}
+
+	categoryRepo.EXPECT().GetCategoryByName(ctx, models.UncategorizedName).Return(nil, fmt.Errorf("error"))
+	_, err := usecase.GetUncategorized(ctx)
+	require.Error(t, err)
+
+	categoryRepo.EXPECT().GetCategoryByName(ctx, models.UncategorizedName).Return(testModelCategoryWithId, nil)
+	res, err := usecase.GetUncategorized(ctx)
+	require.NoError(t, err)
+	require.Equal(t, testModelCategoryWithId, res)
+
+	categoryRepo.EXPECT().GetCategoryByName(ctx, models.UncategorizedName).Return(nil, models.ErrorNotFound{})
+	categoryRepo.EXPECT().CreateCategory(ctx, noCategory).Return(testId, nil)
 	cash.EXPECT().CheckCash(ctx, categoriesListKey).Return(false)
-	err = usecase.DeleteCategory(ctx, testId)
+	res, err = usecase.GetUncategorized(ctx)
 	require.NoError(t, err)
+	require.Equal(t, testId, res.Id)
+	require.Equal(t, models.UncategorizedName, res.Name)
 }
 
 func TestGetCategoryByName(t *testing.T) {
@@ -244,7 +396,7 @@ func TestGetCategoryByName(t *testing.T) {
 	logger := zap.L()
 	categoryRepo := mocks.NewMockCategoryStore(ctrl)
 	cash := mocks.NewMockICategoriesCash(ctrl)
-	usecase := NewCategoryUsecase(categoryRepo, cash, logger)
+	usecase := NewCategoryUsecase(categoryRepo, cash, nil, nil, logger)
 
 	categoryRepo.EXPECT().GetCategoryByName(ctx, testModelCategoryWithId.Name).Return(nil, fmt.Errorf("error"))
 	res, err := usecase.GetCategoryByName(ctx, testModelCategoryWithId.Name)
@@ -265,7 +417,8 @@ func TestDeleteCategoryCash(t *testing.T) {
 	logger := zap.L()
 	categoryRepo := mocks.NewMockCategoryStore(ctrl)
 	cash := mocks.NewMockICategoriesCash(ctrl)
-	usecase := NewCategoryUsecase(categoryRepo, cash, logger)
+	items := &categoryItems{}
+	usecase := NewCategoryUsecase(categoryRepo, cash, items, nil, logger)
 
 	cash.EXPECT().DeleteCash(ctx, "testNamenameasc").Return(err)
 	err := usecase.DeleteCategoryCash(ctx, "testName")
@@ -286,4 +439,6 @@ func TestDeleteCategoryCash(t *testing.T) {
 	cash.EXPECT().DeleteCash(ctx, "testNameQuantity").Return(nil)
 	err = usecase.DeleteCategoryCash(ctx, "testName")
 	require.NoError(t, err)
-}
\ No newline at end of file
+	// Caches sorted by popularity and novelty are deleted after the caches sorted by name and price
+	require.Equal(t, []string{"testName", "testName"}, items.statsSorted)
+}
@@ -0,0 +1,228 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository"
+	"OnlineShopBackend/internal/shipping"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ ICheckoutUsecase = &CheckoutUsecase{}
+
+type CheckoutUsecase struct {
+	uow        repository.UnitOfWork
+	orderStore repository.OrderStore
+	cartStore  repository.CartStore
+	// addressStore is the address book the address of the order is taken from
+	addressStore repository.AddressStore
+	// cartTTL is the time the cart lives after the last change
+	cartTTL time.Duration
+	// shipping is the rules of the shipping methods the customer chooses from
+	shipping shipping.Rules
+	// pricing is used to calculate the value of the order for free shipping thresholds
+	pricing models.CartPricing
+	logger  *zap.Logger
+}
+
+func NewCheckoutUsecase(uow repository.UnitOfWork, orderStore repository.OrderStore, cartStore repository.CartStore, addressStore repository.AddressStore,
+	cartTTL time.Duration, shipping shipping.Rules, pricing models.CartPricing, logger *zap.Logger) ICheckoutUsecase {
+	logger.Debug("Enter in usecase NewCheckoutUsecase()")
+	return &CheckoutUsecase{
+		uow:          uow,
+		orderStore:   orderStore,
+		cartStore:    cartStore,
+		addressStore: addressStore,
+		cartTTL:      cartTTL,
+		shipping:     shipping,
+		pricing:      pricing,
+		logger:       logger,
+	}
+}
+
+// shippingOf returns the weight in grams and the value of the items of the cart
+// after the discount, which are used to calculate the shipping cost
+func (usecase *CheckoutUsecase) shippingOf(cart *models.Cart) (int, int64) {
+	weight := 0
+	for _, item := range cart.Items {
+		weight += int(item.Weight) * item.Quantity
+	}
+	totals := cart.CalculateTotals(usecase.pricing)
+	return weight, totals.Subtotal - totals.Discount
+}
+
+// ShippingQuotes returns the cost and the period of delivery of the items of the cart
+// to the address by every shipping method which delivers them there
+func (usecase *CheckoutUsecase) ShippingQuotes(ctx context.Context, cart *models.Cart, address models.UserAddress) ([]shipping.Quote, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase ShippingQuotes() with args: ctx, cartId: %v, address: %v", cart.Id, address)
+	if err := checkOwner(ctx, cart.UserId); err != nil {
+		return nil, fmt.Errorf("cart %v of another user: %w", cart.Id, err)
+	}
+	weight, value := usecase.shippingOf(cart)
+	return usecase.shipping.Quotes(address, weight, value), nil
+}
+
+// OrderAddress returns the address of the order of the user: the address if it is set, the saved address
+// with addressId or the default shipping address of the user if both are not set. The saved address
+// of another user is not found even if an admin places the order
+func (usecase *CheckoutUsecase) OrderAddress(ctx context.Context, userId uuid.UUID, address *models.UserAddress, addressId uuid.UUID) (models.UserAddress, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase OrderAddress() with args: ctx, userId: %v, address: %v, addressId: %v", userId, address, addressId)
+	if err := checkOwner(ctx, userId); err != nil {
+		return models.UserAddress{}, err
+	}
+	if address != nil {
+		return *address, nil
+	}
+	if addressId != uuid.Nil {
+		saved, err := usecase.addressStore.GetAddress(ctx, addressId)
+		if err != nil {
+			return models.UserAddress{}, fmt.Errorf("error on get address: %w", err)
+		}
+		if saved.UserId != userId {
+			return models.UserAddress{}, fmt.Errorf("address %v of another user: %w", addressId, models.ErrorNotFound{})
+		}
+		return saved.Address, nil
+	}
+	addresses, err := usecase.addressStore.GetAddresses(ctx, userId)
+	if err != nil {
+		return models.UserAddress{}, fmt.Errorf("error on get addresses: %w", err)
+	}
+	for _, saved := range addresses {
+		if saved.DefaultShipping {
+			return saved.Address, nil
+		}
+	}
+	return models.UserAddress{}, fmt.Errorf("user %v: %w", userId, models.ErrorAddressNotSet{})
+}
+
+// quote returns the quote of the shipping method for the order of the items of the cart,
+// if the method is not chosen the first method delivering the order to the address is used
+func (usecase *CheckoutUsecase) quote(cart *models.Cart, address models.UserAddress, method string) (shipping.Quote, error) {
+	weight, value := usecase.shippingOf(cart)
+	if method != "" {
+		return usecase.shipping.Quote(method, address, weight, value)
+	}
+	quotes := usecase.shipping.Quotes(address, weight, value)
+	if len(quotes) == 0 {
+		return shipping.Quote{}, fmt.Errorf("no shipping method delivers the order: %w", models.ErrorShippingUnavailable{})
+	}
+	return quotes[0], nil
+}
+
+// newOrder returns the new order of the items of the cart with the discount delivered by the shipping method of the quote
+func newOrder(cart *models.Cart, user models.User, address models.UserAddress, quote shipping.Quote, discount int64) models.Order {
+	now := time.Now()
+	return models.Order{
+		User:           user,
+		Address:        address,
+		Status:         models.StatusCreated,
+		CreatedAt:      now,
+		ShipmentTime:   now.Add(quote.Period),
+		Items:          append([]models.ItemWithQuantity{}[:0:0], cart.Items...),
+		ShippingMethod: quote.Method,
+		ShippingCost:   quote.Cost,
+		Discount:       discount,
+	}
+}
+
+// orderedItems returns the lines of the cart with the items of the order. Quantities, prices and weights
+// are taken from the cart, items saved for later are not ordered even if they are requested
+// and items which are not in the cart are rejected
+func orderedItems(cart *models.Cart, itemIds []uuid.UUID) ([]models.ItemWithQuantity, error) {
+	requested := make(map[uuid.UUID]struct{}, len(itemIds))
+	for _, id := range itemIds {
+		requested[id] = struct{}{}
+	}
+	for _, item := range cart.SavedItems {
+		delete(requested, item.Id)
+	}
+	items := make([]models.ItemWithQuantity, 0, len(requested))
+	for _, item := range cart.Items {
+		if _, ok := requested[item.Id]; ok {
+			items = append(items, item)
+			delete(requested, item.Id)
+		}
+	}
+	for id := range requested {
+		return nil, fmt.Errorf("item %v is not in cart %v: %w", id, cart.Id, models.ErrorNotInCart{})
+	}
+	return items, nil
+}
+
+// Checkout places the order of the items of the cart, clears the cart and returns the order
+// with id of the cart of the user after the checkout. Items saved for later stay in the cart,
+// which is kept as the cart of the user or replaced by the new cart if it has expired.
+// The shipping cost of the chosen method and the discount are saved with the order and the period of the method
+// sets the shipment time. The cart is locked and read in the transaction of the checkout, so the lines
+// added to the cart meanwhile are not cleared without being ordered. All changes are made in one transaction,
+// so either all of them are saved or none
+func (usecase *CheckoutUsecase) Checkout(ctx context.Context, cartId uuid.UUID, itemIds []uuid.UUID, user models.User, address models.UserAddress, method string) (*models.Order, uuid.UUID, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase Checkout() with args: ctx, cartId: %v, itemIds: %v, userId: %v, address: %v, method: %s",
+		cartId, itemIds, user.ID, address, method)
+	if err := checkOwner(ctx, user.ID); err != nil {
+		return nil, uuid.Nil, err
+	}
+	var order *models.Order
+	newCartId := cartId
+	err := usecase.uow.Do(ctx, func(ctx context.Context) error {
+		if err := usecase.cartStore.LockCart(ctx, cartId); err != nil {
+			return fmt.Errorf("error on lock cart: %w", err)
+		}
+		stored, err := usecase.cartStore.GetCart(ctx, cartId)
+		if err != nil {
+			return fmt.Errorf("error on get cart: %w", err)
+		}
+		// The cart of another user is not found as well as the order of another user
+		if stored.UserId != user.ID {
+			return fmt.Errorf("cart %v of another user: %w", cartId, models.ErrorNotFound{})
+		}
+		items, err := orderedItems(stored, itemIds)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return fmt.Errorf("no items to order in cart %v: %w", cartId, models.ErrorEmptyCart{})
+		}
+		// The negative quantity would put the stock back instead of reserving it
+		for _, item := range items {
+			if item.Quantity < 1 {
+				return fmt.Errorf("quantity of item %v must be positive: %w", item.Id, models.ErrorQuantityExceeded{})
+			}
+		}
+		cart := &models.Cart{Id: cartId, UserId: stored.UserId, Items: items}
+		quote, err := usecase.quote(cart, address, method)
+		if err != nil {
+			return fmt.Errorf("error on shipping: %w", err)
+		}
+		ordr := newOrder(cart, user, address, quote, cart.CalculateTotals(usecase.pricing).Discount)
+		order, err = usecase.orderStore.Create(ctx, &ordr)
+		if err != nil {
+			return fmt.Errorf("error on create order: %w", err)
+		}
+		if err := usecase.cartStore.ClearCart(ctx, cartId, order.ID); err != nil {
+			return fmt.Errorf("error on clear cart: %w", err)
+		}
+		err = usecase.cartStore.ExtendCart(ctx, cartId, usecase.cartTTL)
+		if errors.Is(err, models.ErrorNotFound{}) {
+			newCartId, err = usecase.cartStore.Create(ctx, user.ID)
+			if err != nil {
+				return fmt.Errorf("error on create cart: %w", err)
+			}
+			err = usecase.cartStore.ExtendCart(ctx, newCartId, usecase.cartTTL)
+		}
+		if err != nil {
+			return fmt.Errorf("error on extend cart: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, uuid.Nil, fmt.Errorf("error on checkout: %w", err)
+	}
+	usecase.logger.Sugar().Infof("Order %v placed from cart %v", order.ID, cartId)
+	return order, newCartId, nil
+}
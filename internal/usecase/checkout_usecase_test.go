@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/mocks"
+	"OnlineShopBackend/internal/shipping"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCheckout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	orderRepo := mocks.NewMockOrderStore(ctrl)
+	cartRepo := mocks.NewMockCartStore(ctrl)
+	usecase := NewCheckoutUsecase(uow, orderRepo, cartRepo, nil, testCartTTL, shipping.Default(300, 5000), models.CartPricing{}, logger)
+	ctx := models.ContextWithActor(context.Background(), testActor)
+	user := models.User{ID: testId, Email: "user@mail.ru"}
+	savedId := uuid.New()
+	cart := &models.Cart{
+		Id:         testId,
+		UserId:     testId,
+		Items:      []models.ItemWithQuantity{{Item: models.Item{Id: testId, Price: 100}, Quantity: 2}},
+		SavedItems: []models.ItemWithQuantity{{Item: models.Item{Id: savedId, Price: 100}, Quantity: 1}},
+	}
+	itemIds := []uuid.UUID{testId}
+	orderId, newCartId := uuid.New(), uuid.New()
+	// The unit of work calls the function as the transaction and returns its error
+	uow.EXPECT().Do(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	}).AnyTimes()
+	stored := func(cart *models.Cart) {
+		cartRepo.EXPECT().LockCart(ctx, cart.Id).Return(nil)
+		cartRepo.EXPECT().GetCart(ctx, cart.Id).Return(cart, nil)
+	}
+	created := func(ctx context.Context, order *models.Order) (*models.Order, error) {
+		order.ID = orderId
+		return order, nil
+	}
+
+	cartRepo.EXPECT().LockCart(ctx, testId).Return(models.ErrorNotFound{})
+	_, _, err := usecase.Checkout(ctx, testId, itemIds, user, testUser.Address, "")
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	// Carts of other users and empty carts are not ordered
+	stored(&models.Cart{Id: testId, UserId: uuid.New(), Items: cart.Items})
+	_, _, err = usecase.Checkout(ctx, testId, itemIds, user, testUser.Address, "")
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	_, _, err = usecase.Checkout(ctx, testId, itemIds, models.User{ID: uuid.New()}, testUser.Address, "")
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	stored(&models.Cart{Id: testId, UserId: testId})
+	_, _, err = usecase.Checkout(ctx, testId, nil, user, testUser.Address, "")
+	require.ErrorIs(t, err, models.ErrorEmptyCart{})
+
+	// Items saved for later are not ordered, items which are not in the cart are rejected
+	stored(cart)
+	_, _, err = usecase.Checkout(ctx, testId, []uuid.UUID{savedId}, user, testUser.Address, "")
+	require.ErrorIs(t, err, models.ErrorEmptyCart{})
+
+	stored(cart)
+	_, _, err = usecase.Checkout(ctx, testId, []uuid.UUID{testId, uuid.New()}, user, testUser.Address, "")
+	require.ErrorIs(t, err, models.ErrorNotInCart{})
+
+	// Lines with quantities below one are not ordered
+	negative := []models.ItemWithQuantity{{Item: models.Item{Id: testId, Price: 100}, Quantity: -5}}
+	stored(&models.Cart{Id: testId, UserId: testId, Items: negative})
+	_, _, err = usecase.Checkout(ctx, testId, itemIds, user, testUser.Address, "")
+	require.ErrorIs(t, err, models.ErrorQuantityExceeded{})
+
+	stored(cart)
+	orderRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil, fmt.Errorf("error"))
+	res, cartId, err := usecase.Checkout(ctx, testId, itemIds, user, testUser.Address, "")
+	require.Error(t, err)
+	require.Nil(t, res)
+	require.Equal(t, uuid.Nil, cartId)
+
+	// The order is not placed if the cart is not cleared
+	stored(cart)
+	orderRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(created)
+	cartRepo.EXPECT().ClearCart(ctx, testId, orderId).Return(fmt.Errorf("error"))
+	_, _, err = usecase.Checkout(ctx, testId, itemIds, user, testUser.Address, "")
+	require.Error(t, err)
+
+	stored(cart)
+	orderRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(created)
+	cartRepo.EXPECT().ClearCart(ctx, testId, orderId).Return(nil)
+	cartRepo.EXPECT().ExtendCart(ctx, testId, testCartTTL).Return(fmt.Errorf("error"))
+	_, _, err = usecase.Checkout(ctx, testId, itemIds, user, testUser.Address, "")
+	require.Error(t, err)
+
+	stored(cart)
+	orderRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(created)
+	cartRepo.EXPECT().ClearCart(ctx, testId, orderId).Return(nil)
+	cartRepo.EXPECT().ExtendCart(ctx, testId, testCartTTL).Return(nil)
+	res, cartId, err = usecase.Checkout(ctx, testId, []uuid.UUID{testId, savedId}, user, testUser.Address, "")
+	require.NoError(t, err)
+	require.Equal(t, orderId, res.ID)
+	require.Equal(t, models.StatusCreated, res.Status)
+	require.Equal(t, cart.Items, res.Items)
+	require.Equal(t, testId, cartId)
+
+	// The new cart is created if the cart has expired
+	stored(cart)
+	orderRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(created)
+	cartRepo.EXPECT().ClearCart(ctx, testId, orderId).Return(nil)
+	cartRepo.EXPECT().ExtendCart(ctx, testId, testCartTTL).Return(models.ErrorNotFound{})
+	cartRepo.EXPECT().Create(ctx, testId).Return(newCartId, nil)
+	cartRepo.EXPECT().ExtendCart(ctx, newCartId, testCartTTL).Return(nil)
+	res, cartId, err = usecase.Checkout(ctx, testId, itemIds, user, testUser.Address, "")
+	require.NoError(t, err)
+	require.Equal(t, orderId, res.ID)
+	require.Equal(t, newCartId, cartId)
+}
+
+func TestCheckoutShipping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	orderRepo := mocks.NewMockOrderStore(ctrl)
+	cartRepo := mocks.NewMockCartStore(ctrl)
+	pricing := models.CartPricing{DiscountPercent: 10}
+	usecase := NewCheckoutUsecase(uow, orderRepo, cartRepo, nil, testCartTTL, shipping.Default(300, 5000), pricing, zap.L())
+	ctx := models.ContextWithActor(context.Background(), testActor)
+	user := models.User{ID: testId}
+	home := models.UserAddress{Zipcode: "101000", Country: "Russia", City: "Moscow", Street: "Tverskaya 1"}
+	cart := func(price int32, weight int32) *models.Cart {
+		return &models.Cart{
+			Id:     testId,
+			UserId: testId,
+			Items:  []models.ItemWithQuantity{{Item: models.Item{Id: testId, Price: price, Weight: weight}, Quantity: 2}},
+		}
+	}
+	// checkout places the order of the cart with the price and the weight of its item
+	checkout := func(price int32, weight int32, address models.UserAddress, method string) (*models.Order, error) {
+		cartRepo.EXPECT().GetCart(ctx, testId).Return(cart(price, weight), nil)
+		order, _, err := usecase.Checkout(ctx, testId, []uuid.UUID{testId}, user, address, method)
+		return order, err
+	}
+	uow.EXPECT().Do(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	}).AnyTimes()
+	var placed *models.Order
+	orderRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, order *models.Order) (*models.Order, error) {
+		placed = order
+		return order, nil
+	}).AnyTimes()
+	cartRepo.EXPECT().LockCart(ctx, testId).Return(nil).AnyTimes()
+	cartRepo.EXPECT().ClearCart(ctx, testId, gomock.Any()).Return(nil).AnyTimes()
+	cartRepo.EXPECT().ExtendCart(ctx, testId, testCartTTL).Return(nil).AnyTimes()
+
+	// The period of the method sets the shipment time
+	order, err := checkout(1000, 500, home, shipping.Courier)
+	require.NoError(t, err)
+	require.Equal(t, shipping.Courier, placed.ShippingMethod)
+	require.Equal(t, int64(300), order.ShippingCost)
+	require.Equal(t, int64(200), order.Discount)
+	require.WithinDuration(t, time.Now().Add(models.StandardShipmentPeriod), order.ShipmentTime, time.Minute)
+
+	// The shipping is free from the threshold of the value after the discount
+	order, err = checkout(2800, 500, home, shipping.Courier)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), order.ShippingCost)
+	order, err = checkout(2700, 500, home, shipping.Courier)
+	require.NoError(t, err)
+	require.Equal(t, int64(300), order.ShippingCost)
+
+	order, err = checkout(1000, 500, home, shipping.Post)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(models.ProlongedShipmentPeriod), order.ShipmentTime, time.Minute)
+
+	// The courier doesn't deliver heavy orders and orders abroad, the post does
+	_, err = checkout(1000, 15000, home, shipping.Courier)
+	require.ErrorIs(t, err, models.ErrorShippingUnavailable{})
+	_, err = checkout(1000, 500, testUser.Address, shipping.Courier)
+	require.ErrorIs(t, err, models.ErrorShippingUnavailable{})
+	_, err = checkout(1000, 500, home, "drone")
+	require.ErrorIs(t, err, models.ErrorShippingUnavailable{})
+	order, err = checkout(1000, 500, testUser.Address, "")
+	require.NoError(t, err)
+	require.Equal(t, shipping.Post, order.ShippingMethod)
+	require.Equal(t, int64(1200), order.ShippingCost)
+
+	quotes, err := usecase.ShippingQuotes(ctx, cart(1000, 500), home)
+	require.NoError(t, err)
+	require.Len(t, quotes, 3)
+	require.Equal(t, shipping.Pickup, quotes[1].Method)
+	require.Zero(t, quotes[1].Cost)
+	_, err = usecase.ShippingQuotes(ctx, &models.Cart{Id: testId, UserId: uuid.New()}, home)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+}
+
+func TestOrderAddress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	addressRepo := mocks.NewMockAddressStore(ctrl)
+	usecase := NewCheckoutUsecase(nil, nil, nil, addressRepo, testCartTTL, shipping.Default(300, 5000), models.CartPricing{}, zap.L())
+	ctx := models.ContextWithActor(context.Background(), testActor)
+	userId := testActor.UserId
+	addressId := uuid.New()
+	home := models.SavedAddress{Id: addressId, UserId: userId, Name: "home", Address: models.UserAddress{City: "Moscow"}}
+
+	_, err := usecase.OrderAddress(ctx, uuid.New(), nil, uuid.Nil)
+	require.True(t, errors.Is(err, models.ErrorNotFound{}))
+
+	// The address of the request is used as is
+	address := models.UserAddress{City: "Saint Petersburg"}
+	res, err := usecase.OrderAddress(ctx, userId, &address, addressId)
+	require.NoError(t, err)
+	require.Equal(t, address, res)
+
+	// The saved address of another user is not found even for an admin
+	another := home
+	another.UserId = uuid.New()
+	addressRepo.EXPECT().GetAddress(testAdminCtx, addressId).Return(&another, nil)
+	_, err = usecase.OrderAddress(testAdminCtx, userId, nil, addressId)
+	require.True(t, errors.Is(err, models.ErrorNotFound{}))
+
+	addressRepo.EXPECT().GetAddress(ctx, addressId).Return(&home, nil)
+	res, err = usecase.OrderAddress(ctx, userId, nil, addressId)
+	require.NoError(t, err)
+	require.Equal(t, home.Address, res)
+
+	addressRepo.EXPECT().GetAddresses(ctx, userId).Return([]models.SavedAddress{home}, nil)
+	_, err = usecase.OrderAddress(ctx, userId, nil, uuid.Nil)
+	require.True(t, errors.Is(err, models.ErrorAddressNotSet{}))
+
+	work := home
+	work.Address.City = "Kazan"
+	work.DefaultShipping = true
+	addressRepo.EXPECT().GetAddresses(ctx, userId).Return([]models.SavedAddress{work, home}, nil)
+	res, err = usecase.OrderAddress(ctx, userId, nil, uuid.Nil)
+	require.NoError(t, err)
+	require.Equal(t, "Kazan", res.City)
+
+	addressRepo.EXPECT().GetAddresses(ctx, userId).Return(nil, fmt.Errorf("error"))
+	_, err = usecase.OrderAddress(ctx, userId, nil, uuid.Nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/feed"
+	"OnlineShopBackend/internal/filestorage"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var _ IFeedUsecase = &FeedUsecase{}
+
+// feedRegenerationDelay is a time for collecting changes of catalog,
+// for example creating item and uploading its images, before regeneration of feeds
+const feedRegenerationDelay = 5 * time.Second
+
+type FeedUsecase struct {
+	itemStore     repository.ItemStore
+	categoryStore repository.CategoryStore
+	catalogStore  repository.CatalogStore
+	filestorage   filestorage.FileStorager
+	shop          feed.Shop
+	logger        *zap.Logger
+}
+
+func NewFeedUsecase(
+	itemStore repository.ItemStore,
+	categoryStore repository.CategoryStore,
+	catalogStore repository.CatalogStore,
+	fs filestorage.FileStorager,
+	shop feed.Shop,
+	logger *zap.Logger,
+) IFeedUsecase {
+	logger.Debug("Enter in usecase NewFeedUsecase()")
+	return &FeedUsecase{
+		itemStore:     itemStore,
+		categoryStore: categoryStore,
+		catalogStore:  catalogStore,
+		filestorage:   fs,
+		shop:          shop,
+		logger:        logger,
+	}
+}
+
+// Generate builds sitemap and product feeds from the current catalog and saves them in filestorage
+func (usecase *FeedUsecase) Generate(ctx context.Context) error {
+	usecase.logger.Debug("Enter in usecase Generate() with args: ctx")
+	categoryChan, err := usecase.categoryStore.GetCategoryList(ctx)
+	if err != nil {
+		return fmt.Errorf("error on get category list: %w", err)
+	}
+	categories := make([]models.Category, 0, 10)
+	for category := range categoryChan {
+		categories = append(categories, category)
+	}
+	itemChan, err := usecase.itemStore.ItemsList(ctx)
+	if err != nil {
+		return fmt.Errorf("error on get items list: %w", err)
+	}
+	items := make([]models.Item, 0, 100)
+	for item := range itemChan {
+		items = append(items, item)
+	}
+	// Stable order makes feeds change only when the catalog changes
+	sort.Slice(categories, func(i, j int) bool { return categories[i].Name < categories[j].Name })
+	sort.Slice(items, func(i, j int) bool { return items[i].Id.String() < items[j].Id.String() })
+
+	sitemap, err := feed.Sitemap(usecase.shop, categories, items)
+	if err != nil {
+		return fmt.Errorf("error on build sitemap: %w", err)
+	}
+	merchant, err := feed.GoogleMerchant(usecase.shop, items)
+	if err != nil {
+		return fmt.Errorf("error on build google merchant feed: %w", err)
+	}
+	yml, err := feed.YandexMarket(usecase.shop, categories, items, time.Now())
+	if err != nil {
+		return fmt.Errorf("error on build yandex market feed: %w", err)
+	}
+	feeds := map[string][]byte{
+		feed.SitemapFile:        sitemap,
+		feed.GoogleMerchantFile: merchant,
+		feed.YandexMarketFile:   yml,
+	}
+	for name, data := range feeds {
+		if err := usecase.filestorage.PutFeed(name, data); err != nil {
+			return fmt.Errorf("error on put feed %s: %w", name, err)
+		}
+	}
+	usecase.logger.Sugar().Infof("Feeds generated for %d categories and %d items", len(categories), len(items))
+	return nil
+}
+
+// Run generates feeds on start and regenerates them after every change
+// of catalog until ctx is done
+func (usecase *FeedUsecase) Run(ctx context.Context) {
+	usecase.logger.Debug("Enter in usecase feed Run() with args: ctx")
+	if err := usecase.Generate(ctx); err != nil {
+		usecase.logger.Error(err.Error())
+	}
+	changes, err := usecase.catalogStore.ListenCatalogChanges(ctx)
+	if err != nil {
+		usecase.logger.Sugar().Errorf("can't listen catalog changes, feeds will not be regenerated: %s", err)
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-changes:
+			if !ok {
+				return
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(feedRegenerationDelay):
+		}
+		// Changes received during the delay are already included
+		select {
+		case <-changes:
+		default:
+		}
+		if err := usecase.Generate(ctx); err != nil {
+			usecase.logger.Error(err.Error())
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/feed"
+	fs "OnlineShopBackend/internal/filestorage/mocks"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/mocks"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var testShop = feed.Shop{
+	Name:     "Shop",
+	Company:  "Company",
+	SiteURL:  "http://localhost:3000",
+	Currency: "RUB",
+}
+
+func TestGenerateFeeds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	itemRepo := mocks.NewMockItemStore(ctrl)
+	categoryRepo := mocks.NewMockCategoryStore(ctrl)
+	catalogRepo := mocks.NewMockCatalogStore(ctrl)
+	filestorage := fs.NewMockFileStorager(ctrl)
+	usecase := NewFeedUsecase(itemRepo, categoryRepo, catalogRepo, filestorage, testShop, logger)
+
+	categoryRepo.EXPECT().GetCategoryList(ctx).Return(nil, fmt.Errorf("error"))
+	err := usecase.Generate(ctx)
+	require.Error(t, err)
+
+	categoryChan := make(chan models.Category, 1)
+	categoryChan <- *testModelCategoryWithId
+	close(categoryChan)
+	itemChan := make(chan models.Item, 1)
+	itemChan <- models.Item{Id: testId, Title: "test", Category: *testModelCategoryWithId}
+	close(itemChan)
+	categoryRepo.EXPECT().GetCategoryList(ctx).Return(categoryChan, nil)
+	itemRepo.EXPECT().ItemsList(ctx).Return(itemChan, nil)
+	filestorage.EXPECT().PutFeed(feed.SitemapFile, gomock.Any()).Return(nil)
+	filestorage.EXPECT().PutFeed(feed.GoogleMerchantFile, gomock.Any()).Return(nil)
+	filestorage.EXPECT().PutFeed(feed.YandexMarketFile, gomock.Any()).Return(nil)
+	err = usecase.Generate(ctx)
+	require.NoError(t, err)
+}
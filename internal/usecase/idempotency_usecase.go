@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/cash"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ IIdempotencyUsecase = &IdempotencyUsecase{}
+
+type IdempotencyUsecase struct {
+	idempotencyCash cash.IIdempotencyCash
+	// ttl is the time the response to the request is replayed on retries
+	ttl time.Duration
+	// lockTTL is the time the request is considered in progress, so the key
+	// is released if the service stopped before the response was saved
+	lockTTL time.Duration
+	logger  *zap.Logger
+}
+
+func NewIdempotencyUsecase(idempotencyCash cash.IIdempotencyCash, ttl time.Duration, lockTTL time.Duration, logger *zap.Logger) IIdempotencyUsecase {
+	logger.Debug("Enter in usecase NewIdempotencyUsecase()")
+	return &IdempotencyUsecase{
+		idempotencyCash: idempotencyCash,
+		ttl:             ttl,
+		lockTTL:         lockTTL,
+		logger:          logger,
+	}
+}
+
+// Begin starts the request of the user with the idempotency key. If it is the first request
+// with the key, Begin returns nil and the request should be handled. If the request with the key
+// was already handled, Begin returns it with the response to replay. If the key was used with
+// another request or the request with the key is still in progress, Begin returns the error
+func (usecase *IdempotencyUsecase) Begin(ctx context.Context, userId uuid.UUID, key string, requestHash string) (*models.IdempotentRequest, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase Begin() with args: ctx, userId: %v, key: %s, requestHash: %s", userId, key, requestHash)
+	request := &models.IdempotentRequest{
+		UserId:      userId,
+		Key:         key,
+		RequestHash: requestHash,
+	}
+	// The saved request may expire between the calls, so creation is tried once more
+	for i := 0; i < 2; i++ {
+		created, err := usecase.idempotencyCash.CreateIdempotentRequest(ctx, request, usecase.lockTTL)
+		if err != nil {
+			return nil, fmt.Errorf("error on begin idempotent request: %w", err)
+		}
+		if created {
+			return nil, nil
+		}
+		saved, err := usecase.idempotencyCash.GetIdempotentRequest(ctx, userId, key)
+		if errors.Is(err, models.ErrorNotFound{}) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error on begin idempotent request: %w", err)
+		}
+		if saved.RequestHash != requestHash {
+			return nil, models.ErrorIdempotencyKeyReused{}
+		}
+		if !saved.Done {
+			return nil, models.ErrorRequestInProgress{}
+		}
+		usecase.logger.Sugar().Infof("Replay response to request of user %v with idempotency key %s", userId, key)
+		return saved, nil
+	}
+	return nil, models.ErrorRequestInProgress{}
+}
+
+// Complete saves the response to the request begun by Begin to replay it on retries
+func (usecase *IdempotencyUsecase) Complete(ctx context.Context, request *models.IdempotentRequest) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase Complete() with args: ctx, userId: %v, key: %s, statusCode: %d", request.UserId, request.Key, request.StatusCode)
+	request.Done = true
+	err := usecase.idempotencyCash.SaveIdempotentRequest(ctx, request, usecase.ttl)
+	if err != nil {
+		return fmt.Errorf("error on complete idempotent request: %w", err)
+	}
+	return nil
+}
+
+// Release deletes the request begun by Begin without the response,
+// so the request with the same idempotency key can be made again
+func (usecase *IdempotencyUsecase) Release(ctx context.Context, userId uuid.UUID, key string) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase Release() with args: ctx, userId: %v, key: %s", userId, key)
+	err := usecase.idempotencyCash.DeleteIdempotentRequest(ctx, userId, key)
+	if err != nil {
+		return fmt.Errorf("error on release idempotent request: %w", err)
+	}
+	return nil
+}
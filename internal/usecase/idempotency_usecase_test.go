@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/mocks"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestIdempotencyBegin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	idempotencyCash := mocks.NewMockIIdempotencyCash(ctrl)
+	usecase := NewIdempotencyUsecase(idempotencyCash, time.Hour, time.Minute, logger)
+	ctx := context.Background()
+	userId := uuid.New()
+	request := &models.IdempotentRequest{UserId: userId, Key: "key", RequestHash: "hash"}
+
+	idempotencyCash.EXPECT().CreateIdempotentRequest(ctx, request, time.Minute).Return(false, fmt.Errorf("error"))
+	res, err := usecase.Begin(ctx, userId, "key", "hash")
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	idempotencyCash.EXPECT().CreateIdempotentRequest(ctx, request, time.Minute).Return(true, nil)
+	res, err = usecase.Begin(ctx, userId, "key", "hash")
+	require.NoError(t, err)
+	require.Nil(t, res)
+
+	idempotencyCash.EXPECT().CreateIdempotentRequest(ctx, request, time.Minute).Return(false, nil)
+	idempotencyCash.EXPECT().GetIdempotentRequest(ctx, userId, "key").Return(&models.IdempotentRequest{RequestHash: "other"}, nil)
+	res, err = usecase.Begin(ctx, userId, "key", "hash")
+	require.ErrorIs(t, err, models.ErrorIdempotencyKeyReused{})
+	require.Nil(t, res)
+
+	idempotencyCash.EXPECT().CreateIdempotentRequest(ctx, request, time.Minute).Return(false, nil)
+	idempotencyCash.EXPECT().GetIdempotentRequest(ctx, userId, "key").Return(&models.IdempotentRequest{RequestHash: "hash"}, nil)
+	res, err = usecase.Begin(ctx, userId, "key", "hash")
+	require.ErrorIs(t, err, models.ErrorRequestInProgress{})
+	require.Nil(t, res)
+
+	saved := &models.IdempotentRequest{RequestHash: "hash", Done: true, StatusCode: 201, Body: []byte("{}")}
+	idempotencyCash.EXPECT().CreateIdempotentRequest(ctx, request, time.Minute).Return(false, nil)
+	idempotencyCash.EXPECT().GetIdempotentRequest(ctx, userId, "key").Return(saved, nil)
+	res, err = usecase.Begin(ctx, userId, "key", "hash")
+	require.NoError(t, err)
+	require.Equal(t, saved, res)
+
+	// The saved request expired between the calls
+	gomock.InOrder(
+		idempotencyCash.EXPECT().CreateIdempotentRequest(ctx, request, time.Minute).Return(false, nil),
+		idempotencyCash.EXPECT().GetIdempotentRequest(ctx, userId, "key").Return(nil, models.ErrorNotFound{}),
+		idempotencyCash.EXPECT().CreateIdempotentRequest(ctx, request, time.Minute).Return(true, nil),
+	)
+	res, err = usecase.Begin(ctx, userId, "key", "hash")
+	require.NoError(t, err)
+	require.Nil(t, res)
+}
+
+func TestIdempotencyCompleteAndRelease(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	idempotencyCash := mocks.NewMockIIdempotencyCash(ctrl)
+	usecase := NewIdempotencyUsecase(idempotencyCash, time.Hour, time.Minute, logger)
+	ctx := context.Background()
+	userId := uuid.New()
+
+	request := &models.IdempotentRequest{UserId: userId, Key: "key", RequestHash: "hash", StatusCode: 201}
+	idempotencyCash.EXPECT().SaveIdempotentRequest(ctx, request, time.Hour).Return(fmt.Errorf("error"))
+	require.Error(t, usecase.Complete(ctx, request))
+
+	idempotencyCash.EXPECT().SaveIdempotentRequest(ctx, request, time.Hour).Return(nil)
+	require.NoError(t, usecase.Complete(ctx, request))
+	require.True(t, request.Done)
+
+	idempotencyCash.EXPECT().DeleteIdempotentRequest(ctx, userId, "key").Return(fmt.Errorf("error"))
+	require.Error(t, usecase.Release(ctx, userId, "key"))
+
+	idempotencyCash.EXPECT().DeleteIdempotentRequest(ctx, userId, "key").Return(nil)
+	require.NoError(t, usecase.Release(ctx, userId, "key"))
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/filestorage"
+	"OnlineShopBackend/internal/invoice"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ IInvoiceUsecase = &InvoiceUsecase{}
+
+type InvoiceUsecase struct {
+	orderStore   repository.OrderStore
+	invoiceStore repository.InvoiceStore
+	// filestorage keeps rendered invoices, so the invoice is rendered again only when the order changes
+	filestorage filestorage.FileStorager
+	shop        invoice.Shop
+	// pricing is used to calculate the sums of the order as in the cart
+	pricing  models.CartPricing
+	currency string
+	// prefix is printed before the number of the invoice
+	prefix string
+	// taxRate is the percent of VAT included in prices
+	taxRate int64
+	logger  *zap.Logger
+}
+
+func NewInvoiceUsecase(orderStore repository.OrderStore, invoiceStore repository.InvoiceStore, filestorage filestorage.FileStorager,
+	shop invoice.Shop, pricing models.CartPricing, currency string, prefix string, taxRate int64, logger *zap.Logger) IInvoiceUsecase {
+	logger.Debug("Enter in usecase NewInvoiceUsecase()")
+	return &InvoiceUsecase{
+		orderStore:   orderStore,
+		invoiceStore: invoiceStore,
+		filestorage:  filestorage,
+		shop:         shop,
+		pricing:      pricing,
+		currency:     currency,
+		prefix:       prefix,
+		taxRate:      taxRate,
+		logger:       logger,
+	}
+}
+
+// GetInvoice returns the number of the invoice of the order and the path to its PDF file.
+// The invoice gets the next number when it is requested first time. The rendered file is
+// cached by the fingerprint of its data, so the invoice is rendered again after the order is changed
+func (usecase *InvoiceUsecase) GetInvoice(ctx context.Context, orderId uuid.UUID) (string, string, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase GetInvoice() with args: ctx, orderId: %v", orderId)
+	order, err := usecase.orderStore.GetOrderByID(ctx, orderId)
+	if err != nil {
+		return "", "", fmt.Errorf("error on get order: %w", err)
+	}
+	if err := checkOwner(ctx, order.User.ID); err != nil {
+		return "", "", fmt.Errorf("order %v of another user: %w", orderId, err)
+	}
+	record, err := usecase.invoiceStore.GetOrCreateInvoice(ctx, orderId)
+	if err != nil {
+		return "", "", fmt.Errorf("error on get invoice: %w", err)
+	}
+	data := usecase.invoiceData(&order, record)
+	version := data.Fingerprint()
+	if path, err := usecase.filestorage.GetInvoicePath(orderId.String(), version); err == nil {
+		usecase.logger.Sugar().Debugf("Invoice %s of order %v is taken from the cache", data.Number, orderId)
+		return data.Number, path, nil
+	}
+	err = usecase.filestorage.PutInvoice(orderId.String(), version, invoice.Render(data))
+	if err != nil {
+		return "", "", fmt.Errorf("error on put invoice: %w", err)
+	}
+	path, err := usecase.filestorage.GetInvoicePath(orderId.String(), version)
+	if err != nil {
+		return "", "", fmt.Errorf("error on get invoice path: %w", err)
+	}
+	usecase.logger.Sugar().Infof("Invoice %s of order %v is rendered", data.Number, orderId)
+	return data.Number, path, nil
+}
+
+// invoiceData returns the data printed on the invoice of the order
+func (usecase *InvoiceUsecase) invoiceData(order *models.Order, record *models.Invoice) invoice.Invoice {
+	totals := order.Totals(usecase.pricing)
+	lines := make([]invoice.Line, 0, len(order.Items))
+	for _, item := range order.Items {
+		lines = append(lines, invoice.Line{
+			Title:    item.Title,
+			Quantity: item.Quantity,
+			Price:    int64(item.Price),
+			Amount:   totals.LineTotals[item.Id],
+		})
+	}
+	customer := strings.TrimSpace(order.User.Firstname + " " + order.User.Lastname)
+	if order.User.Email != "" {
+		if customer != "" {
+			customer += ", "
+		}
+		customer += order.User.Email
+	}
+	address := make([]string, 0, 7)
+	for _, part := range []string{order.Address.Zipcode, order.Address.Country, order.Address.City, order.Address.Street,
+		order.Address.Apartment, order.Address.Recipient, order.Address.Phone} {
+		if part != "" {
+			address = append(address, part)
+		}
+	}
+	return invoice.Invoice{
+		Number:   fmt.Sprintf("%s%06d", usecase.prefix, record.Number),
+		IssuedAt: record.IssuedAt.UTC(),
+		OrderId:  order.ID.String(),
+		Status:   string(order.Status),
+		Shop:     usecase.shop,
+		Customer: customer,
+		Address:  strings.Join(address, ", "),
+		Lines:    lines,
+		Subtotal: totals.Subtotal,
+		Discount: totals.Discount,
+		Shipping: totals.Shipping,
+		Total:    totals.Total,
+		TaxRate:  usecase.taxRate,
+		Tax:      invoice.IncludedTax(totals.Total, usecase.taxRate),
+		Currency: usecase.currency,
+	}
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/filestorage"
+	"OnlineShopBackend/internal/invoice"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/mocks"
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestGetInvoice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	orderRepo := mocks.NewMockOrderStore(ctrl)
+	invoiceRepo := mocks.NewMockInvoiceStore(ctrl)
+	filesPath, invoicesPath := t.TempDir(), t.TempDir()
+	storage := filestorage.NewOnDiskLocalStorage("", filesPath, invoicesPath, zap.L())
+	shop := invoice.Shop{Name: "Online Shop", Company: "GBteammates"}
+	pricing := models.CartPricing{ShippingCost: 300, FreeShippingThreshold: 5000}
+	usecase := NewInvoiceUsecase(orderRepo, invoiceRepo, storage, shop, pricing, "RUB", "INV-", 20, zap.L())
+	ctx := models.ContextWithActor(context.Background(), testActor)
+	orderId := uuid.New()
+	order := models.Order{
+		ID:     orderId,
+		User:   models.User{ID: testId, Firstname: "Ivan", Email: "ivan@example.com"},
+		Status: models.StatusCreated,
+		Items: []models.ItemWithQuantity{
+			{Item: models.Item{Id: uuid.New(), Title: "Lamp", Price: 1200}, Quantity: 2},
+		},
+	}
+	record := &models.Invoice{OrderId: orderId, Number: 7, IssuedAt: time.Date(2023, 1, 10, 12, 0, 0, 0, time.UTC)}
+
+	orderRepo.EXPECT().GetOrderByID(ctx, orderId).Return(models.Order{ID: orderId, User: models.User{ID: uuid.New()}}, nil)
+	_, _, err := usecase.GetInvoice(ctx, orderId)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	orderRepo.EXPECT().GetOrderByID(ctx, orderId).Return(order, nil)
+	invoiceRepo.EXPECT().GetOrCreateInvoice(ctx, orderId).Return(record, nil)
+	number, path, err := usecase.GetInvoice(ctx, orderId)
+	require.NoError(t, err)
+	require.Equal(t, "INV-000007", number)
+	// Invoices are not stored with public files
+	require.True(t, strings.HasPrefix(path, invoicesPath))
+	file, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(file, []byte("%PDF-")))
+	require.Contains(t, string(file), "2 700.00")
+
+	// The unchanged order is served from the cache
+	orderRepo.EXPECT().GetOrderByID(ctx, orderId).Return(order, nil)
+	invoiceRepo.EXPECT().GetOrCreateInvoice(ctx, orderId).Return(record, nil)
+	_, cached, err := usecase.GetInvoice(ctx, orderId)
+	require.NoError(t, err)
+	require.Equal(t, path, cached)
+
+	// The changed order is rendered again and the old file is removed
+	order.Status = models.StatusPaid
+	orderRepo.EXPECT().GetOrderByID(ctx, orderId).Return(order, nil)
+	invoiceRepo.EXPECT().GetOrCreateInvoice(ctx, orderId).Return(record, nil)
+	_, changed, err := usecase.GetInvoice(ctx, orderId)
+	require.NoError(t, err)
+	require.NotEqual(t, path, changed)
+	_, err = os.Stat(path)
+	require.True(t, os.IsNotExist(err))
+
+	// The discount and the shipping of the order placed with the shipping method
+	// are saved at checkout, they don't change with the pricing
+	order.ShippingMethod = "courier"
+	order.ShippingCost = 500
+	order.Discount = 240
+	orderRepo.EXPECT().GetOrderByID(ctx, orderId).Return(order, nil)
+	invoiceRepo.EXPECT().GetOrCreateInvoice(ctx, orderId).Return(record, nil)
+	data := usecase.(*InvoiceUsecase).invoiceData(&order, record)
+	require.Equal(t, int64(240), data.Discount)
+	require.Equal(t, int64(500), data.Shipping)
+	require.Equal(t, int64(2400-240+500), data.Total)
+	_, snapshot, err := usecase.GetInvoice(ctx, orderId)
+	require.NoError(t, err)
+	file, err = os.ReadFile(snapshot)
+	require.NoError(t, err)
+	require.Contains(t, string(file), "2 660.00")
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -27,6 +28,18 @@ const (
 	itemsQuantityKey      = "ItemsQuantity"
 )
 
+// statsSortTypes are the types of sort by popularity and novelty. Caches of lists
+// with these sorts are not updated after changes of items but deleted and
+// created again on the next request
+var statsSortTypes = []string{"popular", "newest"}
+
+// statsSortVersionKey returns the key of the version of caches of lists with the stats sort type.
+// Keys of these caches contain the version, so the caches of all lists, including the lists
+// of search results, are invalidated at once by incrementing the version
+func statsSortVersionKey(sortType string) string {
+	return "StatsSortVersion" + sortType
+}
+
 type ItemUsecase struct {
 	itemStore repository.ItemStore
 	itemCash  cash.IItemsCash
@@ -45,6 +58,7 @@ func (usecase *ItemUsecase) CreateItem(ctx context.Context, item *models.Item) (
 	if err != nil {
 		return uuid.Nil, fmt.Errorf("error on create item: %w", err)
 	}
+	usecase.DeleteStatsSortedCash(ctx, "")
 	err = usecase.UpdateCash(ctx, id, "create")
 	if err != nil {
 		usecase.logger.Debug(err.Error())
@@ -59,6 +73,7 @@ func (usecase *ItemUsecase) UpdateItem(ctx context.Context, item *models.Item) e
 	if err != nil {
 		return fmt.Errorf("error on update item: %w", err)
 	}
+	usecase.DeleteStatsSortedCash(ctx, "")
 	err = usecase.UpdateCash(ctx, item.Id, "update")
 	if err != nil {
 		usecase.logger.Debug(err.Error())
@@ -83,6 +98,7 @@ func (usecase *ItemUsecase) AddFavouriteItem(ctx context.Context, userId uuid.UU
 	if err != nil {
 		return err
 	}
+	usecase.DeleteStatsSortedCash(ctx, userId.String())
 	usecase.UpdateFavouriteItemsCash(ctx, userId, itemId, "add")
 	usecase.UpdateFavIdsCash(ctx, userId, itemId, "add")
 	return nil
@@ -95,6 +111,7 @@ func (usecase *ItemUsecase) DeleteFavouriteItem(ctx context.Context, userId uuid
 	if err != nil {
 		return err
 	}
+	usecase.DeleteStatsSortedCash(ctx, userId.String())
 	usecase.UpdateFavouriteItemsCash(ctx, userId, itemId, "delete")
 	usecase.UpdateFavIdsCash(ctx, userId, itemId, "delete")
 	return nil
@@ -107,6 +124,7 @@ func (usecase *ItemUsecase) DeleteItem(ctx context.Context, id uuid.UUID) error
 	if err != nil {
 		return err
 	}
+	usecase.DeleteStatsSortedCash(ctx, "")
 	err = usecase.UpdateCash(ctx, id, "delete")
 	if err != nil {
 		usecase.logger.Error(fmt.Sprintf("error on update cash: %v", err))
@@ -281,8 +299,9 @@ func (usecase *ItemUsecase) ItemsList(ctx context.Context, limitOptions map[stri
 	defer cancel()
 	limit, offset := limitOptions["limit"], limitOptions["offset"]
 	sortType, sortOrder := sortOptions["sortType"], sortOptions["sortOrder"]
+	key := usecase.sortedCashKey(ctxT, itemsListKey, sortType, sortOrder)
 	// Check whether there is a cache with that name
-	if ok := usecase.itemCash.CheckCash(ctxT, itemsListKey+sortType+sortOrder); !ok {
+	if ok := usecase.itemCash.CheckCash(ctxT, key); !ok {
 		// If the cache does not exist, request a list of items from the database
 		itemIncomingChan, err := usecase.itemStore.ItemsList(ctx)
 		if err != nil {
@@ -295,11 +314,11 @@ func (usecase *ItemUsecase) ItemsList(ctx context.Context, limitOptions map[stri
 		// Sort the list of items based on the sorting parameters
 		usecase.SortItems(items, sortType, sortOrder)
 		// Create a cache with a sorted list of items
-		err = usecase.itemCash.CreateItemsCash(ctxT, items, itemsListKey+sortType+sortOrder)
+		err = usecase.itemCash.CreateItemsCash(ctxT, items, key)
 		if err != nil {
-			usecase.logger.Sugar().Warnf("error on create items list cash with key: %s, error: %v", itemsListKey+sortType+sortOrder, err)
+			usecase.logger.Sugar().Warnf("error on create items list cash with key: %s, error: %v", key, err)
 		} else {
-			usecase.logger.Sugar().Infof("Create items list cash with key: %s success", itemsListKey+sortType+sortOrder)
+			usecase.logger.Sugar().Infof("Create items list cash with key: %s success", key)
 		}
 		// Create a cache with a quantity of items in list
 		err = usecase.itemCash.CreateItemsQuantityCash(ctxT, len(items), itemsQuantityKey)
@@ -310,9 +329,9 @@ func (usecase *ItemUsecase) ItemsList(ctx context.Context, limitOptions map[stri
 		}
 	}
 	// Get items list from cache
-	items, err := usecase.itemCash.GetItemsCash(ctxT, itemsListKey+sortType+sortOrder)
+	items, err := usecase.itemCash.GetItemsCash(ctxT, key)
 	if err != nil {
-		usecase.logger.Sugar().Warnf("error on get cash with key: %s, err: %v", itemsListKey+sortType+sortOrder, err)
+		usecase.logger.Sugar().Warnf("error on get cash with key: %s, err: %v", key, err)
 		// If error on get cache, request a list of items from the database
 		itemIncomingChan, err := usecase.itemStore.ItemsList(ctx)
 		if err != nil {
@@ -354,9 +373,10 @@ func (usecase *ItemUsecase) GetItemsByCategory(ctx context.Context, categoryName
 
 	limit, offset := limitOptions["limit"], limitOptions["offset"]
 	sortType, sortOrder := sortOptions["sortType"], sortOptions["sortOrder"]
+	key := usecase.sortedCashKey(ctxT, categoryName, sortType, sortOrder)
 
 	// Check whether there is a cache of items in category
-	if ok := usecase.itemCash.CheckCash(ctxT, categoryName+sortType+sortOrder); !ok {
+	if ok := usecase.itemCash.CheckCash(ctxT, key); !ok {
 		// If the cache does not exist, request a list of items in
 		// category from the database
 		itemIncomingChan, err := usecase.itemStore.GetItemsByCategory(ctx, categoryName)
@@ -370,11 +390,11 @@ func (usecase *ItemUsecase) GetItemsByCategory(ctx context.Context, categoryName
 		// Sort the list of items in category based on the sorting parameters
 		usecase.SortItems(items, sortType, sortOrder)
 		// Create a cache with a sorted list of items in category
-		err = usecase.itemCash.CreateItemsCash(ctxT, items, categoryName+sortType+sortOrder)
+		err = usecase.itemCash.CreateItemsCash(ctxT, items, key)
 		if err != nil {
-			usecase.logger.Sugar().Warnf("error on create items cash with key: %s, error: %v", categoryName+sortType+sortOrder, err)
+			usecase.logger.Sugar().Warnf("error on create items cash with key: %s, error: %v", key, err)
 		} else {
-			usecase.logger.Sugar().Infof("Create items cash with key: %s success", categoryName+sortType+sortOrder)
+			usecase.logger.Sugar().Infof("Create items cash with key: %s success", key)
 		}
 		// Create a cache with a quantity of items in category
 		err = usecase.itemCash.CreateItemsQuantityCash(ctxT, len(items), categoryName+"Quantity")
@@ -385,9 +405,9 @@ func (usecase *ItemUsecase) GetItemsByCategory(ctx context.Context, categoryName
 		}
 	}
 	// Get items list from cache
-	items, err := usecase.itemCash.GetItemsCash(ctxT, categoryName+sortType+sortOrder)
+	items, err := usecase.itemCash.GetItemsCash(ctxT, key)
 	if err != nil {
-		usecase.logger.Sugar().Warnf("error on get cache with key: %s, error: %v", categoryName+sortType+sortOrder, err)
+		usecase.logger.Sugar().Warnf("error on get cache with key: %s, error: %v", key, err)
 		// If error on get cache, request a list of items from the database
 		itemIncomingChan, err := usecase.itemStore.GetItemsByCategory(ctx, categoryName)
 		if err != nil {
@@ -427,9 +447,10 @@ func (usecase *ItemUsecase) SearchLine(ctx context.Context, param string, limitO
 
 	limit, offset := limitOptions["limit"], limitOptions["offset"]
 	sortType, sortOrder := sortOptions["sortType"], sortOptions["sortOrder"]
+	key := usecase.sortedCashKey(ctxT, param, sortType, sortOrder)
 
 	// Check whether there is a cache of this search request
-	if ok := usecase.itemCash.CheckCash(ctxT, param+sortType+sortOrder); !ok {
+	if ok := usecase.itemCash.CheckCash(ctxT, key); !ok {
 		// If the cache does not exist, request a list of items by
 		// search request from the database
 		itemIncomingChan, err := usecase.itemStore.SearchLine(ctx, param)
@@ -450,17 +471,17 @@ func (usecase *ItemUsecase) SearchLine(ctx context.Context, param string, limitO
 		// Sort the list of items in search request based on the sorting parameters
 		usecase.SortItems(items, sortType, sortOrder)
 		// Create a cache with a sorted list of items in search request
-		err = usecase.itemCash.CreateItemsCash(ctxT, items, param+sortType+sortOrder)
+		err = usecase.itemCash.CreateItemsCash(ctxT, items, key)
 		if err != nil {
-			usecase.logger.Sugar().Warnf("error on create cash of items in search with key: %s, error: %v", param+sortType+sortOrder, err)
+			usecase.logger.Sugar().Warnf("error on create cash of items in search with key: %s, error: %v", key, err)
 		} else {
-			usecase.logger.Sugar().Infof("Create cash of items in search with key: %s success", param+sortType+sortOrder)
+			usecase.logger.Sugar().Infof("Create cash of items in search with key: %s success", key)
 		}
 	}
 	// Get items list from cache
-	items, err := usecase.itemCash.GetItemsCash(ctxT, param+sortType+sortOrder)
+	items, err := usecase.itemCash.GetItemsCash(ctxT, key)
 	if err != nil {
-		usecase.logger.Sugar().Warnf("error on get cache with key: %s, error: %v", param+sortType+sortOrder, err)
+		usecase.logger.Sugar().Warnf("error on get cache with key: %s, error: %v", key, err)
 		// If error on get cache, request a list of items from the database
 		itemIncomingChan, err := usecase.itemStore.SearchLine(ctx, param)
 		if err != nil {
@@ -500,8 +521,9 @@ func (usecase *ItemUsecase) GetFavouriteItems(ctx context.Context, userId uuid.U
 
 	limit, offset := limitOptions["limit"], limitOptions["offset"]
 	sortType, sortOrder := sortOptions["sortType"], sortOptions["sortOrder"]
+	key := usecase.sortedCashKey(ctxT, userId.String(), sortType, sortOrder)
 	// Check whether there is a cache of items in favourites
-	if ok := usecase.itemCash.CheckCash(ctxT, userId.String()+sortType+sortOrder); !ok {
+	if ok := usecase.itemCash.CheckCash(ctxT, key); !ok {
 		// If the cache does not exist, request a list of items in
 		// favourites from the database
 		itemIncomingChan, err := usecase.itemStore.GetFavouriteItems(ctx, userId)
@@ -516,11 +538,11 @@ func (usecase *ItemUsecase) GetFavouriteItems(ctx context.Context, userId uuid.U
 		// based on the sorting parameters
 		usecase.SortItems(items, sortType, sortOrder)
 		// Create a cache with a sorted list of items in favourites
-		err = usecase.itemCash.CreateItemsCash(ctxT, items, userId.String()+sortType+sortOrder)
+		err = usecase.itemCash.CreateItemsCash(ctxT, items, key)
 		if err != nil {
-			usecase.logger.Sugar().Warnf("error on create favourite items cash with key: %s, error: %v", userId.String()+sortType+sortOrder, err)
+			usecase.logger.Sugar().Warnf("error on create favourite items cash with key: %s, error: %v", key, err)
 		} else {
-			usecase.logger.Sugar().Infof("Create favourite items cash with key: %s success", userId.String()+sortType+sortOrder)
+			usecase.logger.Sugar().Infof("Create favourite items cash with key: %s success", key)
 		}
 		// Create a cache with a quantity of items in favourites
 		err = usecase.itemCash.CreateItemsQuantityCash(ctxT, len(items), userId.String()+"Quantity")
@@ -531,9 +553,9 @@ func (usecase *ItemUsecase) GetFavouriteItems(ctx context.Context, userId uuid.U
 		}
 	}
 	// Get items list from cache
-	items, err := usecase.itemCash.GetItemsCash(ctxT, userId.String()+sortType+sortOrder)
+	items, err := usecase.itemCash.GetItemsCash(ctxT, key)
 	if err != nil {
-		usecase.logger.Sugar().Warnf("error on get items in favourite cash with key: %s, error: %v", userId.String()+sortType+sortOrder, err)
+		usecase.logger.Sugar().Warnf("error on get items in favourite cash with key: %s, error: %v", key, err)
 		// If error on get cache, request a list of items in favourite from the database
 		itemIncomingChan, err := usecase.itemStore.GetFavouriteItems(ctx, userId)
 		if err != nil {
@@ -917,7 +939,60 @@ func (usecase *ItemUsecase) SortItems(items []models.Item, sortType string, sort
 	case sortType == "price" && sortOrder == "desc":
 		sort.Slice(items, func(i, j int) bool { return items[i].Price > items[j].Price })
 		return
+	case sortType == "popular" && sortOrder == "asc":
+		sort.Slice(items, func(i, j int) bool { return items[i].Popularity() < items[j].Popularity() })
+		return
+	case sortType == "popular" && sortOrder == "desc":
+		sort.Slice(items, func(i, j int) bool { return items[i].Popularity() > items[j].Popularity() })
+		return
+	case sortType == "newest" && sortOrder == "asc":
+		sort.Slice(items, func(i, j int) bool { return items[i].CreatedAt.Before(items[j].CreatedAt) })
+		return
+	case sortType == "newest" && sortOrder == "desc":
+		sort.Slice(items, func(i, j int) bool { return items[i].CreatedAt.After(items[j].CreatedAt) })
+		return
 	default:
 		usecase.logger.Sugar().Errorf("unknown type of sort: %v", sortType)
 	}
 }
+
+// sortedCashKey returns the key of cache of the list with prefix sorted by the sort type and order,
+// keys of lists sorted by popularity and novelty end with the current version of these caches
+func (usecase *ItemUsecase) sortedCashKey(ctx context.Context, prefix string, sortType string, sortOrder string) string {
+	key := prefix + sortType + sortOrder
+	for _, statsSortType := range statsSortTypes {
+		if sortType != statsSortType {
+			continue
+		}
+		version, err := usecase.itemCash.GetCashVersion(ctx, statsSortVersionKey(sortType))
+		if err != nil {
+			usecase.logger.Sugar().Warnf("error on get version of cash: %s, error: %v", sortType, err)
+		}
+		return key + ":" + strconv.FormatInt(version, 10)
+	}
+	return key
+}
+
+// DeleteStatsSortedCash deletes caches of lists of items sorted by popularity and novelty
+// with keys starting with prefix, empty prefix means caches of all lists. Caches of all lists
+// are invalidated by the new version, caches with prefix are deleted by their keys
+func (usecase *ItemUsecase) DeleteStatsSortedCash(ctx context.Context, prefix string) {
+	usecase.logger.Sugar().Debugf("Enter in usecase DeleteStatsSortedCash() with args: ctx, prefix: %s", prefix)
+	for _, sortType := range statsSortTypes {
+		if prefix == "" {
+			err := usecase.itemCash.IncrCashVersion(ctx, statsSortVersionKey(sortType))
+			if err != nil {
+				usecase.logger.Sugar().Warnf("error on increment version of cash: %s, error: %v", sortType, err)
+			}
+			continue
+		}
+		keys := []string{
+			usecase.sortedCashKey(ctx, prefix, sortType, "asc"),
+			usecase.sortedCashKey(ctx, prefix, sortType, "desc"),
+		}
+		err := usecase.itemCash.DeleteCash(ctx, keys...)
+		if err != nil {
+			usecase.logger.Sugar().Warnf("error on delete cash with keys: %v, error: %v", keys, err)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -86,6 +87,8 @@ func TestCreateItem(t *testing.T) {
 	require.Equal(t, res, uuid.Nil)
 
 	itemRepo.EXPECT().CreateItem(ctx, &testModelItem).Return(testId, nil)
+	cash.EXPECT().IncrCashVersion(ctx, "StatsSortVersionpopular").Return(nil)
+	cash.EXPECT().IncrCashVersion(ctx, "StatsSortVersionnewest").Return(err)
 	cash.EXPECT().CheckCash(ctx, itemsListKeyNameAsc).Return(false)
 	cash.EXPECT().CheckCash(ctx, itemsListKeyNameDesc).Return(false)
 	cash.EXPECT().CheckCash(ctx, itemsListKeyPriceAsc).Return(false)
@@ -109,6 +112,8 @@ func TestUpdateItem(t *testing.T) {
 	require.Error(t, err)
 
 	itemRepo.EXPECT().UpdateItem(ctx, &testModelItem).Return(nil)
+	cash.EXPECT().IncrCashVersion(ctx, "StatsSortVersionpopular").Return(nil)
+	cash.EXPECT().IncrCashVersion(ctx, "StatsSortVersionnewest").Return(err)
 	cash.EXPECT().CheckCash(ctx, itemsListKeyNameAsc).Return(false)
 	cash.EXPECT().CheckCash(ctx, itemsListKeyNameDesc).Return(false)
 	cash.EXPECT().CheckCash(ctx, itemsListKeyPriceAsc).Return(false)
@@ -139,6 +144,30 @@ func TestGetItem(t *testing.T) {
 	require.Nil(t, res)
 }
 
+func TestStatsSortedItemsList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	itemRepo := mocks.NewMockItemStore(ctrl)
+	cash := mocks.NewMockIItemsCash(ctrl)
+	usecase := NewItemUsecase(itemRepo, cash, logger)
+	ctx := gomock.Any()
+	sortOptions := map[string]string{"sortType": "popular", "sortOrder": "desc"}
+
+	// The key of the list sorted by popularity has the current version of these caches
+	cash.EXPECT().GetCashVersion(ctx, "StatsSortVersionpopular").Return(int64(3), nil)
+	cash.EXPECT().CheckCash(ctx, itemsListKey+"populardesc:3").Return(true)
+	cash.EXPECT().GetItemsCash(ctx, itemsListKey+"populardesc:3").Return(items, nil)
+	res, err := usecase.ItemsList(context.Background(), testLimitOptionsItemsList, sortOptions)
+	require.NoError(t, err)
+	require.Equal(t, items, res)
+
+	// Caches of all lists are invalidated by the next version
+	cash.EXPECT().IncrCashVersion(ctx, "StatsSortVersionpopular").Return(nil)
+	cash.EXPECT().IncrCashVersion(ctx, "StatsSortVersionnewest").Return(nil)
+	usecase.DeleteStatsSortedCash(context.Background(), "")
+}
+
 func TestItemsList(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -691,6 +720,8 @@ func TestDeleteItem(t *testing.T) {
 	require.Error(t, err)
 
 	itemRepo.EXPECT().DeleteItem(ctx, testId).Return(nil)
+	cash.EXPECT().IncrCashVersion(ctx, "StatsSortVersionpopular").Return(nil)
+	cash.EXPECT().IncrCashVersion(ctx, "StatsSortVersionnewest").Return(err)
 	cash.EXPECT().CheckCash(ctx, itemsListKeyNameAsc).Return(false)
 	cash.EXPECT().CheckCash(ctx, itemsListKeyNameDesc).Return(false)
 	cash.EXPECT().CheckCash(ctx, itemsListKeyPriceAsc).Return(false)
@@ -713,6 +744,10 @@ func TestAddFavouriteItem(t *testing.T) {
 	require.Error(t, err)
 
 	itemRepo.EXPECT().AddFavouriteItem(ctx, testId, testItemId).Return(nil)
+	cash.EXPECT().GetCashVersion(ctx, "StatsSortVersionpopular").Return(int64(2), nil).Times(2)
+	cash.EXPECT().DeleteCash(ctx, testId.String()+"popularasc:2", testId.String()+"populardesc:2").Return(nil)
+	cash.EXPECT().GetCashVersion(ctx, "StatsSortVersionnewest").Return(int64(0), nil).Times(2)
+	cash.EXPECT().DeleteCash(ctx, testId.String()+"newestasc:0", testId.String()+"newestdesc:0").Return(nil)
 	cash.EXPECT().CheckCash(ctx, testId.String()+"nameasc").Return(false)
 	cash.EXPECT().CheckCash(ctx, testId.String()+"namedesc").Return(false)
 	cash.EXPECT().CheckCash(ctx, testId.String()+"priceasc").Return(false)
@@ -737,6 +772,10 @@ func TestDeleteFavouriteItem(t *testing.T) {
 	require.Error(t, err)
 
 	itemRepo.EXPECT().DeleteFavouriteItem(ctx, testId, testItemId).Return(nil)
+	cash.EXPECT().GetCashVersion(ctx, "StatsSortVersionpopular").Return(int64(2), nil).Times(2)
+	cash.EXPECT().DeleteCash(ctx, testId.String()+"popularasc:2", testId.String()+"populardesc:2").Return(nil)
+	cash.EXPECT().GetCashVersion(ctx, "StatsSortVersionnewest").Return(int64(0), nil).Times(2)
+	cash.EXPECT().DeleteCash(ctx, testId.String()+"newestasc:0", testId.String()+"newestdesc:0").Return(nil)
 	cash.EXPECT().CheckCash(ctx, testId.String()+"nameasc").Return(false)
 	cash.EXPECT().CheckCash(ctx, testId.String()+"namedesc").Return(false)
 	cash.EXPECT().CheckCash(ctx, testId.String()+"priceasc").Return(false)
@@ -991,6 +1030,26 @@ func TestSortItems(t *testing.T) {
 		{Price: 20},
 		{Price: 10},
 	})
+	testItems3 := []models.Item{
+		{Title: "A", Views: 10},
+		{Title: "B", Views: 3, CartAdds: 3},
+		{Title: "C", Views: 12},
+	}
+	usecase.SortItems(testItems3, "popular", "desc")
+	require.Equal(t, []string{"B", "C", "A"}, []string{testItems3[0].Title, testItems3[1].Title, testItems3[2].Title})
+	usecase.SortItems(testItems3, "popular", "asc")
+	require.Equal(t, []string{"A", "C", "B"}, []string{testItems3[0].Title, testItems3[1].Title, testItems3[2].Title})
+
+	now := time.Now()
+	testItems4 := []models.Item{
+		{Title: "A", CreatedAt: now.Add(-time.Hour)},
+		{Title: "B", CreatedAt: now},
+		{Title: "C", CreatedAt: now.Add(-2 * time.Hour)},
+	}
+	usecase.SortItems(testItems4, "newest", "desc")
+	require.Equal(t, []string{"B", "A", "C"}, []string{testItems4[0].Title, testItems4[1].Title, testItems4[2].Title})
+	usecase.SortItems(testItems4, "newest", "asc")
+	require.Equal(t, []string{"C", "A", "B"}, []string{testItems4[0].Title, testItems4[1].Title, testItems4[2].Title})
 	usecase.SortItems(testItems, "pricee", "desc")
 }
 
@@ -15,11 +15,6 @@ type OrderUsecaseMock struct {
 
 var _ usecase.IOrderUsecase = (*OrderUsecaseMock)(nil)
 
-func (o *OrderUsecaseMock) PlaceOrder(ctx context.Context, cart *models.Cart, user models.User, address models.UserAddress) (*models.Order, error) {
-	return &models.Order{
-		ID: uuid.New(),
-	}, o.Err
-}
 func (o *OrderUsecaseMock) ChangeStatus(ctx context.Context, order *models.Order, newStatus models.Status) error {
 	return o.Err
 }
@@ -7,7 +7,10 @@ package mocks
 import (
 	user "OnlineShopBackend/internal/delivery/user"
 	models "OnlineShopBackend/internal/models"
+	shipping "OnlineShopBackend/internal/shipping"
 	context "context"
+	io "io"
+	http "net/http"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -94,6 +97,18 @@ func (mr *MockIItemUsecaseMockRecorder) DeleteItem(ctx, id interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockIItemUsecase)(nil).DeleteItem), ctx, id)
 }
 
+// DeleteStatsSortedCash mocks base method.
+func (m *MockIItemUsecase) DeleteStatsSortedCash(ctx context.Context, prefix string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteStatsSortedCash", ctx, prefix)
+}
+
+// DeleteStatsSortedCash indicates an expected call of DeleteStatsSortedCash.
+func (mr *MockIItemUsecaseMockRecorder) DeleteStatsSortedCash(ctx, prefix interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStatsSortedCash", reflect.TypeOf((*MockIItemUsecase)(nil).DeleteStatsSortedCash), ctx, prefix)
+}
+
 // GetFavouriteItems mocks base method.
 func (m *MockIItemUsecase) GetFavouriteItems(ctx context.Context, userId uuid.UUID, limitOptions map[string]int, sortOptions map[string]string) ([]models.Item, error) {
 	m.ctrl.T.Helper()
@@ -361,17 +376,17 @@ func (mr *MockICategoryUsecaseMockRecorder) CreateCategory(ctx, category interfa
 }
 
 // DeleteCategory mocks base method.
-func (m *MockICategoryUsecase) DeleteCategory(ctx context.Context, id uuid.UUID) error {
+func (m *MockICategoryUsecase) DeleteCategory(ctx context.Context, id, targetId uuid.UUID, uncategorized bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCategory", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteCategory", ctx, id, targetId, uncategorized)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteCategory indicates an expected call of DeleteCategory.
-func (mr *MockICategoryUsecaseMockRecorder) DeleteCategory(ctx, id interface{}) *gomock.Call {
+func (mr *MockICategoryUsecaseMockRecorder) DeleteCategory(ctx, id, targetId, uncategorized interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockICategoryUsecase)(nil).DeleteCategory), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockICategoryUsecase)(nil).DeleteCategory), ctx, id, targetId, uncategorized)
 }
 
 // DeleteCategoryCash mocks base method.
@@ -433,6 +448,35 @@ func (mr *MockICategoryUsecaseMockRecorder) GetCategoryList(ctx interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryList", reflect.TypeOf((*MockICategoryUsecase)(nil).GetCategoryList), ctx)
 }
 
+// GetUncategorized mocks base method.
+func (m *MockICategoryUsecase) GetUncategorized(ctx context.Context) (*models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUncategorized", ctx)
+	ret0, _ := ret[0].(*models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUncategorized indicates an expected call of GetUncategorized.
+func (mr *MockICategoryUsecaseMockRecorder) GetUncategorized(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUncategorized", reflect.TypeOf((*MockICategoryUsecase)(nil).GetUncategorized), ctx)
+}
+
+// MergeCategories mocks base method.
+func (m *MockICategoryUsecase) MergeCategories(ctx context.Context, sourceId, targetId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeCategories", ctx, sourceId, targetId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeCategories indicates an expected call of MergeCategories.
+func (mr *MockICategoryUsecaseMockRecorder) MergeCategories(ctx, sourceId, targetId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeCategories", reflect.TypeOf((*MockICategoryUsecase)(nil).MergeCategories), ctx, sourceId, targetId)
+}
+
 // UpdateCash mocks base method.
 func (m *MockICategoryUsecase) UpdateCash(ctx context.Context, id uuid.UUID, op string) error {
 	m.ctrl.T.Helper()
@@ -556,21 +600,6 @@ func (mr *MockIOrderUsecaseMockRecorder) GetOrdersForUser(ctx, user interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersForUser", reflect.TypeOf((*MockIOrderUsecase)(nil).GetOrdersForUser), ctx, user)
 }
 
-// PlaceOrder mocks base method.
-func (m *MockIOrderUsecase) PlaceOrder(ctx context.Context, cart *models.Cart, user models.User, address models.UserAddress) (*models.Order, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PlaceOrder", ctx, cart, user, address)
-	ret0, _ := ret[0].(*models.Order)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// PlaceOrder indicates an expected call of PlaceOrder.
-func (mr *MockIOrderUsecaseMockRecorder) PlaceOrder(ctx, cart, user, address interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceOrder", reflect.TypeOf((*MockIOrderUsecase)(nil).PlaceOrder), ctx, cart, user, address)
-}
-
 // MockICartUsecase is a mock of ICartUsecase interface.
 type MockICartUsecase struct {
 	ctrl     *gomock.Controller
@@ -608,6 +637,20 @@ func (mr *MockICartUsecaseMockRecorder) AddItemToCart(ctx, cartId, itemId interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItemToCart", reflect.TypeOf((*MockICartUsecase)(nil).AddItemToCart), ctx, cartId, itemId)
 }
 
+// ClearCart mocks base method.
+func (m *MockICartUsecase) ClearCart(ctx context.Context, cartId, orderId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearCart", ctx, cartId, orderId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearCart indicates an expected call of ClearCart.
+func (mr *MockICartUsecaseMockRecorder) ClearCart(ctx, cartId, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearCart", reflect.TypeOf((*MockICartUsecase)(nil).ClearCart), ctx, cartId, orderId)
+}
+
 // Create mocks base method.
 func (m *MockICartUsecase) Create(ctx context.Context, userId uuid.UUID) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -681,6 +724,297 @@ func (mr *MockICartUsecaseMockRecorder) GetCartByUserId(ctx, userId interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartByUserId", reflect.TypeOf((*MockICartUsecase)(nil).GetCartByUserId), ctx, userId)
 }
 
+// MergeGuestCart mocks base method.
+func (m *MockICartUsecase) MergeGuestCart(ctx context.Context, guestCartId, userId uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeGuestCart", ctx, guestCartId, userId)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeGuestCart indicates an expected call of MergeGuestCart.
+func (mr *MockICartUsecaseMockRecorder) MergeGuestCart(ctx, guestCartId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeGuestCart", reflect.TypeOf((*MockICartUsecase)(nil).MergeGuestCart), ctx, guestCartId, userId)
+}
+
+// SetItemQuantity mocks base method.
+func (m *MockICartUsecase) SetItemQuantity(ctx context.Context, cartId, itemId uuid.UUID, quantity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetItemQuantity", ctx, cartId, itemId, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetItemQuantity indicates an expected call of SetItemQuantity.
+func (mr *MockICartUsecaseMockRecorder) SetItemQuantity(ctx, cartId, itemId, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetItemQuantity", reflect.TypeOf((*MockICartUsecase)(nil).SetItemQuantity), ctx, cartId, itemId, quantity)
+}
+
+// SetItemSaved mocks base method.
+func (m *MockICartUsecase) SetItemSaved(ctx context.Context, cartId, itemId uuid.UUID, saved bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetItemSaved", ctx, cartId, itemId, saved)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetItemSaved indicates an expected call of SetItemSaved.
+func (mr *MockICartUsecaseMockRecorder) SetItemSaved(ctx, cartId, itemId, saved interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetItemSaved", reflect.TypeOf((*MockICartUsecase)(nil).SetItemSaved), ctx, cartId, itemId, saved)
+}
+
+// MockICartCleanupUsecase is a mock of ICartCleanupUsecase interface.
+type MockICartCleanupUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockICartCleanupUsecaseMockRecorder
+}
+
+// MockICartCleanupUsecaseMockRecorder is the mock recorder for MockICartCleanupUsecase.
+type MockICartCleanupUsecaseMockRecorder struct {
+	mock *MockICartCleanupUsecase
+}
+
+// NewMockICartCleanupUsecase creates a new mock instance.
+func NewMockICartCleanupUsecase(ctrl *gomock.Controller) *MockICartCleanupUsecase {
+	mock := &MockICartCleanupUsecase{ctrl: ctrl}
+	mock.recorder = &MockICartCleanupUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICartCleanupUsecase) EXPECT() *MockICartCleanupUsecaseMockRecorder {
+	return m.recorder
+}
+
+// PurgeExpiredCarts mocks base method.
+func (m *MockICartCleanupUsecase) PurgeExpiredCarts(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpiredCarts", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpiredCarts indicates an expected call of PurgeExpiredCarts.
+func (mr *MockICartCleanupUsecaseMockRecorder) PurgeExpiredCarts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpiredCarts", reflect.TypeOf((*MockICartCleanupUsecase)(nil).PurgeExpiredCarts), ctx)
+}
+
+// Run mocks base method.
+func (m *MockICartCleanupUsecase) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockICartCleanupUsecaseMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockICartCleanupUsecase)(nil).Run), ctx)
+}
+
+// MockICheckoutUsecase is a mock of ICheckoutUsecase interface.
+type MockICheckoutUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockICheckoutUsecaseMockRecorder
+}
+
+// MockICheckoutUsecaseMockRecorder is the mock recorder for MockICheckoutUsecase.
+type MockICheckoutUsecaseMockRecorder struct {
+	mock *MockICheckoutUsecase
+}
+
+// NewMockICheckoutUsecase creates a new mock instance.
+func NewMockICheckoutUsecase(ctrl *gomock.Controller) *MockICheckoutUsecase {
+	mock := &MockICheckoutUsecase{ctrl: ctrl}
+	mock.recorder = &MockICheckoutUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICheckoutUsecase) EXPECT() *MockICheckoutUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Checkout mocks base method.
+func (m *MockICheckoutUsecase) Checkout(ctx context.Context, cartId uuid.UUID, itemIds []uuid.UUID, user models.User, address models.UserAddress, method string) (*models.Order, uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkout", ctx, cartId, itemIds, user, address, method)
+	ret0, _ := ret[0].(*models.Order)
+	ret1, _ := ret[1].(uuid.UUID)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Checkout indicates an expected call of Checkout.
+func (mr *MockICheckoutUsecaseMockRecorder) Checkout(ctx, cartId, itemIds, user, address, method interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*MockICheckoutUsecase)(nil).Checkout), ctx, cartId, itemIds, user, address, method)
+}
+
+// OrderAddress mocks base method.
+func (m *MockICheckoutUsecase) OrderAddress(ctx context.Context, userId uuid.UUID, address *models.UserAddress, addressId uuid.UUID) (models.UserAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrderAddress", ctx, userId, address, addressId)
+	ret0, _ := ret[0].(models.UserAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OrderAddress indicates an expected call of OrderAddress.
+func (mr *MockICheckoutUsecaseMockRecorder) OrderAddress(ctx, userId, address, addressId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrderAddress", reflect.TypeOf((*MockICheckoutUsecase)(nil).OrderAddress), ctx, userId, address, addressId)
+}
+
+// ShippingQuotes mocks base method.
+func (m *MockICheckoutUsecase) ShippingQuotes(ctx context.Context, cart *models.Cart, address models.UserAddress) ([]shipping.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShippingQuotes", ctx, cart, address)
+	ret0, _ := ret[0].([]shipping.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShippingQuotes indicates an expected call of ShippingQuotes.
+func (mr *MockICheckoutUsecaseMockRecorder) ShippingQuotes(ctx, cart, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShippingQuotes", reflect.TypeOf((*MockICheckoutUsecase)(nil).ShippingQuotes), ctx, cart, address)
+}
+
+// MockICartReminderUsecase is a mock of ICartReminderUsecase interface.
+type MockICartReminderUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockICartReminderUsecaseMockRecorder
+}
+
+// MockICartReminderUsecaseMockRecorder is the mock recorder for MockICartReminderUsecase.
+type MockICartReminderUsecaseMockRecorder struct {
+	mock *MockICartReminderUsecase
+}
+
+// NewMockICartReminderUsecase creates a new mock instance.
+func NewMockICartReminderUsecase(ctrl *gomock.Controller) *MockICartReminderUsecase {
+	mock := &MockICartReminderUsecase{ctrl: ctrl}
+	mock.recorder = &MockICartReminderUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICartReminderUsecase) EXPECT() *MockICartReminderUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockICartReminderUsecase) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockICartReminderUsecaseMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockICartReminderUsecase)(nil).Run), ctx)
+}
+
+// SendReminders mocks base method.
+func (m *MockICartReminderUsecase) SendReminders(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendReminders", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendReminders indicates an expected call of SendReminders.
+func (mr *MockICartReminderUsecaseMockRecorder) SendReminders(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendReminders", reflect.TypeOf((*MockICartReminderUsecase)(nil).SendReminders), ctx)
+}
+
+// MockINotificationUsecase is a mock of INotificationUsecase interface.
+type MockINotificationUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockINotificationUsecaseMockRecorder
+}
+
+// MockINotificationUsecaseMockRecorder is the mock recorder for MockINotificationUsecase.
+type MockINotificationUsecaseMockRecorder struct {
+	mock *MockINotificationUsecase
+}
+
+// NewMockINotificationUsecase creates a new mock instance.
+func NewMockINotificationUsecase(ctrl *gomock.Controller) *MockINotificationUsecase {
+	mock := &MockINotificationUsecase{ctrl: ctrl}
+	mock.recorder = &MockINotificationUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockINotificationUsecase) EXPECT() *MockINotificationUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetPreferences mocks base method.
+func (m *MockINotificationUsecase) GetPreferences(ctx context.Context, userId uuid.UUID) (*models.NotificationPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", ctx, userId)
+	ret0, _ := ret[0].(*models.NotificationPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockINotificationUsecaseMockRecorder) GetPreferences(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockINotificationUsecase)(nil).GetPreferences), ctx, userId)
+}
+
+// Run mocks base method.
+func (m *MockINotificationUsecase) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockINotificationUsecaseMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockINotificationUsecase)(nil).Run), ctx)
+}
+
+// SendNotifications mocks base method.
+func (m *MockINotificationUsecase) SendNotifications(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendNotifications", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendNotifications indicates an expected call of SendNotifications.
+func (mr *MockINotificationUsecaseMockRecorder) SendNotifications(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendNotifications", reflect.TypeOf((*MockINotificationUsecase)(nil).SendNotifications), ctx)
+}
+
+// SetPreferences mocks base method.
+func (m *MockINotificationUsecase) SetPreferences(ctx context.Context, preferences *models.NotificationPreferences) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPreferences", ctx, preferences)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPreferences indicates an expected call of SetPreferences.
+func (mr *MockINotificationUsecaseMockRecorder) SetPreferences(ctx, preferences interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPreferences", reflect.TypeOf((*MockINotificationUsecase)(nil).SetPreferences), ctx, preferences)
+}
+
 // MockIUserUsecase is a mock of IUserUsecase interface.
 type MockIUserUsecase struct {
 	ctrl     *gomock.Controller
@@ -807,3 +1141,887 @@ func (mr *MockIUserUsecaseMockRecorder) UpdateUserRole(ctx, roleId, email interf
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockIUserUsecase)(nil).UpdateUserRole), ctx, roleId, email)
 }
+
+// MockIQuestionUsecase is a mock of IQuestionUsecase interface.
+type MockIQuestionUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIQuestionUsecaseMockRecorder
+}
+
+// MockIQuestionUsecaseMockRecorder is the mock recorder for MockIQuestionUsecase.
+type MockIQuestionUsecaseMockRecorder struct {
+	mock *MockIQuestionUsecase
+}
+
+// NewMockIQuestionUsecase creates a new mock instance.
+func NewMockIQuestionUsecase(ctrl *gomock.Controller) *MockIQuestionUsecase {
+	mock := &MockIQuestionUsecase{ctrl: ctrl}
+	mock.recorder = &MockIQuestionUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIQuestionUsecase) EXPECT() *MockIQuestionUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AnswerQuestion mocks base method.
+func (m *MockIQuestionUsecase) AnswerQuestion(ctx context.Context, answer *models.Answer, role string) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnswerQuestion", ctx, answer, role)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnswerQuestion indicates an expected call of AnswerQuestion.
+func (mr *MockIQuestionUsecaseMockRecorder) AnswerQuestion(ctx, answer, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnswerQuestion", reflect.TypeOf((*MockIQuestionUsecase)(nil).AnswerQuestion), ctx, answer, role)
+}
+
+// AskQuestion mocks base method.
+func (m *MockIQuestionUsecase) AskQuestion(ctx context.Context, question *models.Question) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AskQuestion", ctx, question)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AskQuestion indicates an expected call of AskQuestion.
+func (mr *MockIQuestionUsecaseMockRecorder) AskQuestion(ctx, question interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AskQuestion", reflect.TypeOf((*MockIQuestionUsecase)(nil).AskQuestion), ctx, question)
+}
+
+// GetItemQuestions mocks base method.
+func (m *MockIQuestionUsecase) GetItemQuestions(ctx context.Context, itemId uuid.UUID) ([]models.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemQuestions", ctx, itemId)
+	ret0, _ := ret[0].([]models.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemQuestions indicates an expected call of GetItemQuestions.
+func (mr *MockIQuestionUsecaseMockRecorder) GetItemQuestions(ctx, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemQuestions", reflect.TypeOf((*MockIQuestionUsecase)(nil).GetItemQuestions), ctx, itemId)
+}
+
+// GetUnansweredQuestions mocks base method.
+func (m *MockIQuestionUsecase) GetUnansweredQuestions(ctx context.Context) ([]models.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnansweredQuestions", ctx)
+	ret0, _ := ret[0].([]models.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnansweredQuestions indicates an expected call of GetUnansweredQuestions.
+func (mr *MockIQuestionUsecaseMockRecorder) GetUnansweredQuestions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnansweredQuestions", reflect.TypeOf((*MockIQuestionUsecase)(nil).GetUnansweredQuestions), ctx)
+}
+
+// UpvoteAnswer mocks base method.
+func (m *MockIQuestionUsecase) UpvoteAnswer(ctx context.Context, answerId, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpvoteAnswer", ctx, answerId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpvoteAnswer indicates an expected call of UpvoteAnswer.
+func (mr *MockIQuestionUsecaseMockRecorder) UpvoteAnswer(ctx, answerId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpvoteAnswer", reflect.TypeOf((*MockIQuestionUsecase)(nil).UpvoteAnswer), ctx, answerId, userId)
+}
+
+// MockIFeedUsecase is a mock of IFeedUsecase interface.
+type MockIFeedUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIFeedUsecaseMockRecorder
+}
+
+// MockIFeedUsecaseMockRecorder is the mock recorder for MockIFeedUsecase.
+type MockIFeedUsecaseMockRecorder struct {
+	mock *MockIFeedUsecase
+}
+
+// NewMockIFeedUsecase creates a new mock instance.
+func NewMockIFeedUsecase(ctrl *gomock.Controller) *MockIFeedUsecase {
+	mock := &MockIFeedUsecase{ctrl: ctrl}
+	mock.recorder = &MockIFeedUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIFeedUsecase) EXPECT() *MockIFeedUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Generate mocks base method.
+func (m *MockIFeedUsecase) Generate(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generate", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Generate indicates an expected call of Generate.
+func (mr *MockIFeedUsecaseMockRecorder) Generate(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockIFeedUsecase)(nil).Generate), ctx)
+}
+
+// Run mocks base method.
+func (m *MockIFeedUsecase) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIFeedUsecaseMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIFeedUsecase)(nil).Run), ctx)
+}
+
+// MockIStatsUsecase is a mock of IStatsUsecase interface.
+type MockIStatsUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIStatsUsecaseMockRecorder
+}
+
+// MockIStatsUsecaseMockRecorder is the mock recorder for MockIStatsUsecase.
+type MockIStatsUsecaseMockRecorder struct {
+	mock *MockIStatsUsecase
+}
+
+// NewMockIStatsUsecase creates a new mock instance.
+func NewMockIStatsUsecase(ctrl *gomock.Controller) *MockIStatsUsecase {
+	mock := &MockIStatsUsecase{ctrl: ctrl}
+	mock.recorder = &MockIStatsUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIStatsUsecase) EXPECT() *MockIStatsUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Flush mocks base method.
+func (m *MockIStatsUsecase) Flush(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flush", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockIStatsUsecaseMockRecorder) Flush(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockIStatsUsecase)(nil).Flush), ctx)
+}
+
+// RecordItemCartAdd mocks base method.
+func (m *MockIStatsUsecase) RecordItemCartAdd(ctx context.Context, itemId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordItemCartAdd", ctx, itemId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordItemCartAdd indicates an expected call of RecordItemCartAdd.
+func (mr *MockIStatsUsecaseMockRecorder) RecordItemCartAdd(ctx, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordItemCartAdd", reflect.TypeOf((*MockIStatsUsecase)(nil).RecordItemCartAdd), ctx, itemId)
+}
+
+// RecordItemView mocks base method.
+func (m *MockIStatsUsecase) RecordItemView(ctx context.Context, itemId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordItemView", ctx, itemId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordItemView indicates an expected call of RecordItemView.
+func (mr *MockIStatsUsecaseMockRecorder) RecordItemView(ctx, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordItemView", reflect.TypeOf((*MockIStatsUsecase)(nil).RecordItemView), ctx, itemId)
+}
+
+// Run mocks base method.
+func (m *MockIStatsUsecase) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIStatsUsecaseMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIStatsUsecase)(nil).Run), ctx)
+}
+
+// MockIAuditUsecase is a mock of IAuditUsecase interface.
+type MockIAuditUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuditUsecaseMockRecorder
+}
+
+// MockIAuditUsecaseMockRecorder is the mock recorder for MockIAuditUsecase.
+type MockIAuditUsecaseMockRecorder struct {
+	mock *MockIAuditUsecase
+}
+
+// NewMockIAuditUsecase creates a new mock instance.
+func NewMockIAuditUsecase(ctrl *gomock.Controller) *MockIAuditUsecase {
+	mock := &MockIAuditUsecase{ctrl: ctrl}
+	mock.recorder = &MockIAuditUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuditUsecase) EXPECT() *MockIAuditUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetAuditLog mocks base method.
+func (m *MockIAuditUsecase) GetAuditLog(ctx context.Context, filter models.AuditFilter) ([]models.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLog", ctx, filter)
+	ret0, _ := ret[0].([]models.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditLog indicates an expected call of GetAuditLog.
+func (mr *MockIAuditUsecaseMockRecorder) GetAuditLog(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLog", reflect.TypeOf((*MockIAuditUsecase)(nil).GetAuditLog), ctx, filter)
+}
+
+// MockIIdempotencyUsecase is a mock of IIdempotencyUsecase interface.
+type MockIIdempotencyUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIIdempotencyUsecaseMockRecorder
+}
+
+// MockIIdempotencyUsecaseMockRecorder is the mock recorder for MockIIdempotencyUsecase.
+type MockIIdempotencyUsecaseMockRecorder struct {
+	mock *MockIIdempotencyUsecase
+}
+
+// NewMockIIdempotencyUsecase creates a new mock instance.
+func NewMockIIdempotencyUsecase(ctrl *gomock.Controller) *MockIIdempotencyUsecase {
+	mock := &MockIIdempotencyUsecase{ctrl: ctrl}
+	mock.recorder = &MockIIdempotencyUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIIdempotencyUsecase) EXPECT() *MockIIdempotencyUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Begin mocks base method.
+func (m *MockIIdempotencyUsecase) Begin(ctx context.Context, userId uuid.UUID, key, requestHash string) (*models.IdempotentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Begin", ctx, userId, key, requestHash)
+	ret0, _ := ret[0].(*models.IdempotentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Begin indicates an expected call of Begin.
+func (mr *MockIIdempotencyUsecaseMockRecorder) Begin(ctx, userId, key, requestHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockIIdempotencyUsecase)(nil).Begin), ctx, userId, key, requestHash)
+}
+
+// Complete mocks base method.
+func (m *MockIIdempotencyUsecase) Complete(ctx context.Context, request *models.IdempotentRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockIIdempotencyUsecaseMockRecorder) Complete(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockIIdempotencyUsecase)(nil).Complete), ctx, request)
+}
+
+// Release mocks base method.
+func (m *MockIIdempotencyUsecase) Release(ctx context.Context, userId uuid.UUID, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, userId, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockIIdempotencyUsecaseMockRecorder) Release(ctx, userId, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockIIdempotencyUsecase)(nil).Release), ctx, userId, key)
+}
+
+// MockIPaymentUsecase is a mock of IPaymentUsecase interface.
+type MockIPaymentUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPaymentUsecaseMockRecorder
+}
+
+// MockIPaymentUsecaseMockRecorder is the mock recorder for MockIPaymentUsecase.
+type MockIPaymentUsecaseMockRecorder struct {
+	mock *MockIPaymentUsecase
+}
+
+// NewMockIPaymentUsecase creates a new mock instance.
+func NewMockIPaymentUsecase(ctrl *gomock.Controller) *MockIPaymentUsecase {
+	mock := &MockIPaymentUsecase{ctrl: ctrl}
+	mock.recorder = &MockIPaymentUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPaymentUsecase) EXPECT() *MockIPaymentUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CancelUnpaidOrders mocks base method.
+func (m *MockIPaymentUsecase) CancelUnpaidOrders(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelUnpaidOrders", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelUnpaidOrders indicates an expected call of CancelUnpaidOrders.
+func (mr *MockIPaymentUsecaseMockRecorder) CancelUnpaidOrders(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelUnpaidOrders", reflect.TypeOf((*MockIPaymentUsecase)(nil).CancelUnpaidOrders), ctx)
+}
+
+// GetPayment mocks base method.
+func (m *MockIPaymentUsecase) GetPayment(ctx context.Context, orderId uuid.UUID) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayment", ctx, orderId)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayment indicates an expected call of GetPayment.
+func (mr *MockIPaymentUsecaseMockRecorder) GetPayment(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayment", reflect.TypeOf((*MockIPaymentUsecase)(nil).GetPayment), ctx, orderId)
+}
+
+// HandleWebhook mocks base method.
+func (m *MockIPaymentUsecase) HandleWebhook(ctx context.Context, header http.Header, body []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleWebhook", ctx, header, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleWebhook indicates an expected call of HandleWebhook.
+func (mr *MockIPaymentUsecaseMockRecorder) HandleWebhook(ctx, header, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleWebhook", reflect.TypeOf((*MockIPaymentUsecase)(nil).HandleWebhook), ctx, header, body)
+}
+
+// Pay mocks base method.
+func (m *MockIPaymentUsecase) Pay(ctx context.Context, orderId uuid.UUID) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pay", ctx, orderId)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pay indicates an expected call of Pay.
+func (mr *MockIPaymentUsecaseMockRecorder) Pay(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pay", reflect.TypeOf((*MockIPaymentUsecase)(nil).Pay), ctx, orderId)
+}
+
+// Refund mocks base method.
+func (m *MockIPaymentUsecase) Refund(ctx context.Context, orderId uuid.UUID, amount int64) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refund", ctx, orderId, amount)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MockIPaymentUsecaseMockRecorder) Refund(ctx, orderId, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MockIPaymentUsecase)(nil).Refund), ctx, orderId, amount)
+}
+
+// RefundCanceled mocks base method.
+func (m *MockIPaymentUsecase) RefundCanceled(ctx context.Context, orderId uuid.UUID) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundCanceled", ctx, orderId)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundCanceled indicates an expected call of RefundCanceled.
+func (mr *MockIPaymentUsecaseMockRecorder) RefundCanceled(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundCanceled", reflect.TypeOf((*MockIPaymentUsecase)(nil).RefundCanceled), ctx, orderId)
+}
+
+// Run mocks base method.
+func (m *MockIPaymentUsecase) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIPaymentUsecaseMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIPaymentUsecase)(nil).Run), ctx)
+}
+
+// MockIReturnUsecase is a mock of IReturnUsecase interface.
+type MockIReturnUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIReturnUsecaseMockRecorder
+}
+
+// MockIReturnUsecaseMockRecorder is the mock recorder for MockIReturnUsecase.
+type MockIReturnUsecaseMockRecorder struct {
+	mock *MockIReturnUsecase
+}
+
+// NewMockIReturnUsecase creates a new mock instance.
+func NewMockIReturnUsecase(ctrl *gomock.Controller) *MockIReturnUsecase {
+	mock := &MockIReturnUsecase{ctrl: ctrl}
+	mock.recorder = &MockIReturnUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIReturnUsecase) EXPECT() *MockIReturnUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ChangeReturnStatus mocks base method.
+func (m *MockIReturnUsecase) ChangeReturnStatus(ctx context.Context, id uuid.UUID, status models.ReturnStatus, comment string) (*models.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeReturnStatus", ctx, id, status, comment)
+	ret0, _ := ret[0].(*models.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeReturnStatus indicates an expected call of ChangeReturnStatus.
+func (mr *MockIReturnUsecaseMockRecorder) ChangeReturnStatus(ctx, id, status, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeReturnStatus", reflect.TypeOf((*MockIReturnUsecase)(nil).ChangeReturnStatus), ctx, id, status, comment)
+}
+
+// CreateReturn mocks base method.
+func (m *MockIReturnUsecase) CreateReturn(ctx context.Context, ret *models.Return) (*models.Return, error) {
+	m.ctrl.T.Helper()
+	ret_2 := m.ctrl.Call(m, "CreateReturn", ctx, ret)
+	ret0, _ := ret_2[0].(*models.Return)
+	ret1, _ := ret_2[1].(error)
+	return ret0, ret1
+}
+
+// CreateReturn indicates an expected call of CreateReturn.
+func (mr *MockIReturnUsecaseMockRecorder) CreateReturn(ctx, ret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReturn", reflect.TypeOf((*MockIReturnUsecase)(nil).CreateReturn), ctx, ret)
+}
+
+// GetOrderReturns mocks base method.
+func (m *MockIReturnUsecase) GetOrderReturns(ctx context.Context, orderId uuid.UUID) ([]models.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderReturns", ctx, orderId)
+	ret0, _ := ret[0].([]models.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderReturns indicates an expected call of GetOrderReturns.
+func (mr *MockIReturnUsecaseMockRecorder) GetOrderReturns(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderReturns", reflect.TypeOf((*MockIReturnUsecase)(nil).GetOrderReturns), ctx, orderId)
+}
+
+// GetReturn mocks base method.
+func (m *MockIReturnUsecase) GetReturn(ctx context.Context, id uuid.UUID) (*models.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReturn", ctx, id)
+	ret0, _ := ret[0].(*models.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReturn indicates an expected call of GetReturn.
+func (mr *MockIReturnUsecaseMockRecorder) GetReturn(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturn", reflect.TypeOf((*MockIReturnUsecase)(nil).GetReturn), ctx, id)
+}
+
+// GetReturns mocks base method.
+func (m *MockIReturnUsecase) GetReturns(ctx context.Context, status models.ReturnStatus, offset, limit int) ([]models.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReturns", ctx, status, offset, limit)
+	ret0, _ := ret[0].([]models.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReturns indicates an expected call of GetReturns.
+func (mr *MockIReturnUsecaseMockRecorder) GetReturns(ctx, status, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturns", reflect.TypeOf((*MockIReturnUsecase)(nil).GetReturns), ctx, status, offset, limit)
+}
+
+// RefundReturn mocks base method.
+func (m *MockIReturnUsecase) RefundReturn(ctx context.Context, id uuid.UUID, amount int64) (*models.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundReturn", ctx, id, amount)
+	ret0, _ := ret[0].(*models.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundReturn indicates an expected call of RefundReturn.
+func (mr *MockIReturnUsecaseMockRecorder) RefundReturn(ctx, id, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundReturn", reflect.TypeOf((*MockIReturnUsecase)(nil).RefundReturn), ctx, id, amount)
+}
+
+// SetTrackingNumber mocks base method.
+func (m *MockIReturnUsecase) SetTrackingNumber(ctx context.Context, id uuid.UUID, trackingNumber string) (*models.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTrackingNumber", ctx, id, trackingNumber)
+	ret0, _ := ret[0].(*models.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTrackingNumber indicates an expected call of SetTrackingNumber.
+func (mr *MockIReturnUsecaseMockRecorder) SetTrackingNumber(ctx, id, trackingNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrackingNumber", reflect.TypeOf((*MockIReturnUsecase)(nil).SetTrackingNumber), ctx, id, trackingNumber)
+}
+
+// MockIOrderCancelUsecase is a mock of IOrderCancelUsecase interface.
+type MockIOrderCancelUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOrderCancelUsecaseMockRecorder
+}
+
+// MockIOrderCancelUsecaseMockRecorder is the mock recorder for MockIOrderCancelUsecase.
+type MockIOrderCancelUsecaseMockRecorder struct {
+	mock *MockIOrderCancelUsecase
+}
+
+// NewMockIOrderCancelUsecase creates a new mock instance.
+func NewMockIOrderCancelUsecase(ctrl *gomock.Controller) *MockIOrderCancelUsecase {
+	mock := &MockIOrderCancelUsecase{ctrl: ctrl}
+	mock.recorder = &MockIOrderCancelUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOrderCancelUsecase) EXPECT() *MockIOrderCancelUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CancelOrder mocks base method.
+func (m *MockIOrderCancelUsecase) CancelOrder(ctx context.Context, orderId uuid.UUID, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrder", ctx, orderId, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockIOrderCancelUsecaseMockRecorder) CancelOrder(ctx, orderId, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockIOrderCancelUsecase)(nil).CancelOrder), ctx, orderId, reason)
+}
+
+// MockIInvoiceUsecase is a mock of IInvoiceUsecase interface.
+type MockIInvoiceUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIInvoiceUsecaseMockRecorder
+}
+
+// MockIInvoiceUsecaseMockRecorder is the mock recorder for MockIInvoiceUsecase.
+type MockIInvoiceUsecaseMockRecorder struct {
+	mock *MockIInvoiceUsecase
+}
+
+// NewMockIInvoiceUsecase creates a new mock instance.
+func NewMockIInvoiceUsecase(ctrl *gomock.Controller) *MockIInvoiceUsecase {
+	mock := &MockIInvoiceUsecase{ctrl: ctrl}
+	mock.recorder = &MockIInvoiceUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIInvoiceUsecase) EXPECT() *MockIInvoiceUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetInvoice mocks base method.
+func (m *MockIInvoiceUsecase) GetInvoice(ctx context.Context, orderId uuid.UUID) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoice", ctx, orderId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetInvoice indicates an expected call of GetInvoice.
+func (mr *MockIInvoiceUsecaseMockRecorder) GetInvoice(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoice", reflect.TypeOf((*MockIInvoiceUsecase)(nil).GetInvoice), ctx, orderId)
+}
+
+// MockIOrderListUsecase is a mock of IOrderListUsecase interface.
+type MockIOrderListUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOrderListUsecaseMockRecorder
+}
+
+// MockIOrderListUsecaseMockRecorder is the mock recorder for MockIOrderListUsecase.
+type MockIOrderListUsecaseMockRecorder struct {
+	mock *MockIOrderListUsecase
+}
+
+// NewMockIOrderListUsecase creates a new mock instance.
+func NewMockIOrderListUsecase(ctrl *gomock.Controller) *MockIOrderListUsecase {
+	mock := &MockIOrderListUsecase{ctrl: ctrl}
+	mock.recorder = &MockIOrderListUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOrderListUsecase) EXPECT() *MockIOrderListUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ExportOrders mocks base method.
+func (m *MockIOrderListUsecase) ExportOrders(ctx context.Context, filter models.OrderFilter, format string, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportOrders", ctx, filter, format, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportOrders indicates an expected call of ExportOrders.
+func (mr *MockIOrderListUsecaseMockRecorder) ExportOrders(ctx, filter, format, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportOrders", reflect.TypeOf((*MockIOrderListUsecase)(nil).ExportOrders), ctx, filter, format, w)
+}
+
+// GetOrders mocks base method.
+func (m *MockIOrderListUsecase) GetOrders(ctx context.Context, filter models.OrderFilter) (*models.OrderList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrders", ctx, filter)
+	ret0, _ := ret[0].(*models.OrderList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrders indicates an expected call of GetOrders.
+func (mr *MockIOrderListUsecaseMockRecorder) GetOrders(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrders", reflect.TypeOf((*MockIOrderListUsecase)(nil).GetOrders), ctx, filter)
+}
+
+// MockIAddressUsecase is a mock of IAddressUsecase interface.
+type MockIAddressUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAddressUsecaseMockRecorder
+}
+
+// MockIAddressUsecaseMockRecorder is the mock recorder for MockIAddressUsecase.
+type MockIAddressUsecaseMockRecorder struct {
+	mock *MockIAddressUsecase
+}
+
+// NewMockIAddressUsecase creates a new mock instance.
+func NewMockIAddressUsecase(ctrl *gomock.Controller) *MockIAddressUsecase {
+	mock := &MockIAddressUsecase{ctrl: ctrl}
+	mock.recorder = &MockIAddressUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAddressUsecase) EXPECT() *MockIAddressUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateAddress mocks base method.
+func (m *MockIAddressUsecase) CreateAddress(ctx context.Context, address *models.SavedAddress) (*models.SavedAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAddress", ctx, address)
+	ret0, _ := ret[0].(*models.SavedAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAddress indicates an expected call of CreateAddress.
+func (mr *MockIAddressUsecaseMockRecorder) CreateAddress(ctx, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAddress", reflect.TypeOf((*MockIAddressUsecase)(nil).CreateAddress), ctx, address)
+}
+
+// DeleteAddress mocks base method.
+func (m *MockIAddressUsecase) DeleteAddress(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAddress", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAddress indicates an expected call of DeleteAddress.
+func (mr *MockIAddressUsecaseMockRecorder) DeleteAddress(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAddress", reflect.TypeOf((*MockIAddressUsecase)(nil).DeleteAddress), ctx, id)
+}
+
+// GetAddress mocks base method.
+func (m *MockIAddressUsecase) GetAddress(ctx context.Context, id uuid.UUID) (*models.SavedAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddress", ctx, id)
+	ret0, _ := ret[0].(*models.SavedAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddress indicates an expected call of GetAddress.
+func (mr *MockIAddressUsecaseMockRecorder) GetAddress(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddress", reflect.TypeOf((*MockIAddressUsecase)(nil).GetAddress), ctx, id)
+}
+
+// GetAddresses mocks base method.
+func (m *MockIAddressUsecase) GetAddresses(ctx context.Context, userId uuid.UUID) ([]models.SavedAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddresses", ctx, userId)
+	ret0, _ := ret[0].([]models.SavedAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddresses indicates an expected call of GetAddresses.
+func (mr *MockIAddressUsecaseMockRecorder) GetAddresses(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddresses", reflect.TypeOf((*MockIAddressUsecase)(nil).GetAddresses), ctx, userId)
+}
+
+// UpdateAddress mocks base method.
+func (m *MockIAddressUsecase) UpdateAddress(ctx context.Context, address *models.SavedAddress) (*models.SavedAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAddress", ctx, address)
+	ret0, _ := ret[0].(*models.SavedAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAddress indicates an expected call of UpdateAddress.
+func (mr *MockIAddressUsecaseMockRecorder) UpdateAddress(ctx, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAddress", reflect.TypeOf((*MockIAddressUsecase)(nil).UpdateAddress), ctx, address)
+}
+
+// MockIShipmentUsecase is a mock of IShipmentUsecase interface.
+type MockIShipmentUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIShipmentUsecaseMockRecorder
+}
+
+// MockIShipmentUsecaseMockRecorder is the mock recorder for MockIShipmentUsecase.
+type MockIShipmentUsecaseMockRecorder struct {
+	mock *MockIShipmentUsecase
+}
+
+// NewMockIShipmentUsecase creates a new mock instance.
+func NewMockIShipmentUsecase(ctrl *gomock.Controller) *MockIShipmentUsecase {
+	mock := &MockIShipmentUsecase{ctrl: ctrl}
+	mock.recorder = &MockIShipmentUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIShipmentUsecase) EXPECT() *MockIShipmentUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetShipment mocks base method.
+func (m *MockIShipmentUsecase) GetShipment(ctx context.Context, orderId uuid.UUID) (*models.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShipment", ctx, orderId)
+	ret0, _ := ret[0].(*models.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShipment indicates an expected call of GetShipment.
+func (mr *MockIShipmentUsecaseMockRecorder) GetShipment(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShipment", reflect.TypeOf((*MockIShipmentUsecase)(nil).GetShipment), ctx, orderId)
+}
+
+// Run mocks base method.
+func (m *MockIShipmentUsecase) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIShipmentUsecaseMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIShipmentUsecase)(nil).Run), ctx)
+}
+
+// Ship mocks base method.
+func (m *MockIShipmentUsecase) Ship(ctx context.Context, orderId uuid.UUID) (*models.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ship", ctx, orderId)
+	ret0, _ := ret[0].(*models.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Ship indicates an expected call of Ship.
+func (mr *MockIShipmentUsecaseMockRecorder) Ship(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ship", reflect.TypeOf((*MockIShipmentUsecase)(nil).Ship), ctx, orderId)
+}
+
+// TrackShipments mocks base method.
+func (m *MockIShipmentUsecase) TrackShipments(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrackShipments", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TrackShipments indicates an expected call of TrackShipments.
+func (mr *MockIShipmentUsecaseMockRecorder) TrackShipments(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackShipments", reflect.TypeOf((*MockIShipmentUsecase)(nil).TrackShipments), ctx)
+}
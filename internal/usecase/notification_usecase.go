@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/feed"
+	"OnlineShopBackend/internal/mail"
+	"OnlineShopBackend/internal/metrics"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ INotificationUsecase = &NotificationUsecase{}
+
+// notificationLease is the time for which claimed notifications are not claimed by other workers
+const notificationLease = 5 * time.Minute
+
+// notificationTemplates is the templates of emails by the kind of notifications
+var notificationTemplates = map[models.NotificationKind]string{
+	models.NotificationRegistration: mail.RegistrationTemplate,
+	models.NotificationOrderPlaced:  mail.OrderPlacedTemplate,
+	models.NotificationOrderStatus:  mail.OrderStatusTemplate,
+	models.NotificationOrderShipped: mail.OrderShippedTemplate,
+}
+
+type NotificationUsecase struct {
+	store      repository.NotificationStore
+	orderStore repository.OrderStore
+	sender     mail.Sender
+	shop       feed.Shop
+	pricing    models.CartPricing
+	// period is the interval between runs of the worker
+	period time.Duration
+	// batchSize is the maximum number of notifications sent in one run
+	batchSize int
+	// maxAttempts is the number of attempts to send the email before it fails,
+	// retryDelay is the delay after the first failed attempt which doubles after every next one
+	maxAttempts int
+	retryDelay  time.Duration
+	logger      *zap.Logger
+}
+
+func NewNotificationUsecase(store repository.NotificationStore, orderStore repository.OrderStore, sender mail.Sender, shop feed.Shop,
+	pricing models.CartPricing, period time.Duration, batchSize int, maxAttempts int, retryDelay time.Duration, logger *zap.Logger) INotificationUsecase {
+	logger.Debug("Enter in usecase NewNotificationUsecase()")
+	return &NotificationUsecase{
+		store:       store,
+		orderStore:  orderStore,
+		sender:      sender,
+		shop:        shop,
+		pricing:     pricing,
+		period:      period,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+		retryDelay:  retryDelay,
+		logger:      logger,
+	}
+}
+
+// SendNotifications sends emails of pending notifications due to be sent and returns the number of sent emails.
+// Emails turned off by users are skipped, the failed email is retried later until the last attempt
+func (usecase *NotificationUsecase) SendNotifications(ctx context.Context) (int, error) {
+	usecase.logger.Debug("Enter in usecase SendNotifications() with args: ctx")
+	notifications, err := usecase.store.ClaimNotifications(ctx, usecase.batchSize, notificationLease)
+	if err != nil {
+		return 0, fmt.Errorf("error on claim notifications: %w", err)
+	}
+	sent := 0
+	for i := range notifications {
+		select {
+		case <-ctx.Done():
+			return sent, ctx.Err()
+		default:
+		}
+		notification := &notifications[i]
+		usecase.send(ctx, notification)
+		if err := usecase.store.UpdateNotification(ctx, notification); err != nil {
+			return sent, fmt.Errorf("error on update notification: %w", err)
+		}
+		if notification.State == models.NotificationSent {
+			sent++
+		}
+	}
+	if sent > 0 {
+		usecase.logger.Sugar().Infof("%d notifications sent", sent)
+	}
+	return sent, nil
+}
+
+// send makes the attempt to send the email of the notification and sets the state of the notification
+func (usecase *NotificationUsecase) send(ctx context.Context, notification *models.Notification) {
+	if !notification.Preferences.Allows(notification.Kind) {
+		notification.State = models.NotificationSkipped
+		return
+	}
+	message, err := usecase.message(ctx, notification)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		usecase.logger.Sugar().Warnf("notification %v is skipped: %s", notification.Id, err)
+		notification.State = models.NotificationSkipped
+		return
+	}
+	if err == nil {
+		err = usecase.sender.Send(ctx, message)
+	}
+	notification.Attempts++
+	if err == nil {
+		metrics.NotificationsMetrics.NotificationsSentTotal.Inc()
+		notification.State = models.NotificationSent
+		notification.SentAt = time.Now()
+		notification.LastError = ""
+		return
+	}
+	notification.LastError = err.Error()
+	if notification.Attempts >= usecase.maxAttempts {
+		metrics.NotificationsMetrics.NotificationsFailedTotal.Inc()
+		usecase.logger.Sugar().Errorf("can't send notification %v after %d attempts: %s", notification.Id, notification.Attempts, err)
+		notification.State = models.NotificationFailed
+		return
+	}
+	metrics.NotificationsMetrics.NotificationsRetriedTotal.Inc()
+	usecase.logger.Sugar().Warnf("can't send notification %v, attempt %d: %s", notification.Id, notification.Attempts, err)
+	notification.NextAttemptAt = time.Now().Add(usecase.retryDelay << (notification.Attempts - 1))
+}
+
+// message builds the email of the notification in the language chosen by the user
+func (usecase *NotificationUsecase) message(ctx context.Context, notification *models.Notification) (mail.Message, error) {
+	name, ok := notificationTemplates[notification.Kind]
+	if !ok {
+		return mail.Message{}, fmt.Errorf("unknown kind of notification %q", notification.Kind)
+	}
+	language := notification.Preferences.Language
+	if notification.Kind == models.NotificationRegistration {
+		return mail.NewLocalizedMessage(notification.Email, name, language, mail.AccountData{
+			Name:     notification.Name,
+			ShopName: usecase.shop.Name,
+			SiteURL:  usecase.shop.SiteURL,
+		})
+	}
+	order, err := usecase.orderStore.GetOrderByID(ctx, notification.OrderId)
+	if err != nil {
+		return mail.Message{}, fmt.Errorf("error on get order %v: %w", notification.OrderId, err)
+	}
+	return mail.NewLocalizedMessage(notification.Email, name, language, usecase.orderData(notification, order))
+}
+
+func (usecase *NotificationUsecase) orderData(notification *models.Notification, order models.Order) mail.OrderData {
+	totals := order.Totals(usecase.pricing)
+	address := make([]string, 0, 7)
+	for _, part := range []string{order.Address.Zipcode, order.Address.Country, order.Address.City, order.Address.Street,
+		order.Address.Apartment, order.Address.Recipient, order.Address.Phone} {
+		if part != "" {
+			address = append(address, part)
+		}
+	}
+	data := mail.OrderData{
+		Name:     notification.Name,
+		ShopName: usecase.shop.Name,
+		Currency: usecase.shop.Currency,
+		OrderId:  order.ID.String(),
+		OrderURL: strings.TrimSuffix(usecase.shop.SiteURL, "/") + "/orders/" + order.ID.String(),
+		// The status at the event is reported, the order may have changed it since then
+		Status:         string(notification.Status),
+		Address:        strings.Join(address, ", "),
+		ShippingMethod: order.ShippingMethod,
+		Items:          make([]mail.OrderItem, 0, len(order.Items)),
+		Subtotal:       totals.Subtotal,
+		Discount:       totals.Discount,
+		Shipping:       totals.Shipping,
+		Total:          totals.Total,
+	}
+	for _, item := range order.Items {
+		data.Items = append(data.Items, mail.OrderItem{
+			Title:    item.Title,
+			Quantity: item.Quantity,
+			Price:    item.Price,
+		})
+	}
+	return data
+}
+
+// Run sends pending notifications periodically until ctx is done
+func (usecase *NotificationUsecase) Run(ctx context.Context) {
+	usecase.logger.Debug("Enter in usecase notification Run() with args: ctx")
+	ticker := time.NewTicker(usecase.period)
+	defer ticker.Stop()
+	for {
+		if _, err := usecase.SendNotifications(ctx); err != nil {
+			usecase.logger.Error(err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetPreferences returns the notification preferences of the user, only the user or an admin may get them
+func (usecase *NotificationUsecase) GetPreferences(ctx context.Context, userId uuid.UUID) (*models.NotificationPreferences, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase GetPreferences() with args: ctx, userId: %v", userId)
+	if err := checkOwner(ctx, userId); err != nil {
+		return nil, fmt.Errorf("preferences of another user: %w", err)
+	}
+	preferences, err := usecase.store.GetNotificationPreferences(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("error on get notification preferences: %w", err)
+	}
+	return preferences, nil
+}
+
+// SetPreferences saves the notification preferences of the user, only the user or an admin may change them
+func (usecase *NotificationUsecase) SetPreferences(ctx context.Context, preferences *models.NotificationPreferences) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase SetPreferences() with args: ctx, preferences: %v", preferences)
+	if err := checkOwner(ctx, preferences.UserId); err != nil {
+		return fmt.Errorf("preferences of another user: %w", err)
+	}
+	if preferences.Language != "" && !mail.ValidLanguage(preferences.Language) {
+		return fmt.Errorf("unknown language %q", preferences.Language)
+	}
+	if err := usecase.store.SetNotificationPreferences(ctx, preferences); err != nil {
+		return fmt.Errorf("error on set notification preferences: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/feed"
+	"OnlineShopBackend/internal/mail"
+	mailmocks "OnlineShopBackend/internal/mail/mocks"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/mocks"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSendNotifications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	notificationRepo := mocks.NewMockNotificationStore(ctrl)
+	orderRepo := mocks.NewMockOrderStore(ctrl)
+	sender := mailmocks.NewMockSender(ctrl)
+	shop := feed.Shop{Name: "Shop", SiteURL: "http://localhost:3000/", Currency: "RUB"}
+	pricing := models.CartPricing{ShippingCost: 300, FreeShippingThreshold: 5000}
+	usecase := NewNotificationUsecase(notificationRepo, orderRepo, sender, shop, pricing, time.Minute, 10, 3, time.Minute, zap.L())
+	ctx := context.Background()
+
+	notificationRepo.EXPECT().ClaimNotifications(ctx, 10, notificationLease).Return(nil, fmt.Errorf("error"))
+	res, err := usecase.SendNotifications(ctx)
+	require.Error(t, err)
+	require.Equal(t, 0, res)
+
+	userId := uuid.New()
+	preferences := models.DefaultNotificationPreferences(userId)
+	preferences.Language = "ru"
+	order := models.Order{
+		ID:      uuid.New(),
+		Address: models.UserAddress{Zipcode: "123456", City: "Moscow", Street: "Lenina, 1"},
+		Status:  models.StatusCourier,
+		Items:   []models.ItemWithQuantity{{Item: models.Item{Title: "smartphone", Price: 1000}, Quantity: 2}},
+	}
+	notification := func(kind models.NotificationKind, attempts int) models.Notification {
+		return models.Notification{
+			Id:          uuid.New(),
+			Kind:        kind,
+			UserId:      userId,
+			OrderId:     order.ID,
+			Status:      models.StatusPaid,
+			State:       models.NotificationPending,
+			Attempts:    attempts,
+			Email:       "user@mail.ru",
+			Name:        "Ivan",
+			Preferences: preferences,
+		}
+	}
+	registration := notification(models.NotificationRegistration, 0)
+	registration.OrderId = uuid.Nil
+	status := notification(models.NotificationOrderStatus, 0)
+	deleted := notification(models.NotificationOrderStatus, 0)
+	deleted.OrderId = uuid.New()
+	shipped := notification(models.NotificationOrderShipped, 0)
+	lastAttempt := notification(models.NotificationOrderShipped, 2)
+	turnedOff := notification(models.NotificationOrderPlaced, 0)
+	turnedOff.Preferences.OrderPlaced = false
+
+	notificationRepo.EXPECT().ClaimNotifications(ctx, 10, notificationLease).Return(
+		[]models.Notification{registration, turnedOff, status, deleted, shipped, lastAttempt}, nil)
+	orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(order, nil).Times(3)
+	orderRepo.EXPECT().GetOrderByID(ctx, deleted.OrderId).Return(models.Order{}, models.ErrorNotFound{})
+	var messages []mail.Message
+	sender.EXPECT().Send(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, message mail.Message) error {
+		messages = append(messages, message)
+		return nil
+	}).Times(2)
+	sender.EXPECT().Send(ctx, gomock.Any()).Return(fmt.Errorf("connection refused")).Times(2)
+	updated := make(map[uuid.UUID]models.Notification)
+	notificationRepo.EXPECT().UpdateNotification(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, notification *models.Notification) error {
+		updated[notification.Id] = *notification
+		return nil
+	}).Times(6)
+	before := time.Now()
+	res, err = usecase.SendNotifications(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, res)
+
+	require.Equal(t, models.NotificationSent, updated[registration.Id].State)
+	require.Equal(t, 1, updated[registration.Id].Attempts)
+	require.False(t, updated[registration.Id].SentAt.IsZero())
+	require.Equal(t, models.NotificationSkipped, updated[turnedOff.Id].State)
+	require.Equal(t, models.NotificationSent, updated[status.Id].State)
+	// The notification about the deleted order is not sent
+	require.Equal(t, models.NotificationSkipped, updated[deleted.Id].State)
+	// The failed email is retried later until the last attempt
+	require.Equal(t, models.NotificationPending, updated[shipped.Id].State)
+	require.Equal(t, 1, updated[shipped.Id].Attempts)
+	require.Equal(t, "connection refused", updated[shipped.Id].LastError)
+	require.True(t, updated[shipped.Id].NextAttemptAt.After(before.Add(time.Minute-time.Second)))
+	require.Equal(t, models.NotificationFailed, updated[lastAttempt.Id].State)
+	require.Equal(t, 3, updated[lastAttempt.Id].Attempts)
+
+	// Emails are in the language of the user and report the status at the event
+	require.Len(t, messages, 2)
+	require.Equal(t, "user@mail.ru", messages[0].To)
+	require.Contains(t, messages[0].Text, "Здравствуйте, Ivan!")
+	require.Contains(t, messages[0].Text, "http://localhost:3000")
+	require.Equal(t, fmt.Sprintf("Shop: заказ %s оплачен", order.ID), messages[1].Subject)
+	require.Contains(t, messages[1].Text, "http://localhost:3000/orders/"+order.ID.String())
+
+	notificationRepo.EXPECT().ClaimNotifications(ctx, 10, notificationLease).Return([]models.Notification{turnedOff}, nil)
+	notificationRepo.EXPECT().UpdateNotification(ctx, gomock.Any()).Return(fmt.Errorf("error"))
+	_, err = usecase.SendNotifications(ctx)
+	require.Error(t, err)
+}
+
+func TestOrderNotificationData(t *testing.T) {
+	usecase := &NotificationUsecase{
+		shop:    feed.Shop{Name: "Shop", SiteURL: "http://localhost:3000", Currency: "RUB"},
+		pricing: models.CartPricing{ShippingCost: 300, FreeShippingThreshold: 5000},
+	}
+	order := models.Order{
+		ID:      uuid.New(),
+		Address: models.UserAddress{Zipcode: "123456", City: "Moscow", Street: "Lenina, 1", Apartment: "12"},
+		Items:   []models.ItemWithQuantity{{Item: models.Item{Title: "smartphone", Price: 1000}, Quantity: 2}},
+	}
+	data := usecase.orderData(&models.Notification{Name: "Ivan", Status: models.StatusCreated}, order)
+	require.Equal(t, "123456, Moscow, Lenina, 1, 12", data.Address)
+	require.Equal(t, string(models.StatusCreated), data.Status)
+	require.Equal(t, int64(2000), data.Subtotal)
+	require.Equal(t, int64(300), data.Shipping)
+	require.Equal(t, int64(2300), data.Total)
+	require.Equal(t, []mail.OrderItem{{Title: "smartphone", Quantity: 2, Price: 1000}}, data.Items)
+
+	message, err := mail.NewLocalizedMessage("user@mail.ru", mail.OrderPlacedTemplate, "", data)
+	require.NoError(t, err)
+	require.Contains(t, message.Text, "smartphone x 2: 1000 RUB")
+	require.Contains(t, message.Text, "Total: 2300 RUB")
+}
+
+func TestNotificationPreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	notificationRepo := mocks.NewMockNotificationStore(ctrl)
+	usecase := NewNotificationUsecase(notificationRepo, nil, nil, feed.Shop{}, models.CartPricing{}, time.Minute, 10, 3, time.Minute, zap.L())
+	ctx := models.ContextWithActor(context.Background(), testActor)
+
+	_, err := usecase.GetPreferences(ctx, uuid.New())
+	require.True(t, errors.Is(err, models.ErrorNotFound{}))
+	err = usecase.SetPreferences(ctx, &models.NotificationPreferences{UserId: uuid.New()})
+	require.True(t, errors.Is(err, models.ErrorNotFound{}))
+
+	defaults := models.DefaultNotificationPreferences(testActor.UserId)
+	notificationRepo.EXPECT().GetNotificationPreferences(ctx, testActor.UserId).Return(&defaults, nil)
+	res, err := usecase.GetPreferences(ctx, testActor.UserId)
+	require.NoError(t, err)
+	require.True(t, res.OrderShipped)
+
+	preferences := models.NotificationPreferences{UserId: testActor.UserId, Language: "de"}
+	require.Error(t, usecase.SetPreferences(ctx, &preferences))
+
+	preferences.Language = "ru"
+	notificationRepo.EXPECT().SetNotificationPreferences(ctx, &preferences).Return(nil)
+	require.NoError(t, usecase.SetPreferences(ctx, &preferences))
+}
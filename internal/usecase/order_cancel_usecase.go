@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ IOrderCancelUsecase = &OrderCancelUsecase{}
+
+type OrderCancelUsecase struct {
+	uow          repository.UnitOfWork
+	orderStore   repository.OrderStore
+	paymentStore repository.PaymentStore
+	// paymentUsecase refunds the payment of the canceled order
+	paymentUsecase IPaymentUsecase
+	logger         *zap.Logger
+}
+
+func NewOrderCancelUsecase(uow repository.UnitOfWork, orderStore repository.OrderStore, paymentStore repository.PaymentStore,
+	paymentUsecase IPaymentUsecase, logger *zap.Logger) IOrderCancelUsecase {
+	logger.Debug("Enter in usecase NewOrderCancelUsecase()")
+	return &OrderCancelUsecase{
+		uow:            uow,
+		orderStore:     orderStore,
+		paymentStore:   paymentStore,
+		paymentUsecase: paymentUsecase,
+		logger:         logger,
+	}
+}
+
+// CancelOrder cancels the order with the reason. The customer may cancel own order until it is processed,
+// the admin may cancel any order before shipping. The stock reserved by the order is released,
+// the pending payment is canceled and the succeeded payment is refunded in the same transaction
+func (usecase *OrderCancelUsecase) CancelOrder(ctx context.Context, orderId uuid.UUID, reason string) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase CancelOrder() with args: ctx, orderId: %v, reason: %s", orderId, reason)
+	order, err := usecase.orderStore.GetOrderByID(ctx, orderId)
+	if err != nil {
+		return fmt.Errorf("error on get order: %w", err)
+	}
+	if err := checkOwner(ctx, order.User.ID); err != nil {
+		return fmt.Errorf("order %v of another user: %w", orderId, err)
+	}
+	actor, _ := models.ActorFromContext(ctx)
+	admin := actor.Role == models.Admin
+	if !order.Status.CanBeCanceled(admin) {
+		return fmt.Errorf("order %v in status %q can't be canceled: %w", orderId, order.Status, models.ErrorWrongStatus{})
+	}
+	err = usecase.uow.Do(ctx, func(ctx context.Context) error {
+		if _, err := usecase.orderStore.CancelOrder(ctx, orderId, reason, models.CancelableStatuses(admin)); err != nil {
+			return fmt.Errorf("error on cancel order: %w", err)
+		}
+		payments, err := usecase.paymentStore.GetPayments(ctx, orderId)
+		if err != nil {
+			return fmt.Errorf("error on get payments: %w", err)
+		}
+		refund := false
+		for i := range payments {
+			switch payments[i].Status {
+			case models.PaymentPending:
+				payments[i].Status = models.PaymentCanceled
+				if err := usecase.paymentStore.UpdatePayment(ctx, &payments[i]); err != nil {
+					return fmt.Errorf("error on cancel payment %v: %w", payments[i].Id, err)
+				}
+			case models.PaymentSucceeded:
+				refund = true
+			}
+		}
+		// The refund goes last, so that nothing fails after the money is returned
+		if refund {
+			if _, err := usecase.paymentUsecase.RefundCanceled(ctx, orderId); err != nil {
+				return fmt.Errorf("error on refund order: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	usecase.logger.Sugar().Infof("Order %v canceled: %s", orderId, reason)
+	return nil
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/mocks"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestOrderCancelUsecase(ctrl *gomock.Controller) (*OrderCancelUsecase, *mocks.MockOrderStore, *mocks.MockPaymentStore, *refunder) {
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	uow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	}).AnyTimes()
+	orderRepo := mocks.NewMockOrderStore(ctrl)
+	paymentRepo := mocks.NewMockPaymentStore(ctrl)
+	paymentUsecase := &refunder{}
+	usecase := NewOrderCancelUsecase(uow, orderRepo, paymentRepo, paymentUsecase, zap.L())
+	return usecase.(*OrderCancelUsecase), orderRepo, paymentRepo, paymentUsecase
+}
+
+func TestCancelOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	usecase, orderRepo, paymentRepo, paymentUsecase := newTestOrderCancelUsecase(ctrl)
+	ctx := models.ContextWithActor(context.Background(), testActor)
+	adminCtx := models.ContextWithActor(context.Background(), models.Actor{UserId: uuid.New(), Role: models.Admin})
+	orderId := uuid.New()
+	order := func(status models.Status) models.Order {
+		return models.Order{ID: orderId, User: models.User{ID: testId}, Status: status}
+	}
+
+	orderRepo.EXPECT().GetOrderByID(ctx, orderId).Return(models.Order{ID: orderId, User: models.User{ID: uuid.New()}, Status: models.StatusCreated}, nil)
+	err := usecase.CancelOrder(ctx, orderId, "Changed my mind")
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	// The customer can't cancel the processed order, but the admin can
+	orderRepo.EXPECT().GetOrderByID(ctx, orderId).Return(order(models.StatusProcessed), nil)
+	err = usecase.CancelOrder(ctx, orderId, "Changed my mind")
+	require.ErrorIs(t, err, models.ErrorWrongStatus{})
+	orderRepo.EXPECT().GetOrderByID(adminCtx, orderId).Return(order(models.StatusCourier), nil)
+	err = usecase.CancelOrder(adminCtx, orderId, "Out of stock")
+	require.ErrorIs(t, err, models.ErrorWrongStatus{})
+
+	orderRepo.EXPECT().GetOrderByID(adminCtx, orderId).Return(order(models.StatusProcessed), nil)
+	orderRepo.EXPECT().CancelOrder(adminCtx, orderId, "Out of stock", models.CancelableStatuses(true)).Return(models.StatusProcessed, nil)
+	paymentRepo.EXPECT().GetPayments(adminCtx, orderId).Return(nil, nil)
+	err = usecase.CancelOrder(adminCtx, orderId, "Out of stock")
+	require.NoError(t, err)
+
+	// The order is changed concurrently
+	orderRepo.EXPECT().GetOrderByID(ctx, orderId).Return(order(models.StatusCreated), nil)
+	orderRepo.EXPECT().CancelOrder(ctx, orderId, "Changed my mind", models.CancelableStatuses(false)).Return(models.Status(""), models.ErrorWrongStatus{})
+	err = usecase.CancelOrder(ctx, orderId, "Changed my mind")
+	require.ErrorIs(t, err, models.ErrorWrongStatus{})
+
+	// The pending payment is canceled
+	orderRepo.EXPECT().GetOrderByID(ctx, orderId).Return(order(models.StatusCreated), nil)
+	orderRepo.EXPECT().CancelOrder(ctx, orderId, "Changed my mind", models.CancelableStatuses(false)).Return(models.StatusCreated, nil)
+	paymentRepo.EXPECT().GetPayments(ctx, orderId).Return([]models.Payment{{OrderId: orderId, Status: models.PaymentPending}}, nil)
+	paymentRepo.EXPECT().UpdatePayment(ctx, &models.Payment{OrderId: orderId, Status: models.PaymentCanceled}).Return(nil)
+	err = usecase.CancelOrder(ctx, orderId, "Changed my mind")
+	require.NoError(t, err)
+
+	// The succeeded payment is refunded in full
+	refunded := false
+	paymentUsecase.refund = func(ctx context.Context, id uuid.UUID, amount int64) (*models.Payment, error) {
+		require.Equal(t, orderId, id)
+		require.Zero(t, amount)
+		refunded = true
+		return &models.Payment{}, nil
+	}
+	orderRepo.EXPECT().GetOrderByID(ctx, orderId).Return(order(models.StatusPaid), nil)
+	orderRepo.EXPECT().CancelOrder(ctx, orderId, "Changed my mind", models.CancelableStatuses(false)).Return(models.StatusPaid, nil)
+	paymentRepo.EXPECT().GetPayments(ctx, orderId).Return([]models.Payment{{OrderId: orderId, Status: models.PaymentSucceeded}}, nil)
+	err = usecase.CancelOrder(ctx, orderId, "Changed my mind")
+	require.NoError(t, err)
+	require.True(t, refunded)
+
+	paymentUsecase.refund = func(ctx context.Context, id uuid.UUID, amount int64) (*models.Payment, error) {
+		return nil, fmt.Errorf("error")
+	}
+	orderRepo.EXPECT().GetOrderByID(ctx, orderId).Return(order(models.StatusPaid), nil)
+	orderRepo.EXPECT().CancelOrder(ctx, orderId, "Changed my mind", models.CancelableStatuses(false)).Return(models.StatusPaid, nil)
+	paymentRepo.EXPECT().GetPayments(ctx, orderId).Return([]models.Payment{{OrderId: orderId, Status: models.PaymentSucceeded}}, nil)
+	err = usecase.CancelOrder(ctx, orderId, "Changed my mind")
+	require.Error(t, err)
+}
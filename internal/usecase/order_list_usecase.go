@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/export"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+var _ IOrderListUsecase = &OrderListUsecase{}
+
+// Limits of quantity of orders returned by one request
+const (
+	defaultOrdersLimit = 50
+	maxOrdersLimit     = 500
+)
+
+type OrderListUsecase struct {
+	orderStore repository.OrderStore
+	pricing    models.CartPricing
+	logger     *zap.Logger
+}
+
+func NewOrderListUsecase(orderStore repository.OrderStore, pricing models.CartPricing, logger *zap.Logger) IOrderListUsecase {
+	logger.Debug("Enter in usecase NewOrderListUsecase()")
+	return &OrderListUsecase{orderStore: orderStore, pricing: pricing, logger: logger}
+}
+
+// GetOrders returns the page of orders satisfying the filter with the quantities
+// of orders by status for the admin
+func (usecase *OrderListUsecase) GetOrders(ctx context.Context, filter models.OrderFilter) (*models.OrderList, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase GetOrders() with args: ctx, filter: %v", filter)
+	if err := checkAdmin(ctx); err != nil {
+		return nil, fmt.Errorf("orders can be listed by admin only: %w", err)
+	}
+	if filter.Status != "" && !filter.Status.Valid() {
+		return nil, fmt.Errorf("error on get orders: unknown status %q", filter.Status)
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.From.After(filter.To) {
+		return nil, fmt.Errorf("error on get orders: start of period is after its end")
+	}
+	if filter.MinTotal != nil && filter.MaxTotal != nil && *filter.MinTotal > *filter.MaxTotal {
+		return nil, fmt.Errorf("error on get orders: minimal total is greater than maximal")
+	}
+	switch filter.SortType {
+	case "", models.OrderSortCreatedAt, models.OrderSortTotal, models.OrderSortStatus, models.OrderSortEmail:
+	default:
+		return nil, fmt.Errorf("error on get orders: unknown sort type %q", filter.SortType)
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultOrdersLimit
+	}
+	if filter.Limit > maxOrdersLimit {
+		filter.Limit = maxOrdersLimit
+	}
+	orders, err := usecase.orderStore.GetOrders(ctx, filter, usecase.pricing)
+	if err != nil {
+		return nil, fmt.Errorf("error on get orders: %w", err)
+	}
+	counts, err := usecase.orderStore.CountOrdersByStatus(ctx, filter, usecase.pricing)
+	if err != nil {
+		return nil, fmt.Errorf("error on count orders: %w", err)
+	}
+	list := &models.OrderList{Orders: orders, StatusCounts: make(map[models.Status]int, len(counts))}
+	for status, count := range counts {
+		list.StatusCounts[status] = count
+		if filter.Status == "" || filter.Status == status {
+			list.Quantity += count
+		}
+	}
+	return list, nil
+}
+
+// exportColumns is the header of the export of orders
+var exportColumns = []interface{}{
+	"Order ID", "Created at", "Status", "Customer", "Email", "Zipcode", "Country", "City", "Street", "Apartment", "Recipient", "Phone",
+	"Shipping method",
+	"Item ID", "Item", "Price", "Quantity", "Line total", "Order subtotal", "Order discount", "Order shipping", "Order total",
+}
+
+// ExportOrders writes lines of orders satisfying the filter to w in the format of export,
+// one row per line with the customer, the address and the totals of its order. Only admins export orders
+func (usecase *OrderListUsecase) ExportOrders(ctx context.Context, filter models.OrderFilter, format string, w io.Writer) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase ExportOrders() with args: ctx, filter: %v, format: %s", filter, format)
+	if err := checkAdmin(ctx); err != nil {
+		return fmt.Errorf("orders can be exported by admin only: %w", err)
+	}
+	if !export.Valid(format) {
+		return fmt.Errorf("error on export orders: unknown format %q", format)
+	}
+	if filter.Status != "" && !filter.Status.Valid() {
+		return fmt.Errorf("error on export orders: unknown status %q", filter.Status)
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.From.After(filter.To) {
+		return fmt.Errorf("error on export orders: start of period is after its end")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	lines, errs, err := usecase.orderStore.ExportOrders(ctx, filter, usecase.pricing)
+	if err != nil {
+		return fmt.Errorf("error on export orders: %w", err)
+	}
+	writer, err := export.NewWriter(format, w)
+	if err != nil {
+		return fmt.Errorf("error on export orders: %w", err)
+	}
+	if err := writer.Write(exportColumns); err != nil {
+		return fmt.Errorf("error on export orders: %w", err)
+	}
+	for line := range lines {
+		err := writer.Write([]interface{}{
+			line.OrderId,
+			line.CreatedAt,
+			string(line.Status),
+			strings.TrimSpace(line.User.Firstname + " " + line.User.Lastname),
+			line.User.Email,
+			line.Address.Zipcode,
+			line.Address.Country,
+			line.Address.City,
+			line.Address.Street,
+			line.Address.Apartment,
+			line.Address.Recipient,
+			line.Address.Phone,
+			line.ShippingMethod,
+			line.Item.Id,
+			line.Item.Title,
+			line.Price,
+			line.Quantity,
+			int64(line.Price) * int64(line.Quantity),
+			line.Subtotal,
+			line.Discount,
+			line.Shipping,
+			line.Total,
+		})
+		if err != nil {
+			return fmt.Errorf("error on export orders: %w", err)
+		}
+	}
+	// Lines are read until the first error, so the export is incomplete if the reading failed
+	if err := <-errs; err != nil {
+		return fmt.Errorf("error on export orders: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("error on export orders: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/mocks"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestGetOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := testAdminCtx
+	orderRepo := mocks.NewMockOrderStore(ctrl)
+	pricing := models.CartPricing{ShippingCost: 300}
+	usecase := NewOrderListUsecase(orderRepo, pricing, zap.L())
+
+	_, err := usecase.GetOrders(models.ContextWithActor(context.Background(), testActor), models.OrderFilter{})
+	require.ErrorIs(t, err, models.ErrorForbidden{})
+
+	now := time.Now()
+	_, err = usecase.GetOrders(ctx, models.OrderFilter{From: now, To: now.Add(-time.Hour)})
+	require.Error(t, err)
+	_, err = usecase.GetOrders(ctx, models.OrderFilter{Status: "unknown"})
+	require.Error(t, err)
+	_, err = usecase.GetOrders(ctx, models.OrderFilter{SortType: "title"})
+	require.Error(t, err)
+	min, max := int64(100), int64(10)
+	_, err = usecase.GetOrders(ctx, models.OrderFilter{MinTotal: &min, MaxTotal: &max})
+	require.Error(t, err)
+
+	orderRepo.EXPECT().GetOrders(ctx, models.OrderFilter{Limit: defaultOrdersLimit}, pricing).Return(nil, fmt.Errorf("error"))
+	_, err = usecase.GetOrders(ctx, models.OrderFilter{})
+	require.Error(t, err)
+
+	filter := models.OrderFilter{Status: models.StatusPaid, SortType: models.OrderSortTotal, Limit: maxOrdersLimit}
+	orders := []models.OrderSummary{{ID: testId, Status: models.StatusPaid, Total: 1300}}
+	orderRepo.EXPECT().GetOrders(ctx, filter, pricing).Return(orders, nil)
+	orderRepo.EXPECT().CountOrdersByStatus(ctx, filter, pricing).Return(nil, fmt.Errorf("error"))
+	_, err = usecase.GetOrders(ctx, models.OrderFilter{Status: models.StatusPaid, SortType: models.OrderSortTotal, Limit: 5000})
+	require.Error(t, err)
+
+	counts := map[models.Status]int{models.StatusPaid: 3, models.StatusCreated: 2}
+	orderRepo.EXPECT().GetOrders(ctx, filter, pricing).Return(orders, nil)
+	orderRepo.EXPECT().CountOrdersByStatus(ctx, filter, pricing).Return(counts, nil)
+	list, err := usecase.GetOrders(ctx, filter)
+	require.NoError(t, err)
+	require.Equal(t, &models.OrderList{Orders: orders, Quantity: 3, StatusCounts: counts}, list)
+
+	filter.Status = ""
+	orderRepo.EXPECT().GetOrders(ctx, filter, pricing).Return(orders, nil)
+	orderRepo.EXPECT().CountOrdersByStatus(ctx, filter, pricing).Return(counts, nil)
+	list, err = usecase.GetOrders(ctx, filter)
+	require.NoError(t, err)
+	require.Equal(t, 5, list.Quantity)
+}
+
+func TestExportOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := testAdminCtx
+	orderRepo := mocks.NewMockOrderStore(ctrl)
+	pricing := models.CartPricing{ShippingCost: 300}
+	usecase := NewOrderListUsecase(orderRepo, pricing, zap.L())
+
+	require.ErrorIs(t, usecase.ExportOrders(models.ContextWithActor(context.Background(), testActor), models.OrderFilter{}, "csv", io.Discard),
+		models.ErrorForbidden{})
+	require.Error(t, usecase.ExportOrders(ctx, models.OrderFilter{}, "pdf", io.Discard))
+	now := time.Now()
+	require.Error(t, usecase.ExportOrders(ctx, models.OrderFilter{From: now, To: now.Add(-time.Hour)}, "csv", io.Discard))
+
+	filter := models.OrderFilter{From: now.Add(-time.Hour), To: now}
+	orderRepo.EXPECT().ExportOrders(gomock.Any(), filter, pricing).Return(nil, nil, fmt.Errorf("error"))
+	require.Error(t, usecase.ExportOrders(ctx, filter, "csv", io.Discard))
+
+	lines := make(chan models.OrderExportLine, 2)
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, item := range []models.Item{testItem11, testItem2} {
+		lines <- models.OrderExportLine{
+			OrderId:   testId,
+			CreatedAt: createdAt,
+			Status:    models.StatusPaid,
+			User:      models.User{Firstname: "Name", Lastname: "Lastname", Email: "user@mail.ru"},
+			Address: models.UserAddress{Zipcode: "123456", Country: "Russia", City: "Moscow", Street: "Lenina, 1",
+				Apartment: "12", Recipient: "Ivan Ivanov", Phone: "+79001234567"},
+			Item:     item,
+			Price:    item.Price,
+			Quantity: 2,
+			Subtotal: 2 * int64(testItem11.Price+testItem2.Price),
+			Shipping: 300,
+			Total:    2*int64(testItem11.Price+testItem2.Price) + 300,
+		}
+	}
+	close(lines)
+	errs := make(chan error, 1)
+	errs <- fmt.Errorf("can't scan order line")
+	close(errs)
+	orderRepo.EXPECT().ExportOrders(gomock.Any(), filter, pricing).Return(lines, errs, nil)
+	require.Error(t, usecase.ExportOrders(ctx, filter, "csv", io.Discard))
+
+	lines = make(chan models.OrderExportLine, 2)
+	for _, item := range []models.Item{testItem11, testItem2} {
+		lines <- models.OrderExportLine{
+			OrderId:   testId,
+			CreatedAt: createdAt,
+			Status:    models.StatusPaid,
+			User:      models.User{Firstname: "Name", Lastname: "Lastname", Email: "user@mail.ru"},
+			Address: models.UserAddress{Zipcode: "123456", Country: "Russia", City: "Moscow", Street: "Lenina, 1",
+				Apartment: "12", Recipient: "Ivan Ivanov", Phone: "+79001234567"},
+			Item:     item,
+			Price:    item.Price,
+			Quantity: 2,
+			Subtotal: 2 * int64(testItem11.Price+testItem2.Price),
+			Shipping: 300,
+			Total:    2*int64(testItem11.Price+testItem2.Price) + 300,
+		}
+	}
+	close(lines)
+	errs = make(chan error)
+	close(errs)
+	orderRepo.EXPECT().ExportOrders(gomock.Any(), filter, pricing).Return(lines, errs, nil)
+	var buf bytes.Buffer
+	require.NoError(t, usecase.ExportOrders(ctx, filter, "csv", &buf))
+	rows := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, rows, 3)
+	require.True(t, strings.HasPrefix(rows[0], "\ufeffOrder ID,Created at,Status,Customer,"))
+	require.Equal(t, fmt.Sprintf("%s,2023-01-02 03:04:05,%s,Name Lastname,user@mail.ru,123456,Russia,Moscow,\"Lenina, 1\",12,Ivan Ivanov,+79001234567,,%s,%s,%d,2,%d,%d,0,300,%d",
+		testId, models.StatusPaid, testItem2.Id, testItem2.Title, testItem2.Price, 2*testItem2.Price,
+		2*int64(testItem11.Price+testItem2.Price), 2*int64(testItem11.Price+testItem2.Price)+300), rows[2])
+}
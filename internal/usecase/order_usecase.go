@@ -5,7 +5,6 @@ import (
 	"OnlineShopBackend/internal/repository"
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -25,40 +24,28 @@ func NewOrderUsecase(orderStore repository.OrderStore, logger *zap.SugaredLogger
 	}
 }
 
-func (o *order) PlaceOrder(ctx context.Context, cart *models.Cart, user models.User, address models.UserAddress) (*models.Order, error) {
+// ChangeStatus moves the order to the next status by the admin. The cancel, the payment and the shipment
+// change statuses by their own operations, so other transitions return ErrorWrongStatus
+func (o *order) ChangeStatus(ctx context.Context, order *models.Order, newStatus models.Status) error {
 	select {
 	case <-ctx.Done():
 		o.logger.Error("context closed")
-		return nil, fmt.Errorf("context closed")
+		return fmt.Errorf("context closed")
 	default:
-		ordr := models.Order{
-			User:         user,
-			Address:      address,
-			Status:       models.StatusCreated,
-			CreatedAt:    time.Now(),
-			ShipmentTime: time.Now().Add(models.ProlongedShipmentPeriod),
-			Items:        append([]models.ItemWithQuantity{}[:0:0], cart.Items...),
+		if err := checkAdmin(ctx); err != nil {
+			return fmt.Errorf("status of order %v can be changed by admin only: %w", order.ID, err)
 		}
-		res, err := o.orderStore.Create(ctx, &ordr)
+		stored, err := o.getOrder(ctx, order.ID)
 		if err != nil {
-			o.logger.Errorf("can't add order to db %s", err)
-			return nil, fmt.Errorf("can't place order to db : %w", err)
+			return err
 		}
-		o.logger.Debugf("order %s created", res.ID.String())
-		return res, nil
-	}
-}
-
-func (o *order) ChangeStatus(ctx context.Context, order *models.Order, newStatus models.Status) error {
-	select {
-	case <-ctx.Done():
-		o.logger.Error("context closed")
-		return fmt.Errorf("context closed")
-	default:
-		if newStatus == order.Status {
+		if newStatus == stored.Status {
 			return nil
 		}
-		if err := o.orderStore.ChangeStatus(ctx, order, newStatus); err != nil {
+		if !stored.Status.CanChangeTo(newStatus) {
+			return fmt.Errorf("order %v can't move from %q to %q: %w", order.ID, stored.Status, newStatus, models.ErrorWrongStatus{})
+		}
+		if err := o.orderStore.ChangeStatus(ctx, stored, newStatus); err != nil {
 			o.logger.Errorf("can't change status of order: %s", err)
 			return fmt.Errorf("can't change status of order: %w", err)
 		}
@@ -71,6 +58,9 @@ func (o *order) GetOrdersForUser(ctx context.Context, user *models.User) ([]mode
 		o.logger.Error("context closed")
 		return nil, fmt.Errorf("context closed")
 	default:
+		if err := checkOwner(ctx, user.ID); err != nil {
+			return nil, err
+		}
 		result := make([]models.Order, 0, 10)
 		resChan, err := o.orderStore.GetOrdersForUser(ctx, user)
 		if err != nil {
@@ -89,7 +79,15 @@ func (o *order) DeleteOrder(ctx context.Context, order *models.Order) error {
 		o.logger.Error("context closed")
 		return fmt.Errorf("context closed")
 	default:
-		if err := o.orderStore.DeleteOrder(ctx, order); err != nil {
+		stored, err := o.getOrder(ctx, order.ID)
+		if err != nil {
+			return err
+		}
+		// Customers cancel their orders, only admins delete them
+		if actor, _ := models.ActorFromContext(ctx); actor.Role != models.Admin {
+			return fmt.Errorf("order %v can be deleted by admin only: %w", order.ID, models.ErrorForbidden{})
+		}
+		if err := o.orderStore.DeleteOrder(ctx, stored); err != nil {
 			o.logger.Error("can't delete order %s", err)
 			return fmt.Errorf("can't delete order %w", err)
 		}
@@ -103,10 +101,17 @@ func (o *order) ChangeAddress(ctx context.Context, order *models.Order, newAddre
 		o.logger.Error("context closed")
 		return fmt.Errorf("context closed")
 	default:
-		if newAddress == order.Address {
+		stored, err := o.getOrder(ctx, order.ID)
+		if err != nil {
+			return err
+		}
+		if newAddress == stored.Address {
 			return nil
 		}
-		if err := o.orderStore.ChangeAddress(ctx, order, newAddress); err != nil {
+		if !stored.Status.AddressChangeable() {
+			return fmt.Errorf("address of order %v in status %q can't be changed: %w", stored.ID, stored.Status, models.ErrorWrongStatus{})
+		}
+		if err := o.orderStore.ChangeAddress(ctx, stored, newAddress); err != nil {
 			o.logger.Errorf("can't change address %s: ", err)
 			return fmt.Errorf("can't change address %w: ", err)
 		}
@@ -120,12 +125,22 @@ func (o *order) GetOrder(ctx context.Context, id uuid.UUID) (*models.Order, erro
 		o.logger.Error("context closed")
 		return nil, fmt.Errorf("context closed")
 	default:
-		res, err := o.orderStore.GetOrderByID(ctx, id)
-		if err != nil {
-			o.logger.Errorf("can't get order: %s", err)
-			return nil, fmt.Errorf("can't get order: %w", err)
-		}
-		return &res, nil
+		return o.getOrder(ctx, id)
 	}
 
 }
+
+// getOrder returns the order if the caller from ctx is its owner or an admin,
+// orders of other users are not found
+func (o *order) getOrder(ctx context.Context, id uuid.UUID) (*models.Order, error) {
+	res, err := o.orderStore.GetOrderByID(ctx, id)
+	if err != nil {
+		o.logger.Errorf("can't get order: %s", err)
+		return nil, fmt.Errorf("can't get order: %w", err)
+	}
+	if err := checkOwner(ctx, res.User.ID); err != nil {
+		o.logger.Errorf("can't get order: order %v of another user", id)
+		return nil, fmt.Errorf("can't get order: %w", err)
+	}
+	return &res, nil
+}
@@ -66,10 +66,18 @@ var (
 	}
 
 	lgr = zap.NewExample().Sugar()
+
+	testAdminCtx = models.ContextWithActor(context.Background(), models.Actor{UserId: uuid.New(), Role: models.Admin})
 )
 
 type orderRepoMock struct {
 	err error
+	// ownerID is the user of orders returned by GetOrderByID, random if empty
+	ownerID uuid.UUID
+	// status is the last status set by ChangeStatus
+	status models.Status
+	// stored is the status of orders returned by GetOrderByID, the status of testOrder if empty
+	stored models.Status
 }
 
 var _ repository.OrderStore = (*orderRepoMock)(nil)
@@ -85,13 +93,20 @@ func (orMock *orderRepoMock) ChangeAddress(ctx context.Context, order *models.Or
 	order.Address = address
 	return orMock.err
 }
+func (orMock *orderRepoMock) CancelOrder(ctx context.Context, id uuid.UUID, reason string, statuses []models.Status) (models.Status, error) {
+	return models.StatusCreated, orMock.err
+}
 func (orMock *orderRepoMock) ChangeStatus(ctx context.Context, order *models.Order, status models.Status) error {
 	order.Status = status
+	orMock.status = status
 	return orMock.err
 }
 
 func (orMock *orderRepoMock) GetOrderByID(ctx context.Context, id uuid.UUID) (models.Order, error) {
-	userID, _ := uuid.NewRandom()
+	userID := orMock.ownerID
+	if userID == uuid.Nil {
+		userID, _ = uuid.NewRandom()
+	}
 	itemID1, _ := uuid.NewRandom()
 	itemID2, _ := uuid.NewRandom()
 	order := testOrder
@@ -99,6 +114,9 @@ func (orMock *orderRepoMock) GetOrderByID(ctx context.Context, id uuid.UUID) (mo
 	order.User.ID = userID
 	order.Items[0].Id = itemID1
 	order.Items[1].Id = itemID2
+	if orMock.stored != "" {
+		order.Status = orMock.stored
+	}
 	return order, orMock.err
 }
 
@@ -107,67 +125,65 @@ func (orMock *orderRepoMock) GetOrdersForUser(ctx context.Context, user *models.
 	return res, orMock.err
 }
 
-func TestPlaceOrder(t *testing.T) {
-	uscs := NewOrderUsecase(&orderRepoMock{}, lgr)
-	cartID, _ := uuid.NewRandom()
-	userID, _ := uuid.NewRandom()
-	cart := models.Cart{
-		Id:     cartID,
-		UserId: userID,
-		Items: []models.ItemWithQuantity{
-			{Item: testItem11, Quantity: 1},
-			{Item: testItem2, Quantity: 1},
-		},
-		ExpireAt: time.Now().Add(2 * time.Hour),
-	}
-	res, err := uscs.PlaceOrder(context.Background(), &cart, testUser, testOrder.Address)
-	require.NoError(t, err)
-	assert.Equal(t, testUser.Address, res.Address)
-	assert.Equal(t, cart.Items, res.Items)
+func (orMock *orderRepoMock) GetOrders(ctx context.Context, filter models.OrderFilter, pricing models.CartPricing) ([]models.OrderSummary, error) {
+	return nil, orMock.err
 }
 
-func TestPlaceOrderDBError(t *testing.T) {
-	uscs := NewOrderUsecase(&orderRepoMock{err: fmt.Errorf("test error")}, lgr)
-	cartID, _ := uuid.NewRandom()
-	userID, _ := uuid.NewRandom()
-	cart := models.Cart{
-		Id:     cartID,
-		UserId: userID,
-		Items: []models.ItemWithQuantity{
-			{Item: testItem11, Quantity: 2},
-			{Item: testItem2, Quantity: 1},
-		},
-		ExpireAt: time.Now().Add(2 * time.Hour),
-	}
-	res, err := uscs.PlaceOrder(context.Background(), &cart, testUser, testOrder.Address)
-	require.Error(t, err)
-	assert.Nil(t, res)
+func (orMock *orderRepoMock) CountOrdersByStatus(ctx context.Context, filter models.OrderFilter, pricing models.CartPricing) (map[models.Status]int, error) {
+	return nil, orMock.err
+}
+
+func (orMock *orderRepoMock) ExportOrders(ctx context.Context, filter models.OrderFilter, pricing models.CartPricing) (chan models.OrderExportLine, chan error, error) {
+	res := make(chan models.OrderExportLine)
+	close(res)
+	errs := make(chan error)
+	close(errs)
+	return res, errs, orMock.err
 }
 
 func TestChangeStatus(t *testing.T) {
-	uscs := NewOrderUsecase(&orderRepoMock{}, lgr)
-	err := uscs.ChangeStatus(context.Background(), &testOrder, models.StatusProcessed)
-	defer func() {
-		testOrder.Status = models.StatusCreated
-	}()
+	repo := &orderRepoMock{stored: models.StatusProcessing}
+	uscs := NewOrderUsecase(repo, lgr)
+	order := testOrder
+	err := uscs.ChangeStatus(testAdminCtx, &order, models.StatusProcessed)
 	require.NoError(t, err)
-	assert.Equal(t, models.StatusProcessed, testOrder.Status)
+	assert.Equal(t, models.StatusProcessed, repo.status)
 
+	// Customers can't change statuses
+	repo.status = ""
+	customerCtx := models.ContextWithActor(context.Background(), models.Actor{UserId: uuid.New(), Role: models.Customer})
+	err = uscs.ChangeStatus(customerCtx, &order, models.StatusProcessed)
+	require.ErrorIs(t, err, models.ErrorForbidden{})
+
+	// Cancel, payment, shipment and skipped statuses are not changed by hand
+	for _, tc := range []struct {
+		stored, next models.Status
+	}{
+		{models.StatusProcessing, models.StatusCanceled},
+		{models.StatusCreated, models.StatusPaid},
+		{models.StatusCreated, models.StatusProcessing},
+		{models.StatusPaid, models.StatusProcessed},
+		{models.StatusReady, models.StatusCourier},
+		{models.StatusCanceled, models.StatusProcessing},
+		{models.StatusShipped, models.StatusProcessing},
+	} {
+		repo.stored = tc.stored
+		err = uscs.ChangeStatus(testAdminCtx, &order, tc.next)
+		require.ErrorIs(t, err, models.ErrorWrongStatus{}, "%s -> %s", tc.stored, tc.next)
+	}
+	require.Empty(t, repo.status)
 }
 
 func TestChangeStatusError(t *testing.T) {
 	uscs := NewOrderUsecase(&orderRepoMock{err: fmt.Errorf("test error")}, lgr)
-	err := uscs.ChangeStatus(context.Background(), &testOrder, models.StatusProcessed)
-	defer func() {
-		testOrder.Status = models.StatusCreated
-	}()
+	err := uscs.ChangeStatus(testAdminCtx, &testOrder, models.StatusProcessed)
 	require.Error(t, err)
 }
 
 func TestChangeAddress(t *testing.T) {
 	uscs := NewOrderUsecase(&orderRepoMock{}, lgr)
 	oldAddress := testOrder.Address
-	err := uscs.ChangeAddress(context.Background(), &testOrder, models.UserAddress{
+	err := uscs.ChangeAddress(testAdminCtx, &testOrder, models.UserAddress{
 		Street:  "הלל 49",
 		City:    "חיפה",
 		Zipcode: "313455",
@@ -182,7 +198,7 @@ func TestChangeAddress(t *testing.T) {
 func TestChangeAddressError(t *testing.T) {
 	uscs := NewOrderUsecase(&orderRepoMock{err: fmt.Errorf("test error")}, lgr)
 	oldAddress := testOrder.Address
-	err := uscs.ChangeAddress(context.Background(), &testOrder, models.UserAddress{
+	err := uscs.ChangeAddress(testAdminCtx, &testOrder, models.UserAddress{
 		Street:  "הלל 49",
 		City:    "חיפה",
 		Zipcode: "313455",
@@ -194,17 +210,65 @@ func TestChangeAddressError(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestChangeAddressStatus(t *testing.T) {
+	newAddress := models.UserAddress{City: "Moscow"}
+	for _, status := range []models.Status{models.StatusCourier, models.StatusShipped, models.StatusCanceled} {
+		uscs := NewOrderUsecase(&orderRepoMock{stored: status}, lgr)
+		err := uscs.ChangeAddress(testAdminCtx, &models.Order{ID: uuid.New()}, newAddress)
+		require.ErrorIs(t, err, models.ErrorWrongStatus{}, status)
+	}
+	for _, status := range []models.Status{models.StatusPaid, models.StatusReady} {
+		uscs := NewOrderUsecase(&orderRepoMock{stored: status}, lgr)
+		err := uscs.ChangeAddress(testAdminCtx, &models.Order{ID: uuid.New()}, newAddress)
+		require.NoError(t, err, status)
+	}
+}
+
 func TestDeleteOrder(t *testing.T) {
 	uscs := NewOrderUsecase(&orderRepoMock{}, lgr)
-	err := uscs.DeleteOrder(context.Background(), &testOrder)
+	err := uscs.DeleteOrder(testAdminCtx, &testOrder)
 	require.NoError(t, err)
 }
 
 func TestGetOrder(t *testing.T) {
 	id, _ := uuid.NewRandom()
 	uscs := NewOrderUsecase(&orderRepoMock{}, lgr)
-	order, err := uscs.GetOrder(context.Background(), id)
+	order, err := uscs.GetOrder(testAdminCtx, id)
 	require.NoError(t, err)
 	assert.Equal(t, testOrder.User.Firstname, order.User.Firstname)
 	assert.Equal(t, testOrder.ShipmentTime, order.ShipmentTime)
 }
+
+func TestOrderOwnership(t *testing.T) {
+	ownerID, otherID := uuid.New(), uuid.New()
+	repo := &orderRepoMock{ownerID: ownerID}
+	uscs := NewOrderUsecase(repo, lgr)
+	ownerCtx := models.ContextWithActor(context.Background(), models.Actor{UserId: ownerID, Role: models.Customer})
+	otherCtx := models.ContextWithActor(context.Background(), models.Actor{UserId: otherID, Role: models.Customer})
+	id := uuid.New()
+
+	// Orders of other users are not found
+	_, err := uscs.GetOrder(otherCtx, id)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+	_, err = uscs.GetOrder(context.Background(), id)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+	_, err = uscs.GetOrdersForUser(otherCtx, &models.User{ID: ownerID})
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+	err = uscs.ChangeAddress(otherCtx, &models.Order{ID: id}, models.UserAddress{City: "Moscow"})
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+	err = uscs.ChangeStatus(otherCtx, &models.Order{ID: id}, models.StatusProcessed)
+	require.ErrorIs(t, err, models.ErrorForbidden{})
+	err = uscs.DeleteOrder(otherCtx, &models.Order{ID: id})
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+	require.Empty(t, repo.status)
+
+	// The owner has access to own orders
+	order, err := uscs.GetOrder(ownerCtx, id)
+	require.NoError(t, err)
+	assert.Equal(t, ownerID, order.User.ID)
+	err = uscs.ChangeAddress(ownerCtx, &models.Order{ID: id}, models.UserAddress{City: "Moscow"})
+	require.NoError(t, err)
+	// but cancels them instead of deleting
+	err = uscs.DeleteOrder(ownerCtx, &models.Order{ID: id})
+	require.ErrorIs(t, err, models.ErrorForbidden{})
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// checkOwner returns ErrorNotFound if the caller from ctx is neither the user with ownerId
+// nor an admin, so resources of other users look like missing ones
+func checkOwner(ctx context.Context, ownerId uuid.UUID) error {
+	actor, _ := models.ActorFromContext(ctx)
+	if !actor.CanAccess(ownerId) {
+		return models.ErrorNotFound{}
+	}
+	return nil
+}
+
+// checkGuestCart returns ErrorNotFound if the caller from ctx has no signed token of the guest cart with cartId,
+// so guest carts can't be accessed by their ids only
+func checkGuestCart(ctx context.Context, cartId uuid.UUID) error {
+	guestCartId, ok := models.GuestCartFromContext(ctx)
+	if !ok || guestCartId != cartId {
+		return models.ErrorNotFound{}
+	}
+	return nil
+}
+
+// checkAdmin returns ErrorForbidden if the caller from ctx is not an admin
+func checkAdmin(ctx context.Context) error {
+	actor, _ := models.ActorFromContext(ctx)
+	if actor.Role != models.Admin {
+		return models.ErrorForbidden{}
+	}
+	return nil
+}
@@ -0,0 +1,304 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/metrics"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/payment"
+	"OnlineShopBackend/internal/repository"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ IPaymentUsecase = &PaymentUsecase{}
+
+type PaymentUsecase struct {
+	uow          repository.UnitOfWork
+	orderStore   repository.OrderStore
+	paymentStore repository.PaymentStore
+	provider     payment.Provider
+	// pricing is used to calculate the amount of the order as in the cart
+	pricing  models.CartPricing
+	currency string
+	// timeout is the time the order waits for the payment before it is canceled
+	timeout time.Duration
+	// period is the interval between runs of the cancellation of unpaid orders
+	period time.Duration
+	// batchSize is the maximum number of orders canceled in one transaction
+	batchSize int
+	logger    *zap.Logger
+}
+
+func NewPaymentUsecase(uow repository.UnitOfWork, orderStore repository.OrderStore, paymentStore repository.PaymentStore, provider payment.Provider,
+	pricing models.CartPricing, currency string, timeout time.Duration, period time.Duration, batchSize int, logger *zap.Logger) IPaymentUsecase {
+	logger.Debug("Enter in usecase NewPaymentUsecase()")
+	return &PaymentUsecase{
+		uow:          uow,
+		orderStore:   orderStore,
+		paymentStore: paymentStore,
+		provider:     provider,
+		pricing:      pricing,
+		currency:     currency,
+		timeout:      timeout,
+		period:       period,
+		batchSize:    batchSize,
+		logger:       logger,
+	}
+}
+
+// getOrder returns the order if the caller from ctx is its owner or an admin
+func (usecase *PaymentUsecase) getOrder(ctx context.Context, orderId uuid.UUID) (*models.Order, error) {
+	order, err := usecase.orderStore.GetOrderByID(ctx, orderId)
+	if err != nil {
+		return nil, fmt.Errorf("error on get order: %w", err)
+	}
+	if err := checkOwner(ctx, order.User.ID); err != nil {
+		return nil, fmt.Errorf("order %v of another user: %w", orderId, err)
+	}
+	return &order, nil
+}
+
+// Pay creates the payment for the order waiting for the payment in the provider and returns it
+// with the client secret to confirm the payment. If the order already has the pending payment,
+// it is returned instead of the new one
+func (usecase *PaymentUsecase) Pay(ctx context.Context, orderId uuid.UUID) (*models.Payment, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase Pay() with args: ctx, orderId: %v", orderId)
+	order, err := usecase.getOrder(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != models.StatusCreated {
+		return nil, fmt.Errorf("order %v in status %q can't be paid: %w", orderId, order.Status, models.ErrorWrongStatus{})
+	}
+	payments, err := usecase.paymentStore.GetPayments(ctx, orderId)
+	if err != nil {
+		return nil, fmt.Errorf("error on get payments: %w", err)
+	}
+	if len(payments) > 0 && payments[0].Status == models.PaymentPending {
+		return &payments[0], nil
+	}
+	amount := order.Totals(usecase.pricing).Total
+	if amount <= 0 {
+		return nil, fmt.Errorf("no items to pay in order %v: %w", orderId, models.ErrorEmptyCart{})
+	}
+	intent, err := usecase.provider.CreateIntent(ctx, amount, usecase.currency, orderId.String())
+	if err != nil {
+		return nil, fmt.Errorf("error on create payment intent: %w", err)
+	}
+	pay := &models.Payment{
+		OrderId:      orderId,
+		Provider:     usecase.provider.Name(),
+		ExternalId:   intent.Id,
+		ClientSecret: intent.ClientSecret,
+		Amount:       amount,
+		Currency:     usecase.currency,
+		Status:       models.PaymentPending,
+	}
+	pay.Id, err = usecase.paymentStore.CreatePayment(ctx, pay)
+	if err != nil {
+		return nil, fmt.Errorf("error on create payment: %w", err)
+	}
+	usecase.logger.Sugar().Infof("Payment %v of %d %s created for order %v", pay.Id, amount, usecase.currency, orderId)
+	return pay, nil
+}
+
+// GetPayment returns the last payment of the order
+func (usecase *PaymentUsecase) GetPayment(ctx context.Context, orderId uuid.UUID) (*models.Payment, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase GetPayment() with args: ctx, orderId: %v", orderId)
+	if _, err := usecase.getOrder(ctx, orderId); err != nil {
+		return nil, err
+	}
+	payments, err := usecase.paymentStore.GetPayments(ctx, orderId)
+	if err != nil {
+		return nil, fmt.Errorf("error on get payments: %w", err)
+	}
+	if len(payments) == 0 {
+		return nil, fmt.Errorf("payment of order %v: %w", orderId, models.ErrorNotFound{})
+	}
+	return &payments[0], nil
+}
+
+// HandleWebhook checks the request from the provider and applies the event to the payment.
+// The authorized payment is captured. When the payment succeeds, the order becomes paid,
+// if the order was canceled before that, the payment is refunded. Repeated events are ignored
+func (usecase *PaymentUsecase) HandleWebhook(ctx context.Context, header http.Header, body []byte) error {
+	usecase.logger.Debug("Enter in usecase HandleWebhook() with args: ctx, header, body")
+	event, err := usecase.provider.ParseWebhook(header, body)
+	if err != nil {
+		return fmt.Errorf("error on parse webhook: %w", err)
+	}
+	err = usecase.uow.Do(ctx, func(ctx context.Context) error {
+		pay, err := usecase.paymentStore.GetPaymentByExternalId(ctx, usecase.provider.Name(), event.IntentId)
+		if err != nil {
+			return fmt.Errorf("error on get payment %s: %w", event.IntentId, err)
+		}
+		switch event.Type {
+		case payment.EventAuthorized:
+			if pay.Status != models.PaymentPending && pay.Status != models.PaymentCanceled {
+				return nil
+			}
+			if err := usecase.provider.Capture(ctx, pay.ExternalId); err != nil {
+				return fmt.Errorf("error on capture payment %v: %w", pay.Id, err)
+			}
+			return usecase.succeed(ctx, pay)
+		case payment.EventSucceeded:
+			if pay.Status != models.PaymentPending && pay.Status != models.PaymentCanceled {
+				return nil
+			}
+			return usecase.succeed(ctx, pay)
+		case payment.EventFailed:
+			if pay.Status != models.PaymentPending {
+				return nil
+			}
+			pay.Status = models.PaymentFailed
+			metrics.PaymentsMetrics.PaymentsFailedTotal.Inc()
+			return usecase.paymentStore.UpdatePayment(ctx, pay)
+		case payment.EventRefunded:
+			// The amount of the refund event is the total refunded amount of the payment,
+			// refunds made by the shop are already saved
+			if event.Amount <= pay.RefundedAmount || event.Amount > pay.Amount {
+				return nil
+			}
+			pay.RefundedAmount = event.Amount
+			if pay.RefundedAmount == pay.Amount {
+				pay.Status = models.PaymentRefunded
+			}
+			return usecase.paymentStore.UpdatePayment(ctx, pay)
+		default:
+			usecase.logger.Sugar().Warnf("Unknown type of payment event: %s", event.Type)
+			return nil
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("error on handle webhook: %w", err)
+	}
+	return nil
+}
+
+// succeed marks the payment as succeeded and the order as paid. If the order
+// is not waiting for the payment anymore, the payment is refunded in full
+func (usecase *PaymentUsecase) succeed(ctx context.Context, pay *models.Payment) error {
+	pay.Status = models.PaymentSucceeded
+	paid, err := usecase.paymentStore.PayOrder(ctx, pay.OrderId)
+	if err != nil {
+		return fmt.Errorf("error on pay order %v: %w", pay.OrderId, err)
+	}
+	if paid {
+		metrics.PaymentsMetrics.PaymentsSucceededTotal.Inc()
+		usecase.logger.Sugar().Infof("Order %v paid by payment %v", pay.OrderId, pay.Id)
+		return usecase.paymentStore.UpdatePayment(ctx, pay)
+	}
+	usecase.logger.Sugar().Warnf("Payment %v succeeded for order %v which is not waiting for payment, refund it", pay.Id, pay.OrderId)
+	if err := usecase.provider.Refund(ctx, pay.ExternalId, pay.Amount); err != nil {
+		return fmt.Errorf("error on refund payment %v: %w", pay.Id, err)
+	}
+	metrics.PaymentsMetrics.RefundsTotal.Inc()
+	pay.RefundedAmount = pay.Amount
+	pay.Status = models.PaymentRefunded
+	return usecase.paymentStore.UpdatePayment(ctx, pay)
+}
+
+// Refund returns the amount of the succeeded payment of the order to the customer,
+// zero amount means the rest of the payment which is not refunded yet. Only admins refund payments
+func (usecase *PaymentUsecase) Refund(ctx context.Context, orderId uuid.UUID, amount int64) (*models.Payment, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase Refund() with args: ctx, orderId: %v, amount: %d", orderId, amount)
+	if err := checkAdmin(ctx); err != nil {
+		return nil, fmt.Errorf("payment of order %v can be refunded by admin only: %w", orderId, err)
+	}
+	return usecase.refund(ctx, orderId, amount)
+}
+
+// RefundCanceled returns the rest of the succeeded payment of the order canceled by the caller,
+// the permission to cancel the order is checked by the caller
+func (usecase *PaymentUsecase) RefundCanceled(ctx context.Context, orderId uuid.UUID) (*models.Payment, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase RefundCanceled() with args: ctx, orderId: %v", orderId)
+	return usecase.refund(ctx, orderId, 0)
+}
+
+func (usecase *PaymentUsecase) refund(ctx context.Context, orderId uuid.UUID, amount int64) (*models.Payment, error) {
+	var refunded *models.Payment
+	err := usecase.uow.Do(ctx, func(ctx context.Context) error {
+		payments, err := usecase.paymentStore.GetPayments(ctx, orderId)
+		if err != nil {
+			return fmt.Errorf("error on get payments: %w", err)
+		}
+		for i := range payments {
+			if payments[i].Status == models.PaymentSucceeded {
+				refunded = &payments[i]
+				break
+			}
+		}
+		if refunded == nil {
+			return fmt.Errorf("no succeeded payment of order %v: %w", orderId, models.ErrorWrongStatus{})
+		}
+		rest := refunded.Amount - refunded.RefundedAmount
+		if amount == 0 {
+			amount = rest
+		}
+		if amount < 0 || amount > rest {
+			return fmt.Errorf("refund of %d exceeds %d not refunded: %w", amount, rest, models.ErrorAmountExceeded{})
+		}
+		if err := usecase.provider.Refund(ctx, refunded.ExternalId, amount); err != nil {
+			return fmt.Errorf("error on refund payment %v: %w", refunded.Id, err)
+		}
+		refunded.RefundedAmount += amount
+		if refunded.RefundedAmount == refunded.Amount {
+			refunded.Status = models.PaymentRefunded
+		}
+		return usecase.paymentStore.UpdatePayment(ctx, refunded)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error on refund: %w", err)
+	}
+	metrics.PaymentsMetrics.RefundsTotal.Inc()
+	usecase.logger.Sugar().Infof("%d %s of payment %v refunded", amount, refunded.Currency, refunded.Id)
+	return refunded, nil
+}
+
+// CancelUnpaidOrders cancels orders which have not been paid in time batch by batch
+// and returns the number of canceled orders
+func (usecase *PaymentUsecase) CancelUnpaidOrders(ctx context.Context) (int, error) {
+	usecase.logger.Debug("Enter in usecase CancelUnpaidOrders() with args: ctx")
+	total := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return total, ctx.Err()
+		default:
+		}
+		ids, err := usecase.paymentStore.CancelUnpaidOrders(ctx, usecase.timeout, usecase.batchSize)
+		if err != nil {
+			return total, fmt.Errorf("error on cancel unpaid orders: %w", err)
+		}
+		total += len(ids)
+		metrics.PaymentsMetrics.UnpaidOrdersCanceledTotal.Add(float64(len(ids)))
+		if len(ids) < usecase.batchSize {
+			break
+		}
+	}
+	if total > 0 {
+		usecase.logger.Sugar().Infof("%d unpaid orders canceled", total)
+	}
+	return total, nil
+}
+
+// Run cancels unpaid orders periodically until ctx is done
+func (usecase *PaymentUsecase) Run(ctx context.Context) {
+	usecase.logger.Debug("Enter in usecase payment Run() with args: ctx")
+	ticker := time.NewTicker(usecase.period)
+	defer ticker.Stop()
+	for {
+		if _, err := usecase.CancelUnpaidOrders(ctx); err != nil {
+			usecase.logger.Error(err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/payment"
+	"OnlineShopBackend/internal/repository/mocks"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestPaymentUsecase(ctrl *gomock.Controller) (*PaymentUsecase, *mocks.MockOrderStore, *mocks.MockPaymentStore, *payment.MockProvider) {
+	logger := zap.L()
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	// The unit of work calls the function as the transaction and returns its error
+	uow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	}).AnyTimes()
+	orderRepo := mocks.NewMockOrderStore(ctrl)
+	paymentRepo := mocks.NewMockPaymentStore(ctrl)
+	provider := payment.NewMockProvider("secret", logger)
+	usecase := NewPaymentUsecase(uow, orderRepo, paymentRepo, provider, testCartPricing, "RUB", time.Hour, time.Minute, 2, logger)
+	return usecase.(*PaymentUsecase), orderRepo, paymentRepo, provider
+}
+
+func TestPay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	usecase, orderRepo, paymentRepo, _ := newTestPaymentUsecase(ctrl)
+	ctx := models.ContextWithActor(context.Background(), testActor)
+	orderId := uuid.New()
+	order := models.Order{
+		ID:     orderId,
+		User:   models.User{ID: testId},
+		Status: models.StatusCreated,
+		Items:  []models.ItemWithQuantity{{Item: models.Item{Id: testId, Price: 500}, Quantity: 3}},
+	}
+
+	orderRepo.EXPECT().GetOrderByID(ctx, orderId).Return(models.Order{ID: orderId, User: models.User{ID: uuid.New()}}, nil)
+	_, err := usecase.Pay(ctx, orderId)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	orderRepo.EXPECT().GetOrderByID(ctx, orderId).Return(models.Order{ID: orderId, User: models.User{ID: testId}, Status: models.StatusPaid}, nil)
+	_, err = usecase.Pay(ctx, orderId)
+	require.ErrorIs(t, err, models.ErrorWrongStatus{})
+
+	// The pending payment is returned again
+	pending := models.Payment{Id: uuid.New(), OrderId: orderId, Status: models.PaymentPending}
+	orderRepo.EXPECT().GetOrderByID(ctx, orderId).Return(order, nil)
+	paymentRepo.EXPECT().GetPayments(ctx, orderId).Return([]models.Payment{pending}, nil)
+	res, err := usecase.Pay(ctx, orderId)
+	require.NoError(t, err)
+	require.Equal(t, pending.Id, res.Id)
+
+	// The amount is the total of the order with the discount and the shipping
+	paymentId := uuid.New()
+	orderRepo.EXPECT().GetOrderByID(ctx, orderId).Return(order, nil)
+	paymentRepo.EXPECT().GetPayments(ctx, orderId).Return([]models.Payment{{Status: models.PaymentFailed}}, nil)
+	paymentRepo.EXPECT().CreatePayment(ctx, gomock.Any()).Return(paymentId, nil)
+	res, err = usecase.Pay(ctx, orderId)
+	require.NoError(t, err)
+	require.Equal(t, paymentId, res.Id)
+	require.Equal(t, int64(1500-150+300), res.Amount)
+	require.Equal(t, "RUB", res.Currency)
+	require.Equal(t, "mock", res.Provider)
+	require.Equal(t, models.PaymentPending, res.Status)
+	require.NotEmpty(t, res.ExternalId)
+	require.NotEmpty(t, res.ClientSecret)
+
+	orderRepo.EXPECT().GetOrderByID(ctx, orderId).Return(order, nil)
+	paymentRepo.EXPECT().GetPayments(ctx, orderId).Return(nil, nil)
+	paymentRepo.EXPECT().CreatePayment(ctx, gomock.Any()).Return(uuid.Nil, fmt.Errorf("error"))
+	_, err = usecase.Pay(ctx, orderId)
+	require.Error(t, err)
+}
+
+func TestHandleWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	usecase, _, paymentRepo, provider := newTestPaymentUsecase(ctrl)
+	ctx := context.Background()
+	intent, err := provider.CreateIntent(ctx, 1000, "RUB", testId.String())
+	require.NoError(t, err)
+	webhook := func(eventType payment.EventType, amount int64) (http.Header, []byte) {
+		body := []byte(fmt.Sprintf(`{"type": %q, "intentId": %q, "amount": %d}`, eventType, intent.Id, amount))
+		header := make(http.Header)
+		header.Set(payment.SignatureHeader, provider.Sign(body))
+		return header, body
+	}
+	pending := func() *models.Payment {
+		return &models.Payment{Id: testId, OrderId: testId, ExternalId: intent.Id, Amount: 1000, Status: models.PaymentPending}
+	}
+
+	header, body := webhook(payment.EventSucceeded, 1000)
+	header.Set(payment.SignatureHeader, "00")
+	err = usecase.HandleWebhook(ctx, header, body)
+	require.ErrorIs(t, err, payment.ErrInvalidSignature)
+
+	header, body = webhook(payment.EventSucceeded, 1000)
+	paymentRepo.EXPECT().GetPaymentByExternalId(ctx, "mock", intent.Id).Return(nil, models.ErrorNotFound{})
+	err = usecase.HandleWebhook(ctx, header, body)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	// The authorized payment is captured and the order becomes paid
+	header, body = webhook(payment.EventAuthorized, 1000)
+	paymentRepo.EXPECT().GetPaymentByExternalId(ctx, "mock", intent.Id).Return(pending(), nil)
+	paymentRepo.EXPECT().PayOrder(ctx, testId).Return(true, nil)
+	paymentRepo.EXPECT().UpdatePayment(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, pay *models.Payment) error {
+		require.Equal(t, models.PaymentSucceeded, pay.Status)
+		return nil
+	})
+	require.NoError(t, usecase.HandleWebhook(ctx, header, body))
+
+	// Repeated events are ignored
+	header, body = webhook(payment.EventSucceeded, 1000)
+	paymentRepo.EXPECT().GetPaymentByExternalId(ctx, "mock", intent.Id).Return(&models.Payment{Status: models.PaymentSucceeded}, nil)
+	require.NoError(t, usecase.HandleWebhook(ctx, header, body))
+
+	// The payment of the canceled order is refunded
+	paymentRepo.EXPECT().GetPaymentByExternalId(ctx, "mock", intent.Id).Return(pending(), nil)
+	paymentRepo.EXPECT().PayOrder(ctx, testId).Return(false, nil)
+	paymentRepo.EXPECT().UpdatePayment(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, pay *models.Payment) error {
+		require.Equal(t, models.PaymentRefunded, pay.Status)
+		require.Equal(t, int64(1000), pay.RefundedAmount)
+		return nil
+	})
+	require.NoError(t, usecase.HandleWebhook(ctx, header, body))
+
+	header, body = webhook(payment.EventFailed, 0)
+	paymentRepo.EXPECT().GetPaymentByExternalId(ctx, "mock", intent.Id).Return(pending(), nil)
+	paymentRepo.EXPECT().UpdatePayment(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, pay *models.Payment) error {
+		require.Equal(t, models.PaymentFailed, pay.Status)
+		return nil
+	})
+	require.NoError(t, usecase.HandleWebhook(ctx, header, body))
+
+	// The refund event carries the total refunded amount
+	header, body = webhook(payment.EventRefunded, 400)
+	paymentRepo.EXPECT().GetPaymentByExternalId(ctx, "mock", intent.Id).Return(&models.Payment{Amount: 1000, RefundedAmount: 400, Status: models.PaymentSucceeded}, nil)
+	require.NoError(t, usecase.HandleWebhook(ctx, header, body))
+}
+
+func TestRefund(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	usecase, _, paymentRepo, provider := newTestPaymentUsecase(ctrl)
+	ctx := testAdminCtx
+	orderId := uuid.New()
+	intent, err := provider.CreateIntent(ctx, 1000, "RUB", orderId.String())
+	require.NoError(t, err)
+	require.NoError(t, provider.Capture(ctx, intent.Id))
+	succeeded := models.Payment{Id: testId, OrderId: orderId, ExternalId: intent.Id, Amount: 1000, Status: models.PaymentSucceeded}
+
+	// Customers don't refund payments, even of their own orders
+	_, err = usecase.Refund(models.ContextWithActor(context.Background(), testActor), orderId, 100)
+	require.ErrorIs(t, err, models.ErrorForbidden{})
+
+	paymentRepo.EXPECT().GetPayments(ctx, orderId).Return([]models.Payment{{Status: models.PaymentFailed}}, nil)
+	_, err = usecase.Refund(ctx, orderId, 100)
+	require.ErrorIs(t, err, models.ErrorWrongStatus{})
+
+	paymentRepo.EXPECT().GetPayments(ctx, orderId).Return([]models.Payment{succeeded}, nil)
+	_, err = usecase.Refund(ctx, orderId, 1001)
+	require.ErrorIs(t, err, models.ErrorAmountExceeded{})
+
+	paymentRepo.EXPECT().GetPayments(ctx, orderId).Return([]models.Payment{succeeded}, nil)
+	paymentRepo.EXPECT().UpdatePayment(ctx, gomock.Any()).Return(nil)
+	res, err := usecase.Refund(ctx, orderId, 300)
+	require.NoError(t, err)
+	require.Equal(t, int64(300), res.RefundedAmount)
+	require.Equal(t, models.PaymentSucceeded, res.Status)
+
+	// Zero amount refunds the rest
+	succeeded.RefundedAmount = 300
+	paymentRepo.EXPECT().GetPayments(ctx, orderId).Return([]models.Payment{succeeded}, nil)
+	paymentRepo.EXPECT().UpdatePayment(ctx, gomock.Any()).Return(nil)
+	res, err = usecase.Refund(ctx, orderId, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), res.RefundedAmount)
+	require.Equal(t, models.PaymentRefunded, res.Status)
+
+	// The payment of the order canceled by the customer is refunded in full
+	intent, err = provider.CreateIntent(ctx, 1000, "RUB", orderId.String())
+	require.NoError(t, err)
+	require.NoError(t, provider.Capture(ctx, intent.Id))
+	succeeded = models.Payment{Id: testId, OrderId: orderId, ExternalId: intent.Id, Amount: 1000, Status: models.PaymentSucceeded}
+	customerCtx := models.ContextWithActor(context.Background(), testActor)
+	paymentRepo.EXPECT().GetPayments(customerCtx, orderId).Return([]models.Payment{succeeded}, nil)
+	paymentRepo.EXPECT().UpdatePayment(customerCtx, gomock.Any()).Return(nil)
+	res, err = usecase.RefundCanceled(customerCtx, orderId)
+	require.NoError(t, err)
+	require.Equal(t, models.PaymentRefunded, res.Status)
+}
+
+func TestCancelUnpaidOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	usecase, _, paymentRepo, _ := newTestPaymentUsecase(ctrl)
+	ctx := context.Background()
+
+	paymentRepo.EXPECT().CancelUnpaidOrders(ctx, time.Hour, 2).Return(nil, fmt.Errorf("error"))
+	res, err := usecase.CancelUnpaidOrders(ctx)
+	require.Error(t, err)
+	require.Equal(t, 0, res)
+
+	// Batches are canceled until a batch is not full
+	gomock.InOrder(
+		paymentRepo.EXPECT().CancelUnpaidOrders(ctx, time.Hour, 2).Return([]uuid.UUID{uuid.New(), uuid.New()}, nil),
+		paymentRepo.EXPECT().CancelUnpaidOrders(ctx, time.Hour, 2).Return([]uuid.UUID{uuid.New()}, nil),
+	)
+	res, err = usecase.CancelUnpaidOrders(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, res)
+}
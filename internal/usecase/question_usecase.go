@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ IQuestionUsecase = &QuestionUsecase{}
+
+type QuestionUsecase struct {
+	questionStore repository.QuestionStore
+	logger        *zap.Logger
+}
+
+func NewQuestionUsecase(questionStore repository.QuestionStore, logger *zap.Logger) IQuestionUsecase {
+	logger.Debug("Enter in usecase NewQuestionUsecase()")
+	return &QuestionUsecase{questionStore: questionStore, logger: logger}
+}
+
+// AskQuestion creates new question about item and returns its id or error
+func (usecase *QuestionUsecase) AskQuestion(ctx context.Context, question *models.Question) (uuid.UUID, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase AskQuestion() with args: ctx, question: %v", question)
+	id, err := usecase.questionStore.CreateQuestion(ctx, question)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error on create question: %w", err)
+	}
+	return id, nil
+}
+
+// GetItemQuestions returns all questions about item with their answers
+func (usecase *QuestionUsecase) GetItemQuestions(ctx context.Context, itemId uuid.UUID) ([]models.Question, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase GetItemQuestions() with args: ctx, itemId: %v", itemId)
+	questionChan, err := usecase.questionStore.GetQuestionsByItem(ctx, itemId)
+	if err != nil {
+		return nil, fmt.Errorf("error on get questions by item: %w", err)
+	}
+	questions := make([]models.Question, 0, 10)
+	for question := range questionChan {
+		questions = append(questions, question)
+	}
+	return questions, nil
+}
+
+// AnswerQuestion creates answer to the question. Only admins and the seller
+// of the item can answer questions about it
+func (usecase *QuestionUsecase) AnswerQuestion(ctx context.Context, answer *models.Answer, role string) (uuid.UUID, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase AnswerQuestion() with args: ctx, answer: %v, role: %s", answer, role)
+	question, err := usecase.questionStore.GetQuestion(ctx, answer.QuestionId)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error on get question: %w", err)
+	}
+	if role != models.Admin {
+		if role != models.Seller {
+			return uuid.Nil, models.ErrorForbidden{}
+		}
+		sellerId, err := usecase.questionStore.GetItemSellerId(ctx, question.ItemId)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("error on get seller of item: %w", err)
+		}
+		if sellerId != answer.UserId {
+			usecase.logger.Sugar().Debugf("user %v is not a seller of item %v", answer.UserId, question.ItemId)
+			return uuid.Nil, models.ErrorForbidden{}
+		}
+	}
+	id, err := usecase.questionStore.CreateAnswer(ctx, answer)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error on create answer: %w", err)
+	}
+	return id, nil
+}
+
+// UpvoteAnswer adds vote of user to the answer
+func (usecase *QuestionUsecase) UpvoteAnswer(ctx context.Context, answerId uuid.UUID, userId uuid.UUID) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase UpvoteAnswer() with args: ctx, answerId: %v, userId: %v", answerId, userId)
+	err := usecase.questionStore.UpvoteAnswer(ctx, answerId, userId)
+	if err != nil {
+		return fmt.Errorf("error on upvote answer: %w", err)
+	}
+	return nil
+}
+
+// GetUnansweredQuestions returns the queue of questions which still have no answers,
+// the oldest questions go first
+func (usecase *QuestionUsecase) GetUnansweredQuestions(ctx context.Context) ([]models.Question, error) {
+	usecase.logger.Debug("Enter in usecase GetUnansweredQuestions() with args: ctx")
+	questionChan, err := usecase.questionStore.GetUnansweredQuestions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error on get unanswered questions: %w", err)
+	}
+	questions := make([]models.Question, 0, 10)
+	for question := range questionChan {
+		questions = append(questions, question)
+	}
+	return questions, nil
+}
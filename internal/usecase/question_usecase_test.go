@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/mocks"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var (
+	testSellerId      = uuid.New()
+	testModelQuestion = &models.Question{
+		Id:     testId,
+		ItemId: testId,
+		UserId: testId,
+		Text:   "test question",
+	}
+	testModelAnswer = &models.Answer{
+		QuestionId: testId,
+		UserId:     testSellerId,
+		Text:       "test answer",
+	}
+)
+
+func TestAskQuestion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	questionRepo := mocks.NewMockQuestionStore(ctrl)
+	usecase := NewQuestionUsecase(questionRepo, logger)
+
+	questionRepo.EXPECT().CreateQuestion(ctx, testModelQuestion).Return(uuid.Nil, err)
+	res, err := usecase.AskQuestion(ctx, testModelQuestion)
+	require.Error(t, err)
+	require.Equal(t, res, uuid.Nil)
+
+	questionRepo.EXPECT().CreateQuestion(ctx, testModelQuestion).Return(testId, nil)
+	res, err = usecase.AskQuestion(ctx, testModelQuestion)
+	require.NoError(t, err)
+	require.Equal(t, res, testId)
+}
+
+func TestGetItemQuestions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	questionRepo := mocks.NewMockQuestionStore(ctrl)
+	usecase := NewQuestionUsecase(questionRepo, logger)
+
+	questionRepo.EXPECT().GetQuestionsByItem(ctx, testId).Return(nil, err)
+	res, err := usecase.GetItemQuestions(ctx, testId)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	questionChan := make(chan models.Question, 1)
+	questionChan <- *testModelQuestion
+	close(questionChan)
+	questionRepo.EXPECT().GetQuestionsByItem(ctx, testId).Return(questionChan, nil)
+	res, err = usecase.GetItemQuestions(ctx, testId)
+	require.NoError(t, err)
+	require.Equal(t, res, []models.Question{*testModelQuestion})
+}
+
+func TestAnswerQuestion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	questionRepo := mocks.NewMockQuestionStore(ctrl)
+	usecase := NewQuestionUsecase(questionRepo, logger)
+
+	questionRepo.EXPECT().GetQuestion(ctx, testId).Return(nil, models.ErrorNotFound{})
+	res, err := usecase.AnswerQuestion(ctx, testModelAnswer, models.Admin)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, models.ErrorNotFound{}))
+	require.Equal(t, res, uuid.Nil)
+
+	questionRepo.EXPECT().GetQuestion(ctx, testId).Return(testModelQuestion, nil)
+	res, err = usecase.AnswerQuestion(ctx, testModelAnswer, models.Customer)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, models.ErrorForbidden{}))
+	require.Equal(t, res, uuid.Nil)
+
+	questionRepo.EXPECT().GetQuestion(ctx, testId).Return(testModelQuestion, nil)
+	questionRepo.EXPECT().GetItemSellerId(ctx, testId).Return(uuid.New(), nil)
+	res, err = usecase.AnswerQuestion(ctx, testModelAnswer, models.Seller)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, models.ErrorForbidden{}))
+	require.Equal(t, res, uuid.Nil)
+
+	questionRepo.EXPECT().GetQuestion(ctx, testId).Return(testModelQuestion, nil)
+	questionRepo.EXPECT().GetItemSellerId(ctx, testId).Return(testSellerId, nil)
+	questionRepo.EXPECT().CreateAnswer(ctx, testModelAnswer).Return(testId, nil)
+	res, err = usecase.AnswerQuestion(ctx, testModelAnswer, models.Seller)
+	require.NoError(t, err)
+	require.Equal(t, res, testId)
+
+	questionRepo.EXPECT().GetQuestion(ctx, testId).Return(testModelQuestion, nil)
+	questionRepo.EXPECT().CreateAnswer(ctx, testModelAnswer).Return(uuid.Nil, fmt.Errorf("error on create answer"))
+	res, err = usecase.AnswerQuestion(ctx, testModelAnswer, models.Admin)
+	require.Error(t, err)
+	require.Equal(t, res, uuid.Nil)
+
+	questionRepo.EXPECT().GetQuestion(ctx, testId).Return(testModelQuestion, nil)
+	questionRepo.EXPECT().CreateAnswer(ctx, testModelAnswer).Return(testId, nil)
+	res, err = usecase.AnswerQuestion(ctx, testModelAnswer, models.Admin)
+	require.NoError(t, err)
+	require.Equal(t, res, testId)
+}
+
+func TestUpvoteAnswer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	questionRepo := mocks.NewMockQuestionStore(ctrl)
+	usecase := NewQuestionUsecase(questionRepo, logger)
+
+	questionRepo.EXPECT().UpvoteAnswer(ctx, testId, testId).Return(models.ErrorAlreadyExists{})
+	err := usecase.UpvoteAnswer(ctx, testId, testId)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, models.ErrorAlreadyExists{}))
+
+	questionRepo.EXPECT().UpvoteAnswer(ctx, testId, testId).Return(nil)
+	err = usecase.UpvoteAnswer(ctx, testId, testId)
+	require.NoError(t, err)
+}
+
+func TestGetUnansweredQuestions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	questionRepo := mocks.NewMockQuestionStore(ctrl)
+	usecase := NewQuestionUsecase(questionRepo, logger)
+
+	questionRepo.EXPECT().GetUnansweredQuestions(ctx).Return(nil, err)
+	res, err := usecase.GetUnansweredQuestions(ctx)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	questionChan := make(chan models.Question, 1)
+	questionChan <- *testModelQuestion
+	close(questionChan)
+	questionRepo.EXPECT().GetUnansweredQuestions(ctx).Return(questionChan, nil)
+	res, err = usecase.GetUnansweredQuestions(ctx)
+	require.NoError(t, err)
+	require.Equal(t, res, []models.Question{*testModelQuestion})
+}
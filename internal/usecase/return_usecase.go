@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ IReturnUsecase = &ReturnUsecase{}
+
+type ReturnUsecase struct {
+	uow         repository.UnitOfWork
+	orderStore  repository.OrderStore
+	returnStore repository.ReturnStore
+	// paymentUsecase refunds returns through the payment of the order
+	paymentUsecase IPaymentUsecase
+	logger         *zap.Logger
+}
+
+func NewReturnUsecase(uow repository.UnitOfWork, orderStore repository.OrderStore, returnStore repository.ReturnStore,
+	paymentUsecase IPaymentUsecase, logger *zap.Logger) IReturnUsecase {
+	logger.Debug("Enter in usecase NewReturnUsecase()")
+	return &ReturnUsecase{
+		uow:            uow,
+		orderStore:     orderStore,
+		returnStore:    returnStore,
+		paymentUsecase: paymentUsecase,
+		logger:         logger,
+	}
+}
+
+// CreateReturn opens the return of lines of the delivered order of the caller from ctx.
+// The quantity of every line must not exceed the quantity ordered minus the quantity
+// already in other returns which are not rejected, lines of the same item are summed
+func (usecase *ReturnUsecase) CreateReturn(ctx context.Context, ret *models.Return) (*models.Return, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase CreateReturn() with args: ctx, orderId: %v, items: %v", ret.OrderId, ret.Items)
+	order, err := usecase.orderStore.GetOrderByID(ctx, ret.OrderId)
+	if err != nil {
+		return nil, fmt.Errorf("error on get order: %w", err)
+	}
+	if err := checkOwner(ctx, order.User.ID); err != nil {
+		return nil, fmt.Errorf("order %v of another user: %w", ret.OrderId, err)
+	}
+	if order.Status != models.StatusShipped {
+		return nil, fmt.Errorf("order %v in status %q can't be returned: %w", ret.OrderId, order.Status, models.ErrorWrongStatus{})
+	}
+	if len(ret.Items) == 0 {
+		return nil, fmt.Errorf("no items to return: %w", models.ErrorEmptyCart{})
+	}
+	ordered := make(map[uuid.UUID]int, len(order.Items))
+	for _, item := range order.Items {
+		ordered[item.Id] += item.Quantity
+	}
+	var id uuid.UUID
+	err = usecase.uow.Do(ctx, func(ctx context.Context) error {
+		returned, err := usecase.returnStore.GetReturnedQuantities(ctx, ret.OrderId)
+		if err != nil {
+			return fmt.Errorf("error on get returned quantities: %w", err)
+		}
+		requested := make(map[uuid.UUID]int, len(ret.Items))
+		lines := make([]models.ReturnItem, 0, len(ret.Items))
+		for _, item := range ret.Items {
+			if item.Quantity <= 0 {
+				return fmt.Errorf("quantity of item %v must be positive: %w", item.ItemId, models.ErrorQuantityExceeded{})
+			}
+			if _, ok := requested[item.ItemId]; !ok {
+				lines = append(lines, models.ReturnItem{ItemId: item.ItemId})
+			}
+			requested[item.ItemId] += item.Quantity
+			if requested[item.ItemId]+returned[item.ItemId] > ordered[item.ItemId] {
+				return fmt.Errorf("item %v: %d of %d ordered already returned: %w",
+					item.ItemId, returned[item.ItemId], ordered[item.ItemId], models.ErrorQuantityExceeded{})
+			}
+		}
+		// The return has one line of the item
+		for i := range lines {
+			lines[i].Quantity = requested[lines[i].ItemId]
+		}
+		ret.Items = lines
+		ret.UserId = order.User.ID
+		ret.Status = models.ReturnRequested
+		id, err = usecase.returnStore.CreateReturn(ctx, ret)
+		if err != nil {
+			return fmt.Errorf("error on create return: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	usecase.logger.Sugar().Infof("Return %v of order %v requested", id, ret.OrderId)
+	return usecase.returnStore.GetReturn(ctx, id)
+}
+
+// GetReturn returns the return if the caller from ctx is its owner or an admin
+func (usecase *ReturnUsecase) GetReturn(ctx context.Context, id uuid.UUID) (*models.Return, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase GetReturn() with args: ctx, id: %v", id)
+	ret, err := usecase.returnStore.GetReturn(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error on get return: %w", err)
+	}
+	if err := checkOwner(ctx, ret.UserId); err != nil {
+		return nil, fmt.Errorf("return %v of another user: %w", id, err)
+	}
+	return ret, nil
+}
+
+// GetOrderReturns returns returns of the order, the check of the owner of the order is up to the caller
+func (usecase *ReturnUsecase) GetOrderReturns(ctx context.Context, orderId uuid.UUID) ([]models.Return, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase GetOrderReturns() with args: ctx, orderId: %v", orderId)
+	returns, err := usecase.returnStore.GetOrderReturns(ctx, orderId)
+	if err != nil {
+		return nil, fmt.Errorf("error on get returns of order: %w", err)
+	}
+	return returns, nil
+}
+
+// GetReturns returns the page of returns in the status to the admin, empty status means all returns
+func (usecase *ReturnUsecase) GetReturns(ctx context.Context, status models.ReturnStatus, offset int, limit int) ([]models.Return, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase GetReturns() with args: ctx, status: %s, offset: %d, limit: %d", status, offset, limit)
+	if err := checkAdmin(ctx); err != nil {
+		return nil, fmt.Errorf("returns can be listed by admin only: %w", err)
+	}
+	returns, err := usecase.returnStore.GetReturns(ctx, status, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error on get returns: %w", err)
+	}
+	return returns, nil
+}
+
+// ChangeReturnStatus moves the return to the status by the admin. When the goods
+// of the return are received, they are put back to stock in the same transaction
+func (usecase *ReturnUsecase) ChangeReturnStatus(ctx context.Context, id uuid.UUID, status models.ReturnStatus, comment string) (*models.Return, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase ChangeReturnStatus() with args: ctx, id: %v, status: %s, comment: %s", id, status, comment)
+	if err := checkAdmin(ctx); err != nil {
+		return nil, fmt.Errorf("status of return can be changed by admin only: %w", err)
+	}
+	if status == models.ReturnRefunded {
+		return nil, fmt.Errorf("return is refunded by refund only: %w", models.ErrorWrongStatus{})
+	}
+	err := usecase.uow.Do(ctx, func(ctx context.Context) error {
+		ret, err := usecase.returnStore.GetReturn(ctx, id)
+		if err != nil {
+			return fmt.Errorf("error on get return: %w", err)
+		}
+		if !ret.Status.CanChangeTo(status) {
+			return fmt.Errorf("return %v can't move from %s to %s: %w", id, ret.Status, status, models.ErrorWrongStatus{})
+		}
+		if err := usecase.returnStore.ChangeReturnStatus(ctx, id, ret.Status, status, comment); err != nil {
+			return fmt.Errorf("error on change return status: %w", err)
+		}
+		if status == models.ReturnReceived {
+			if err := usecase.returnStore.RestockReturn(ctx, id); err != nil {
+				return fmt.Errorf("error on restock return: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	usecase.logger.Sugar().Infof("Return %v moved to %s", id, status)
+	return usecase.returnStore.GetReturn(ctx, id)
+}
+
+// SetTrackingNumber saves the number of the parcel the customer sent the goods of the approved return with
+func (usecase *ReturnUsecase) SetTrackingNumber(ctx context.Context, id uuid.UUID, trackingNumber string) (*models.Return, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase SetTrackingNumber() with args: ctx, id: %v, trackingNumber: %s", id, trackingNumber)
+	ret, err := usecase.GetReturn(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ret.Status != models.ReturnApproved {
+		return nil, fmt.Errorf("return %v in status %s can't be sent: %w", id, ret.Status, models.ErrorWrongStatus{})
+	}
+	if err := usecase.returnStore.SetTrackingNumber(ctx, id, trackingNumber); err != nil {
+		return nil, fmt.Errorf("error on set tracking number: %w", err)
+	}
+	ret.TrackingNumber = trackingNumber
+	return ret, nil
+}
+
+// RefundReturn refunds the received return through the payment of the order and marks it as refunded.
+// Zero amount means the sum of the returned lines, the amount may be less for the partial refund. Only admins refund returns
+func (usecase *ReturnUsecase) RefundReturn(ctx context.Context, id uuid.UUID, amount int64) (*models.Return, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase RefundReturn() with args: ctx, id: %v, amount: %d", id, amount)
+	if err := checkAdmin(ctx); err != nil {
+		return nil, fmt.Errorf("return can be refunded by admin only: %w", err)
+	}
+	err := usecase.uow.Do(ctx, func(ctx context.Context) error {
+		ret, err := usecase.returnStore.GetReturn(ctx, id)
+		if err != nil {
+			return fmt.Errorf("error on get return: %w", err)
+		}
+		if ret.Status != models.ReturnReceived {
+			return fmt.Errorf("return %v in status %s can't be refunded: %w", id, ret.Status, models.ErrorWrongStatus{})
+		}
+		total := ret.Total()
+		if amount == 0 {
+			amount = total
+		}
+		if amount < 0 || amount > total {
+			return fmt.Errorf("refund of %d exceeds %d of return: %w", amount, total, models.ErrorAmountExceeded{})
+		}
+		if _, err := usecase.paymentUsecase.Refund(ctx, ret.OrderId, amount); err != nil {
+			return fmt.Errorf("error on refund order %v: %w", ret.OrderId, err)
+		}
+		if err := usecase.returnStore.SetRefundAmount(ctx, id, amount); err != nil {
+			return fmt.Errorf("error on set refund amount: %w", err)
+		}
+		comment := fmt.Sprintf("refunded %d", amount)
+		if err := usecase.returnStore.ChangeReturnStatus(ctx, id, ret.Status, models.ReturnRefunded, comment); err != nil {
+			return fmt.Errorf("error on change return status: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	usecase.logger.Sugar().Infof("Return %v refunded with %d", id, amount)
+	return usecase.returnStore.GetReturn(ctx, id)
+}
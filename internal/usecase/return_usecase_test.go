@@ -0,0 +1,260 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/mocks"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// refunder is the payment usecase which refunds orders by the function,
+// the mock of the usecase can't be used here because of the import cycle
+type refunder struct {
+	IPaymentUsecase
+	refund func(ctx context.Context, orderId uuid.UUID, amount int64) (*models.Payment, error)
+}
+
+func (r *refunder) Refund(ctx context.Context, orderId uuid.UUID, amount int64) (*models.Payment, error) {
+	return r.refund(ctx, orderId, amount)
+}
+
+func (r *refunder) RefundCanceled(ctx context.Context, orderId uuid.UUID) (*models.Payment, error) {
+	return r.refund(ctx, orderId, 0)
+}
+
+func newTestReturnUsecase(ctrl *gomock.Controller) (*ReturnUsecase, *mocks.MockOrderStore, *mocks.MockReturnStore, *refunder) {
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	uow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	}).AnyTimes()
+	orderRepo := mocks.NewMockOrderStore(ctrl)
+	returnRepo := mocks.NewMockReturnStore(ctrl)
+	paymentUsecase := &refunder{}
+	usecase := NewReturnUsecase(uow, orderRepo, returnRepo, paymentUsecase, zap.L())
+	return usecase.(*ReturnUsecase), orderRepo, returnRepo, paymentUsecase
+}
+
+func TestCreateReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	usecase, orderRepo, returnRepo, _ := newTestReturnUsecase(ctrl)
+	ctx := models.ContextWithActor(context.Background(), testActor)
+	orderId := uuid.New()
+	itemId := uuid.New()
+	order := models.Order{
+		ID:     orderId,
+		User:   models.User{ID: testId},
+		Status: models.StatusShipped,
+		Items:  []models.ItemWithQuantity{{Item: models.Item{Id: itemId, Price: 500}, Quantity: 3}},
+	}
+	newReturn := func(quantity int) *models.Return {
+		return &models.Return{
+			OrderId: orderId,
+			Reason:  "Wrong size",
+			Items:   []models.ReturnItem{{ItemId: itemId, Quantity: quantity}},
+		}
+	}
+
+	orderRepo.EXPECT().GetOrderByID(ctx, orderId).Return(models.Order{ID: orderId, User: models.User{ID: uuid.New()}, Status: models.StatusShipped}, nil)
+	_, err := usecase.CreateReturn(ctx, newReturn(1))
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	orderRepo.EXPECT().GetOrderByID(ctx, orderId).Return(models.Order{ID: orderId, User: models.User{ID: testId}, Status: models.StatusCreated}, nil)
+	_, err = usecase.CreateReturn(ctx, newReturn(1))
+	require.ErrorIs(t, err, models.ErrorWrongStatus{})
+
+	// Two of three items are already returned
+	orderRepo.EXPECT().GetOrderByID(ctx, orderId).Return(order, nil)
+	returnRepo.EXPECT().GetReturnedQuantities(ctx, orderId).Return(map[uuid.UUID]int{itemId: 2}, nil)
+	_, err = usecase.CreateReturn(ctx, newReturn(2))
+	require.ErrorIs(t, err, models.ErrorQuantityExceeded{})
+
+	// The item is not in the order
+	ret := newReturn(1)
+	ret.Items[0].ItemId = uuid.New()
+	orderRepo.EXPECT().GetOrderByID(ctx, orderId).Return(order, nil)
+	returnRepo.EXPECT().GetReturnedQuantities(ctx, orderId).Return(map[uuid.UUID]int{}, nil)
+	_, err = usecase.CreateReturn(ctx, ret)
+	require.ErrorIs(t, err, models.ErrorQuantityExceeded{})
+
+	orderRepo.EXPECT().GetOrderByID(ctx, orderId).Return(order, nil)
+	returnRepo.EXPECT().GetReturnedQuantities(ctx, orderId).Return(map[uuid.UUID]int{}, nil)
+	returnRepo.EXPECT().CreateReturn(ctx, gomock.Any()).Return(uuid.Nil, fmt.Errorf("error"))
+	_, err = usecase.CreateReturn(ctx, newReturn(1))
+	require.Error(t, err)
+
+	returnId := uuid.New()
+	orderRepo.EXPECT().GetOrderByID(ctx, orderId).Return(order, nil)
+	returnRepo.EXPECT().GetReturnedQuantities(ctx, orderId).Return(map[uuid.UUID]int{itemId: 2}, nil)
+	returnRepo.EXPECT().CreateReturn(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, ret *models.Return) (uuid.UUID, error) {
+		require.Equal(t, testId, ret.UserId)
+		require.Equal(t, models.ReturnRequested, ret.Status)
+		return returnId, nil
+	})
+	returnRepo.EXPECT().GetReturn(ctx, returnId).Return(&models.Return{Id: returnId, Status: models.ReturnRequested}, nil)
+	res, err := usecase.CreateReturn(ctx, newReturn(1))
+	require.NoError(t, err)
+	require.Equal(t, returnId, res.Id)
+
+	// Lines of the same item are saved as one line
+	ret = newReturn(1)
+	ret.Items = append(ret.Items, models.ReturnItem{ItemId: itemId, Quantity: 2})
+	orderRepo.EXPECT().GetOrderByID(ctx, orderId).Return(order, nil)
+	returnRepo.EXPECT().GetReturnedQuantities(ctx, orderId).Return(map[uuid.UUID]int{}, nil)
+	returnRepo.EXPECT().CreateReturn(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, ret *models.Return) (uuid.UUID, error) {
+		require.Equal(t, []models.ReturnItem{{ItemId: itemId, Quantity: 3}}, ret.Items)
+		return returnId, nil
+	})
+	returnRepo.EXPECT().GetReturn(ctx, returnId).Return(&models.Return{Id: returnId, Status: models.ReturnRequested}, nil)
+	_, err = usecase.CreateReturn(ctx, ret)
+	require.NoError(t, err)
+}
+
+func TestGetReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	usecase, _, returnRepo, _ := newTestReturnUsecase(ctrl)
+	ctx := models.ContextWithActor(context.Background(), testActor)
+	returnId := uuid.New()
+
+	returnRepo.EXPECT().GetReturn(ctx, returnId).Return(nil, models.ErrorNotFound{})
+	_, err := usecase.GetReturn(ctx, returnId)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	returnRepo.EXPECT().GetReturn(ctx, returnId).Return(&models.Return{Id: returnId, UserId: uuid.New()}, nil)
+	_, err = usecase.GetReturn(ctx, returnId)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	returnRepo.EXPECT().GetReturn(ctx, returnId).Return(&models.Return{Id: returnId, UserId: testId}, nil)
+	res, err := usecase.GetReturn(ctx, returnId)
+	require.NoError(t, err)
+	require.Equal(t, returnId, res.Id)
+}
+
+func TestChangeReturnStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	usecase, _, returnRepo, _ := newTestReturnUsecase(ctrl)
+	ctx := testAdminCtx
+	returnId := uuid.New()
+
+	// Customers don't move their returns
+	_, err := usecase.ChangeReturnStatus(models.ContextWithActor(context.Background(), testActor), returnId, models.ReturnReceived, "")
+	require.ErrorIs(t, err, models.ErrorForbidden{})
+
+	_, err = usecase.ChangeReturnStatus(ctx, returnId, models.ReturnRefunded, "")
+	require.ErrorIs(t, err, models.ErrorWrongStatus{})
+
+	returnRepo.EXPECT().GetReturn(ctx, returnId).Return(&models.Return{Id: returnId, Status: models.ReturnRequested}, nil)
+	_, err = usecase.ChangeReturnStatus(ctx, returnId, models.ReturnReceived, "")
+	require.ErrorIs(t, err, models.ErrorWrongStatus{})
+
+	returnRepo.EXPECT().GetReturn(ctx, returnId).Return(&models.Return{Id: returnId, Status: models.ReturnRequested}, nil)
+	returnRepo.EXPECT().ChangeReturnStatus(ctx, returnId, models.ReturnRequested, models.ReturnApproved, "Send it back").Return(nil)
+	returnRepo.EXPECT().GetReturn(ctx, returnId).Return(&models.Return{Id: returnId, Status: models.ReturnApproved}, nil)
+	res, err := usecase.ChangeReturnStatus(ctx, returnId, models.ReturnApproved, "Send it back")
+	require.NoError(t, err)
+	require.Equal(t, models.ReturnApproved, res.Status)
+
+	// Received goods are put back to stock
+	returnRepo.EXPECT().GetReturn(ctx, returnId).Return(&models.Return{Id: returnId, Status: models.ReturnApproved}, nil)
+	returnRepo.EXPECT().ChangeReturnStatus(ctx, returnId, models.ReturnApproved, models.ReturnReceived, "").Return(nil)
+	returnRepo.EXPECT().RestockReturn(ctx, returnId).Return(fmt.Errorf("error"))
+	_, err = usecase.ChangeReturnStatus(ctx, returnId, models.ReturnReceived, "")
+	require.Error(t, err)
+
+	returnRepo.EXPECT().GetReturn(ctx, returnId).Return(&models.Return{Id: returnId, Status: models.ReturnApproved}, nil)
+	returnRepo.EXPECT().ChangeReturnStatus(ctx, returnId, models.ReturnApproved, models.ReturnReceived, "").Return(nil)
+	returnRepo.EXPECT().RestockReturn(ctx, returnId).Return(nil)
+	returnRepo.EXPECT().GetReturn(ctx, returnId).Return(&models.Return{Id: returnId, Status: models.ReturnReceived}, nil)
+	res, err = usecase.ChangeReturnStatus(ctx, returnId, models.ReturnReceived, "")
+	require.NoError(t, err)
+	require.Equal(t, models.ReturnReceived, res.Status)
+}
+
+func TestSetTrackingNumber(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	usecase, _, returnRepo, _ := newTestReturnUsecase(ctrl)
+	ctx := models.ContextWithActor(context.Background(), testActor)
+	returnId := uuid.New()
+
+	returnRepo.EXPECT().GetReturn(ctx, returnId).Return(&models.Return{Id: returnId, UserId: testId, Status: models.ReturnRequested}, nil)
+	_, err := usecase.SetTrackingNumber(ctx, returnId, "RA123456789RU")
+	require.ErrorIs(t, err, models.ErrorWrongStatus{})
+
+	returnRepo.EXPECT().GetReturn(ctx, returnId).Return(&models.Return{Id: returnId, UserId: testId, Status: models.ReturnApproved}, nil)
+	returnRepo.EXPECT().SetTrackingNumber(ctx, returnId, "RA123456789RU").Return(nil)
+	res, err := usecase.SetTrackingNumber(ctx, returnId, "RA123456789RU")
+	require.NoError(t, err)
+	require.Equal(t, "RA123456789RU", res.TrackingNumber)
+}
+
+func TestRefundReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	usecase, _, returnRepo, paymentUsecase := newTestReturnUsecase(ctrl)
+	ctx := testAdminCtx
+	returnId := uuid.New()
+	orderId := uuid.New()
+	received := &models.Return{
+		Id:      returnId,
+		OrderId: orderId,
+		Status:  models.ReturnReceived,
+		Items:   []models.ReturnItem{{ItemId: testId, Price: 500, Quantity: 2}},
+	}
+
+	_, err := usecase.RefundReturn(models.ContextWithActor(context.Background(), testActor), returnId, 0)
+	require.ErrorIs(t, err, models.ErrorForbidden{})
+
+	returnRepo.EXPECT().GetReturn(ctx, returnId).Return(&models.Return{Id: returnId, Status: models.ReturnApproved}, nil)
+	_, err = usecase.RefundReturn(ctx, returnId, 0)
+	require.ErrorIs(t, err, models.ErrorWrongStatus{})
+
+	returnRepo.EXPECT().GetReturn(ctx, returnId).Return(received, nil)
+	_, err = usecase.RefundReturn(ctx, returnId, 1001)
+	require.ErrorIs(t, err, models.ErrorAmountExceeded{})
+
+	returnRepo.EXPECT().GetReturn(ctx, returnId).Return(received, nil)
+	paymentUsecase.refund = func(ctx context.Context, id uuid.UUID, amount int64) (*models.Payment, error) {
+		require.Equal(t, orderId, id)
+		require.Equal(t, int64(1000), amount)
+		return nil, models.ErrorWrongStatus{}
+	}
+	_, err = usecase.RefundReturn(ctx, returnId, 0)
+	require.ErrorIs(t, err, models.ErrorWrongStatus{})
+
+	// The partial refund
+	returnRepo.EXPECT().GetReturn(ctx, returnId).Return(received, nil)
+	paymentUsecase.refund = func(ctx context.Context, id uuid.UUID, amount int64) (*models.Payment, error) {
+		require.Equal(t, int64(700), amount)
+		return &models.Payment{}, nil
+	}
+	returnRepo.EXPECT().SetRefundAmount(ctx, returnId, int64(700)).Return(nil)
+	returnRepo.EXPECT().ChangeReturnStatus(ctx, returnId, models.ReturnReceived, models.ReturnRefunded, "refunded 700").Return(nil)
+	returnRepo.EXPECT().GetReturn(ctx, returnId).Return(&models.Return{Id: returnId, Status: models.ReturnRefunded, RefundAmount: 700}, nil)
+	res, err := usecase.RefundReturn(ctx, returnId, 700)
+	require.NoError(t, err)
+	require.Equal(t, models.ReturnRefunded, res.Status)
+	require.Equal(t, int64(700), res.RefundAmount)
+}
+
+func TestGetReturns(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	usecase, _, returnRepo, _ := newTestReturnUsecase(ctrl)
+
+	_, err := usecase.GetReturns(models.ContextWithActor(context.Background(), testActor), "", 0, 10)
+	require.ErrorIs(t, err, models.ErrorForbidden{})
+
+	returnRepo.EXPECT().GetReturns(testAdminCtx, models.ReturnRequested, 0, 10).Return([]models.Return{{Id: testId}}, nil)
+	res, err := usecase.GetReturns(testAdminCtx, models.ReturnRequested, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/carrier"
+	"OnlineShopBackend/internal/metrics"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ IShipmentUsecase = &ShipmentUsecase{}
+
+type ShipmentUsecase struct {
+	store      repository.ShipmentStore
+	orderStore repository.OrderStore
+	carrier    carrier.Carrier
+	// period is the interval between runs of the tracking of shipments
+	period time.Duration
+	// batchSize is the maximum number of shipments tracked in one run
+	batchSize int
+	logger    *zap.Logger
+}
+
+func NewShipmentUsecase(store repository.ShipmentStore, orderStore repository.OrderStore, carrier carrier.Carrier,
+	period time.Duration, batchSize int, logger *zap.Logger) IShipmentUsecase {
+	logger.Debug("Enter in usecase NewShipmentUsecase()")
+	return &ShipmentUsecase{
+		store:      store,
+		orderStore: orderStore,
+		carrier:    carrier,
+		period:     period,
+		batchSize:  batchSize,
+		logger:     logger,
+	}
+}
+
+// Ship creates the shipment of the processed or ready order in the carrier and hands the order over to the courier.
+// Only the admin ships orders. If the order is in another status, ErrorWrongStatus is returned
+func (usecase *ShipmentUsecase) Ship(ctx context.Context, orderId uuid.UUID) (*models.Shipment, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase Ship() with args: ctx, orderId: %v", orderId)
+	if err := checkAdmin(ctx); err != nil {
+		return nil, fmt.Errorf("order %v can be shipped by admin only: %w", orderId, err)
+	}
+	order, err := usecase.orderStore.GetOrderByID(ctx, orderId)
+	if err != nil {
+		return nil, fmt.Errorf("error on get order: %w", err)
+	}
+	// The status is checked before the request to the carrier, so the shipment is not created for nothing,
+	// and checked again by the store in the transaction
+	statuses := models.ShippableStatuses()
+	shippable := false
+	for _, status := range statuses {
+		if status == order.Status {
+			shippable = true
+			break
+		}
+	}
+	if !shippable {
+		return nil, fmt.Errorf("order %v in status %q can't be shipped: %w", orderId, order.Status, models.ErrorWrongStatus{})
+	}
+	parcel := carrier.Parcel{
+		OrderId:   order.ID.String(),
+		Zipcode:   order.Address.Zipcode,
+		Country:   order.Address.Country,
+		City:      order.Address.City,
+		Street:    order.Address.Street,
+		Apartment: order.Address.Apartment,
+		Recipient: order.Address.Recipient,
+		Phone:     order.Address.Phone,
+	}
+	for _, item := range order.Items {
+		parcel.Weight += int64(item.Weight) * int64(item.Quantity)
+	}
+	created, err := usecase.carrier.CreateShipment(ctx, parcel)
+	if err != nil {
+		return nil, fmt.Errorf("error on create shipment in carrier %s: %w", usecase.carrier.Name(), err)
+	}
+	shipment := &models.Shipment{
+		OrderId:        orderId,
+		Carrier:        usecase.carrier.Name(),
+		TrackingNumber: created.TrackingNumber,
+		Events:         make([]models.TrackingEvent, 0),
+	}
+	if err := usecase.store.CreateShipment(ctx, shipment, statuses); err != nil {
+		return nil, fmt.Errorf("error on create shipment: %w", err)
+	}
+	metrics.ShipmentsMetrics.ShipmentsCreatedTotal.Inc()
+	usecase.logger.Sugar().Infof("order %v is shipped by %s with tracking number %s", orderId, shipment.Carrier, shipment.TrackingNumber)
+	return shipment, nil
+}
+
+// GetShipment returns the shipment of the order with tracking events, the check of the owner of the order is up to the caller
+func (usecase *ShipmentUsecase) GetShipment(ctx context.Context, orderId uuid.UUID) (*models.Shipment, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase GetShipment() with args: ctx, orderId: %v", orderId)
+	shipment, err := usecase.store.GetShipment(ctx, orderId)
+	if err != nil {
+		return nil, fmt.Errorf("error on get shipment: %w", err)
+	}
+	return shipment, nil
+}
+
+// TrackShipments requests tracking events of shipments not delivered yet and returns the number of delivered shipments.
+// The order of the delivered shipment becomes delivered. The shipment which failed to be tracked is checked again
+// after other shipments
+func (usecase *ShipmentUsecase) TrackShipments(ctx context.Context) (int, error) {
+	usecase.logger.Debug("Enter in usecase TrackShipments() with args: ctx")
+	shipments, err := usecase.store.GetShipmentsToTrack(ctx, usecase.carrier.Name(), usecase.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("error on get shipments to track: %w", err)
+	}
+	delivered := 0
+	for i := range shipments {
+		select {
+		case <-ctx.Done():
+			return delivered, ctx.Err()
+		default:
+		}
+		shipment := &shipments[i]
+		events, err := usecase.carrier.Track(ctx, shipment.TrackingNumber)
+		if err != nil {
+			metrics.ShipmentsMetrics.TrackingErrorsTotal.Inc()
+			usecase.logger.Sugar().Warnf("can't track shipment %s of order %v: %s", shipment.TrackingNumber, shipment.OrderId, err)
+		}
+		shipment.Events = make([]models.TrackingEvent, 0, len(events))
+		for _, event := range events {
+			shipment.Events = append(shipment.Events, models.TrackingEvent{
+				Status:      string(event.Status),
+				Description: event.Description,
+				Location:    event.Location,
+				Time:        event.Time,
+			})
+		}
+		isDelivered := carrier.Delivered(events)
+		if err := usecase.store.UpdateTracking(ctx, shipment, isDelivered); err != nil {
+			return delivered, fmt.Errorf("error on update tracking: %w", err)
+		}
+		if isDelivered {
+			metrics.ShipmentsMetrics.ShipmentsDeliveredTotal.Inc()
+			delivered++
+		}
+	}
+	if delivered > 0 {
+		usecase.logger.Sugar().Infof("%d shipments delivered", delivered)
+	}
+	return delivered, nil
+}
+
+// Run tracks shipments periodically until ctx is done
+func (usecase *ShipmentUsecase) Run(ctx context.Context) {
+	usecase.logger.Debug("Enter in usecase shipment Run() with args: ctx")
+	ticker := time.NewTicker(usecase.period)
+	defer ticker.Stop()
+	for {
+		if _, err := usecase.TrackShipments(ctx); err != nil {
+			usecase.logger.Error(err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
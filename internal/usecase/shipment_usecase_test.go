@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/carrier"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/mocks"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// testCarrier returns the tracking number and events set by the test
+type testCarrier struct {
+	parcels []carrier.Parcel
+	events  map[string][]carrier.Event
+	err     error
+}
+
+func (c *testCarrier) Name() string {
+	return "test"
+}
+
+func (c *testCarrier) CreateShipment(ctx context.Context, parcel carrier.Parcel) (*carrier.Shipment, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	c.parcels = append(c.parcels, parcel)
+	return &carrier.Shipment{TrackingNumber: "TEST-1"}, nil
+}
+
+func (c *testCarrier) Track(ctx context.Context, trackingNumber string) ([]carrier.Event, error) {
+	events, ok := c.events[trackingNumber]
+	if !ok {
+		return nil, carrier.ErrUnknownTrackingNumber
+	}
+	return events, nil
+}
+
+func TestShip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	shipmentRepo := mocks.NewMockShipmentStore(ctrl)
+	orderRepo := mocks.NewMockOrderStore(ctrl)
+	testCarrier := &testCarrier{}
+	usecase := NewShipmentUsecase(shipmentRepo, orderRepo, testCarrier, time.Minute, 10, zap.L())
+	ctx := testAdminCtx
+
+	_, err := usecase.Ship(models.ContextWithActor(context.Background(), testActor), testId)
+	require.True(t, errors.Is(err, models.ErrorForbidden{}))
+
+	orderRepo.EXPECT().GetOrderByID(ctx, testId).Return(models.Order{}, models.ErrorNotFound{})
+	_, err = usecase.Ship(ctx, testId)
+	require.True(t, errors.Is(err, models.ErrorNotFound{}))
+
+	order := models.Order{
+		ID:      testId,
+		Status:  models.StatusPaid,
+		Address: models.UserAddress{Zipcode: "123456", City: "Moscow", Street: "Lenina, 1", Recipient: "Ivan"},
+		Items: []models.ItemWithQuantity{
+			{Item: models.Item{Title: "smartphone", Weight: 200}, Quantity: 2},
+			{Item: models.Item{Title: "case", Weight: 50}, Quantity: 1},
+		},
+	}
+	orderRepo.EXPECT().GetOrderByID(ctx, testId).Return(order, nil)
+	_, err = usecase.Ship(ctx, testId)
+	require.True(t, errors.Is(err, models.ErrorWrongStatus{}))
+	require.Empty(t, testCarrier.parcels)
+
+	order.Status = models.StatusReady
+	testCarrier.err = fmt.Errorf("carrier is unavailable")
+	orderRepo.EXPECT().GetOrderByID(ctx, testId).Return(order, nil)
+	_, err = usecase.Ship(ctx, testId)
+	require.Error(t, err)
+
+	testCarrier.err = nil
+	orderRepo.EXPECT().GetOrderByID(ctx, testId).Return(order, nil).Times(2)
+	shipmentRepo.EXPECT().CreateShipment(ctx, gomock.Any(), models.ShippableStatuses()).Return(models.ErrorAlreadyExists{})
+	_, err = usecase.Ship(ctx, testId)
+	require.True(t, errors.Is(err, models.ErrorAlreadyExists{}))
+
+	shipmentRepo.EXPECT().CreateShipment(ctx, gomock.Any(), models.ShippableStatuses()).Return(nil)
+	res, err := usecase.Ship(ctx, testId)
+	require.NoError(t, err)
+	require.Equal(t, "test", res.Carrier)
+	require.Equal(t, "TEST-1", res.TrackingNumber)
+	require.Equal(t, testId, res.OrderId)
+	parcel := testCarrier.parcels[len(testCarrier.parcels)-1]
+	require.Equal(t, "Moscow", parcel.City)
+	require.Equal(t, "Ivan", parcel.Recipient)
+	require.Equal(t, int64(450), parcel.Weight)
+}
+
+func TestGetShipment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	shipmentRepo := mocks.NewMockShipmentStore(ctrl)
+	usecase := NewShipmentUsecase(shipmentRepo, nil, &testCarrier{}, time.Minute, 10, zap.L())
+	ctx := context.Background()
+
+	shipmentRepo.EXPECT().GetShipment(ctx, testId).Return(nil, models.ErrorNotFound{})
+	_, err := usecase.GetShipment(ctx, testId)
+	require.True(t, errors.Is(err, models.ErrorNotFound{}))
+
+	shipment := &models.Shipment{OrderId: testId, Carrier: "test", TrackingNumber: "TEST-1"}
+	shipmentRepo.EXPECT().GetShipment(ctx, testId).Return(shipment, nil)
+	res, err := usecase.GetShipment(ctx, testId)
+	require.NoError(t, err)
+	require.Equal(t, shipment, res)
+}
+
+func TestTrackShipments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	shipmentRepo := mocks.NewMockShipmentStore(ctrl)
+	now := time.Now()
+	testCarrier := &testCarrier{events: map[string][]carrier.Event{
+		"TEST-1": {
+			{Status: carrier.EventAccepted, Time: now.Add(-2 * time.Hour)},
+			{Status: carrier.EventInTransit, Location: "Moscow", Time: now.Add(-time.Hour)},
+		},
+		"TEST-2": {
+			{Status: carrier.EventAccepted, Time: now.Add(-2 * time.Hour)},
+			{Status: carrier.EventDelivered, Time: now},
+		},
+	}}
+	usecase := NewShipmentUsecase(shipmentRepo, nil, testCarrier, time.Minute, 10, zap.L())
+	ctx := context.Background()
+
+	shipmentRepo.EXPECT().GetShipmentsToTrack(ctx, "test", 10).Return(nil, fmt.Errorf("error"))
+	res, err := usecase.TrackShipments(ctx)
+	require.Error(t, err)
+	require.Equal(t, 0, res)
+
+	inTransit := models.Shipment{Id: uuid.New(), OrderId: uuid.New(), TrackingNumber: "TEST-1"}
+	delivered := models.Shipment{Id: uuid.New(), OrderId: uuid.New(), TrackingNumber: "TEST-2"}
+	unknown := models.Shipment{Id: uuid.New(), OrderId: uuid.New(), TrackingNumber: "TEST-3"}
+	shipmentRepo.EXPECT().GetShipmentsToTrack(ctx, "test", 10).Return([]models.Shipment{inTransit, delivered, unknown}, nil)
+	updated := make(map[string]bool)
+	events := make(map[string][]models.TrackingEvent)
+	shipmentRepo.EXPECT().UpdateTracking(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, shipment *models.Shipment, delivered bool) error {
+			updated[shipment.TrackingNumber] = delivered
+			events[shipment.TrackingNumber] = shipment.Events
+			return nil
+		}).Times(3)
+	res, err = usecase.TrackShipments(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, res)
+	require.Equal(t, map[string]bool{"TEST-1": false, "TEST-2": true, "TEST-3": false}, updated)
+	require.Len(t, events["TEST-1"], 2)
+	require.Equal(t, "in_transit", events["TEST-1"][1].Status)
+	require.Equal(t, "Moscow", events["TEST-1"][1].Location)
+	// The shipment which failed to be tracked is marked as checked without events
+	require.Empty(t, events["TEST-3"])
+
+	shipmentRepo.EXPECT().GetShipmentsToTrack(ctx, "test", 10).Return([]models.Shipment{delivered}, nil)
+	shipmentRepo.EXPECT().UpdateTracking(ctx, gomock.Any(), true).Return(fmt.Errorf("error"))
+	_, err = usecase.TrackShipments(ctx)
+	require.Error(t, err)
+}
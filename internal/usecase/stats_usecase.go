@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/repository"
+	"OnlineShopBackend/internal/repository/cash"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ IStatsUsecase = &StatsUsecase{}
+
+// popularSortType is the sort by popularity, caches of lists with this sort
+// are invalidated after the counters are flushed
+const popularSortType = "popular"
+
+type StatsUsecase struct {
+	itemStore     repository.ItemStore
+	itemCash      cash.IItemsCash
+	statsCash     cash.IStatsCash
+	flushInterval time.Duration
+	logger        *zap.Logger
+}
+
+func NewStatsUsecase(itemStore repository.ItemStore, itemCash cash.IItemsCash, statsCash cash.IStatsCash, flushInterval time.Duration, logger *zap.Logger) IStatsUsecase {
+	logger.Debug("Enter in usecase NewStatsUsecase()")
+	return &StatsUsecase{
+		itemStore:     itemStore,
+		itemCash:      itemCash,
+		statsCash:     statsCash,
+		flushInterval: flushInterval,
+		logger:        logger,
+	}
+}
+
+// RecordItemView increments the counter of views of the item in cache
+func (usecase *StatsUsecase) RecordItemView(ctx context.Context, itemId uuid.UUID) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase RecordItemView() with args: ctx, itemId: %v", itemId)
+	// Context with timeout so as not to wait for an answer from the cache for too long
+	ctxT, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	err := usecase.statsCash.IncrItemStat(ctxT, itemId, cash.ViewsStat)
+	if err != nil {
+		return fmt.Errorf("error on record item view: %w", err)
+	}
+	return nil
+}
+
+// RecordItemCartAdd increments the counter of adding of the item to cart in cache
+func (usecase *StatsUsecase) RecordItemCartAdd(ctx context.Context, itemId uuid.UUID) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase RecordItemCartAdd() with args: ctx, itemId: %v", itemId)
+	// Context with timeout so as not to wait for an answer from the cache for too long
+	ctxT, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	err := usecase.statsCash.IncrItemStat(ctxT, itemId, cash.CartAddsStat)
+	if err != nil {
+		return fmt.Errorf("error on record item cart add: %w", err)
+	}
+	return nil
+}
+
+// Flush writes the counters collected in cache to the database and invalidates
+// caches of lists sorted by popularity, so they are created with new counters
+func (usecase *StatsUsecase) Flush(ctx context.Context) error {
+	usecase.logger.Debug("Enter in usecase Flush() with args: ctx")
+	stats, err := usecase.statsCash.GetItemsStats(ctx)
+	if err != nil {
+		return fmt.Errorf("error on get items stats: %w", err)
+	}
+	if len(stats) == 0 {
+		return nil
+	}
+	err = usecase.itemStore.AddItemsStats(ctx, stats)
+	if err != nil {
+		// Counters stay in cache and will be written on the next flush
+		return fmt.Errorf("error on add items stats: %w", err)
+	}
+	err = usecase.statsCash.DeleteFlushedItemsStats(ctx)
+	if err != nil {
+		return fmt.Errorf("error on delete flushed items stats: %w", err)
+	}
+	err = usecase.itemCash.IncrCashVersion(ctx, statsSortVersionKey(popularSortType))
+	if err != nil {
+		usecase.logger.Sugar().Warnf("error on increment version of cash: %s, error: %v", popularSortType, err)
+	}
+	usecase.logger.Sugar().Infof("Stats of %d items flushed", len(stats))
+	return nil
+}
+
+// Run flushes counters to the database periodically until ctx is done. Counters
+// which are not flushed before stop stay in cache until the next start
+func (usecase *StatsUsecase) Run(ctx context.Context) {
+	usecase.logger.Debug("Enter in usecase stats Run() with args: ctx")
+	ticker := time.NewTicker(usecase.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := usecase.Flush(ctx); err != nil {
+			usecase.logger.Error(err.Error())
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/cash"
+	"OnlineShopBackend/internal/repository/mocks"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRecordItemView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	itemRepo := mocks.NewMockItemStore(ctrl)
+	itemsCash := mocks.NewMockIItemsCash(ctrl)
+	statsCash := mocks.NewMockIStatsCash(ctrl)
+	usecase := NewStatsUsecase(itemRepo, itemsCash, statsCash, time.Minute, logger)
+
+	statsCash.EXPECT().IncrItemStat(gomock.Any(), testId, cash.ViewsStat).Return(fmt.Errorf("error"))
+	err := usecase.RecordItemView(ctx, testId)
+	require.Error(t, err)
+
+	statsCash.EXPECT().IncrItemStat(gomock.Any(), testId, cash.ViewsStat).Return(nil)
+	err = usecase.RecordItemView(ctx, testId)
+	require.NoError(t, err)
+}
+
+func TestRecordItemCartAdd(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	itemRepo := mocks.NewMockItemStore(ctrl)
+	itemsCash := mocks.NewMockIItemsCash(ctrl)
+	statsCash := mocks.NewMockIStatsCash(ctrl)
+	usecase := NewStatsUsecase(itemRepo, itemsCash, statsCash, time.Minute, logger)
+
+	statsCash.EXPECT().IncrItemStat(gomock.Any(), testId, cash.CartAddsStat).Return(fmt.Errorf("error"))
+	err := usecase.RecordItemCartAdd(ctx, testId)
+	require.Error(t, err)
+
+	statsCash.EXPECT().IncrItemStat(gomock.Any(), testId, cash.CartAddsStat).Return(nil)
+	err = usecase.RecordItemCartAdd(ctx, testId)
+	require.NoError(t, err)
+}
+
+func TestFlushStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	itemRepo := mocks.NewMockItemStore(ctrl)
+	itemsCash := mocks.NewMockIItemsCash(ctrl)
+	statsCash := mocks.NewMockIStatsCash(ctrl)
+	usecase := NewStatsUsecase(itemRepo, itemsCash, statsCash, time.Minute, logger)
+	stats := []models.ItemStats{{ItemId: testId, Views: 3, CartAdds: 1}}
+
+	statsCash.EXPECT().GetItemsStats(ctx).Return(nil, fmt.Errorf("error"))
+	err := usecase.Flush(ctx)
+	require.Error(t, err)
+
+	statsCash.EXPECT().GetItemsStats(ctx).Return(nil, nil)
+	err = usecase.Flush(ctx)
+	require.NoError(t, err)
+
+	// Counters must stay in cache if they are not written to the database
+	statsCash.EXPECT().GetItemsStats(ctx).Return(stats, nil)
+	itemRepo.EXPECT().AddItemsStats(ctx, stats).Return(fmt.Errorf("error"))
+	err = usecase.Flush(ctx)
+	require.Error(t, err)
+
+	statsCash.EXPECT().GetItemsStats(ctx).Return(stats, nil)
+	itemRepo.EXPECT().AddItemsStats(ctx, stats).Return(nil)
+	statsCash.EXPECT().DeleteFlushedItemsStats(ctx).Return(nil)
+	itemsCash.EXPECT().IncrCashVersion(ctx, "StatsSortVersionpopular").Return(fmt.Errorf("error"))
+	err = usecase.Flush(ctx)
+	require.NoError(t, err)
+}
@@ -3,7 +3,10 @@ package usecase
 import (
 	"OnlineShopBackend/internal/delivery/user"
 	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/shipping"
 	"context"
+	"io"
+	"net/http"
 
 	"github.com/google/uuid"
 )
@@ -29,6 +32,7 @@ type IItemUsecase interface {
 	ItemsQuantityInSearch(ctx context.Context, search string) (int, error)
 	GetFavouriteItemsId(ctx context.Context, userId uuid.UUID) (*map[uuid.UUID]uuid.UUID, error)
 	UpdateFavIdsCash(ctx context.Context, userId, itemId uuid.UUID, op string)
+	DeleteStatsSortedCash(ctx context.Context, prefix string)
 }
 
 type ICategoryUsecase interface {
@@ -37,13 +41,14 @@ type ICategoryUsecase interface {
 	GetCategory(ctx context.Context, id uuid.UUID) (*models.Category, error)
 	GetCategoryList(ctx context.Context) ([]models.Category, error)
 	UpdateCash(ctx context.Context, id uuid.UUID, op string) error
-	DeleteCategory(ctx context.Context, id uuid.UUID) error
+	DeleteCategory(ctx context.Context, id uuid.UUID, targetId uuid.UUID, uncategorized bool) error
+	MergeCategories(ctx context.Context, sourceId uuid.UUID, targetId uuid.UUID) error
 	GetCategoryByName(ctx context.Context, name string) (*models.Category, error)
+	GetUncategorized(ctx context.Context) (*models.Category, error)
 	DeleteCategoryCash(ctx context.Context, name string) error
 }
 
 type IOrderUsecase interface {
-	PlaceOrder(ctx context.Context, cart *models. Cart, user models.User, address models.UserAddress) (*models.Order, error)
 	ChangeStatus(ctx context.Context, order *models.Order, newStatus models.Status) error
 	GetOrdersForUser(ctx context.Context, user *models.User) ([]models.Order, error)
 	DeleteOrder(ctx context.Context, order *models.Order) error
@@ -55,11 +60,38 @@ type ICartUsecase interface {
 	DeleteItemFromCart(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID) error
 	Create(ctx context.Context, userId uuid.UUID) (uuid.UUID, error)
 	AddItemToCart(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID) error
+	SetItemQuantity(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID, quantity int) error
 	DeleteCart(ctx context.Context, cartId uuid.UUID) error
 	GetCartByUserId(ctx context.Context, userId uuid.UUID) (*models.Cart, error)
+	MergeGuestCart(ctx context.Context, guestCartId uuid.UUID, userId uuid.UUID) (uuid.UUID, error)
+	SetItemSaved(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID, saved bool) error
+	ClearCart(ctx context.Context, cartId uuid.UUID, orderId uuid.UUID) error
 
 }
 
+type ICartCleanupUsecase interface {
+	PurgeExpiredCarts(ctx context.Context) (int, error)
+	Run(ctx context.Context)
+}
+
+type ICheckoutUsecase interface {
+	Checkout(ctx context.Context, cartId uuid.UUID, itemIds []uuid.UUID, user models.User, address models.UserAddress, method string) (*models.Order, uuid.UUID, error)
+	OrderAddress(ctx context.Context, userId uuid.UUID, address *models.UserAddress, addressId uuid.UUID) (models.UserAddress, error)
+	ShippingQuotes(ctx context.Context, cart *models.Cart, address models.UserAddress) ([]shipping.Quote, error)
+}
+
+type ICartReminderUsecase interface {
+	SendReminders(ctx context.Context) (int, error)
+	Run(ctx context.Context)
+}
+
+type INotificationUsecase interface {
+	SendNotifications(ctx context.Context) (int, error)
+	Run(ctx context.Context)
+	GetPreferences(ctx context.Context, userId uuid.UUID) (*models.NotificationPreferences, error)
+	SetPreferences(ctx context.Context, preferences *models.NotificationPreferences) error
+}
+
 type IUserUsecase interface {
 	CreateUser(ctx context.Context, user *user.CreateUserData) (*models.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
@@ -69,3 +101,81 @@ type IUserUsecase interface {
 	GetRightsList(ctx context.Context) ([]models.Rights, error)
 	CreateRights(ctx context.Context, rights *models.Rights) (uuid.UUID, error)
 }
+
+type IQuestionUsecase interface {
+	AskQuestion(ctx context.Context, question *models.Question) (uuid.UUID, error)
+	GetItemQuestions(ctx context.Context, itemId uuid.UUID) ([]models.Question, error)
+	AnswerQuestion(ctx context.Context, answer *models.Answer, role string) (uuid.UUID, error)
+	UpvoteAnswer(ctx context.Context, answerId uuid.UUID, userId uuid.UUID) error
+	GetUnansweredQuestions(ctx context.Context) ([]models.Question, error)
+}
+
+type IFeedUsecase interface {
+	Generate(ctx context.Context) error
+	Run(ctx context.Context)
+}
+
+type IStatsUsecase interface {
+	RecordItemView(ctx context.Context, itemId uuid.UUID) error
+	RecordItemCartAdd(ctx context.Context, itemId uuid.UUID) error
+	Flush(ctx context.Context) error
+	Run(ctx context.Context)
+}
+
+type IAuditUsecase interface {
+	GetAuditLog(ctx context.Context, filter models.AuditFilter) ([]models.AuditEntry, error)
+}
+
+type IIdempotencyUsecase interface {
+	Begin(ctx context.Context, userId uuid.UUID, key string, requestHash string) (*models.IdempotentRequest, error)
+	Complete(ctx context.Context, request *models.IdempotentRequest) error
+	Release(ctx context.Context, userId uuid.UUID, key string) error
+}
+
+type IPaymentUsecase interface {
+	Pay(ctx context.Context, orderId uuid.UUID) (*models.Payment, error)
+	GetPayment(ctx context.Context, orderId uuid.UUID) (*models.Payment, error)
+	HandleWebhook(ctx context.Context, header http.Header, body []byte) error
+	Refund(ctx context.Context, orderId uuid.UUID, amount int64) (*models.Payment, error)
+	RefundCanceled(ctx context.Context, orderId uuid.UUID) (*models.Payment, error)
+	CancelUnpaidOrders(ctx context.Context) (int, error)
+	Run(ctx context.Context)
+}
+
+type IReturnUsecase interface {
+	CreateReturn(ctx context.Context, ret *models.Return) (*models.Return, error)
+	GetReturn(ctx context.Context, id uuid.UUID) (*models.Return, error)
+	GetOrderReturns(ctx context.Context, orderId uuid.UUID) ([]models.Return, error)
+	GetReturns(ctx context.Context, status models.ReturnStatus, offset int, limit int) ([]models.Return, error)
+	ChangeReturnStatus(ctx context.Context, id uuid.UUID, status models.ReturnStatus, comment string) (*models.Return, error)
+	SetTrackingNumber(ctx context.Context, id uuid.UUID, trackingNumber string) (*models.Return, error)
+	RefundReturn(ctx context.Context, id uuid.UUID, amount int64) (*models.Return, error)
+}
+
+type IOrderCancelUsecase interface {
+	CancelOrder(ctx context.Context, orderId uuid.UUID, reason string) error
+}
+
+type IInvoiceUsecase interface {
+	GetInvoice(ctx context.Context, orderId uuid.UUID) (string, string, error)
+}
+
+type IOrderListUsecase interface {
+	GetOrders(ctx context.Context, filter models.OrderFilter) (*models.OrderList, error)
+	ExportOrders(ctx context.Context, filter models.OrderFilter, format string, w io.Writer) error
+}
+
+type IAddressUsecase interface {
+	CreateAddress(ctx context.Context, address *models.SavedAddress) (*models.SavedAddress, error)
+	GetAddress(ctx context.Context, id uuid.UUID) (*models.SavedAddress, error)
+	GetAddresses(ctx context.Context, userId uuid.UUID) ([]models.SavedAddress, error)
+	UpdateAddress(ctx context.Context, address *models.SavedAddress) (*models.SavedAddress, error)
+	DeleteAddress(ctx context.Context, id uuid.UUID) error
+}
+
+type IShipmentUsecase interface {
+	Ship(ctx context.Context, orderId uuid.UUID) (*models.Shipment, error)
+	GetShipment(ctx context.Context, orderId uuid.UUID) (*models.Shipment, error)
+	TrackShipments(ctx context.Context) (int, error)
+	Run(ctx context.Context)
+}
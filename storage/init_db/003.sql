@@ -0,0 +1,42 @@
+ALTER TABLE items ADD COLUMN seller_id UUID NULL,
+    ADD CONSTRAINT fk_seller_id
+        FOREIGN KEY(seller_id) REFERENCES users(id);
+
+CREATE TABLE item_questions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    item_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    text TEXT NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT fk_item_id
+        FOREIGN KEY(item_id) REFERENCES items(id),
+    CONSTRAINT fk_user_id
+        FOREIGN KEY(user_id) REFERENCES users(id)
+);
+
+CREATE INDEX item_questions_item_id_idx ON item_questions(item_id);
+
+CREATE TABLE item_answers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    question_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    text TEXT NOT NULL,
+    votes INTEGER NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT fk_question_id
+        FOREIGN KEY(question_id) REFERENCES item_questions(id),
+    CONSTRAINT fk_user_id
+        FOREIGN KEY(user_id) REFERENCES users(id)
+);
+
+CREATE INDEX item_answers_question_id_idx ON item_answers(question_id);
+
+CREATE TABLE answer_votes (
+    answer_id UUID,
+    user_id UUID,
+    PRIMARY KEY(answer_id, user_id),
+    CONSTRAINT fk_answer_id
+        FOREIGN KEY(answer_id) REFERENCES item_answers(id),
+    CONSTRAINT fk_user_id
+        FOREIGN KEY(user_id) REFERENCES users(id)
+);
@@ -0,0 +1,14 @@
+-- Date of creation and counters of views and adding to cart
+-- are used for sorting items by novelty and popularity
+ALTER TABLE items
+    ADD COLUMN created_at timestamptz NOT NULL DEFAULT now(),
+    ADD COLUMN views BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN cart_adds BIGINT NOT NULL DEFAULT 0;
+
+-- Counters are flushed from cache periodically and must not
+-- cause regeneration of the feeds of the catalog
+DROP TRIGGER items_catalog_changed ON items;
+
+CREATE TRIGGER items_catalog_changed
+    AFTER INSERT OR DELETE OR UPDATE OF name, category, description, price, vendor, pictures, deleted_at ON items
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_catalog_changed();
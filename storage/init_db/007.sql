@@ -0,0 +1,4 @@
+-- Quantity of the item in stock, NULL means that the stock of the item
+-- is not tracked and any quantity can be put in the cart
+ALTER TABLE items
+    ADD COLUMN stock INTEGER CHECK (stock >= 0);
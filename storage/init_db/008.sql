@@ -0,0 +1,3 @@
+-- Expired carts are selected by the cleanup worker and skipped on reading
+CREATE INDEX IF NOT EXISTS carts_expire_at_idx ON carts (expire_at);
+CREATE INDEX IF NOT EXISTS carts_user_id_idx ON carts (user_id);
@@ -0,0 +1,11 @@
+-- Price of the item at the time it was added to the cart, it is compared
+-- with the current price of the item to notify the user about the change
+ALTER TABLE cart_items
+    ADD COLUMN price INTEGER;
+
+UPDATE cart_items SET price = items.price
+FROM items
+WHERE items.id = cart_items.item_id;
+
+ALTER TABLE cart_items
+    ALTER COLUMN price SET NOT NULL;
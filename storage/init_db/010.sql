@@ -0,0 +1,4 @@
+-- Lines saved for later stay in the cart but are not included
+-- in the totals of the cart and in the order
+ALTER TABLE cart_items
+    ADD COLUMN saved BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,23 @@
+-- The time of the last change of the cart is used to find abandoned carts
+ALTER TABLE carts
+    ADD COLUMN updated_at timestamp NOT NULL DEFAULT now();
+
+-- Reminders about abandoned carts. A reminder is sent once after each change
+-- of the cart and is converted when the cart becomes an order. Reminders
+-- are kept after the cart is deleted, so there is no foreign key to carts
+CREATE TABLE cart_reminders (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    cart_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    email VARCHAR(256) NOT NULL,
+    sent_at timestamp NOT NULL DEFAULT now(),
+    order_id UUID,
+    converted_at timestamp,
+    CONSTRAINT fk_user_id
+        FOREIGN KEY(user_id) REFERENCES users(id),
+    CONSTRAINT fk_order_id
+        FOREIGN KEY(order_id) REFERENCES orders(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS cart_reminders_cart_id_idx ON cart_reminders (cart_id);
+CREATE INDEX IF NOT EXISTS carts_updated_at_idx ON carts (updated_at);
@@ -0,0 +1,23 @@
+-- Payments for orders in the payment provider. An order may have several
+-- payments if the customer retried after a failure, the paid order has
+-- one succeeded payment
+CREATE TABLE payments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL,
+    provider VARCHAR(64) NOT NULL,
+    external_id VARCHAR(256) NOT NULL,
+    client_secret VARCHAR(256) NOT NULL DEFAULT '',
+    amount BIGINT NOT NULL,
+    refunded_amount BIGINT NOT NULL DEFAULT 0,
+    currency VARCHAR(3) NOT NULL,
+    status VARCHAR(32) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT fk_order_id
+        FOREIGN KEY(order_id) REFERENCES orders(id) ON DELETE CASCADE,
+    CONSTRAINT payments_provider_external_id_key UNIQUE (provider, external_id)
+);
+
+CREATE INDEX IF NOT EXISTS payments_order_id_idx ON payments (order_id);
+-- Unpaid orders are canceled after the timeout
+CREATE INDEX IF NOT EXISTS orders_status_created_at_idx ON orders (status, created_at);
@@ -0,0 +1,45 @@
+-- Requests of customers to return lines of delivered orders
+CREATE TABLE returns (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    reason TEXT NOT NULL,
+    status VARCHAR(32) NOT NULL,
+    tracking_number VARCHAR(256) NOT NULL DEFAULT '',
+    refund_amount BIGINT NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT fk_order_id
+        FOREIGN KEY(order_id) REFERENCES orders(id) ON DELETE CASCADE,
+    CONSTRAINT fk_user_id
+        FOREIGN KEY(user_id) REFERENCES users(id)
+);
+
+-- Returned lines with the price of the item at the time the return was requested
+CREATE TABLE return_items (
+    return_id UUID NOT NULL,
+    item_id UUID NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    price INTEGER NOT NULL,
+    PRIMARY KEY(return_id, item_id),
+    CONSTRAINT fk_return_id
+        FOREIGN KEY(return_id) REFERENCES returns(id) ON DELETE CASCADE,
+    CONSTRAINT fk_item_id
+        FOREIGN KEY(item_id) REFERENCES items(id)
+);
+
+-- Every change of the status of the return with the user who made it
+CREATE TABLE return_status_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    return_id UUID NOT NULL,
+    status VARCHAR(32) NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    actor_id UUID,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT fk_return_id
+        FOREIGN KEY(return_id) REFERENCES returns(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS returns_order_id_idx ON returns (order_id);
+CREATE INDEX IF NOT EXISTS returns_status_idx ON returns (status, created_at);
+CREATE INDEX IF NOT EXISTS return_status_history_return_id_idx ON return_status_history (return_id, created_at);
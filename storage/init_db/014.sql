@@ -0,0 +1,8 @@
+-- Reason the order was canceled with
+ALTER TABLE orders
+    ADD COLUMN cancel_reason TEXT NOT NULL DEFAULT '';
+
+-- Stock of items of the order is reserved when the order is placed and released when it is canceled
+-- or deleted before shipping. Orders placed before the reservation have nothing to release
+ALTER TABLE orders
+    ADD COLUMN stock_reserved BOOLEAN NOT NULL DEFAULT false;
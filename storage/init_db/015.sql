@@ -0,0 +1,19 @@
+-- Price of the item at the moment the order was placed, so changes of the catalog
+-- do not change placed orders and their invoices
+ALTER TABLE order_items
+    ADD COLUMN price INTEGER;
+
+UPDATE order_items SET price = items.price FROM items WHERE items.id = order_items.item_id;
+
+ALTER TABLE order_items
+    ALTER COLUMN price SET NOT NULL;
+
+-- Invoices of orders. The numbers are sequential and have no gaps,
+-- the number is given to the order once, when the invoice is requested first time
+CREATE TABLE invoices (
+    order_id UUID PRIMARY KEY,
+    number BIGINT NOT NULL UNIQUE,
+    issued_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT fk_order_id
+        FOREIGN KEY(order_id) REFERENCES orders(id) ON DELETE CASCADE
+);
@@ -0,0 +1,9 @@
+-- Weight of the item in grams, it is used to calculate the shipping cost
+ALTER TABLE items
+    ADD COLUMN weight INTEGER NOT NULL DEFAULT 0;
+
+-- Shipping method chosen at checkout and the cost of the delivery calculated at checkout.
+-- Orders placed before shipping methods have no method
+ALTER TABLE orders
+    ADD COLUMN shipping_method TEXT NOT NULL DEFAULT '',
+    ADD COLUMN shipping_cost BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,25 @@
+-- Structured address of the order instead of the string "zipcode -> country -> city -> street".
+-- The apartment, the name of the recipient and the phone are optional
+ALTER TABLE orders
+    ADD COLUMN zipcode VARCHAR(16) NOT NULL DEFAULT '',
+    ADD COLUMN country VARCHAR(256) NOT NULL DEFAULT '',
+    ADD COLUMN city VARCHAR(256) NOT NULL DEFAULT '',
+    ADD COLUMN street TEXT NOT NULL DEFAULT '',
+    ADD COLUMN apartment VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN recipient VARCHAR(256) NOT NULL DEFAULT '',
+    ADD COLUMN phone VARCHAR(32) NOT NULL DEFAULT '';
+
+-- The street is everything after the third separator, so streets containing " -> " are kept whole.
+-- Addresses in another format are moved to the street as they are
+UPDATE orders SET
+    zipcode = CASE WHEN cardinality(parsed.parts) >= 4 THEN left(parsed.parts[1], 16) ELSE '' END,
+    country = CASE WHEN cardinality(parsed.parts) >= 4 THEN left(parsed.parts[2], 256) ELSE '' END,
+    city = CASE WHEN cardinality(parsed.parts) >= 4 THEN left(parsed.parts[3], 256) ELSE '' END,
+    street = CASE WHEN cardinality(parsed.parts) >= 4 THEN array_to_string(parsed.parts[4:], ' -> ')
+        ELSE coalesce(orders.address, '') END
+FROM (SELECT id, string_to_array(address, ' -> ') AS parts FROM orders) AS parsed
+WHERE parsed.id = orders.id;
+
+ALTER TABLE orders DROP COLUMN address;
+
+CREATE INDEX orders_city_idx ON orders (lower(city));
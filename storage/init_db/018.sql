@@ -0,0 +1,26 @@
+-- Address book of users. Each user has at most one default address for shipping,
+-- names of addresses of the user are unique
+CREATE TABLE user_addresses (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    name VARCHAR(64) NOT NULL,
+    zipcode VARCHAR(16) NOT NULL DEFAULT '',
+    country VARCHAR(256) NOT NULL DEFAULT '',
+    city VARCHAR(256) NOT NULL DEFAULT '',
+    street TEXT NOT NULL DEFAULT '',
+    apartment VARCHAR(64) NOT NULL DEFAULT '',
+    recipient VARCHAR(256) NOT NULL DEFAULT '',
+    phone VARCHAR(32) NOT NULL DEFAULT '',
+    default_shipping BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT fk_user_id
+        FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT user_addresses_name_key UNIQUE (user_id, name)
+);
+
+CREATE UNIQUE INDEX user_addresses_default_shipping_idx ON user_addresses (user_id) WHERE default_shipping;
+
+-- The address from the profile becomes the default home address of the user
+INSERT INTO user_addresses (user_id, name, zipcode, country, city, street, default_shipping)
+SELECT id, 'home', coalesce(zipcode, ''), coalesce(country, ''), coalesce(city, ''), street, true
+FROM users WHERE coalesce(street, '') <> '';
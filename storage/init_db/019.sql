@@ -0,0 +1,71 @@
+-- Choice of the user of emails about orders and of their language,
+-- users without the row get all emails in the default language
+CREATE TABLE notification_preferences (
+    user_id UUID PRIMARY KEY,
+    language VARCHAR(8) NOT NULL DEFAULT '',
+    order_placed BOOLEAN NOT NULL DEFAULT true,
+    order_status BOOLEAN NOT NULL DEFAULT true,
+    order_shipped BOOLEAN NOT NULL DEFAULT true,
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT fk_user_id
+        FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Emails to users waiting to be sent by the worker. The email is pending until it is sent,
+-- skipped by the preferences of the user or failed after the last attempt
+CREATE TABLE notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind VARCHAR(32) NOT NULL,
+    user_id UUID NOT NULL,
+    order_id UUID,
+    status VARCHAR(256),
+    state VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    next_attempt_at timestamptz NOT NULL DEFAULT now(),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    sent_at timestamptz,
+    CONSTRAINT fk_user_id
+        FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_order_id
+        FOREIGN KEY(order_id) REFERENCES orders(id) ON DELETE CASCADE
+);
+
+CREATE INDEX notifications_pending_idx ON notifications (next_attempt_at) WHERE state = 'pending';
+
+-- Emails are queued in the transaction of the event, so every path changing users
+-- or orders queues them and the email is not queued if the change is rolled back
+CREATE OR REPLACE FUNCTION queue_user_notification() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO notifications (kind, user_id) VALUES ('registration', NEW.id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER users_registration_notification
+    AFTER INSERT ON users
+    FOR EACH ROW EXECUTE FUNCTION queue_user_notification();
+
+-- The order handed over to the courier is shipped, other changes of the status are reported as they are
+CREATE OR REPLACE FUNCTION queue_order_notification() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO notifications (kind, user_id, order_id, status) VALUES ('order_placed', NEW.user_id, NEW.id, NEW.status);
+    ELSIF NEW.status = 'picked by courier' THEN
+        INSERT INTO notifications (kind, user_id, order_id, status) VALUES ('order_shipped', NEW.user_id, NEW.id, NEW.status);
+    ELSE
+        INSERT INTO notifications (kind, user_id, order_id, status) VALUES ('order_status', NEW.user_id, NEW.id, NEW.status);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER orders_placed_notification
+    AFTER INSERT ON orders
+    FOR EACH ROW WHEN (NEW.user_id IS NOT NULL)
+    EXECUTE FUNCTION queue_order_notification();
+
+CREATE TRIGGER orders_status_notification
+    AFTER UPDATE OF status ON orders
+    FOR EACH ROW WHEN (NEW.user_id IS NOT NULL AND OLD.status IS DISTINCT FROM NEW.status)
+    EXECUTE FUNCTION queue_order_notification();
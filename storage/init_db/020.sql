@@ -0,0 +1,30 @@
+-- Orders handed over to carriers. The order has one shipment and the tracking number
+-- is unique in the carrier. Shipments not delivered yet are tracked by the worker,
+-- the longest not checked go first
+CREATE TABLE shipments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL,
+    carrier VARCHAR(64) NOT NULL,
+    tracking_number VARCHAR(256) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    delivered_at timestamptz,
+    checked_at timestamptz,
+    CONSTRAINT fk_order_id
+        FOREIGN KEY(order_id) REFERENCES orders(id) ON DELETE CASCADE,
+    CONSTRAINT shipments_order_key UNIQUE(order_id),
+    CONSTRAINT shipments_tracking_number_key UNIQUE(carrier, tracking_number)
+);
+
+CREATE INDEX shipments_tracking_idx ON shipments (carrier, checked_at NULLS FIRST) WHERE delivered_at IS NULL;
+
+-- Tracking events reported by the carrier, the same event is saved once
+CREATE TABLE shipment_events (
+    shipment_id UUID NOT NULL,
+    status VARCHAR(64) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    location VARCHAR(256) NOT NULL DEFAULT '',
+    occurred_at timestamptz NOT NULL,
+    PRIMARY KEY (shipment_id, occurred_at, status),
+    CONSTRAINT fk_shipment_id
+        FOREIGN KEY(shipment_id) REFERENCES shipments(id) ON DELETE CASCADE
+);
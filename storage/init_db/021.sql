@@ -0,0 +1,6 @@
+-- Discount of the order calculated at checkout, so the invoice and the totals of the order
+-- don't change with the pricing. The discount of orders placed before this column is not known,
+-- they are saved without the discount, except orders without the shipping method which are
+-- still priced by the current pricing
+ALTER TABLE orders
+    ADD COLUMN discount BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,13 @@
+-- Failed attempts to send the reminder about the abandoned cart since its last change.
+-- The reminder is retried after next_attempt_at until it fails after the last attempt,
+-- the attempts are counted again after the next change of the cart
+CREATE TABLE cart_reminder_attempts (
+    cart_id UUID PRIMARY KEY,
+    cart_updated_at timestamp NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    next_attempt_at timestamptz NOT NULL DEFAULT now(),
+    failed BOOLEAN NOT NULL DEFAULT false,
+    CONSTRAINT fk_cart_id
+        FOREIGN KEY(cart_id) REFERENCES carts(id) ON DELETE CASCADE
+);
@@ -0,0 +1,4 @@
+-- Numeric id of the item used as the id of its offer in the Yandex Market feed,
+-- the ids of offers are limited by 20 characters, so the UUID of the item doesn't fit
+ALTER TABLE items
+    ADD COLUMN offer_id BIGSERIAL UNIQUE;